@@ -150,7 +150,9 @@ func main() {
 	// Set up Clean Architecture layers
 	dbRepo := repository.NewDatabaseRepository()
 	dbUseCase := usecase.NewDatabaseUseCase(dbRepo)
-	toolRegistry := mcp.NewToolRegistry(mcpServer)
+	// Tools are added through the wrapper so infra_list_tools can list them
+	toolServer := mcp.NewServerWrapper(mcpServer)
+	toolRegistry := mcp.NewToolRegistry(toolServer)
 
 	// Set the database use case in the tool registry
 	ctx := context.Background()
@@ -177,19 +179,23 @@ func main() {
 		logger.Info("Initializing AWS integration with %d profile(s)", len(cfg.AWSProfiles))
 		awsManager := mcp.NewAWSManager()
 
+		// Profiles that fail are skipped; the others still get their tools
 		if err := awsManager.InitializeProfiles(ctx, cfg.AWSProfiles); err != nil {
 			logger.Warn("Failed to initialize AWS profiles: %v", err)
+		}
+		if err := awsManager.RegisterTools(ctx, toolServer); err != nil {
+			logger.Warn("Failed to register AWS tools: %v", err)
 		} else {
-			if err := awsManager.RegisterTools(ctx, mcpServer); err != nil {
-				logger.Warn("Failed to register AWS tools: %v", err)
-			} else {
-				logger.Info("Successfully registered AWS tools")
-			}
+			logger.Info("Successfully registered AWS tools")
 		}
 	} else {
 		logger.Info("No AWS profiles configured, skipping AWS integration")
 	}
 
+	if err := mcp.RegisterListToolsTool(ctx, toolServer); err != nil {
+		logger.Warn("Failed to register %s tool: %v", mcp.ListToolsName, err)
+	}
+
 	// If we have databases, display the available tools
 	if len(dbIDs) > 0 {
 		logger.Info("Available database tools (READ-ONLY MODE):")
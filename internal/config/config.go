@@ -11,6 +11,7 @@ import (
 
 	"github.com/FreePeak/infra-mcp-server/internal/logger"
 	awspkg "github.com/FreePeak/infra-mcp-server/pkg/aws"
+	"github.com/FreePeak/infra-mcp-server/pkg/common"
 	"github.com/FreePeak/infra-mcp-server/pkg/db"
 )
 
@@ -83,6 +84,11 @@ func LoadConfig() (*Config, error) {
 		disableLogging = true
 	}
 
+	// Extra date layouts and the month/day order for numeric dates in tool parameters
+	if err := common.ConfigureDateParsing(getEnv("DATE_FORMATS", ""), getEnv("DATE_ORDER", "")); err != nil {
+		logger.Warn("Warning: Invalid date parsing settings, using defaults: %v", err)
+	}
+
 	config := &Config{
 		ServerPort:     port,
 		TransportMode:  getEnv("TRANSPORT_MODE", "sse"),
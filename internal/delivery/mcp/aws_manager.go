@@ -2,8 +2,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/FreePeak/cortex/pkg/server"
@@ -25,12 +30,30 @@ type AWSManager struct {
 	lambdaService     *awspkg.LambdaService
 	secretsService    *awspkg.SecretsService
 	metricsService    *awspkg.CloudWatchMetricsService
+	dynamodbService   *awspkg.DynamoDBService
+	s3Service         *awspkg.S3Service
+	alarmsService     *awspkg.CloudWatchAlarmsService
+	orgService        *awspkg.OrganizationsService
+	iamService        *awspkg.IAMService
+	route53Service    *awspkg.Route53Service
+	elbService        *awspkg.ELBService
+
+	// Read-through caches for list calls agents repeat, keyed by profile and parameters
+	logGroupsCache   *common.TTLCache[*awspkg.ListLogGroupsResult]
+	dbInstancesCache *common.TTLCache[[]awspkg.DBInstance]
+
+	// initializeProfile creates the clients of one profile; replaced in tests
+	initializeProfile func(ctx context.Context, profileID string) error
 }
 
+// maxConcurrentProfileInits bounds how many profiles InitializeProfiles loads at once
+const maxConcurrentProfileInits = 5
+
 // NewAWSManager creates a new AWS manager
 func NewAWSManager() *AWSManager {
 	config := awspkg.NewAWSConfig()
 	clientManager := awspkg.NewClientManager(config)
+	cacheTTL := getAWSCacheTTL()
 
 	return &AWSManager{
 		config:            config,
@@ -42,30 +65,102 @@ func NewAWSManager() *AWSManager {
 		lambdaService:     awspkg.NewLambdaService(clientManager),
 		secretsService:    awspkg.NewSecretsService(clientManager),
 		metricsService:    awspkg.NewCloudWatchMetricsService(clientManager),
+		dynamodbService:   awspkg.NewDynamoDBService(clientManager),
+		s3Service:         awspkg.NewS3Service(clientManager),
+		alarmsService:     awspkg.NewCloudWatchAlarmsService(clientManager),
+		orgService:        awspkg.NewOrganizationsService(clientManager),
+		iamService:        awspkg.NewIAMService(clientManager),
+		route53Service:    awspkg.NewRoute53Service(clientManager),
+		elbService:        awspkg.NewELBService(clientManager),
+		logGroupsCache:    common.NewTTLCache[*awspkg.ListLogGroupsResult](cacheTTL),
+		dbInstancesCache:  common.NewTTLCache[[]awspkg.DBInstance](cacheTTL),
+		initializeProfile: clientManager.InitializeProfile,
+	}
+}
+
+// getAWSCacheTTL reads the list cache TTL from the AWS_CACHE_TTL environment variable
+// (in seconds, 0 disables caching) or returns the default of 60 seconds
+func getAWSCacheTTL() time.Duration {
+	ttlStr := os.Getenv("AWS_CACHE_TTL")
+	if ttlStr == "" {
+		return 60 * time.Second
+	}
+
+	ttlSeconds, err := strconv.Atoi(ttlStr)
+	if err != nil || ttlSeconds < 0 {
+		logger.Warn("Invalid AWS_CACHE_TTL value '%s', using default 60 seconds", ttlStr)
+		return 60 * time.Second
 	}
+
+	return time.Duration(ttlSeconds) * time.Second
 }
 
-// InitializeProfiles initializes AWS profiles from configuration
+// InitializeProfiles initializes AWS profiles from configuration, up to
+// maxConcurrentProfileInits at a time. A profile that fails is skipped and the others
+// are still initialized; the returned error lists every profile that failed.
 func (am *AWSManager) InitializeProfiles(ctx context.Context, profiles []awspkg.ProfileConfig) error {
+	failures := make(map[string]error)
+
+	// Add every profile before initializing any, so source_profile may refer to a
+	// profile listed later
+	added := make([]awspkg.ProfileConfig, 0, len(profiles))
 	for _, profile := range profiles {
 		if err := am.config.AddProfile(&profile); err != nil {
 			logger.Warn("Failed to add AWS profile %s: %v", profile.ID, err)
+			failures[profile.ID] = err
 			continue
 		}
+		added = append(added, profile)
+	}
 
-		if err := am.clientManager.InitializeProfile(ctx, profile.ID); err != nil {
-			logger.Warn("Failed to initialize AWS profile %s: %v", profile.ID, err)
-			continue
-		}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentProfileInits)
+	for _, profile := range added {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(profile awspkg.ProfileConfig) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := am.initializeProfile(ctx, profile.ID); err != nil {
+				logger.Warn("Failed to initialize AWS profile %s: %v", profile.ID, err)
+				mu.Lock()
+				failures[profile.ID] = err
+				mu.Unlock()
+				return
+			}
 
-		logger.Info("Initialized AWS profile: %s (%s)", profile.ID, profile.Description)
+			logger.Info("Initialized AWS profile: %s (%s)", profile.ID, profile.Description)
+		}(profile)
 	}
+	wg.Wait()
 
-	return nil
+	return profileFailuresError(failures, len(profiles))
+}
+
+// profileFailuresError summarizes the profiles that failed to initialize, sorted by ID,
+// or returns nil when none failed
+func profileFailuresError(failures map[string]error, total int) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(failures))
+	for id := range failures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	details := make([]string, 0, len(ids))
+	for _, id := range ids {
+		details = append(details, fmt.Sprintf("%s: %v", id, failures[id]))
+	}
+	return fmt.Errorf("failed to initialize %d of %d AWS profile(s): %s", len(failures), total, strings.Join(details, "; "))
 }
 
 // RegisterTools registers all AWS tools for all profiles
-func (am *AWSManager) RegisterTools(ctx context.Context, mcpServer *server.MCPServer) error {
+func (am *AWSManager) RegisterTools(ctx context.Context, mcpServer *ServerWrapper) error {
 	profiles := am.config.ListProfiles()
 	if len(profiles) == 0 {
 		logger.Info("No AWS profiles configured, skipping AWS tool registration")
@@ -94,9 +189,61 @@ func (am *AWSManager) RegisterTools(ctx context.Context, mcpServer *server.MCPSe
 
 	logger.Info("AWS tool registration complete: %d registered, %d skipped (pending)", registeredCount, skippedCount)
 
+	am.registerProfileListTool(ctx, mcpServer)
+
 	return nil
 }
 
+// ProfileSummary is the display metadata of an AWS profile, without credentials
+type ProfileSummary struct {
+	ID             string   `json:"id"`
+	Region         string   `json:"region,omitempty"`
+	Project        string   `json:"project,omitempty"`
+	Environment    string   `json:"environment,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	AllowMutations bool     `json:"allow_mutations"`
+	Pending        bool     `json:"pending"`
+}
+
+// listProfiles returns the profiles in an environment that carry every given tag
+func (am *AWSManager) listProfiles(environment string, tags []string) []ProfileSummary {
+	profiles := am.config.FilterProfiles(environment, tags)
+	summaries := make([]ProfileSummary, 0, len(profiles))
+	for _, profile := range profiles {
+		summaries = append(summaries, ProfileSummary{
+			ID:             profile.ID,
+			Region:         profile.Region,
+			Project:        profile.Project,
+			Environment:    profile.Environment,
+			Description:    profile.Description,
+			Tags:           profile.Tags,
+			AllowMutations: profile.AllowMutations,
+			Pending:        am.isProfilePending(profile.ID),
+		})
+	}
+	return summaries
+}
+
+// registerProfileListTool registers the tool that finds profiles by environment and tags.
+// It is registered once, not per profile.
+func (am *AWSManager) registerProfileListTool(ctx context.Context, mcpServer *ServerWrapper) {
+	tool := tools.NewTool(
+		"aws_list_profiles",
+		tools.WithDescription(`List the configured AWS profiles with their region, project, environment, description and tags.
+
+Filter by environment and/or tags to find the profiles to use, e.g. environment=production and
+tag=payments; each profile's tools are named with its ID. Pending profiles have no tools yet.`),
+		tools.WithString("environment", tools.Description("Only profiles in this environment (case-insensitive; default: any)")),
+		tools.WithString("tag", tools.Description("Comma-separated tags the profiles must all carry (case-insensitive; default: any)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		environment, _ := request.Parameters["environment"].(string)
+		tag, _ := request.Parameters["tag"].(string)
+		return FormatListResponse("profiles", am.listProfiles(environment, splitCommaList(tag)), nil)
+	})
+}
+
 // isProfilePending checks if a profile should be skipped due to pending credentials
 func (am *AWSManager) isProfilePending(profileID string) bool {
 	profile, err := am.config.GetProfile(profileID)
@@ -120,7 +267,7 @@ func (am *AWSManager) isProfilePending(profileID string) bool {
 }
 
 // registerProfileTools registers all tools for a specific profile
-func (am *AWSManager) registerProfileTools(ctx context.Context, mcpServer *server.MCPServer, profileID string) error {
+func (am *AWSManager) registerProfileTools(ctx context.Context, mcpServer *ServerWrapper, profileID string) error {
 	profile, err := am.config.GetProfile(profileID)
 	if err != nil {
 		return err
@@ -146,27 +293,146 @@ func (am *AWSManager) registerProfileTools(ctx context.Context, mcpServer *serve
 	// Register Secrets Manager tools
 	am.registerSecretsTools(ctx, mcpServer, profileID, profile)
 
+	// Register DynamoDB tools
+	am.registerDynamoDBTools(ctx, mcpServer, profileID, profile)
+
+	// Register CloudWatch Metrics tools
+	am.registerMetricsTools(ctx, mcpServer, profileID, profile)
+
+	// Register S3 tools
+	am.registerS3Tools(ctx, mcpServer, profileID, profile)
+
+	// Register CloudWatch alarm tools
+	am.registerAlarmTools(ctx, mcpServer, profileID, profile)
+
+	// Register Organizations tools
+	am.registerOrganizationsTools(ctx, mcpServer, profileID, profile)
+
+	// Register IAM tools
+	am.registerIAMTools(ctx, mcpServer, profileID, profile)
+
+	// Register Route53 tools
+	am.registerRoute53Tools(ctx, mcpServer, profileID, profile)
+
+	// Register load balancer tools
+	am.registerELBTools(ctx, mcpServer, profileID, profile)
+
 	return nil
 }
 
 // registerCloudWatchLogsTools registers CloudWatch Logs tools
-func (am *AWSManager) registerCloudWatchLogsTools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
+func (am *AWSManager) registerCloudWatchLogsTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
 	// List log groups
 	toolName := fmt.Sprintf("aws_logs_list_%s", profileID)
 	tool := tools.NewTool(
 		toolName,
 		tools.WithDescription(fmt.Sprintf("List CloudWatch log groups in %s", profile.Description)),
 		tools.WithString("prefix", tools.Description("Optional prefix to filter log groups")),
-		tools.WithNumber("limit", tools.Description("Maximum number of log groups (default: 50)")),
+		tools.WithNumber("limit", tools.Description("Maximum number of log groups (default: 50, 0 for all)")),
+		tools.WithString("next_token", tools.Description("next_token from a previous call to continue listing")),
+		tools.WithBoolean("refresh", tools.Description("Bypass the short-lived list cache and call AWS")),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
 		prefix, _ := request.Parameters["prefix"].(string)
+		nextToken, _ := request.Parameters["next_token"].(string)
+		refresh, _ := request.Parameters["refresh"].(bool)
 		limit := int32(50)
 		if l, ok := request.Parameters["limit"].(float64); ok {
 			limit = int32(l)
 		}
-		logGroups, err := am.cloudwatchService.ListLogGroups(ctx, profileID, prefix, limit)
-		return FormatResponse(logGroups, err)
+
+		cacheKey := fmt.Sprintf("%s|%s|%d|%s", profileID, prefix, limit, nextToken)
+		logGroups, ok := am.logGroupsCache.Get(cacheKey)
+		if !ok || refresh {
+			var err error
+			logGroups, err = am.cloudwatchService.ListLogGroups(ctx, profileID, prefix, limit, nextToken)
+			if err != nil {
+				return FormatResponse(nil, err)
+			}
+			am.logGroupsCache.Set(cacheKey, logGroups)
+		}
+		return FormatPagedResponse(logGroups.LogGroups, logGroups.NextToken, nil)
+	})
+
+	// Audit log groups without a retention policy
+	toolName = fmt.Sprintf("aws_logs_retention_audit_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`List CloudWatch log groups with no retention policy (kept forever) in %s, largest first.
+
+Estimates the monthly storage savings of a 30 or 90 day retention policy and returns the
+"aws logs put-retention-policy" command for each. Nothing is changed.`, profile.Description)),
+		tools.WithString("prefix", tools.Description("Optional prefix to limit the audit to matching log groups")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		prefix, _ := request.Parameters["prefix"].(string)
+		audit, err := am.cloudwatchService.AuditLogRetention(ctx, profileID, prefix)
+		return FormatResponse(audit, err)
+	})
+
+	// Set log group retention - only for profiles that opt in to mutations
+	if profile.AllowMutations {
+		toolName = fmt.Sprintf("aws_logs_set_retention_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf(`Set how long a CloudWatch log group keeps its events in %s. This changes the log group.
+
+Events older than the new retention are deleted by CloudWatch. A retention of 0 removes the
+retention policy so events never expire.`, profile.Description)),
+			tools.WithString("log_group", tools.Description("Log group name"), tools.Required()),
+			tools.WithNumber("retention_days", tools.Description("Retention in days: 0 (never expire) or one of 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			logGroup, _ := request.Parameters["log_group"].(string)
+			retentionDays, ok := request.Parameters["retention_days"].(float64)
+			if !ok {
+				return nil, fmt.Errorf("retention_days parameter is required")
+			}
+			if retentionDays != float64(int32(retentionDays)) {
+				return nil, fmt.Errorf("retention_days must be an integer, got %v", retentionDays)
+			}
+
+			var change *awspkg.RetentionChange
+			var err error
+			if retentionDays == 0 {
+				logger.Warn("Removing retention policy of log group %s (profile %s)", logGroup, profileID)
+				change, err = am.cloudwatchService.DeleteRetentionPolicy(ctx, profileID, logGroup)
+			} else {
+				logger.Warn("Setting retention of log group %s to %d days (profile %s)", logGroup, int32(retentionDays), profileID)
+				change, err = am.cloudwatchService.PutRetentionPolicy(ctx, profileID, logGroup, int32(retentionDays))
+			}
+			if err == nil {
+				// Cached log group listings of this profile show the old retention
+				am.logGroupsCache.InvalidateFunc(func(key string) bool { return strings.HasPrefix(key, profileID+"|") })
+			}
+			return FormatResponse(change, err)
+		})
+	}
+
+	// Metric filters
+	toolName = fmt.Sprintf("aws_logs_metric_filters_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the CloudWatch Logs metric filters in %s: filter pattern and the metric each publishes (namespace, metric name, value, default value, dimensions)", profile.Description)),
+		tools.WithString("log_group", tools.Description("Log group name (default: metric filters of every log group)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		logGroup, _ := request.Parameters["log_group"].(string)
+		filters, err := am.cloudwatchService.ListMetricFilters(ctx, profileID, logGroup)
+		return FormatListResponse("metric_filters", filters, err)
+	})
+
+	// Subscription filters
+	toolName = fmt.Sprintf("aws_logs_subscriptions_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the subscription filters of a CloudWatch log group in %s, showing where its logs are forwarded (Lambda, Kinesis, Firehose or a cross-account destination), with the filter pattern and distribution", profile.Description)),
+		tools.WithString("log_group", tools.Description("Log group name"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		logGroup, _ := request.Parameters["log_group"].(string)
+		filters, err := am.cloudwatchService.ListSubscriptionFilters(ctx, profileID, logGroup)
+		return FormatListResponse("subscription_filters", filters, err)
 	})
 
 	// Query logs - with human-friendly time range support
@@ -176,11 +442,11 @@ func (am *AWSManager) registerCloudWatchLogsTools(ctx context.Context, mcpServer
 		tools.WithDescription(fmt.Sprintf(`Query CloudWatch logs in %s. 
 
 TIME RANGE OPTIONS (in order of precedence):
-1. time_range: Use preset like 'last_7_days', 'last_30_days', 'this_month' (EASIEST)
+1. time_range: Use preset like 'last_7_days', 'last_30_days', 'this_month' (EASIEST), or an explicit range like '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05T12:00:00Z'
 2. start_date/end_date: Use ISO 8601 format like '2025-01-01' or '2025-01-01T10:00:00Z'
 3. start_time/end_time: Epoch milliseconds (advanced)
 
-Available time_range values: last_1_hour, last_3_hours, last_6_hours, last_12_hours, last_24_hours, last_2_days, last_3_days, last_7_days, last_14_days, last_30_days, last_60_days, last_90_days, today, yesterday, this_week, last_week, this_month, last_month
+Available time_range values: last_5_minutes, last_10_minutes, last_15_minutes, last_30_minutes, last_1_hour, last_3_hours, last_6_hours, last_12_hours, last_24_hours, last_2_days, last_3_days, last_7_days, last_14_days, last_30_days, last_60_days, last_90_days, today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, or any last_N_minutes, last_N_hours, last_N_days
 
 Defaults to last 24 hours if no time parameters specified.
 
@@ -191,7 +457,8 @@ FILTER PATTERN SYNTAX:
 - JSON fields: { $.level = "error" }`, profile.Description)),
 		tools.WithString("log_group", tools.Description("Log group name"), tools.Required()),
 		tools.WithString("filter_pattern", tools.Description("CloudWatch filter pattern. Examples: 'ERROR', 'ERROR -DEBUG', '{ $.level = \"error\" }'")),
-		tools.WithString("time_range", tools.Description("Preset time range: last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
 		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
 		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
 		tools.WithNumber("start_time", tools.Description("(Advanced) Epoch milliseconds. Use start_date for easier input.")),
@@ -207,9 +474,14 @@ FILTER PATTERN SYNTAX:
 		startTime := now.Add(-24 * time.Hour).UnixMilli()
 		endTime := now.UnixMilli()
 
+		loc, err := timeLocation(request.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
 		// Priority: time_range > start_date/end_date > start_time/end_time
 		if timeRangeStr, ok := request.Parameters["time_range"].(string); ok && timeRangeStr != "" {
-			tr, err := common.ParseTimeRange(timeRangeStr)
+			tr, err := common.ParseTimeRangeInLocation(timeRangeStr, loc)
 			if err != nil {
 				return nil, fmt.Errorf("invalid time_range: %w", err)
 			}
@@ -219,7 +491,7 @@ FILTER PATTERN SYNTAX:
 			}
 		} else if startDateStr, ok := request.Parameters["start_date"].(string); ok && startDateStr != "" {
 			// Try ISO date parsing
-			st, err := common.ParseDateTimeMillis(startDateStr)
+			st, err := common.ParseDateTimeMillisInLocation(startDateStr, loc)
 			if err != nil {
 				return nil, fmt.Errorf("invalid start_date: %w", err)
 			}
@@ -227,7 +499,7 @@ FILTER PATTERN SYNTAX:
 				startTime = st
 			}
 			if endDateStr, ok := request.Parameters["end_date"].(string); ok && endDateStr != "" {
-				et, err := common.ParseDateTimeMillis(endDateStr)
+				et, err := common.ParseDateTimeMillisInLocation(endDateStr, loc)
 				if err != nil {
 					return nil, fmt.Errorf("invalid end_date: %w", err)
 				}
@@ -254,6 +526,65 @@ FILTER PATTERN SYNTAX:
 		return FormatResponse(result, err)
 	})
 
+	// Query several log streams at once, merged into a single timeline
+	toolName = fmt.Sprintf("aws_logs_query_streams_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Query all log streams of a CloudWatch log group in %s whose names start with a prefix, merged into one view sorted by timestamp ascending. Each event includes its LogStream.
+
+USE THIS FOR: Following an ECS service across its tasks, where each task writes to its own stream (e.g. prefix 'ecs/api/').
+
+Defaults to last 24 hours if no time parameters specified.`, profile.Description)),
+		tools.WithString("log_group", tools.Description("Log group name"), tools.Required()),
+		tools.WithString("stream_prefix", tools.Description("Log stream name prefix, e.g. 'ecs/api/' for all tasks of the api container"), tools.Required()),
+		tools.WithString("filter_pattern", tools.Description("CloudWatch filter pattern. Examples: 'ERROR', '{ $.level = \"error\" }'")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+		tools.WithNumber("limit", tools.Description("Max events to return (default: 100, max: 10000)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		logGroup, _ := request.Parameters["log_group"].(string)
+		streamPrefix, _ := request.Parameters["stream_prefix"].(string)
+		filterPattern, _ := request.Parameters["filter_pattern"].(string)
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := int32(100)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+
+		result, err := am.cloudwatchService.QueryLogsAcrossStreams(ctx, profileID, logGroup, streamPrefix, filterPattern, startTime.UnixMilli(), endTime.UnixMilli(), limit)
+		return FormatResponse(result, err)
+	})
+
+	// Latest events from whichever stream was written to most recently
+	toolName = fmt.Sprintf("aws_logs_latest_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get the newest events from the most recently active log stream of a CloudWatch log group in %s.
+
+USE THIS FOR: "Just show me the latest logs" - picks the stream with the latest event (e.g. the currently running ECS task or Lambda instance) and returns its last events, oldest first, along with the stream details.`, profile.Description)),
+		tools.WithString("log_group", tools.Description("Log group name"), tools.Required()),
+		tools.WithNumber("limit", tools.Description("Number of events to return (default: 50, max: 10000)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		logGroup, _ := request.Parameters["log_group"].(string)
+
+		limit := int32(50)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+
+		result, err := am.cloudwatchService.GetLatestStreamEvents(ctx, profileID, logGroup, limit)
+		return FormatResponse(result, err)
+	})
+
 	// CloudWatch Logs Insights query - for complex queries over large time ranges
 	toolName = fmt.Sprintf("aws_logs_insights_%s", profileID)
 	tool = tools.NewTool(
@@ -263,22 +594,28 @@ FILTER PATTERN SYNTAX:
 USE THIS FOR: Complex queries, aggregations, statistics, searching multiple log groups, large time ranges.
 
 TIME RANGE OPTIONS (in order of precedence):
-1. time_range: Use preset like 'last_7_days', 'last_30_days', 'this_month' (EASIEST)
+1. time_range: Use preset like 'last_7_days', 'last_30_days', 'this_month' (EASIEST), or an explicit range like '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05T12:00:00Z'
 2. start_date/end_date: Use ISO 8601 format like '2025-01-01' or '2025-01-01T10:00:00Z'
 3. start_time/end_time: Epoch milliseconds (advanced)
 
 QUERY EXAMPLES:
 - Find errors: fields @timestamp, @message | filter @message like /ERROR/ | sort @timestamp desc
 - Count by hour: filter @message like /ERROR/ | stats count(*) by bin(1h)
-- Top log streams: stats count(*) as cnt by @logStream | sort cnt desc | limit 10`, profile.Description)),
+- Top log streams: stats count(*) as cnt by @logStream | sort cnt desc | limit 10
+
+RESULT STATUS: complete is true only when the query finished. A query still running after max_wait_seconds is stopped and returns its partial results with timed_out: true; truncated: true means more rows matched than limit allowed.
+
+For top errors, latency percentiles, status counts or slowest requests, aws_logs_insights_template_%s runs a ready-made query.`, profile.Description, profileID)),
 		tools.WithString("log_groups", tools.Description("Comma-separated list of log group names to query"), tools.Required()),
 		tools.WithString("query", tools.Description("CloudWatch Logs Insights query string"), tools.Required()),
-		tools.WithString("time_range", tools.Description("Preset time range: last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
 		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
 		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
 		tools.WithNumber("start_time", tools.Description("(Advanced) Epoch milliseconds. Use start_date for easier input.")),
 		tools.WithNumber("end_time", tools.Description("(Advanced) Epoch milliseconds. Use end_date for easier input.")),
 		tools.WithNumber("limit", tools.Description("Max results (default: 100, max: 10000)")),
+		tools.WithNumber("max_wait_seconds", tools.Description("Longest time to wait for the query before returning partial results (default: 60)")),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
 		logGroupsStr, _ := request.Parameters["log_groups"].(string)
@@ -295,9 +632,14 @@ QUERY EXAMPLES:
 		startTime := now.Add(-24 * time.Hour).UnixMilli()
 		endTime := now.UnixMilli()
 
+		loc, err := timeLocation(request.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
 		// Priority: time_range > start_date/end_date > start_time/end_time
 		if timeRangeStr, ok := request.Parameters["time_range"].(string); ok && timeRangeStr != "" {
-			tr, err := common.ParseTimeRange(timeRangeStr)
+			tr, err := common.ParseTimeRangeInLocation(timeRangeStr, loc)
 			if err != nil {
 				return nil, fmt.Errorf("invalid time_range: %w", err)
 			}
@@ -307,7 +649,7 @@ QUERY EXAMPLES:
 			}
 		} else if startDateStr, ok := request.Parameters["start_date"].(string); ok && startDateStr != "" {
 			// Try ISO date parsing
-			st, err := common.ParseDateTimeMillis(startDateStr)
+			st, err := common.ParseDateTimeMillisInLocation(startDateStr, loc)
 			if err != nil {
 				return nil, fmt.Errorf("invalid start_date: %w", err)
 			}
@@ -315,7 +657,7 @@ QUERY EXAMPLES:
 				startTime = st
 			}
 			if endDateStr, ok := request.Parameters["end_date"].(string); ok && endDateStr != "" {
-				et, err := common.ParseDateTimeMillis(endDateStr)
+				et, err := common.ParseDateTimeMillisInLocation(endDateStr, loc)
 				if err != nil {
 					return nil, fmt.Errorf("invalid end_date: %w", err)
 				}
@@ -337,7 +679,104 @@ QUERY EXAMPLES:
 			limit = int32(l)
 		}
 
-		result, err := am.cloudwatchService.RunInsightsQuery(ctx, profileID, logGroups, queryStr, startTime, endTime, limit)
+		var maxWait time.Duration
+		if w, ok := request.Parameters["max_wait_seconds"].(float64); ok && w > 0 {
+			maxWait = time.Duration(w * float64(time.Second))
+		}
+
+		result, err := am.cloudwatchService.RunInsightsQueryWithStatus(ctx, profileID, logGroups, queryStr, startTime, endTime, limit, maxWait)
+		return FormatResponse(result, err)
+	})
+
+	// CloudWatch Logs Insights templates - common analyses without writing Insights syntax
+	var templateHelp strings.Builder
+	for _, template := range awspkg.InsightsTemplates() {
+		fmt.Fprintf(&templateHelp, "\n- %s: %s", template.Name, template.Description)
+		for _, param := range template.Parameters {
+			fmt.Fprintf(&templateHelp, " [%s, default %s]", param.Name, param.Default)
+		}
+	}
+	toolName = fmt.Sprintf("aws_logs_insights_template_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Run a built-in CloudWatch Logs Insights query template in %s.
+
+USE THIS FOR: Common log analyses without writing Insights syntax. Use aws_logs_insights_%s for custom queries.
+
+TEMPLATES:%s
+
+Returns the rendered query alongside the results.`, profile.Description, profileID, templateHelp.String())),
+		tools.WithString("template", tools.Description("Template name: "+strings.Join(awspkg.InsightsTemplateNames(), ", ")), tools.Required()),
+		tools.WithString("log_groups", tools.Description("Comma-separated list of log group names to query"), tools.Required()),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+		tools.WithString("error_pattern", tools.Description("top_errors: regex like '/(?i)timeout/' or literal text identifying error lines")),
+		tools.WithString("latency_field", tools.Description("latency_percentiles, slowest_requests: numeric latency field (default: @duration)")),
+		tools.WithString("status_field", tools.Description("request_count_by_status: status code field (default: status)")),
+		tools.WithString("bin", tools.Description("latency_percentiles: time bin such as 5m or 1h (default: 1h)")),
+		tools.WithNumber("limit", tools.Description("top_errors, slowest_requests: number of rows (default: 20)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		templateName, _ := request.Parameters["template"].(string)
+		logGroupsStr, _ := request.Parameters["log_groups"].(string)
+		logGroups := splitCommaList(logGroupsStr)
+		if len(logGroups) == 0 {
+			return nil, fmt.Errorf("log_groups is required")
+		}
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		params := make(map[string]string)
+		for _, name := range []string{"error_pattern", "latency_field", "status_field", "bin"} {
+			if value, ok := request.Parameters[name].(string); ok {
+				params[name] = value
+			}
+		}
+		if l, ok := request.Parameters["limit"].(float64); ok && l > 0 {
+			params["limit"] = strconv.Itoa(int(l))
+		}
+
+		// Templates bound their own output, so fetch up to the Insights maximum
+		result, err := am.cloudwatchService.RunInsightsTemplate(ctx, profileID, logGroups, templateName, params, startTime.UnixMilli(), endTime.UnixMilli(), 10000)
+		return FormatResponse(result, err)
+	})
+
+	// Live tail - streams new events for a bounded duration
+	toolName = fmt.Sprintf("aws_logs_tail_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Live tail a CloudWatch log group in %s.
+
+Streams new events as they arrive (CloudWatch StartLiveTail) until duration_seconds elapses or max_events are received.
+Session frames such as resets or sampling are reported under "statuses".`, profile.Description)),
+		tools.WithString("log_group", tools.Description("Log group name or ARN"), tools.Required()),
+		tools.WithString("filter_pattern", tools.Description("CloudWatch filter pattern. Examples: 'ERROR', '{ $.level = \"error\" }'")),
+		tools.WithNumber("duration_seconds", tools.Description("How long to tail (default: 30, max: 300)")),
+		tools.WithNumber("max_events", tools.Description("Stop after this many events (default: 500)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		logGroup, _ := request.Parameters["log_group"].(string)
+		filterPattern, _ := request.Parameters["filter_pattern"].(string)
+
+		duration := 30 * time.Second
+		if d, ok := request.Parameters["duration_seconds"].(float64); ok && d > 0 {
+			duration = time.Duration(d) * time.Second
+		}
+		if duration > 300*time.Second {
+			duration = 300 * time.Second
+		}
+
+		maxEvents := 500
+		if m, ok := request.Parameters["max_events"].(float64); ok && m > 0 {
+			maxEvents = int(m)
+		}
+
+		result, err := am.cloudwatchService.TailLiveLogs(ctx, profileID, logGroup, filterPattern, duration, maxEvents)
 		return FormatResponse(result, err)
 	})
 
@@ -345,7 +784,7 @@ QUERY EXAMPLES:
 }
 
 // registerECSTools registers ECS tools
-func (am *AWSManager) registerECSTools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
+func (am *AWSManager) registerECSTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
 	// List clusters
 	toolName := fmt.Sprintf("aws_ecs_clusters_%s", profileID)
 	tool := tools.NewTool(
@@ -354,7 +793,7 @@ func (am *AWSManager) registerECSTools(ctx context.Context, mcpServer *server.MC
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
 		clusters, err := am.ecsService.ListClusters(ctx, profileID)
-		return FormatResponse(clusters, err)
+		return FormatListResponse("clusters", clusters, err)
 	})
 
 	// List services
@@ -363,27 +802,210 @@ func (am *AWSManager) registerECSTools(ctx context.Context, mcpServer *server.MC
 		toolName,
 		tools.WithDescription(fmt.Sprintf("List ECS services in %s", profile.Description)),
 		tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+		tools.WithBoolean("only_problems", tools.Description("Only return services whose running task count differs from the desired count, with their details (default: false)")),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
 		clusterName, _ := request.Parameters["cluster_name"].(string)
+		onlyProblems, _ := request.Parameters["only_problems"].(bool)
 		services, err := am.ecsService.ListServices(ctx, profileID, clusterName)
-		return FormatResponse(services, err)
+		if err != nil || !onlyProblems {
+			return FormatListResponse("services", services, err)
+		}
+
+		// The ARN list carries no task counts, so describe the services to filter them
+		described, err := am.ecsService.DescribeServices(ctx, profileID, clusterName, services)
+		if err != nil {
+			return FormatResponse(nil, err)
+		}
+		return FormatListResponse("services", onlyUnhealthy(described), nil)
+	})
+
+	// Deployment history timeline
+	toolName = fmt.Sprintf("aws_ecs_deployment_history_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get a chronological deployment timeline for an ECS service in %s.
+
+Combines service deployments (revision, rollout state, duration), task definition registrations and recent service events
+to answer "what deployed and when did it break".`, profile.Description)),
+		tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+		tools.WithString("service_name", tools.Description("Service name or ARN"), tools.Required()),
+		tools.WithNumber("limit", tools.Description("Maximum timeline entries, most recent kept (default: 50)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		clusterName, _ := request.Parameters["cluster_name"].(string)
+		serviceName, _ := request.Parameters["service_name"].(string)
+		limit := 50
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int(l)
+		}
+		history, err := am.ecsService.GetDeploymentHistory(ctx, profileID, clusterName, serviceName, limit)
+		return FormatResponse(history, err)
+	})
+
+	// Current rollout state of a service
+	toolName = fmt.Sprintf("aws_ecs_deployment_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get the current deployment status of an ECS service in %s: whether the rollout is stable, in_progress, stuck or failed, each active deployment (rollout state and reason, desired/running/pending/failed tasks) and the last 10 service events.
+
+USE THIS FOR: "Is my deployment stuck?" - a PRIMARY deployment still IN_PROGRESS after 30 minutes is reported as stuck.
+Services behind a load balancer also return target_group_arns; pass one to aws_elb_target_health to see why tasks fail health checks.`, profile.Description)),
+		tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+		tools.WithString("service_name", tools.Description("Service name or ARN"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		clusterName, _ := request.Parameters["cluster_name"].(string)
+		serviceName, _ := request.Parameters["service_name"].(string)
+		status, err := am.ecsService.GetServiceDeploymentStatus(ctx, profileID, clusterName, serviceName)
+		return FormatResponse(status, err)
+	})
+
+	// CloudWatch metrics of a service
+	toolName = fmt.Sprintf("aws_ecs_metrics_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get CloudWatch metrics of an ECS service in %s as time-ordered series with RFC3339 timestamps.
+
+By default returns CPUUtilization and MemoryUtilization (percent of reserved) from AWS/ECS. With use_container_insights, returns RunningTaskCount, CpuUtilized (CPU units) and MemoryUtilized (MiB) from Container Insights; a cluster without Container Insights returns empty series and a note instead of an error.`, profile.Description)),
+		tools.WithString("cluster_name", tools.Description("Cluster name"), tools.Required()),
+		tools.WithString("service_name", tools.Description("Service name"), tools.Required()),
+		tools.WithBoolean("use_container_insights", tools.Description("Read Container Insights metrics instead of the standard service metrics (default: false)")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05' (default: last 3 hours)")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		clusterName, _ := request.Parameters["cluster_name"].(string)
+		serviceName, _ := request.Parameters["service_name"].(string)
+		useContainerInsights, _ := request.Parameters["use_container_insights"].(bool)
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 3*time.Hour)
+		if err != nil {
+			return FormatResponse(nil, err)
+		}
+		metrics, err := am.metricsService.GetECSMetricsInRange(ctx, profileID, clusterName, serviceName, startTime, endTime, useContainerInsights)
+		return FormatResponse(metrics, err)
+	})
+
+	// Recent logs of every running task of a service, interleaved
+	toolName = fmt.Sprintf("aws_ecs_service_logs_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Show what all running tasks of an ECS service in %s are logging, merged into one timeline sorted by timestamp ascending. Each event includes its task_id and container.
+
+Log streams are resolved from the awslogs configuration of each task's task definition, so no log group or stream prefix is needed.
+
+Defaults to the last 15 minutes if no time parameters specified.`, profile.Description)),
+		tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+		tools.WithString("service_name", tools.Description("Service name or ARN"), tools.Required()),
+		tools.WithString("filter_pattern", tools.Description("CloudWatch filter pattern. Examples: 'ERROR', '{ $.level = \"error\" }'")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+		tools.WithNumber("limit", tools.Description("Max events to return, newest kept (default: 200, max: 10000)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		clusterName, _ := request.Parameters["cluster_name"].(string)
+		serviceName, _ := request.Parameters["service_name"].(string)
+		filterPattern, _ := request.Parameters["filter_pattern"].(string)
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 15*time.Minute)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := int32(200)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+
+		result, err := am.ecsService.GetServiceLogs(ctx, profileID, clusterName, serviceName, filterPattern, startTime.UnixMilli(), endTime.UnixMilli(), limit)
+		return FormatResponse(result, err)
+	})
+
+	// Container logs of a single task
+	toolName = fmt.Sprintf("aws_ecs_task_logs_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get the most recent log events of each container of an ECS task in %s.
+
+The log group and stream are derived from the awslogs log configuration of the task definition, so only the task is needed. Fails with a descriptive error if no container uses the awslogs log driver.`, profile.Description)),
+		tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+		tools.WithString("task_arn", tools.Description("Task ARN or task ID"), tools.Required()),
+		tools.WithNumber("limit", tools.Description("Events per container, most recent (default: 100, max: 10000)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		clusterName, _ := request.Parameters["cluster_name"].(string)
+		taskARN, _ := request.Parameters["task_arn"].(string)
+
+		limit := int32(100)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+
+		logs, err := am.ecsService.GetTaskLogs(ctx, profileID, clusterName, taskARN, limit)
+		return FormatResponse(logs, err)
 	})
 
+	// Scale service - only for profiles that opt in to mutations
+	if profile.AllowMutations {
+		toolName = fmt.Sprintf("aws_ecs_scale_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Set the desired task count of an ECS service in %s. This changes the running service.", profile.Description)),
+			tools.WithString("cluster_name", tools.Description("Cluster name or ARN"), tools.Required()),
+			tools.WithString("service_name", tools.Description("Service name or ARN"), tools.Required()),
+			tools.WithNumber("desired_count", tools.Description("New desired task count (0 or more)"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			clusterName, _ := request.Parameters["cluster_name"].(string)
+			serviceName, _ := request.Parameters["service_name"].(string)
+			desiredCount, ok := request.Parameters["desired_count"].(float64)
+			if !ok {
+				return nil, fmt.Errorf("desired_count parameter is required")
+			}
+			if desiredCount < 0 || desiredCount != float64(int32(desiredCount)) {
+				return nil, fmt.Errorf("desired_count must be a non-negative integer, got %v", desiredCount)
+			}
+			logger.Warn("Scaling ECS service %s/%s to %d (profile %s)", clusterName, serviceName, int32(desiredCount), profileID)
+			service, err := am.ecsService.UpdateServiceDesiredCount(ctx, profileID, clusterName, serviceName, int32(desiredCount))
+			return FormatResponse(service, err)
+		})
+	}
+
 	logger.Info("Registered ECS tools for profile %s", profileID)
 }
 
 // registerRDSTools registers RDS tools
-func (am *AWSManager) registerRDSTools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
+func (am *AWSManager) registerRDSTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
 	// List DB instances
 	toolName := fmt.Sprintf("aws_rds_list_%s", profileID)
 	tool := tools.NewTool(
 		toolName,
 		tools.WithDescription(fmt.Sprintf("List RDS instances in %s", profile.Description)),
+		tools.WithBoolean("refresh", tools.Description("Bypass the short-lived list cache and call AWS")),
+		tools.WithBoolean("only_problems", tools.Description("Only return instances whose status is not available (default: false)")),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
-		instances, err := am.rdsService.ListDBInstances(ctx, profileID)
-		return FormatResponse(instances, err)
+		refresh, _ := request.Parameters["refresh"].(bool)
+		onlyProblems, _ := request.Parameters["only_problems"].(bool)
+
+		instances, ok := am.dbInstancesCache.Get(profileID)
+		if !ok || refresh {
+			var err error
+			instances, err = am.rdsService.ListDBInstances(ctx, profileID)
+			if err != nil {
+				return FormatResponse(nil, err)
+			}
+			am.dbInstancesCache.Set(profileID, instances)
+		}
+
+		if onlyProblems {
+			instances = onlyUnhealthy(instances)
+		}
+		return FormatListResponse("instances", instances, nil)
 	})
 
 	// Describe DB instance
@@ -399,39 +1021,345 @@ func (am *AWSManager) registerRDSTools(ctx context.Context, mcpServer *server.MC
 		return FormatResponse(instance, err)
 	})
 
-	logger.Info("Registered RDS tools for profile %s", profileID)
-}
-
-// registerEC2Tools registers EC2 tools
-func (am *AWSManager) registerEC2Tools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
-	toolName := fmt.Sprintf("aws_ec2_instances_%s", profileID)
-	tool := tools.NewTool(
+	// Describe DB cluster
+	toolName = fmt.Sprintf("aws_rds_cluster_describe_%s", profileID)
+	tool = tools.NewTool(
 		toolName,
-		tools.WithDescription(fmt.Sprintf("List EC2 instances in %s", profile.Description)),
+		tools.WithDescription(fmt.Sprintf("Get RDS/Aurora cluster details in %s, including the current writer, reader/writer endpoints and member failover priorities", profile.Description)),
+		tools.WithString("identifier", tools.Description("DB cluster identifier"), tools.Required()),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		identifier, _ := request.Parameters["identifier"].(string)
+		cluster, err := am.rdsService.DescribeDBCluster(ctx, profileID, identifier)
+		return FormatResponse(cluster, err)
+	})
+
+	// Log files
+	toolName = fmt.Sprintf("aws_rds_log_files_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the log files of an RDS instance in %s (error, slow query and general logs) with their size and last write time", profile.Description)),
+		tools.WithString("identifier", tools.Description("DB instance identifier"), tools.Required()),
+		tools.WithString("filename_contains", tools.Description("Only files whose name contains this text, e.g. 'slowquery'")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		identifier, _ := request.Parameters["identifier"].(string)
+		filenameContains, _ := request.Parameters["filename_contains"].(string)
+		files, err := am.rdsService.ListDBLogFiles(ctx, profileID, identifier, filenameContains)
+		return FormatListResponse("log_files", files, err)
+	})
+
+	// Slow query log
+	toolName = fmt.Sprintf("aws_rds_slow_queries_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Fetch and parse the slow query log of an RDS instance in %s, slowest statements first.
+
+MySQL/MariaDB: parses the slow query log file (requires slow_query_log=1 and log_output=FILE); entries carry duration, lock time, rows sent and rows examined.
+PostgreSQL: parses the statements log_min_duration_statement writes to the error log; entries carry duration, user and database.
+Defaults to the most recently written log file; use aws_rds_log_files to pick an older one.`, profile.Description)),
+		tools.WithString("identifier", tools.Description("DB instance identifier"), tools.Required()),
+		tools.WithString("log_file", tools.Description("Log file name, e.g. 'slowquery/mysql-slowquery.log.3' or 'error/postgresql.log.2025-01-09-15' (default: the latest)")),
+		tools.WithNumber("limit", tools.Description("Maximum entries to return (default: 50)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		identifier, _ := request.Parameters["identifier"].(string)
+		logFile, _ := request.Parameters["log_file"].(string)
+		limit := 50
+		if l, ok := request.Parameters["limit"].(float64); ok && l > 0 {
+			limit = int(l)
+		}
+		slowQueries, err := am.rdsService.GetSlowQueries(ctx, profileID, identifier, logFile, limit)
+		return FormatResponse(slowQueries, err)
+	})
+
+	// CloudWatch metrics
+	toolName = fmt.Sprintf("aws_rds_metrics_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get CloudWatch metrics of an RDS instance in %s: CPU utilization and credit balance, connections, disk queue depth, freeable memory, free storage and read/write latency, as time-ordered series with RFC3339 timestamps", profile.Description)),
+		tools.WithString("identifier", tools.Description("DB instance identifier"), tools.Required()),
+		tools.WithNumber("hours_back", tools.Description("Hours of history to fetch (default: 3). Ignored if time_range or start_date provided.")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		identifier, _ := request.Parameters["identifier"].(string)
+		hoursBack := 3
+		if h, ok := request.Parameters["hours_back"].(float64); ok && h > 0 {
+			hoursBack = int(h)
+		}
+		startTime, endTime, err := parseTimeWindow(request.Parameters, time.Duration(hoursBack)*time.Hour)
+		if err != nil {
+			return FormatResponse(nil, err)
+		}
+		metrics, err := am.metricsService.GetRDSMetricsInRange(ctx, profileID, identifier, startTime, endTime)
+		return FormatResponse(metrics, err)
+	})
+
+	// Start/stop instances - only for profiles that opt in to mutations
+	if profile.AllowMutations {
+		toolName = fmt.Sprintf("aws_rds_start_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Start a stopped RDS instance in %s. This changes the running instance.", profile.Description)),
+			tools.WithString("identifier", tools.Description("DB instance identifier"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			identifier, _ := request.Parameters["identifier"].(string)
+			logger.Warn("Starting RDS instance %s (profile %s)", identifier, profileID)
+			change, err := am.rdsService.StartDBInstance(ctx, profileID, identifier)
+			am.dbInstancesCache.Invalidate(profileID)
+			return FormatResponse(change, err)
+		})
+
+		toolName = fmt.Sprintf("aws_rds_stop_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Stop an available RDS instance in %s. Multi-AZ instances, read replicas and cluster members are rejected. This changes the running instance.", profile.Description)),
+			tools.WithString("identifier", tools.Description("DB instance identifier"), tools.Required()),
+			tools.WithString("snapshot_identifier", tools.Description("Optional name of a snapshot to take before stopping")),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			identifier, _ := request.Parameters["identifier"].(string)
+			snapshotID, _ := request.Parameters["snapshot_identifier"].(string)
+			logger.Warn("Stopping RDS instance %s (profile %s)", identifier, profileID)
+			change, err := am.rdsService.StopDBInstance(ctx, profileID, identifier, snapshotID)
+			am.dbInstancesCache.Invalidate(profileID)
+			return FormatResponse(change, err)
+		})
+	}
+
+	logger.Info("Registered RDS tools for profile %s", profileID)
+}
+
+// registerEC2Tools registers EC2 tools
+func (am *AWSManager) registerEC2Tools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	toolName := fmt.Sprintf("aws_ec2_instances_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List EC2 instances in %s", profile.Description)),
+		tools.WithBoolean("only_problems", tools.Description("Only return instances that are not running, such as stopped, stopping or pending ones; terminated instances are left out (default: false)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		onlyProblems, _ := request.Parameters["only_problems"].(bool)
 		instances, err := am.ec2Service.ListInstances(ctx, profileID)
-		return FormatResponse(instances, err)
+		if err == nil && onlyProblems {
+			instances = onlyUnhealthy(instances)
+		}
+		return FormatListResponse("instances", instances, err)
+	})
+
+	// Security group rules
+	toolName = fmt.Sprintf("aws_ec2_security_group_rules_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get the ingress and egress rules of EC2 security groups in %s, one rule per protocol, port range and CIDR or referenced security group. Ingress rules open to 0.0.0.0/0 or ::/0 on sensitive ports (SSH, RDP, databases) or all ports carry a warning.", profile.Description)),
+		tools.WithString("group_ids", tools.Description("Comma-separated security group IDs (default: all groups)")),
+		tools.WithString("vpc_id", tools.Description("Only groups in this VPC")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		groupIDs, _ := request.Parameters["group_ids"].(string)
+		vpcID, _ := request.Parameters["vpc_id"].(string)
+		rules, err := am.ec2Service.DescribeSecurityGroupRules(ctx, profileID, splitCommaList(groupIDs), vpcID)
+		return FormatListResponse("rules", rules, err)
+	})
+
+	// EBS volumes
+	toolName = fmt.Sprintf("aws_ec2_volumes_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List EBS volumes in %s with size, type, IOPS, throughput, encryption, state and the instances they are attached to", profile.Description)),
+		tools.WithString("instance_id", tools.Description("Only volumes attached to this instance")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		instanceID, _ := request.Parameters["instance_id"].(string)
+		volumes, err := am.ec2Service.ListVolumes(ctx, profileID, instanceID)
+		return FormatListResponse("volumes", volumes, err)
+	})
+
+	// EBS snapshots
+	toolName = fmt.Sprintf("aws_ec2_snapshots_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List EBS snapshots in %s with source volume, size, state, progress and start time. Public snapshots are never included.", profile.Description)),
+		tools.WithBoolean("owner_self", tools.Description("Only snapshots owned by this account; false also includes snapshots shared with it (default: true)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		ownerSelf := true
+		if o, ok := request.Parameters["owner_self"].(bool); ok {
+			ownerSelf = o
+		}
+		snapshots, err := am.ec2Service.ListSnapshots(ctx, profileID, ownerSelf)
+		return FormatListResponse("snapshots", snapshots, err)
+	})
+
+	// CloudWatch metrics
+	toolName = fmt.Sprintf("aws_ec2_metrics_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get CloudWatch metrics of an EC2 instance in %s: CPU utilization, network and instance-store disk bytes, and failed status checks, as time-ordered series with RFC3339 timestamps", profile.Description)),
+		tools.WithString("instance_id", tools.Description("Instance ID"), tools.Required()),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05' (default: last 3 hours)")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		instanceID, _ := request.Parameters["instance_id"].(string)
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 3*time.Hour)
+		if err != nil {
+			return FormatResponse(nil, err)
+		}
+		metrics, err := am.metricsService.GetEC2MetricsInRange(ctx, profileID, instanceID, startTime, endTime)
+		return FormatResponse(metrics, err)
+	})
+
+	// Serial console output
+	toolName = fmt.Sprintf("aws_ec2_console_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get the serial console output of an EC2 instance in %s as text, to debug instances that fail to boot (kernel panics, fsck failures, cloud-init errors)", profile.Description)),
+		tools.WithString("instance_id", tools.Description("Instance ID"), tools.Required()),
+		tools.WithBoolean("latest", tools.Description("Get the most recent output instead of the output captured at the last boot; Nitro instances only (default: false)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		instanceID, _ := request.Parameters["instance_id"].(string)
+		latest, _ := request.Parameters["latest"].(bool)
+		output, err := am.ec2Service.GetConsoleOutput(ctx, profileID, instanceID, latest)
+		return FormatResponse(output, err)
 	})
+
+	// Start/stop/reboot instances - only for profiles that opt in to mutations
+	if profile.AllowMutations {
+		toolName = fmt.Sprintf("aws_ec2_start_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Start EC2 instances in %s. Returns the previous and current state per instance. This changes running resources.", profile.Description)),
+			tools.WithString("instance_ids", tools.Description("Comma-separated list of instance IDs"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			instanceIDsStr, _ := request.Parameters["instance_ids"].(string)
+			instanceIDs := splitCommaList(instanceIDsStr)
+			logger.Warn("Starting EC2 instances %v (profile %s)", instanceIDs, profileID)
+			results, err := am.ec2Service.StartInstances(ctx, profileID, instanceIDs)
+			return FormatResponse(results, err)
+		})
+
+		toolName = fmt.Sprintf("aws_ec2_stop_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Stop EC2 instances in %s. Returns the previous and current state per instance. This changes running resources.", profile.Description)),
+			tools.WithString("instance_ids", tools.Description("Comma-separated list of instance IDs"), tools.Required()),
+			tools.WithBoolean("force", tools.Description("Force the stop without a graceful OS shutdown (default: false)")),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			instanceIDsStr, _ := request.Parameters["instance_ids"].(string)
+			force, _ := request.Parameters["force"].(bool)
+			instanceIDs := splitCommaList(instanceIDsStr)
+			logger.Warn("Stopping EC2 instances %v (force=%t, profile %s)", instanceIDs, force, profileID)
+			results, err := am.ec2Service.StopInstances(ctx, profileID, instanceIDs, force)
+			return FormatResponse(results, err)
+		})
+
+		toolName = fmt.Sprintf("aws_ec2_reboot_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Reboot EC2 instances in %s. Returns the state per instance. This changes running resources.", profile.Description)),
+			tools.WithString("instance_ids", tools.Description("Comma-separated list of instance IDs"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			instanceIDsStr, _ := request.Parameters["instance_ids"].(string)
+			instanceIDs := splitCommaList(instanceIDsStr)
+			logger.Warn("Rebooting EC2 instances %v (profile %s)", instanceIDs, profileID)
+			results, err := am.ec2Service.RebootInstances(ctx, profileID, instanceIDs)
+			return FormatResponse(results, err)
+		})
+	}
+
 	logger.Info("Registered EC2 tools for profile %s", profileID)
 }
 
+// splitCommaList splits a comma-separated parameter, dropping empty entries
+func splitCommaList(value string) []string {
+	parts := strings.Split(value, ",")
+	items := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+	return items
+}
+
+// onlyUnhealthy keeps the resources that report a problem, for the only_problems filter
+// of the list tools
+func onlyUnhealthy[T interface{ Unhealthy() bool }](items []T) []T {
+	problems := make([]T, 0)
+	for _, item := range items {
+		if item.Unhealthy() {
+			problems = append(problems, item)
+		}
+	}
+	return problems
+}
+
 // registerLambdaTools registers Lambda tools
-func (am *AWSManager) registerLambdaTools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
+func (am *AWSManager) registerLambdaTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
 	toolName := fmt.Sprintf("aws_lambda_list_%s", profileID)
 	tool := tools.NewTool(
 		toolName,
 		tools.WithDescription(fmt.Sprintf("List Lambda functions in %s", profile.Description)),
+		tools.WithBoolean("only_problems", tools.Description("Only return functions whose state is not Active or whose last update failed; reads each function's state, one call per function (default: false)")),
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		onlyProblems, _ := request.Parameters["only_problems"].(bool)
 		functions, err := am.lambdaService.ListFunctions(ctx, profileID)
-		return FormatResponse(functions, err)
+		if err != nil || !onlyProblems {
+			return FormatListResponse("functions", functions, err)
+		}
+
+		// ListFunctions does not return function states
+		if err := am.lambdaService.LoadFunctionStates(ctx, profileID, functions); err != nil {
+			return FormatResponse(nil, err)
+		}
+		return FormatListResponse("functions", onlyUnhealthy(functions), nil)
+	})
+
+	// Performance report combining metrics and cold starts from the REPORT log lines
+	toolName = fmt.Sprintf("aws_lambda_performance_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Summarize the performance of a Lambda function in %s.
+
+Returns p50/p95/p99 duration, error rate, throttles and peak concurrency from CloudWatch metrics, plus cold-start rate and init duration from a Logs Insights query on the function's log group.
+Defaults to last 24 hours if no time parameters specified.`, profile.Description)),
+		tools.WithString("function_name", tools.Description("Function name"), tools.Required()),
+		tools.WithString("log_group", tools.Description("Log group the function writes to (default: /aws/lambda/<function_name>)")),
+		tools.WithString("time_range", tools.Description("Preset time range (last_1_hour, last_24_hours, last_7_days, last_30_days, this_month, etc.) or an explicit range: '2025-01-01..2025-01-05' or '2025-01-01 to 2025-01-05'")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		functionName, _ := request.Parameters["function_name"].(string)
+		logGroup, _ := request.Parameters["log_group"].(string)
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := am.lambdaService.GetPerformance(ctx, profileID, functionName, logGroup, startTime, endTime)
+		return FormatResponse(report, err)
 	})
 	logger.Info("Registered Lambda tools for profile %s", profileID)
 }
 
 // registerSecretsTools registers Secrets Manager tools
-func (am *AWSManager) registerSecretsTools(ctx context.Context, mcpServer *server.MCPServer, profileID string, profile *awspkg.ProfileConfig) {
+func (am *AWSManager) registerSecretsTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
 	toolName := fmt.Sprintf("aws_secrets_list_%s", profileID)
 	tool := tools.NewTool(
 		toolName,
@@ -439,7 +1367,508 @@ func (am *AWSManager) registerSecretsTools(ctx context.Context, mcpServer *serve
 	)
 	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
 		secrets, err := am.secretsService.ListSecrets(ctx, profileID)
-		return FormatResponse(secrets, err)
+		return FormatListResponse("secrets", secrets, err)
+	})
+
+	// Rotation status
+	toolName = fmt.Sprintf("aws_secrets_rotation_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get the rotation status of a Secrets Manager secret in %s: whether rotation is enabled, the rotation Lambda, the schedule, and the last and next rotation dates", profile.Description)),
+		tools.WithString("secret_id", tools.Description("Secret name or ARN"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		secretID, _ := request.Parameters["secret_id"].(string)
+		status, err := am.secretsService.GetRotationStatus(ctx, profileID, secretID)
+		return FormatResponse(status, err)
 	})
+
+	// Read secret values - only for profiles that opt in to secret reads
+	if profile.AllowSecretReads {
+		toolName = fmt.Sprintf("aws_secrets_get_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf(`Get the current value of a Secrets Manager secret in %s.
+
+By default the value is NOT returned: only its SHA-256 fingerprint and length, which is enough to confirm a secret exists or has changed between calls. Set reveal to true only when the plaintext is really needed.`, profile.Description)),
+			tools.WithString("secret_id", tools.Description("Secret name or ARN"), tools.Required()),
+			tools.WithBoolean("reveal", tools.Description("Return the plaintext value instead of only its fingerprint (default: false)")),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			secretID, _ := request.Parameters["secret_id"].(string)
+			reveal, _ := request.Parameters["reveal"].(bool)
+			secret, err := am.secretsService.ReadSecret(ctx, profileID, secretID, reveal)
+			if err == nil && reveal {
+				logger.Info("Revealed secret %s (version %s) for profile %s", secret.ARN, secret.VersionID, profileID)
+			}
+			return FormatResponse(secret, err)
+		})
+	}
+
+	// Create/update/rotate secret values - only for profiles that opt in to secret writes
+	if profile.AllowSecretWrites {
+		toolName = fmt.Sprintf("aws_secrets_create_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Create a Secrets Manager secret in %s. Returns the secret ARN and version ID; the value is never echoed back or logged.", profile.Description)),
+			tools.WithString("name", tools.Description("Secret name, e.g. 'prod/api/db-password'"), tools.Required()),
+			tools.WithString("secret_string", tools.Description("Secret value, usually a string or a JSON object"), tools.Required()),
+			tools.WithString("description", tools.Description("Secret description")),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			name, _ := request.Parameters["name"].(string)
+			secretString, _ := request.Parameters["secret_string"].(string)
+			description, _ := request.Parameters["description"].(string)
+			result, err := am.secretsService.CreateSecret(ctx, profileID, name, description, secretString)
+			if err == nil {
+				logger.Info("Created %s for profile %s", result, profileID)
+			}
+			return FormatResponse(result, err)
+		})
+
+		toolName = fmt.Sprintf("aws_secrets_update_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Store a new value for an existing Secrets Manager secret in %s. The new version becomes AWSCURRENT; returns the secret ARN and new version ID without the value.", profile.Description)),
+			tools.WithString("secret_id", tools.Description("Secret name or ARN"), tools.Required()),
+			tools.WithString("secret_string", tools.Description("New secret value"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			secretID, _ := request.Parameters["secret_id"].(string)
+			secretString, _ := request.Parameters["secret_string"].(string)
+			result, err := am.secretsService.PutSecretValue(ctx, profileID, secretID, secretString)
+			if err == nil {
+				logger.Info("Updated %s for profile %s", result, profileID)
+			}
+			return FormatResponse(result, err)
+		})
+
+		toolName = fmt.Sprintf("aws_secrets_rotate_%s", profileID)
+		tool = tools.NewTool(
+			toolName,
+			tools.WithDescription(fmt.Sprintf("Rotate a Secrets Manager secret in %s now, using its configured rotation Lambda. Fails if rotation is not configured on the secret; returns the secret ARN and the version ID being created.", profile.Description)),
+			tools.WithString("secret_id", tools.Description("Secret name or ARN"), tools.Required()),
+		)
+		mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+			secretID, _ := request.Parameters["secret_id"].(string)
+			result, err := am.secretsService.RotateSecret(ctx, profileID, secretID)
+			if err == nil {
+				logger.Info("Started rotation of %s for profile %s", result, profileID)
+			}
+			return FormatResponse(result, err)
+		})
+	}
 	logger.Info("Registered Secrets Manager tools for profile %s", profileID)
 }
+
+// registerDynamoDBTools registers DynamoDB tools
+func (am *AWSManager) registerDynamoDBTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// Query items by key condition
+	toolName := fmt.Sprintf("aws_dynamodb_query_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Query a DynamoDB table by key condition in %s.
+
+Returns at most %d items. Use expression_values to bind placeholders, e.g.
+key_condition: "pk = :pk AND begins_with(sk, :prefix)"
+expression_values: {":pk": "user#123", ":prefix": "order#"}`, profile.Description, awspkg.MaxDynamoDBPeekItems)),
+		tools.WithString("table_name", tools.Description("DynamoDB table name"), tools.Required()),
+		tools.WithString("key_condition", tools.Description("Key condition expression"), tools.Required()),
+		tools.WithString("expression_values", tools.Description("JSON object mapping placeholders to values")),
+		tools.WithNumber("limit", tools.Description(fmt.Sprintf("Maximum number of items (default and max: %d)", awspkg.MaxDynamoDBPeekItems))),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		tableName, _ := request.Parameters["table_name"].(string)
+		keyCondition, _ := request.Parameters["key_condition"].(string)
+
+		var values map[string]interface{}
+		if valuesStr, ok := request.Parameters["expression_values"].(string); ok && valuesStr != "" {
+			if err := json.Unmarshal([]byte(valuesStr), &values); err != nil {
+				return nil, fmt.Errorf("invalid expression_values: %w", err)
+			}
+		}
+
+		limit := int32(awspkg.MaxDynamoDBPeekItems)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+
+		items, err := am.dynamodbService.QueryTable(ctx, profileID, tableName, keyCondition, values, limit)
+		return FormatResponse(items, err)
+	})
+
+	// Peek at a few items with a bounded scan
+	toolName = fmt.Sprintf("aws_dynamodb_peek_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Sample a few items from a DynamoDB table in %s (scan capped at %d items)", profile.Description, awspkg.MaxDynamoDBPeekItems)),
+		tools.WithString("table_name", tools.Description("DynamoDB table name"), tools.Required()),
+		tools.WithNumber("limit", tools.Description(fmt.Sprintf("Maximum number of items (default: 10, max: %d)", awspkg.MaxDynamoDBPeekItems))),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		tableName, _ := request.Parameters["table_name"].(string)
+		limit := int32(10)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+		items, err := am.dynamodbService.ScanTablePeek(ctx, profileID, tableName, limit)
+		return FormatResponse(items, err)
+	})
+
+	// List tables
+	toolName = fmt.Sprintf("aws_dynamodb_list_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List DynamoDB tables in %s", profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		tables, err := am.dynamodbService.ListTables(ctx, profileID)
+		return FormatListResponse("tables", tables, err)
+	})
+
+	// Describe table (metadata only, reads no items)
+	toolName = fmt.Sprintf("aws_dynamodb_describe_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Describe a DynamoDB table in %s: key schema, secondary indexes, billing mode, estimated item count and size", profile.Description)),
+		tools.WithString("table_name", tools.Description("DynamoDB table name"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		tableName, _ := request.Parameters["table_name"].(string)
+		description, err := am.dynamodbService.DescribeTable(ctx, profileID, tableName)
+		return FormatResponse(description, err)
+	})
+
+	logger.Info("Registered DynamoDB tools for profile %s", profileID)
+}
+
+// registerMetricsTools registers CloudWatch Metrics tools
+func (am *AWSManager) registerMetricsTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// Metric data with math expressions
+	toolName := fmt.Sprintf("aws_metrics_data_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get CloudWatch metric data for several metrics and math expressions in one call in %s.
+
+queries is a JSON array. Each entry has an "id" and either a metric spec or an "expression" over other ids:
+[
+  {"id": "errors", "namespace": "AWS/ApplicationELB", "metric_name": "HTTPCode_Target_5XX_Count", "dimensions": {"LoadBalancer": "app/my-alb/123"}, "stat": "Sum", "period": 300, "return_data": false},
+  {"id": "requests", "namespace": "AWS/ApplicationELB", "metric_name": "RequestCount", "dimensions": {"LoadBalancer": "app/my-alb/123"}, "stat": "Sum", "period": 300, "return_data": false},
+  {"id": "error_rate", "expression": "100*errors/requests", "label": "5xx %%"}
+]
+
+stat defaults to Average and period to 300 seconds. Results are keyed by id with points sorted chronologically.
+Defaults to the last 3 hours if no time parameters are given.`, profile.Description)),
+		tools.WithString("queries", tools.Description("JSON array of metric and expression queries"), tools.Required()),
+		tools.WithString("time_range", tools.Description("Preset time range: last_1_hour, last_24_hours, last_7_days, etc.")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		queriesStr, _ := request.Parameters["queries"].(string)
+		var queries []awspkg.MetricDataQuery
+		if err := json.Unmarshal([]byte(queriesStr), &queries); err != nil {
+			return nil, fmt.Errorf("invalid queries: %w", err)
+		}
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 3*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := am.metricsService.GetMetricData(ctx, profileID, queries, startTime, endTime)
+		return FormatResponse(result, err)
+	})
+
+	logger.Info("Registered CloudWatch Metrics tools for profile %s", profileID)
+}
+
+// registerAlarmTools registers CloudWatch alarm tools
+func (am *AWSManager) registerAlarmTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// List alarms
+	toolName := fmt.Sprintf("aws_alarms_list_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List CloudWatch metric and composite alarms in %s with their metric, threshold, comparison operator, current state and state reason", profile.Description)),
+		tools.WithString("state", tools.Description("Only alarms in this state: ALARM, OK or INSUFFICIENT_DATA")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		state, _ := request.Parameters["state"].(string)
+		alarms, err := am.alarmsService.ListAlarms(ctx, profileID, state)
+		return FormatListResponse("alarms", alarms, err)
+	})
+
+	// Alarm state history
+	toolName = fmt.Sprintf("aws_alarms_history_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get the state transitions of a CloudWatch alarm in %s, oldest first, with the old/new state and reason of each. Useful for diagnosing flapping alarms. Defaults to the last 24 hours.", profile.Description)),
+		tools.WithString("alarm_name", tools.Description("Alarm name (metric or composite)"), tools.Required()),
+		tools.WithString("time_range", tools.Description("Preset time range: last_1_hour, last_24_hours, last_7_days, etc.")),
+		tools.WithString("timezone", tools.Description("IANA time zone for time_range boundaries such as today or this_week and for dates without an offset, e.g. 'America/New_York' (default: server local zone for calendar ranges such as today, UTC for dates)")),
+		tools.WithString("start_date", tools.Description("Start date in ISO 8601 format: '2025-01-01' or '2025-01-01T10:00:00Z'. Ignored if time_range provided.")),
+		tools.WithString("end_date", tools.Description("End date in ISO 8601 format: '2025-01-09' or '2025-01-09T23:59:59Z'. Ignored if time_range provided.")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		alarmName, _ := request.Parameters["alarm_name"].(string)
+
+		startTime, endTime, err := parseTimeWindow(request.Parameters, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+
+		history, err := am.alarmsService.DescribeAlarmHistory(ctx, profileID, alarmName, startTime, endTime)
+		return FormatResponse(history, err)
+	})
+
+	// Composite alarm with child alarm states
+	toolName = fmt.Sprintf("aws_alarms_composite_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Describe a CloudWatch composite alarm in %s: its rule and the current state of every child alarm the rule refers to", profile.Description)),
+		tools.WithString("alarm_name", tools.Description("Composite alarm name"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		alarmName, _ := request.Parameters["alarm_name"].(string)
+		composite, err := am.alarmsService.DescribeCompositeAlarm(ctx, profileID, alarmName)
+		return FormatResponse(composite, err)
+	})
+
+	logger.Info("Registered CloudWatch alarm tools for profile %s", profileID)
+}
+
+// timeLocation resolves the optional timezone tool parameter; nil means none was given
+func timeLocation(params map[string]interface{}) (*time.Location, error) {
+	name, _ := params["timezone"].(string)
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// parseTimeWindow reads the time_range or start_date/end_date tool parameters.
+// Without either, the window is the last defaultWindow up to now.
+func parseTimeWindow(params map[string]interface{}, defaultWindow time.Duration) (time.Time, time.Time, error) {
+	endTime := time.Now()
+	startTime := endTime.Add(-defaultWindow)
+
+	loc, err := timeLocation(params)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if timeRangeStr, ok := params["time_range"].(string); ok && timeRangeStr != "" {
+		tr, err := common.ParseTimeRangeInLocation(timeRangeStr, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid time_range: %w", err)
+		}
+		if tr != nil {
+			startTime = tr.Start
+			endTime = tr.End
+		}
+	} else if startDateStr, ok := params["start_date"].(string); ok && startDateStr != "" {
+		st, err := common.ParseDateTimeMillisInLocation(startDateStr, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date: %w", err)
+		}
+		if st > 0 {
+			startTime = time.UnixMilli(st)
+		}
+		if endDateStr, ok := params["end_date"].(string); ok && endDateStr != "" {
+			et, err := common.ParseDateTimeMillisInLocation(endDateStr, loc)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date: %w", err)
+			}
+			if et > 0 {
+				endTime = time.UnixMilli(et)
+			}
+		}
+	}
+
+	return startTime, endTime, nil
+}
+
+// registerS3Tools registers S3 tools
+func (am *AWSManager) registerS3Tools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// List buckets
+	toolName := fmt.Sprintf("aws_s3_buckets_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List S3 buckets in %s", profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		buckets, err := am.s3Service.ListBuckets(ctx, profileID)
+		return FormatListResponse("buckets", buckets, err)
+	})
+
+	// List objects
+	toolName = fmt.Sprintf("aws_s3_objects_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List objects in an S3 bucket in %s", profile.Description)),
+		tools.WithString("bucket", tools.Description("Bucket name"), tools.Required()),
+		tools.WithString("prefix", tools.Description("Optional key prefix to filter objects")),
+		tools.WithString("continuation_token", tools.Description("next_token from a previous call to continue listing")),
+		tools.WithNumber("limit", tools.Description("Maximum number of objects (default: 1000)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		bucket, _ := request.Parameters["bucket"].(string)
+		prefix, _ := request.Parameters["prefix"].(string)
+		continuationToken, _ := request.Parameters["continuation_token"].(string)
+		limit := int32(0)
+		if l, ok := request.Parameters["limit"].(float64); ok {
+			limit = int32(l)
+		}
+		listing, err := am.s3Service.ListObjects(ctx, profileID, bucket, prefix, continuationToken, limit)
+		if err != nil {
+			return FormatPagedResponse(nil, "", err)
+		}
+		return FormatPagedResponse(listing.Objects, listing.NextContinuationToken, nil)
+	})
+
+	// Object metadata
+	toolName = fmt.Sprintf("aws_s3_object_metadata_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("Get the content type and metadata of an S3 object in %s without downloading it", profile.Description)),
+		tools.WithString("bucket", tools.Description("Bucket name"), tools.Required()),
+		tools.WithString("key", tools.Description("Object key"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		bucket, _ := request.Parameters["bucket"].(string)
+		key, _ := request.Parameters["key"].(string)
+		metadata, err := am.s3Service.GetObjectMetadata(ctx, profileID, bucket, key)
+		return FormatResponse(metadata, err)
+	})
+
+	logger.Info("Registered S3 tools for profile %s", profileID)
+}
+
+// registerOrganizationsTools registers AWS Organizations tools
+func (am *AWSManager) registerOrganizationsTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	toolName := fmt.Sprintf("aws_org_accounts_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`List the accounts (ID, name, email, status) of the AWS organization of %s.
+
+Only works when the profile belongs to the organization's management account or a delegated administrator.
+Useful to map profiles to the organization and find accounts not yet configured as profiles.`, profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		accounts, err := am.orgService.ListAccounts(ctx, profileID)
+		return FormatListResponse("accounts", accounts, err)
+	})
+	logger.Info("Registered Organizations tools for profile %s", profileID)
+}
+
+// registerIAMTools registers read-only IAM tools
+func (am *AWSManager) registerIAMTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// Roles
+	toolName := fmt.Sprintf("aws_iam_roles_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`List the IAM roles of %s with their ARN, creation date and when they were last used.
+Roles whose last use could not be looked up are named in last_used_unavailable.
+
+With role_name, returns that role in detail instead: its trust policy, permissions boundary,
+attached managed policies and the names of its inline policies. Read-only.`, profile.Description)),
+		tools.WithString("role_name", tools.Description("Role name to describe (default: list every role)")),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		roleName, _ := request.Parameters["role_name"].(string)
+		if roleName != "" {
+			role, err := am.iamService.GetRole(ctx, profileID, roleName)
+			return FormatResponse(role, err)
+		}
+		roles, err := am.iamService.ListRoles(ctx, profileID)
+		if err != nil {
+			return FormatListResponse("roles", nil, err)
+		}
+		return FormatListResponseWith("roles", roles.Roles, map[string]interface{}{
+			"last_used_unavailable": roles.LastUsedUnavailable,
+			"last_used_error":       roles.LastUsedError,
+		}, nil)
+	})
+
+	// Users
+	toolName = fmt.Sprintf("aws_iam_users_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the IAM users of %s with their ARN, creation date and when their console password was last used. Read-only.", profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		users, err := am.iamService.ListUsers(ctx, profileID)
+		return FormatListResponse("users", users, err)
+	})
+
+	logger.Info("Registered IAM tools for profile %s", profileID)
+}
+
+// registerRoute53Tools registers Route53 DNS tools
+func (am *AWSManager) registerRoute53Tools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// Hosted zones
+	toolName := fmt.Sprintf("aws_route53_zones_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the Route53 hosted zones of %s with their ID, name, record count and whether they are private", profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		zones, err := am.route53Service.ListHostedZones(ctx, profileID)
+		return FormatListResponse("hosted_zones", zones, err)
+	})
+
+	// Record sets
+	toolName = fmt.Sprintf("aws_route53_records_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`List the DNS records of a Route53 hosted zone in %s: name, type, TTL and values.
+
+Alias records show their target (DNS name and hosted zone) instead of values, and weighted,
+latency and failover records show their routing settings. Useful to confirm DNS during deploys.`, profile.Description)),
+		tools.WithString("zone_id", tools.Description("Hosted zone ID, e.g. Z0123456789ABC (see aws_route53_zones)"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		zoneID, _ := request.Parameters["zone_id"].(string)
+		records, err := am.route53Service.ListResourceRecordSets(ctx, profileID, zoneID)
+		return FormatListResponse("records", records, err)
+	})
+
+	logger.Info("Registered Route53 tools for profile %s", profileID)
+}
+
+// registerELBTools registers Elastic Load Balancing tools
+func (am *AWSManager) registerELBTools(ctx context.Context, mcpServer *ServerWrapper, profileID string, profile *awspkg.ProfileConfig) {
+	// Load balancers
+	toolName := fmt.Sprintf("aws_elb_list_%s", profileID)
+	tool := tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf("List the application, network and gateway load balancers of %s with their DNS name, scheme, state and the target groups each routes to", profile.Description)),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		loadBalancers, err := am.elbService.ListLoadBalancers(ctx, profileID)
+		return FormatListResponse("load_balancers", loadBalancers, err)
+	})
+
+	// Target health
+	toolName = fmt.Sprintf("aws_elb_target_health_%s", profileID)
+	tool = tools.NewTool(
+		toolName,
+		tools.WithDescription(fmt.Sprintf(`Get the health of every target in a load balancer target group in %s: target ID, port, state (healthy, unhealthy, initial, draining, unused, unavailable) and, for targets not healthy, the reason and description (e.g. "Health checks failed with these codes: [502]").
+
+USE THIS FOR: "Why does my ECS service show unhealthy?" - aws_ecs_deployment returns the service's target_group_arns.
+Unhealthy targets are listed first.`, profile.Description)),
+		tools.WithString("target_group_arn", tools.Description("Target group ARN (see aws_elb_list or aws_ecs_deployment)"), tools.Required()),
+	)
+	mcpServer.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		targetGroupARN, _ := request.Parameters["target_group_arn"].(string)
+		health, err := am.elbService.DescribeTargetHealth(ctx, profileID, targetGroupARN)
+		return FormatResponse(health, err)
+	})
+
+	logger.Info("Registered load balancer tools for profile %s", profileID)
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awspkg "github.com/FreePeak/infra-mcp-server/pkg/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitializeProfilesAttemptsEveryProfile(t *testing.T) {
+	profiles := make([]awspkg.ProfileConfig, 0, 9)
+	for i := 1; i <= 8; i++ {
+		profiles = append(profiles, awspkg.ProfileConfig{
+			ID:              fmt.Sprintf("account%d", i),
+			AccessKeyID:     "AKIAEXAMPLE",
+			SecretAccessKey: "secret",
+		})
+	}
+	// Rejected by AddProfile, so never initialized
+	profiles = append(profiles, awspkg.ProfileConfig{ID: "broken"})
+
+	var mu sync.Mutex
+	var attempted []string
+	var running, peak int32
+
+	am := NewAWSManager()
+	am.initializeProfile = func(ctx context.Context, profileID string) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		attempted = append(attempted, profileID)
+		mu.Unlock()
+
+		if profileID == "account3" || profileID == "account7" {
+			return errors.New("assume role failed")
+		}
+		return nil
+	}
+
+	err := am.InitializeProfiles(context.Background(), profiles)
+
+	sort.Strings(attempted)
+	assert.Equal(t, []string{"account1", "account2", "account3", "account4", "account5", "account6", "account7", "account8"}, attempted)
+	assert.LessOrEqual(t, int(peak), maxConcurrentProfileInits)
+	assert.EqualError(t, err, "failed to initialize 3 of 9 AWS profile(s): "+
+		"account3: assume role failed; account7: assume role failed; "+
+		"broken: access_key_id cannot be empty for profile broken (or set aws_profile_name, or source_profile and role_arn)")
+}
+
+func TestInitializeProfilesSucceeds(t *testing.T) {
+	am := NewAWSManager()
+	am.initializeProfile = func(ctx context.Context, profileID string) error { return nil }
+
+	err := am.InitializeProfiles(context.Background(), []awspkg.ProfileConfig{
+		{ID: "prod", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret"},
+	})
+
+	assert.NoError(t, err)
+}
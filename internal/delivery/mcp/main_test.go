@@ -0,0 +1,15 @@
+package mcp
+
+import (
+	"os"
+	"testing"
+
+	"github.com/FreePeak/infra-mcp-server/internal/logger"
+)
+
+// TestMain initializes the logger before any test runs; the AWS manager logs profile
+// failures, and logging uninitialized panics
+func TestMain(m *testing.M) {
+	logger.Initialize("error")
+	os.Exit(m.Run())
+}
@@ -1,7 +1,13 @@
 package mcp
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+
+	awspkg "github.com/FreePeak/infra-mcp-server/pkg/aws"
 )
 
 // TextContent represents a text content item in a response
@@ -51,11 +57,23 @@ func FromError(err error) (interface{}, error) {
 	return nil, err
 }
 
-// FormatResponse converts any response type to a properly formatted MCP response
+// formatError prefixes AWS service errors with their category, e.g. "[Throttling] failed
+// to list log groups: ...", so a caller can tell a missing permission from a transient
+// failure. Other errors are returned unchanged.
+func formatError(err error) error {
+	var serviceErr *awspkg.ServiceError
+	if errors.As(err, &serviceErr) {
+		return fmt.Errorf("[%s] %w", serviceErr.Category, err)
+	}
+	return err
+}
+
+// FormatResponse converts any response type to a properly formatted MCP response.
+// Slices are wrapped in the FormatListResponse envelope under "items" with their count.
 func FormatResponse(response interface{}, err error) (interface{}, error) {
 	if err != nil {
 		// Already formatted as JSON-RPC error
-		return response, err
+		return response, formatError(err)
 	}
 
 	// For nil responses, return empty object to avoid null result
@@ -114,6 +132,78 @@ func FormatResponse(response interface{}, err error) (interface{}, error) {
 		}
 	}
 
+	// Give lists an envelope with their count, so a caller can tell how much came back
+	if value := reflect.ValueOf(response); value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		return FormatListResponseWith("items", response, nil, nil)
+	}
+
 	// For any other type, convert to string and wrap in proper content format
 	return FromString(fmt.Sprintf("%v", response)), nil
 }
+
+// FormatPagedResponse formats one page of a listing like FormatResponse formats a slice,
+// adding next_token when more items remain and a truncated flag so the caller knows to
+// ask for the next page
+func FormatPagedResponse(items interface{}, nextToken string, err error) (interface{}, error) {
+	return FormatListResponseWith("items", items, map[string]interface{}{
+		"next_token": nextToken,
+		"truncated":  nextToken != "",
+	}, err)
+}
+
+// FormatListResponse formats the result of a list operation as JSON with the items under
+// key, their count and an empty flag, so a successful listing that found nothing reads
+// differently from a failure. Errors are returned as errors, never as an empty list.
+func FormatListResponse(key string, items interface{}, err error) (interface{}, error) {
+	return FormatListResponseWith(key, items, nil, err)
+}
+
+// FormatListResponseWith is FormatListResponse with extra top-level fields, such as a
+// pagination token. Empty field values (nil, "" or an empty slice) are left out.
+func FormatListResponseWith(key string, items interface{}, fields map[string]interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, formatError(err)
+	}
+
+	count := 0
+	value := reflect.ValueOf(items)
+	switch {
+	case !value.IsValid():
+		items = []interface{}{}
+	case value.Kind() == reflect.Slice:
+		count = value.Len()
+		// Encode a nil slice as [] rather than null
+		if value.IsNil() {
+			items = reflect.MakeSlice(value.Type(), 0, 0).Interface()
+		}
+	default:
+		return nil, fmt.Errorf("list response %s is not a list: %T", key, items)
+	}
+
+	body := map[string]interface{}{
+		key:     items,
+		"count": count,
+		"empty": count == 0,
+	}
+	if count == 0 {
+		body["message"] = fmt.Sprintf("No %s found", strings.ReplaceAll(key, "_", " "))
+	}
+	for name, fieldValue := range fields {
+		if fieldValue == nil || fieldValue == "" {
+			continue
+		}
+		if v := reflect.ValueOf(fieldValue); v.Kind() == reflect.Slice && v.Len() == 0 {
+			continue
+		}
+		body[name] = fieldValue
+	}
+
+	text, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	return NewResponse().
+		WithText(string(text)).
+		WithMetadata("count", count).
+		WithMetadata("empty", count == 0), nil
+}
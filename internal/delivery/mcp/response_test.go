@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	awspkg "github.com/FreePeak/infra-mcp-server/pkg/aws"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -218,3 +219,146 @@ func ExampleNewResponse() {
 	fmt.Println(string(output))
 	// Output: {"content":[{"type":"text","text":"Hello, world!"}],"metadata":{"source":"example"}}
 }
+
+func TestFormatListResponse(t *testing.T) {
+	decode := func(t *testing.T, result interface{}) map[string]interface{} {
+		t.Helper()
+		resp, ok := result.(*Response)
+		if !ok {
+			t.Fatalf("Expected *Response, got %T", result)
+		}
+		if len(resp.Content) != 1 {
+			t.Fatalf("Expected 1 content item, got %d", len(resp.Content))
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(resp.Content[0].Text), &body); err != nil {
+			t.Fatalf("Expected JSON content, got %q: %v", resp.Content[0].Text, err)
+		}
+		return body
+	}
+
+	t.Run("items", func(t *testing.T) {
+		result, err := FormatListResponse("clusters", []string{"arn:aws:ecs:us-east-1:123456789012:cluster/main"}, nil)
+		assert.NoError(t, err)
+		body := decode(t, result)
+		assert.Equal(t, float64(1), body["count"])
+		assert.Equal(t, false, body["empty"])
+		assert.Equal(t, []interface{}{"arn:aws:ecs:us-east-1:123456789012:cluster/main"}, body["clusters"])
+		assert.NotContains(t, body, "message")
+		assert.Equal(t, 1, result.(*Response).Metadata["count"])
+	})
+
+	t.Run("nil slice is an empty list", func(t *testing.T) {
+		var clusters []string
+		result, err := FormatListResponse("clusters", clusters, nil)
+		assert.NoError(t, err)
+		body := decode(t, result)
+		assert.Equal(t, float64(0), body["count"])
+		assert.Equal(t, true, body["empty"])
+		assert.Equal(t, []interface{}{}, body["clusters"])
+		assert.Equal(t, "No clusters found", body["message"])
+		assert.Equal(t, true, result.(*Response).Metadata["empty"])
+	})
+
+	t.Run("underscored key in message", func(t *testing.T) {
+		result, err := FormatListResponse("log_groups", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "No log groups found", decode(t, result)["message"])
+	})
+
+	t.Run("extra fields", func(t *testing.T) {
+		result, err := FormatListResponseWith("log_groups", []string{"/aws/lambda/api"}, map[string]interface{}{"next_token": "abc"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", decode(t, result)["next_token"])
+
+		result, err = FormatListResponseWith("log_groups", []string{"/aws/lambda/api"}, map[string]interface{}{"next_token": ""}, nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, decode(t, result), "next_token")
+
+		var unavailable []string
+		result, err = FormatListResponseWith("roles", []string{"deploy"}, map[string]interface{}{"last_used_unavailable": unavailable}, nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, decode(t, result), "last_used_unavailable")
+	})
+
+	t.Run("error is not an empty list", func(t *testing.T) {
+		listErr := errors.New("failed to list clusters: access denied")
+		result, err := FormatListResponse("clusters", []string{}, listErr)
+		assert.Equal(t, listErr, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("non-list value", func(t *testing.T) {
+		_, err := FormatListResponse("clusters", map[string]int{"a": 1}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatResponseEnvelope(t *testing.T) {
+	decode := func(t *testing.T, result interface{}) map[string]interface{} {
+		t.Helper()
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(result.(*Response).Content[0].Text), &body); err != nil {
+			t.Fatalf("Expected JSON content, got %q: %v", result.(*Response).Content[0].Text, err)
+		}
+		return body
+	}
+
+	t.Run("slice", func(t *testing.T) {
+		result, err := FormatResponse([]map[string]string{{"id": "i-1"}, {"id": "i-2"}}, nil)
+		assert.NoError(t, err)
+		body := decode(t, result)
+		assert.Equal(t, float64(2), body["count"])
+		assert.Equal(t, false, body["empty"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"id": "i-1"}, map[string]interface{}{"id": "i-2"}}, body["items"])
+		assert.NotContains(t, body, "next_token")
+	})
+
+	t.Run("scalar map is unchanged", func(t *testing.T) {
+		result, err := FormatResponse(map[string]interface{}{"region": "us-east-1"}, nil)
+		assert.NoError(t, err)
+		resp := result.(*Response)
+		assert.Equal(t, "map[region:us-east-1]", resp.Content[0].Text)
+		assert.Nil(t, resp.Metadata)
+	})
+
+	t.Run("paged with next token", func(t *testing.T) {
+		result, err := FormatPagedResponse([]string{"a", "b"}, "token-2", nil)
+		assert.NoError(t, err)
+		body := decode(t, result)
+		assert.Equal(t, float64(2), body["count"])
+		assert.Equal(t, "token-2", body["next_token"])
+		assert.Equal(t, true, body["truncated"])
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		result, err := FormatPagedResponse([]string{"c"}, "", nil)
+		assert.NoError(t, err)
+		body := decode(t, result)
+		assert.NotContains(t, body, "next_token")
+		assert.Equal(t, false, body["truncated"])
+	})
+
+	t.Run("paged error", func(t *testing.T) {
+		pageErr := errors.New("failed to list functions: throttled")
+		result, err := FormatPagedResponse(nil, "", pageErr)
+		assert.Equal(t, pageErr, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFormatResponseErrorCategory(t *testing.T) {
+	serviceErr := &awspkg.ServiceError{Category: awspkg.ErrorCategoryThrottling, Code: "ThrottlingException", Message: "Rate exceeded", Err: errors.New("api error ThrottlingException: Rate exceeded")}
+
+	_, err := FormatResponse(nil, fmt.Errorf("failed to list log groups: %w", serviceErr))
+	assert.EqualError(t, err, "[Throttling] failed to list log groups: api error ThrottlingException: Rate exceeded")
+	assert.True(t, errors.Is(err, serviceErr))
+
+	_, err = FormatListResponse("log_groups", nil, serviceErr)
+	assert.EqualError(t, err, "[Throttling] api error ThrottlingException: Rate exceeded")
+
+	// Errors that are not AWS service errors are passed through
+	plain := errors.New("test error")
+	_, err = FormatResponse(nil, plain)
+	assert.Equal(t, plain, err)
+}
@@ -10,18 +10,26 @@ import (
 )
 
 // ServerWrapper provides a wrapper around server.MCPServer to handle type assertions
+// and record every added tool in a catalog
 type ServerWrapper struct {
 	mcpServer *server.MCPServer
+	catalog   *ToolCatalog
 }
 
-// NewServerWrapper creates a new ServerWrapper
+// NewServerWrapper creates a new ServerWrapper with an empty tool catalog
 func NewServerWrapper(mcpServer *server.MCPServer) *ServerWrapper {
 	return &ServerWrapper{
 		mcpServer: mcpServer,
+		catalog:   NewToolCatalog(),
 	}
 }
 
-// AddTool adds a tool to the server
+// Catalog returns the catalog of tools added through the wrapper
+func (sw *ServerWrapper) Catalog() *ToolCatalog {
+	return sw.catalog
+}
+
+// AddTool adds a tool to the server and records it in the catalog
 func (sw *ServerWrapper) AddTool(ctx context.Context, tool interface{}, handler func(ctx context.Context, request server.ToolCallRequest) (interface{}, error)) error {
 	// Log the operation for debugging
 	logger.Debug("Adding tool: %T", tool)
@@ -34,5 +42,9 @@ func (sw *ServerWrapper) AddTool(ctx context.Context, tool interface{}, handler
 	}
 
 	// Pass the tool to the MCPServer's AddTool method
-	return sw.mcpServer.AddTool(ctx, typedTool, handler)
+	if err := sw.mcpServer.AddTool(ctx, typedTool, handler); err != nil {
+		return err
+	}
+	sw.catalog.Add(typedTool)
+	return nil
 }
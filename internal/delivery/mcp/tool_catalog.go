@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/FreePeak/cortex/pkg/tools"
+	"github.com/FreePeak/cortex/pkg/types"
+
+	"github.com/FreePeak/infra-mcp-server/internal/logger"
+)
+
+// ListToolsName is the name of the tool that lists every registered tool
+const ListToolsName = "infra_list_tools"
+
+// ToolInfo describes a registered tool
+type ToolInfo struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// ToolCatalog records the tools registered with the server. Tools are registered
+// dynamically per AWS profile and per database, so the catalog is the only place
+// the full list is known.
+type ToolCatalog struct {
+	mu    sync.RWMutex
+	tools []ToolInfo
+}
+
+// NewToolCatalog creates a new empty tool catalog
+func NewToolCatalog() *ToolCatalog {
+	return &ToolCatalog{
+		tools: make([]ToolInfo, 0),
+	}
+}
+
+// Add records a registered tool
+func (c *ToolCatalog) Add(tool *types.Tool) {
+	info := ToolInfo{
+		Name:        tool.Name,
+		Description: tool.Description,
+		InputSchema: inputSchema(tool.Parameters),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tools = append(c.tools, info)
+}
+
+// List returns the recorded tools sorted by name
+func (c *ToolCatalog) List() []ToolInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]ToolInfo, len(c.tools))
+	copy(result, c.tools)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// inputSchema builds the JSON schema of a tool's parameters
+func inputSchema(parameters []types.ToolParameter) map[string]interface{} {
+	properties := make(map[string]interface{}, len(parameters))
+	required := make([]string, 0)
+	for _, p := range parameters {
+		property := map[string]interface{}{
+			"type":        p.Type,
+			"description": p.Description,
+		}
+		if p.Items != nil {
+			property["items"] = p.Items
+		}
+		properties[p.Name] = property
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// RegisterListToolsTool registers the infra_list_tools tool, which returns every tool
+// recorded in the wrapper's catalog at the time it is called
+func RegisterListToolsTool(ctx context.Context, sw *ServerWrapper) error {
+	tool := tools.NewTool(ListToolsName,
+		tools.WithDescription("List every registered tool with its description and input schema"),
+	)
+
+	return sw.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		catalog := sw.catalog.List()
+		logger.Debug("Listing %d registered tools", len(catalog))
+		return FormatListResponse("tools", catalog, nil)
+	})
+}
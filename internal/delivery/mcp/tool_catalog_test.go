@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/FreePeak/cortex/pkg/server"
+	"github.com/FreePeak/cortex/pkg/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolCatalogRecordsAddedTools(t *testing.T) {
+	ctx := context.Background()
+	sw := NewServerWrapper(server.NewMCPServer("test", "1.0.0", nil))
+
+	tool := tools.NewTool("query_testdb",
+		tools.WithDescription("Run a query"),
+		tools.WithString("query", tools.Description("SQL query"), tools.Required()),
+		tools.WithNumber("limit", tools.Description("Maximum rows")),
+	)
+	err := sw.AddTool(ctx, tool, func(ctx context.Context, request server.ToolCallRequest) (interface{}, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, RegisterListToolsTool(ctx, sw))
+
+	catalog := sw.Catalog().List()
+	assert.Len(t, catalog, 2)
+	assert.Equal(t, ListToolsName, catalog[0].Name)
+
+	query := catalog[1]
+	assert.Equal(t, "query_testdb", query.Name)
+	assert.Equal(t, "Run a query", query.Description)
+	assert.Equal(t, "object", query.InputSchema["type"])
+	assert.Equal(t, []string{"query"}, query.InputSchema["required"])
+	properties := query.InputSchema["properties"].(map[string]interface{})
+	assert.Contains(t, properties, "query")
+	assert.Contains(t, properties, "limit")
+	assert.Equal(t, "number", properties["limit"].(map[string]interface{})["type"])
+}
+
+func TestToolCatalogSkipsFailedTools(t *testing.T) {
+	sw := NewServerWrapper(server.NewMCPServer("test", "1.0.0", nil))
+
+	err := sw.AddTool(context.Background(), tools.NewTool("no_handler"), nil)
+	assert.Error(t, err)
+	assert.Empty(t, sw.Catalog().List())
+}
@@ -23,12 +23,12 @@ type ToolRegistry struct {
 	factory         *ToolTypeFactory
 }
 
-// NewToolRegistry creates a new tool registry
-func NewToolRegistry(mcpServer *server.MCPServer) *ToolRegistry {
+// NewToolRegistry creates a new tool registry that adds tools through the given wrapper
+func NewToolRegistry(sw *ServerWrapper) *ToolRegistry {
 	factory := NewToolTypeFactory()
 	return &ToolRegistry{
-		server:    NewServerWrapper(mcpServer),
-		mcpServer: mcpServer,
+		server:    sw,
+		mcpServer: sw.mcpServer,
 		factory:   factory,
 	}
 }
@@ -75,7 +75,7 @@ func (tr *ToolRegistry) RegisterAllTools(ctx context.Context, useCase UseCasePro
 
 // registerDatabaseTools registers all tools for a specific database
 func (tr *ToolRegistry) registerDatabaseTools(ctx context.Context, dbID string) error {
-	// Get all tool types from the factory (read-only tools only)
+	// Read-only tools are always registered
 	toolTypeNames := []string{
 		"query", "schema",
 	}
@@ -90,6 +90,14 @@ func (tr *ToolRegistry) registerDatabaseTools(ctx context.Context, dbID string)
 
 	logger.Info("Database %s info: %+v", dbID, dbInfo)
 
+	// Write tools are opt-in per connection
+	if metadata, err := tr.databaseUseCase.GetDatabaseMetadata(dbID); err == nil && metadata != nil {
+		if allowWrites, _ := metadata["allow_writes"].(bool); allowWrites {
+			logger.Warn("Database %s has allow_writes enabled, registering execute tool", dbID)
+			toolTypeNames = append(toolTypeNames, "execute")
+		}
+	}
+
 	// Register each tool type for this database
 	registrationErrors := 0
 	for _, typeName := range toolTypeNames {
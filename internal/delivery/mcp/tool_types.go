@@ -7,6 +7,8 @@ import (
 
 	"github.com/FreePeak/cortex/pkg/server"
 	"github.com/FreePeak/cortex/pkg/tools"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/dbtools"
 )
 
 // createTextResponse creates a simple response with a text content
@@ -276,7 +278,7 @@ func (t *ExecuteTool) CreateTool(name string, dbID string) interface{} {
 		name,
 		tools.WithDescription(t.GetDescription(dbID)),
 		tools.WithString("statement",
-			tools.Description("SQL statement to execute (INSERT, UPDATE, DELETE, etc.)"),
+			tools.Description("SQL statement to execute (INSERT, UPDATE, DELETE, MERGE or REPLACE; other statements need allow_ddl)"),
 			tools.Required(),
 		),
 		tools.WithArray("params",
@@ -298,6 +300,16 @@ func (t *ExecuteTool) HandleRequest(ctx context.Context, request server.ToolCall
 		return nil, fmt.Errorf("statement parameter must be a string")
 	}
 
+	// Enforce the connection's write permissions
+	allowWrites, allowDDL := false, false
+	if metadata, err := useCase.GetDatabaseMetadata(dbID); err == nil && metadata != nil {
+		allowWrites, _ = metadata["allow_writes"].(bool)
+		allowDDL, _ = metadata["allow_ddl"].(bool)
+	}
+	if err := dbtools.ValidateWriteStatement(statement, allowWrites, allowDDL); err != nil {
+		return nil, err
+	}
+
 	var statementParams []interface{}
 	if request.Parameters["params"] != nil {
 		if paramsArr, ok := request.Parameters["params"].([]interface{}); ok {
@@ -801,11 +813,14 @@ func NewToolTypeFactory() *ToolTypeFactory {
 		toolTypes: make(map[string]ToolType),
 	}
 
-	// Register read-only tool types only
+	// Register read-only tool types
 	factory.Register(NewQueryTool())
 	factory.Register(NewSchemaTool())
 	factory.Register(NewListDatabasesTool())
 
+	// Execute is only registered for connections with allow_writes enabled
+	factory.Register(NewExecuteTool())
+
 	return factory
 }
 
@@ -69,13 +69,16 @@ func (r *DatabaseRepository) GetDatabaseMetadata(id string) (map[string]interfac
 	
 	// Convert to map for easier handling
 	result := map[string]interface{}{
-		"id":           metadata.ID,
-		"type":         metadata.Type,
-		"display_name": metadata.DisplayName,
-		"project":      metadata.Project,
-		"environment":  metadata.Environment,
-		"description":  metadata.Description,
-		"tags":         metadata.Tags,
+		"id":                metadata.ID,
+		"type":              metadata.Type,
+		"display_name":      metadata.DisplayName,
+		"project":           metadata.Project,
+		"environment":       metadata.Environment,
+		"description":       metadata.Description,
+		"tags":              metadata.Tags,
+		"allow_writes":      metadata.AllowWrites,
+		"allow_ddl":         metadata.AllowDDL,
+		"session_read_only": metadata.ReadOnlySession(),
 	}
 	
 	return result, nil
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// Every resource returned by the AWS tools carries its ARN under "arn" so it can be
+// passed to other tools and matched across services. Services whose APIs do not
+// return an ARN get one built from the region and owning account.
+
+// partitionForRegion returns the ARN partition a region belongs to
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	default:
+		return "aws"
+	}
+}
+
+// buildARN returns the ARN of a resource. Regional resources need the owning account;
+// without one there is no valid ARN, so it returns "".
+func buildARN(service, region, accountID, resource string) string {
+	if resource == "" || (region != "" && accountID == "") {
+		return ""
+	}
+	return arn.ARN{
+		Partition: partitionForRegion(region),
+		Service:   service,
+		Region:    region,
+		AccountID: accountID,
+		Resource:  resource,
+	}.String()
+}
+
+// s3BucketARN returns the ARN of a bucket; bucket ARNs carry no region or account
+func s3BucketARN(region, bucket string) string {
+	if bucket == "" {
+		return ""
+	}
+	return arn.ARN{Partition: partitionForRegion(region), Service: "s3", Resource: bucket}.String()
+}
+
+// normalizeARN returns an ARN in canonical form: without surrounding whitespace and
+// without the ":*" suffix DescribeLogGroups appends to log group ARNs
+func normalizeARN(value string) string {
+	return strings.TrimSuffix(strings.TrimSpace(value), ":*")
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildARN(t *testing.T) {
+	assert.Equal(t, "arn:aws:ec2:us-east-1:123456789012:instance/i-0abc", buildARN("ec2", "us-east-1", "123456789012", "instance/i-0abc"))
+	assert.Equal(t, "arn:aws-cn:ec2:cn-north-1:123456789012:instance/i-0abc", buildARN("ec2", "cn-north-1", "123456789012", "instance/i-0abc"))
+	assert.Equal(t, "arn:aws-us-gov:ec2:us-gov-west-1:123456789012:security-group/sg-1", buildARN("ec2", "us-gov-west-1", "123456789012", "security-group/sg-1"))
+	// A regional resource without its account has no valid ARN
+	assert.Equal(t, "", buildARN("ec2", "us-east-1", "", "instance/i-0abc"))
+
+	assert.Equal(t, "arn:aws:s3:::logs-bucket", s3BucketARN("eu-west-1", "logs-bucket"))
+	assert.Equal(t, "", s3BucketARN("eu-west-1", ""))
+}
+
+func TestNormalizeARN(t *testing.T) {
+	assert.Equal(t, "arn:aws:logs:us-east-1:123456789012:log-group:/ecs/api", normalizeARN("arn:aws:logs:us-east-1:123456789012:log-group:/ecs/api:*"))
+	assert.Equal(t, "arn:aws:ecs:us-east-1:123456789012:cluster/prod", normalizeARN(" arn:aws:ecs:us-east-1:123456789012:cluster/prod "))
+}
+
+func TestResourcesSerializeARN(t *testing.T) {
+	for _, resource := range []interface{}{
+		LogGroup{ARN: "arn:aws:logs:us-east-1:123456789012:log-group:/ecs/api"},
+		Instance{ARN: "arn:aws:ec2:us-east-1:123456789012:instance/i-0abc"},
+		Function{FunctionARN: "arn:aws:lambda:us-east-1:123456789012:function:checkout"},
+		Bucket{ARN: "arn:aws:s3:::logs-bucket"},
+		DBInstance{ARN: "arn:aws:rds:us-east-1:123456789012:db:orders"},
+	} {
+		encoded, err := json.Marshal(resource)
+		assert.NoError(t, err)
+
+		var decoded map[string]interface{}
+		assert.NoError(t, json.Unmarshal(encoded, &decoded))
+		assert.NotEmpty(t, decoded["arn"], "%T", resource)
+	}
+}
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// validateRoleARN checks that role is an IAM role ARN
+func validateRoleARN(role string) error {
+	parsed, err := arn.Parse(role)
+	if err != nil {
+		return fmt.Errorf("invalid ARN %q: %w", role, err)
+	}
+	if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return fmt.Errorf("%q is not an IAM role ARN", role)
+	}
+	return nil
+}
+
+// validateRoleChain checks that every entry of a role chain is an IAM role ARN
+func validateRoleChain(roles []string) error {
+	for i, role := range roles {
+		if err := validateRoleARN(role); err != nil {
+			return fmt.Errorf("role_chain[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// assumeRoleChain returns credentials for the last role the profile assumes, assuming
+// each role in turn with the credentials of the one before it, starting from
+// cfg.Credentials. The external ID only applies to RoleARN, the first hop. Every hop is
+// cached and refreshed before it expires, so intermediate roles are only assumed again
+// when their own credentials run out.
+func assumeRoleChain(cfg aws.Config, profile *ProfileConfig) aws.CredentialsProvider {
+	provider := cfg.Credentials
+	for i, role := range profile.assumedRoles() {
+		hop := cfg.Copy()
+		hop.Credentials = provider
+		externalID := ""
+		if i == 0 && profile.RoleARN != "" {
+			externalID = profile.ExternalID
+		}
+		provider = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(hop), role, func(o *stscreds.AssumeRoleOptions) {
+			if profile.SessionName != "" {
+				o.RoleSessionName = profile.SessionName
+			}
+			if externalID != "" {
+				o.ExternalID = aws.String(externalID)
+			}
+		}))
+	}
+	return provider
+}
@@ -0,0 +1,197 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRoleChain(t *testing.T) {
+	assert.NoError(t, validateRoleChain(nil))
+	assert.NoError(t, validateRoleChain([]string{
+		"arn:aws:iam::111111111111:role/OrganizationAccess",
+		"arn:aws:iam::222222222222:role/path/ReadOnly",
+	}))
+
+	err := validateRoleChain([]string{"arn:aws:iam::111111111111:role/OrganizationAccess", "ReadOnly"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "role_chain[1]")
+
+	err = validateRoleChain([]string{"arn:aws:iam::111111111111:user/mcp"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an IAM role ARN")
+
+	err = validateRoleChain([]string{"arn:aws:s3:::role/bucket"})
+	assert.Error(t, err)
+}
+
+func TestAddProfileRejectsInvalidRoleChain(t *testing.T) {
+	ac := NewAWSConfig()
+	err := ac.AddProfile(&ProfileConfig{
+		ID:              "hub",
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "secret",
+		RoleChain:       []string{"not-an-arn"},
+	})
+	assert.Error(t, err)
+	assert.Empty(t, ac.ListProfiles())
+}
+
+func TestAddProfileCredentialSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ProfileConfig
+		wantErr string
+	}{
+		{
+			name:    "static credentials",
+			profile: ProfileConfig{ID: "base", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret"},
+		},
+		{
+			name: "static credentials with role",
+			profile: ProfileConfig{ID: "spoke", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret",
+				RoleARN: "arn:aws:iam::222222222222:role/ReadOnly", ExternalID: "partner-42"},
+		},
+		{
+			name:    "source profile with role",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "base", RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+		},
+		{
+			name:    "source profile with role chain",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "base", RoleChain: []string{"arn:aws:iam::222222222222:role/ReadOnly"}},
+		},
+		{
+			name:    "no credentials",
+			profile: ProfileConfig{ID: "spoke", RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+			wantErr: "access_key_id cannot be empty",
+		},
+		{
+			name:    "source profile without role",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "base"},
+			wantErr: "no role_arn or role_chain",
+		},
+		{
+			name: "source profile and static credentials",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "base", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret",
+				RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+			wantErr: "cannot set both source_profile and static credentials",
+		},
+		{
+			name:    "own source profile",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "spoke", RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+			wantErr: "cannot be its own source_profile",
+		},
+		{
+			name:    "invalid role ARN",
+			profile: ProfileConfig{ID: "spoke", SourceProfile: "base", RoleARN: "arn:aws:iam::222222222222:user/mcp"},
+			wantErr: "invalid role_arn",
+		},
+		{
+			name:    "external ID without role ARN",
+			profile: ProfileConfig{ID: "spoke", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret", ExternalID: "partner-42"},
+			wantErr: "external_id without role_arn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			err := NewAWSConfig().AddProfile(&profile)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestAssumedRoles(t *testing.T) {
+	profile := &ProfileConfig{RoleChain: []string{"arn:aws:iam::222222222222:role/ReadOnly"}}
+	assert.Equal(t, []string{"arn:aws:iam::222222222222:role/ReadOnly"}, profile.assumedRoles())
+
+	profile.RoleARN = "arn:aws:iam::111111111111:role/OrganizationAccess"
+	assert.Equal(t, []string{
+		"arn:aws:iam::111111111111:role/OrganizationAccess",
+		"arn:aws:iam::222222222222:role/ReadOnly",
+	}, profile.assumedRoles())
+}
+
+func TestAssumeRoleChain(t *testing.T) {
+	base := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIAEXAMPLE", "secret", ""),
+	}
+
+	// Without roles the base credentials are used unchanged
+	assert.Equal(t, base.Credentials, assumeRoleChain(base, &ProfileConfig{}))
+
+	provider := assumeRoleChain(base, &ProfileConfig{
+		RoleChain: []string{
+			"arn:aws:iam::111111111111:role/OrganizationAccess",
+			"arn:aws:iam::222222222222:role/ReadOnly",
+		},
+	})
+	cache, ok := provider.(*aws.CredentialsCache)
+	assert.True(t, ok)
+	assert.True(t, cache.IsCredentialsProvider((*stscreds.AssumeRoleProvider)(nil)))
+
+	// Loading the profile keeps the cached config with the chained provider
+	ac := NewAWSConfig()
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{
+		ID:              "spoke",
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "secret",
+		RoleChain:       []string{"arn:aws:iam::222222222222:role/ReadOnly"},
+	}))
+	cfg, err := ac.LoadProfile(context.Background(), "spoke")
+	assert.NoError(t, err)
+	_, ok = cfg.Credentials.(*aws.CredentialsCache)
+	assert.True(t, ok)
+}
+
+func TestLoadProfileAssumeRole(t *testing.T) {
+	ac := NewAWSConfig()
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{
+		ID:            "workload",
+		SourceProfile: "hub",
+		RoleARN:       "arn:aws:iam::222222222222:role/ReadOnly",
+		ExternalID:    "partner-42",
+		SessionName:   "infra-mcp",
+	}))
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{ID: "hub", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret"}))
+
+	cfg, err := ac.LoadProfile(context.Background(), "workload")
+	assert.NoError(t, err)
+	cache, ok := cfg.Credentials.(*aws.CredentialsCache)
+	assert.True(t, ok)
+	assert.True(t, cache.IsCredentialsProvider((*stscreds.AssumeRoleProvider)(nil)))
+
+	// The source profile is loaded and cached with its own static credentials
+	hub, err := ac.GetConfig("hub")
+	assert.NoError(t, err)
+	assert.False(t, aws.IsCredentialsProvider(hub.Credentials, (*stscreds.AssumeRoleProvider)(nil)))
+
+	// Same cached config on the next load
+	again, err := ac.LoadProfile(context.Background(), "workload")
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Credentials, again.Credentials)
+}
+
+func TestLoadProfileMissingSourceProfile(t *testing.T) {
+	ac := NewAWSConfig()
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{
+		ID:            "workload",
+		SourceProfile: "hub",
+		RoleARN:       "arn:aws:iam::222222222222:role/ReadOnly",
+	}))
+
+	_, err := ac.LoadProfile(context.Background(), "workload")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "source_profile hub of profile workload not found")
+}
@@ -7,10 +7,16 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 )
 
@@ -24,6 +30,12 @@ type ClientManager struct {
 	lambda         map[string]*lambda.Client
 	secretsManager map[string]*secretsmanager.Client
 	cloudwatch     map[string]*cloudwatch.Client
+	dynamodb       map[string]*dynamodb.Client
+	s3             map[string]*s3.Client
+	organizations  map[string]*organizations.Client
+	iam            map[string]*iam.Client
+	route53        map[string]*route53.Client
+	elbv2          map[string]*elasticloadbalancingv2.Client
 	mu             sync.RWMutex
 }
 
@@ -38,20 +50,27 @@ func NewClientManager(config *AWSConfig) *ClientManager {
 		lambda:         make(map[string]*lambda.Client),
 		secretsManager: make(map[string]*secretsmanager.Client),
 		cloudwatch:     make(map[string]*cloudwatch.Client),
+		dynamodb:       make(map[string]*dynamodb.Client),
+		s3:             make(map[string]*s3.Client),
+		organizations:  make(map[string]*organizations.Client),
+		iam:            make(map[string]*iam.Client),
+		route53:        make(map[string]*route53.Client),
+		elbv2:          make(map[string]*elasticloadbalancingv2.Client),
 	}
 }
 
 // InitializeProfile initializes all AWS clients for a profile
 func (cm *ClientManager) InitializeProfile(ctx context.Context, profileID string) error {
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
-
-	// Load AWS config for profile
+	// Load AWS config for profile. This can be slow, so it runs before taking the lock
+	// to let several profiles initialize at once.
 	cfg, err := cm.config.LoadProfile(ctx, profileID)
 	if err != nil {
 		return fmt.Errorf("failed to load profile %s: %w", profileID, err)
 	}
 
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
 	// Initialize all service clients
 	cm.cloudwatchLogs[profileID] = cloudwatchlogs.NewFromConfig(cfg)
 	cm.ecs[profileID] = ecs.NewFromConfig(cfg)
@@ -60,6 +79,12 @@ func (cm *ClientManager) InitializeProfile(ctx context.Context, profileID string
 	cm.lambda[profileID] = lambda.NewFromConfig(cfg)
 	cm.secretsManager[profileID] = secretsmanager.NewFromConfig(cfg)
 	cm.cloudwatch[profileID] = cloudwatch.NewFromConfig(cfg)
+	cm.dynamodb[profileID] = dynamodb.NewFromConfig(cfg)
+	cm.s3[profileID] = s3.NewFromConfig(cfg)
+	cm.organizations[profileID] = organizations.NewFromConfig(cfg)
+	cm.iam[profileID] = iam.NewFromConfig(cfg)
+	cm.route53[profileID] = route53.NewFromConfig(cfg)
+	cm.elbv2[profileID] = elasticloadbalancingv2.NewFromConfig(cfg)
 
 	return nil
 }
@@ -148,6 +173,78 @@ func (cm *ClientManager) GetCloudWatchClient(profileID string) (*cloudwatch.Clie
 	return client, nil
 }
 
+// GetDynamoDBClient returns the DynamoDB client for a profile
+func (cm *ClientManager) GetDynamoDBClient(profileID string) (*dynamodb.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.dynamodb[profileID]
+	if !exists {
+		return nil, fmt.Errorf("DynamoDB client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
+// GetS3Client returns the S3 client for a profile
+func (cm *ClientManager) GetS3Client(profileID string) (*s3.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.s3[profileID]
+	if !exists {
+		return nil, fmt.Errorf("S3 client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
+// GetOrganizationsClient returns the Organizations client for a profile
+func (cm *ClientManager) GetOrganizationsClient(profileID string) (*organizations.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.organizations[profileID]
+	if !exists {
+		return nil, fmt.Errorf("Organizations client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
+// GetIAMClient returns the IAM client for a profile
+func (cm *ClientManager) GetIAMClient(profileID string) (*iam.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.iam[profileID]
+	if !exists {
+		return nil, fmt.Errorf("IAM client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
+// GetRoute53Client returns the Route53 client for a profile
+func (cm *ClientManager) GetRoute53Client(profileID string) (*route53.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.route53[profileID]
+	if !exists {
+		return nil, fmt.Errorf("Route53 client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
+// GetELBv2Client returns the Elastic Load Balancing v2 client for a profile
+func (cm *ClientManager) GetELBv2Client(profileID string) (*elasticloadbalancingv2.Client, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	client, exists := cm.elbv2[profileID]
+	if !exists {
+		return nil, fmt.Errorf("ELBv2 client not initialized for profile %s", profileID)
+	}
+	return client, nil
+}
+
 // ListProfiles returns all initialized profile IDs
 func (cm *ClientManager) ListProfiles() []string {
 	cm.mu.RLock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -26,7 +27,7 @@ func NewCloudWatchService(clientManager *ClientManager) *CloudWatchService {
 // LogGroup represents a CloudWatch log group
 type LogGroup struct {
 	Name          string
-	ARN           string
+	ARN           string `json:"arn"`
 	CreationTime  int64
 	RetentionDays int32
 	StoredBytes   int64
@@ -36,7 +37,7 @@ type LogGroup struct {
 // LogStream represents a CloudWatch log stream
 type LogStream struct {
 	Name              string
-	ARN               string
+	ARN               string `json:"arn"`
 	CreationTime      int64
 	FirstEventTime    int64
 	LastEventTime     int64
@@ -49,33 +50,74 @@ type LogEvent struct {
 	Timestamp     int64
 	Message       string
 	IngestionTime int64
+	LogStream     string `json:",omitempty"`
 }
 
-// ListLogGroups lists all CloudWatch log groups
-func (cw *CloudWatchService) ListLogGroups(ctx context.Context, profileID string, prefix string, limit int32) ([]LogGroup, error) {
+// ListLogGroupsResult contains log groups and a token to continue listing
+type ListLogGroupsResult struct {
+	LogGroups     []LogGroup `json:"log_groups"`
+	TotalReturned int        `json:"total_returned"`
+	NextToken     string     `json:"next_token,omitempty"`
+}
+
+// ListLogGroups lists CloudWatch log groups, following pagination until limit is reached.
+// A limit of 0 fetches every log group. Pass the returned NextToken to continue listing.
+func (cw *CloudWatchService) ListLogGroups(ctx context.Context, profileID string, prefix string, limit int32, nextToken string) (*ListLogGroupsResult, error) {
 	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
 	if err != nil {
 		return nil, err
 	}
 
+	// AWS DescribeLogGroups returns at most 50 log groups per call
+	const maxPerCall int32 = 50
+
 	input := &cloudwatchlogs.DescribeLogGroupsInput{}
 	if prefix != "" {
 		input.LogGroupNamePrefix = aws.String(prefix)
 	}
-	if limit > 0 {
-		input.Limit = aws.Int32(limit)
+
+	// remainingToken continues the listing after the last page fetched, including when
+	// the limit stops collection before the final page
+	var collected int32
+	var remainingToken *string
+	describeLogGroups := func(token *string) ([]types.LogGroup, *string, error) {
+		if token == nil && nextToken != "" {
+			token = aws.String(nextToken)
+		}
+		input.NextToken = token
+
+		// Only request what is still needed so the returned token never skips entries
+		pageSize := maxPerCall
+		if limit > 0 {
+			if remaining := limit - collected; remaining < pageSize {
+				pageSize = remaining
+			}
+		}
+		input.Limit = aws.Int32(pageSize)
+
+		result, err := client.DescribeLogGroups(ctx, input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list log groups: %w", classifyAWSError(err, "logs:DescribeLogGroups", ""))
+		}
+
+		collected += int32(len(result.LogGroups))
+		remainingToken = result.NextToken
+		if limit > 0 && collected >= limit {
+			return result.LogGroups, nil, nil
+		}
+		return result.LogGroups, result.NextToken, nil
 	}
 
-	result, err := client.DescribeLogGroups(ctx, input)
+	groups, err := collectAll(ctx, describeLogGroups)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list log groups: %w", err)
+		return nil, err
 	}
 
-	logGroups := make([]LogGroup, 0, len(result.LogGroups))
-	for _, lg := range result.LogGroups {
+	logGroups := make([]LogGroup, 0, len(groups))
+	for _, lg := range groups {
 		logGroup := LogGroup{
 			Name:         aws.ToString(lg.LogGroupName),
-			ARN:          aws.ToString(lg.Arn),
+			ARN:          logGroupARN(lg),
 			CreationTime: aws.ToInt64(lg.CreationTime),
 			StoredBytes:  aws.ToInt64(lg.StoredBytes),
 		}
@@ -88,7 +130,11 @@ func (cw *CloudWatchService) ListLogGroups(ctx context.Context, profileID string
 		logGroups = append(logGroups, logGroup)
 	}
 
-	return logGroups, nil
+	return &ListLogGroupsResult{
+		LogGroups:     logGroups,
+		TotalReturned: len(logGroups),
+		NextToken:     aws.ToString(remainingToken),
+	}, nil
 }
 
 // GetLogStreams gets log streams for a log group
@@ -109,14 +155,14 @@ func (cw *CloudWatchService) GetLogStreams(ctx context.Context, profileID string
 
 	result, err := client.DescribeLogStreams(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get log streams: %w", err)
+		return nil, fmt.Errorf("failed to get log streams: %w", classifyAWSError(err, "logs:DescribeLogStreams", "log group "+logGroupName))
 	}
 
 	logStreams := make([]LogStream, 0, len(result.LogStreams))
 	for _, ls := range result.LogStreams {
 		logStream := LogStream{
 			Name:              aws.ToString(ls.LogStreamName),
-			ARN:               aws.ToString(ls.Arn),
+			ARN:               normalizeARN(aws.ToString(ls.Arn)),
 			CreationTime:      aws.ToInt64(ls.CreationTime),
 			FirstEventTime:    aws.ToInt64(ls.FirstEventTimestamp),
 			LastEventTime:     aws.ToInt64(ls.LastEventTimestamp),
@@ -182,14 +228,65 @@ func (cw *CloudWatchService) QueryLogsWithPagination(ctx context.Context, profil
 		input.EndTime = aws.Int64(endTime)
 	}
 
+	allEvents, hasMore, err := filterLogEvents(ctx, client, input, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logs: %w", classifyAWSError(err, "logs:FilterLogEvents", "log group "+logGroupName))
+	}
+
+	return newQueryLogsResult(allEvents, hasMore, startTime, endTime), nil
+}
+
+// QueryLogsAcrossStreams queries the log streams of a log group whose names start with
+// streamPrefix, such as the per-task streams of an ECS service, and merges their events
+// into a single view sorted by timestamp ascending. Each event carries its stream name.
+func (cw *CloudWatchService) QueryLogsAcrossStreams(ctx context.Context, profileID string, logGroupName string, streamPrefix string, filterPattern string, startTime int64, endTime int64, limit int32) (*QueryLogsResult, error) {
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+	limit = min(limit, 10000)
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroupName),
+		Limit:        aws.Int32(limit),
+	}
+	if streamPrefix != "" {
+		input.LogStreamNamePrefix = aws.String(streamPrefix)
+	}
+	if filterPattern != "" {
+		input.FilterPattern = aws.String(filterPattern)
+	}
+	if startTime > 0 {
+		input.StartTime = aws.Int64(startTime)
+	}
+	if endTime > 0 {
+		input.EndTime = aws.Int64(endTime)
+	}
+
+	events, hasMore, err := filterLogEvents(ctx, client, input, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query log streams: %w", classifyAWSError(err, "logs:FilterLogEvents", "log group "+logGroupName))
+	}
+
+	sortEventsAscending(events)
+
+	return newQueryLogsResult(events, hasMore, startTime, endTime), nil
+}
+
+// filterLogEvents pages through FilterLogEvents until limit events are collected,
+// reporting whether more events were left
+func filterLogEvents(ctx context.Context, client *cloudwatchlogs.Client, input *cloudwatchlogs.FilterLogEventsInput, limit int32) ([]LogEvent, bool, error) {
 	allEvents := make([]LogEvent, 0)
-	hasMore := false
 
 	// Paginate through results
 	for {
 		result, err := client.FilterLogEvents(ctx, input)
 		if err != nil {
-			return nil, fmt.Errorf("failed to query logs: %w", err)
+			return nil, false, err
 		}
 
 		for _, event := range result.Events {
@@ -197,38 +294,48 @@ func (cw *CloudWatchService) QueryLogsWithPagination(ctx context.Context, profil
 				Timestamp:     aws.ToInt64(event.Timestamp),
 				Message:       aws.ToString(event.Message),
 				IngestionTime: aws.ToInt64(event.IngestionTime),
+				LogStream:     aws.ToString(event.LogStreamName),
 			}
 			allEvents = append(allEvents, logEvent)
 		}
 
 		// Check if we've reached our limit
 		if int32(len(allEvents)) >= limit {
-			allEvents = allEvents[:limit]
-			hasMore = result.NextToken != nil
-			break
+			return allEvents[:limit], result.NextToken != nil, nil
 		}
 
 		// Check if there are more pages
 		if result.NextToken == nil {
-			break
+			return allEvents, false, nil
 		}
 
 		input.NextToken = result.NextToken
 	}
+}
 
+// sortEventsAscending orders events oldest first, keeping the order of events with
+// equal timestamps
+func sortEventsAscending(events []LogEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+}
+
+// newQueryLogsResult wraps queried events with the time range they cover
+func newQueryLogsResult(events []LogEvent, hasMore bool, startTime int64, endTime int64) *QueryLogsResult {
 	// Build time range info for context
 	timeRangeInfo := fmt.Sprintf("Queried from %s to %s",
 		time.UnixMilli(startTime).Format(time.RFC3339),
 		time.UnixMilli(endTime).Format(time.RFC3339))
 
 	return &QueryLogsResult{
-		Events:        allEvents,
-		TotalReturned: len(allEvents),
+		Events:        events,
+		TotalReturned: len(events),
 		HasMore:       hasMore,
 		StartTime:     startTime,
 		EndTime:       endTime,
 		TimeRangeInfo: timeRangeInfo,
-	}, nil
+	}
 }
 
 // TailLogs gets the most recent log events from a log group
@@ -272,7 +379,7 @@ func (cw *CloudWatchService) GetLogEventsByStream(ctx context.Context, profileID
 
 	result, err := client.GetLogEvents(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get log events: %w", err)
+		return nil, fmt.Errorf("failed to get log events: %w", classifyAWSError(err, "logs:GetLogEvents", "log stream "+logStreamName))
 	}
 
 	logEvents := make([]LogEvent, 0, len(result.Events))
@@ -281,6 +388,7 @@ func (cw *CloudWatchService) GetLogEventsByStream(ctx context.Context, profileID
 			Timestamp:     aws.ToInt64(event.Timestamp),
 			Message:       aws.ToString(event.Message),
 			IngestionTime: aws.ToInt64(event.IngestionTime),
+			LogStream:     logStreamName,
 		}
 		logEvents = append(logEvents, logEvent)
 	}
@@ -288,19 +396,80 @@ func (cw *CloudWatchService) GetLogEventsByStream(ctx context.Context, profileID
 	return logEvents, nil
 }
 
-// InsightsQueryResult contains CloudWatch Logs Insights query results
+// LatestStreamEvents contains the most recently active stream of a log group and its newest events
+type LatestStreamEvents struct {
+	LogGroup  string     `json:"log_group"`
+	LogStream LogStream  `json:"log_stream"`
+	Events    []LogEvent `json:"events"`
+}
+
+// GetLatestStreamEvents finds the log stream with the most recent event in a log group
+// and returns its last limit events, oldest first. CloudWatch updates a stream's last
+// event time lazily, so a stream that became active in the last few minutes may rank
+// below one that stopped just before it.
+func (cw *CloudWatchService) GetLatestStreamEvents(ctx context.Context, profileID string, logGroupName string, limit int32) (*LatestStreamEvents, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	streams, err := cw.GetLogStreams(ctx, profileID, logGroupName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("log group %s has no log streams", logGroupName)
+	}
+
+	events, err := cw.GetLogEventsByStream(ctx, profileID, logGroupName, streams[0].Name, limit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LatestStreamEvents{
+		LogGroup:  logGroupName,
+		LogStream: streams[0],
+		Events:    events,
+	}, nil
+}
+
+// Polling of Insights queries
+const (
+	// defaultInsightsMaxWait is how long RunInsightsQuery waits for a query to finish
+	defaultInsightsMaxWait = 60 * time.Second
+	// insightsPollInterval is the delay between two GetQueryResults calls
+	insightsPollInterval = 500 * time.Millisecond
+)
+
+// InsightsQueryResult contains CloudWatch Logs Insights query results. Complete is only
+// set when the query finished; a query still running when the wait ran out returns the
+// results found so far with TimedOut set, and Truncated means rows were cut off by limit.
 type InsightsQueryResult struct {
-	QueryID       string              `json:"query_id"`
-	Status        string              `json:"status"`
-	Results       []map[string]string `json:"results"`
-	TotalRecords  int                 `json:"total_records"`
-	BytesScanned  float64             `json:"bytes_scanned"`
-	StartTime     int64               `json:"start_time_ms"`
-	EndTime       int64               `json:"end_time_ms"`
-	TimeRangeInfo string              `json:"time_range_info"`
-}// RunInsightsQuery executes a CloudWatch Logs Insights query and waits for results
+	QueryID        string              `json:"query_id"`
+	Status         string              `json:"status"`
+	Complete       bool                `json:"complete"`
+	TimedOut       bool                `json:"timed_out"`
+	Truncated      bool                `json:"truncated"`
+	Results        []map[string]string `json:"results"`
+	TotalRecords   int                 `json:"total_records"`
+	RecordsMatched float64             `json:"records_matched"`
+	RecordsScanned float64             `json:"records_scanned"`
+	BytesScanned   float64             `json:"bytes_scanned"`
+	StartTime      int64               `json:"start_time_ms"`
+	EndTime        int64               `json:"end_time_ms"`
+	TimeRangeInfo  string              `json:"time_range_info"`
+}
+
+// RunInsightsQuery executes a CloudWatch Logs Insights query and waits up to 60 seconds for results
 // This is more powerful than FilterLogEvents for complex queries over large time ranges
 func (cw *CloudWatchService) RunInsightsQuery(ctx context.Context, profileID string, logGroupNames []string, queryString string, startTime int64, endTime int64, limit int32) (*InsightsQueryResult, error) {
+	return cw.RunInsightsQueryWithStatus(ctx, profileID, logGroupNames, queryString, startTime, endTime, limit, defaultInsightsMaxWait)
+}
+
+// RunInsightsQueryWithStatus executes a CloudWatch Logs Insights query and polls it for
+// at most maxWait (60 seconds when 0). The result carries the query status and its
+// matched, scanned and byte statistics. A query still running at the deadline is stopped
+// and its partial results are returned with TimedOut set rather than an error.
+func (cw *CloudWatchService) RunInsightsQueryWithStatus(ctx context.Context, profileID string, logGroupNames []string, queryString string, startTime int64, endTime int64, limit int32, maxWait time.Duration) (*InsightsQueryResult, error) {
 	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
 	if err != nil {
 		return nil, err
@@ -312,6 +481,9 @@ func (cw *CloudWatchService) RunInsightsQuery(ctx context.Context, profileID str
 	if limit > 10000 {
 		limit = 10000 // CloudWatch Logs Insights max limit
 	}
+	if maxWait <= 0 {
+		maxWait = defaultInsightsMaxWait
+	}
 
 	// Start the query
 	startQueryInput := &cloudwatchlogs.StartQueryInput{
@@ -324,40 +496,71 @@ func (cw *CloudWatchService) RunInsightsQuery(ctx context.Context, profileID str
 
 	startResult, err := client.StartQuery(ctx, startQueryInput)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start insights query: %w", err)
+		return nil, fmt.Errorf("failed to start insights query: %w", classifyAWSError(err, "logs:StartQuery", "log groups "+strings.Join(logGroupNames, ", ")))
 	}
 
 	queryID := aws.ToString(startResult.QueryId)
 
-	// Poll for results (with timeout)
-	const maxWait = 60 * time.Second
-	const pollInterval = 500 * time.Millisecond
-	deadline := time.Now().Add(maxWait)
+	queryResults, timedOut, err := pollInsightsQuery(ctx, func(ctx context.Context) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		return client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+			QueryId: aws.String(queryID),
+		})
+	}, maxWait, insightsPollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query results: %w", classifyAWSError(err, "logs:GetQueryResults", "query "+queryID))
+	}
 
-	var queryResults *cloudwatchlogs.GetQueryResultsOutput
-	for time.Now().Before(deadline) {
-		getResultsInput := &cloudwatchlogs.GetQueryResultsInput{
+	if timedOut {
+		// Stop the query so it does not keep scanning, and billing, in the background
+		_, _ = client.StopQuery(context.WithoutCancel(ctx), &cloudwatchlogs.StopQueryInput{
 			QueryId: aws.String(queryID),
-		}
+		})
+	}
+
+	result := newInsightsQueryResult(queryID, queryResults, limit, timedOut)
+	result.StartTime = startTime
+	result.EndTime = endTime
+	result.TimeRangeInfo = fmt.Sprintf("Insights query from %s to %s",
+		time.UnixMilli(startTime).Format(time.RFC3339),
+		time.UnixMilli(endTime).Format(time.RFC3339))
+
+	return result, nil
+}
 
-		queryResults, err = client.GetQueryResults(ctx, getResultsInput)
+// pollInsightsQuery calls getResults every interval until the query completes, fails or
+// is cancelled, returning the last response. When maxWait runs out first, the last
+// response is returned with timedOut set.
+func pollInsightsQuery(ctx context.Context, getResults func(ctx context.Context) (*cloudwatchlogs.GetQueryResultsOutput, error), maxWait time.Duration, interval time.Duration) (*cloudwatchlogs.GetQueryResultsOutput, bool, error) {
+	deadline := time.Now().Add(maxWait)
+
+	for {
+		queryResults, err := getResults(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get query results: %w", err)
+			return nil, false, err
 		}
 
-		status := queryResults.Status
-		if status == types.QueryStatusComplete || status == types.QueryStatusFailed || status == types.QueryStatusCancelled {
-			break
+		switch queryResults.Status {
+		case types.QueryStatusComplete, types.QueryStatusFailed, types.QueryStatusCancelled, types.QueryStatusTimeout:
+			return queryResults, false, nil
 		}
 
-		time.Sleep(pollInterval)
-	}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return queryResults, true, nil
+		}
 
-	if queryResults == nil {
-		return nil, fmt.Errorf("query timed out after %v", maxWait)
+		timer := time.NewTimer(min(interval, wait))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, false, ctx.Err()
+		case <-timer.C:
+		}
 	}
+}
 
-	// Parse results
+// newInsightsQueryResult converts a GetQueryResults response
+func newInsightsQueryResult(queryID string, queryResults *cloudwatchlogs.GetQueryResultsOutput, limit int32, timedOut bool) *InsightsQueryResult {
 	results := make([]map[string]string, 0, len(queryResults.Results))
 	for _, row := range queryResults.Results {
 		rowMap := make(map[string]string)
@@ -369,24 +572,23 @@ func (cw *CloudWatchService) RunInsightsQuery(ctx context.Context, profileID str
 		results = append(results, rowMap)
 	}
 
-	// Build time range info
-	timeRangeInfo := fmt.Sprintf("Insights query from %s to %s",
-		time.UnixMilli(startTime).Format(time.RFC3339),
-		time.UnixMilli(endTime).Format(time.RFC3339))
-
-	bytesScanned := float64(0)
-	if queryResults.Statistics != nil {
-		bytesScanned = queryResults.Statistics.BytesScanned
+	result := &InsightsQueryResult{
+		QueryID:      queryID,
+		Status:       string(queryResults.Status),
+		Complete:     queryResults.Status == types.QueryStatusComplete,
+		TimedOut:     timedOut,
+		Results:      results,
+		TotalRecords: len(results),
+	}
+	if stats := queryResults.Statistics; stats != nil {
+		result.RecordsMatched = stats.RecordsMatched
+		result.RecordsScanned = stats.RecordsScanned
+		result.BytesScanned = stats.BytesScanned
 	}
 
-	return &InsightsQueryResult{
-		QueryID:       queryID,
-		Status:        string(queryResults.Status),
-		Results:       results,
-		TotalRecords:  len(results),
-		BytesScanned:  bytesScanned,
-		StartTime:     startTime,
-		EndTime:       endTime,
-		TimeRangeInfo: timeRangeInfo,
-	}, nil
+	// Aggregating queries match more records than they return rows, so only a full page
+	// of rows with more matches left over means rows were cut off
+	result.Truncated = len(results) >= int(limit) && result.RecordsMatched > float64(len(results))
+
+	return result
 }
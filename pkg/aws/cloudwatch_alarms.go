@@ -0,0 +1,371 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CloudWatchAlarmsService provides CloudWatch alarm operations
+type CloudWatchAlarmsService struct {
+	clientManager *ClientManager
+}
+
+// NewCloudWatchAlarmsService creates a new CloudWatch alarms service
+func NewCloudWatchAlarmsService(clientManager *ClientManager) *CloudWatchAlarmsService {
+	return &CloudWatchAlarmsService{
+		clientManager: clientManager,
+	}
+}
+
+// AlarmStateTransition is a single state change of an alarm
+type AlarmStateTransition struct {
+	Timestamp time.Time `json:"timestamp"`
+	OldState  string    `json:"old_state"`
+	NewState  string    `json:"new_state"`
+	Reason    string    `json:"reason"`
+}
+
+// AlarmHistory is the state-change history of an alarm over a time window
+type AlarmHistory struct {
+	AlarmName   string                 `json:"alarm_name"`
+	StartTime   time.Time              `json:"start_time"`
+	EndTime     time.Time              `json:"end_time"`
+	Transitions []AlarmStateTransition `json:"transitions"`
+	// AlarmCount is the number of transitions into ALARM, a quick measure of flapping
+	AlarmCount int `json:"alarm_count"`
+}
+
+// CompositeAlarm is a composite alarm with the child alarms its rule refers to
+type CompositeAlarm struct {
+	AlarmName      string           `json:"alarm_name"`
+	ARN            string           `json:"arn"`
+	Description    string           `json:"description,omitempty"`
+	State          string           `json:"state"`
+	StateReason    string           `json:"state_reason"`
+	StateUpdatedAt *time.Time       `json:"state_updated_at,omitempty"`
+	AlarmRule      string           `json:"alarm_rule"`
+	ChildAlarms    []ChildAlarmRule `json:"child_alarms"`
+	ActionsEnabled bool             `json:"actions_enabled"`
+	Suppressor     string           `json:"actions_suppressor,omitempty"`
+}
+
+// ChildAlarmRule is one ALARM(...)/OK(...)/INSUFFICIENT_DATA(...) term of a composite alarm rule
+type ChildAlarmRule struct {
+	AlarmName     string `json:"alarm_name"`
+	RuleState     string `json:"rule_state"`
+	CurrentState  string `json:"current_state,omitempty"`
+	MatchesNow    bool   `json:"matches_now"`
+	IsComposite   bool   `json:"is_composite"`
+	StateReason   string `json:"state_reason,omitempty"`
+	LookupMissing bool   `json:"lookup_missing,omitempty"`
+}
+
+// AlarmSummary is the configuration and current state of an alarm
+type AlarmSummary struct {
+	AlarmName          string            `json:"alarm_name"`
+	ARN                string            `json:"arn"`
+	Type               string            `json:"type"`
+	Namespace          string            `json:"namespace,omitempty"`
+	MetricName         string            `json:"metric_name,omitempty"`
+	Dimensions         map[string]string `json:"dimensions,omitempty"`
+	Statistic          string            `json:"statistic,omitempty"`
+	Threshold          *float64          `json:"threshold,omitempty"`
+	ComparisonOperator string            `json:"comparison_operator,omitempty"`
+	AlarmRule          string            `json:"alarm_rule,omitempty"`
+	State              string            `json:"state"`
+	StateReason        string            `json:"state_reason"`
+	StateUpdatedAt     *time.Time        `json:"state_updated_at,omitempty"`
+	ActionsEnabled     bool              `json:"actions_enabled"`
+}
+
+// alarmRuleTermPattern matches the state functions of a composite alarm rule, with
+// the alarm name or ARN either quoted or bare
+var alarmRuleTermPattern = regexp.MustCompile(`\b(ALARM|OK|INSUFFICIENT_DATA)\(\s*(?:"([^"]+)"|'([^']+)'|([^\s()"']+))\s*\)`)
+
+// ListAlarms lists metric and composite alarms, optionally only those in stateValue
+// (ALARM, OK or INSUFFICIENT_DATA)
+func (s *CloudWatchAlarmsService) ListAlarms(ctx context.Context, profileID string, stateValue string) ([]AlarmSummary, error) {
+	client, err := s.clientManager.GetCloudWatchClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+	}
+	if stateValue != "" {
+		state, err := parseAlarmState(stateValue)
+		if err != nil {
+			return nil, err
+		}
+		input.StateValue = state
+	}
+
+	alarms := make([]AlarmSummary, 0)
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list alarms: %w", classifyAWSError(err, "cloudwatch:DescribeAlarms", ""))
+		}
+		for _, alarm := range page.MetricAlarms {
+			alarms = append(alarms, newMetricAlarmSummary(alarm))
+		}
+		for _, alarm := range page.CompositeAlarms {
+			alarms = append(alarms, AlarmSummary{
+				AlarmName:      aws.ToString(alarm.AlarmName),
+				ARN:            aws.ToString(alarm.AlarmArn),
+				Type:           string(types.AlarmTypeCompositeAlarm),
+				AlarmRule:      aws.ToString(alarm.AlarmRule),
+				State:          string(alarm.StateValue),
+				StateReason:    aws.ToString(alarm.StateReason),
+				StateUpdatedAt: alarm.StateUpdatedTimestamp,
+				ActionsEnabled: aws.ToBool(alarm.ActionsEnabled),
+			})
+		}
+	}
+
+	return alarms, nil
+}
+
+// newMetricAlarmSummary converts a metric alarm. Alarms on metric math expressions
+// have no single namespace or metric name.
+func newMetricAlarmSummary(alarm types.MetricAlarm) AlarmSummary {
+	summary := AlarmSummary{
+		AlarmName:          aws.ToString(alarm.AlarmName),
+		ARN:                aws.ToString(alarm.AlarmArn),
+		Type:               string(types.AlarmTypeMetricAlarm),
+		Namespace:          aws.ToString(alarm.Namespace),
+		MetricName:         aws.ToString(alarm.MetricName),
+		Statistic:          string(alarm.Statistic),
+		Threshold:          alarm.Threshold,
+		ComparisonOperator: string(alarm.ComparisonOperator),
+		State:              string(alarm.StateValue),
+		StateReason:        aws.ToString(alarm.StateReason),
+		StateUpdatedAt:     alarm.StateUpdatedTimestamp,
+		ActionsEnabled:     aws.ToBool(alarm.ActionsEnabled),
+	}
+	if alarm.ExtendedStatistic != nil {
+		summary.Statistic = aws.ToString(alarm.ExtendedStatistic)
+	}
+	if len(alarm.Dimensions) > 0 {
+		summary.Dimensions = make(map[string]string, len(alarm.Dimensions))
+		for _, dim := range alarm.Dimensions {
+			summary.Dimensions[aws.ToString(dim.Name)] = aws.ToString(dim.Value)
+		}
+	}
+	return summary
+}
+
+// parseAlarmState validates an alarm state filter, accepting any case
+func parseAlarmState(value string) (types.StateValue, error) {
+	state := types.StateValue(strings.ToUpper(strings.TrimSpace(value)))
+	switch state {
+	case types.StateValueAlarm, types.StateValueOk, types.StateValueInsufficientData:
+		return state, nil
+	}
+	return "", fmt.Errorf("invalid alarm state %q: must be ALARM, OK or INSUFFICIENT_DATA", value)
+}
+
+// DescribeAlarmHistory returns the state transitions of an alarm between startTime and endTime,
+// oldest first
+func (s *CloudWatchAlarmsService) DescribeAlarmHistory(ctx context.Context, profileID string, alarmName string, startTime time.Time, endTime time.Time) (*AlarmHistory, error) {
+	client, err := s.clientManager.GetCloudWatchClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if alarmName == "" {
+		return nil, fmt.Errorf("alarm name is required")
+	}
+
+	history := &AlarmHistory{
+		AlarmName:   alarmName,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Transitions: make([]AlarmStateTransition, 0),
+	}
+
+	paginator := cloudwatch.NewDescribeAlarmHistoryPaginator(client, &cloudwatch.DescribeAlarmHistoryInput{
+		AlarmName:       aws.String(alarmName),
+		AlarmTypes:      []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+		HistoryItemType: types.HistoryItemTypeStateUpdate,
+		StartDate:       aws.Time(startTime),
+		EndDate:         aws.Time(endTime),
+		ScanBy:          types.ScanByTimestampAscending,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe alarm history: %w", classifyAWSError(err, "cloudwatch:DescribeAlarmHistory", "alarm "+alarmName))
+		}
+		for _, item := range page.AlarmHistoryItems {
+			transition := parseStateTransition(item)
+			if transition.NewState == string(types.StateValueAlarm) {
+				history.AlarmCount++
+			}
+			history.Transitions = append(history.Transitions, transition)
+		}
+	}
+
+	return history, nil
+}
+
+// DescribeCompositeAlarm returns a composite alarm, its rule, and the current state of
+// every child alarm the rule refers to
+func (s *CloudWatchAlarmsService) DescribeCompositeAlarm(ctx context.Context, profileID string, alarmName string) (*CompositeAlarm, error) {
+	client, err := s.clientManager.GetCloudWatchClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+		AlarmNames: []string{alarmName},
+		AlarmTypes: []types.AlarmType{types.AlarmTypeCompositeAlarm},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe composite alarm: %w", classifyAWSError(err, "cloudwatch:DescribeAlarms", "composite alarm "+alarmName))
+	}
+	if len(result.CompositeAlarms) == 0 {
+		return nil, fmt.Errorf("composite alarm %s not found", alarmName)
+	}
+
+	ca := result.CompositeAlarms[0]
+	composite := &CompositeAlarm{
+		AlarmName:      aws.ToString(ca.AlarmName),
+		ARN:            aws.ToString(ca.AlarmArn),
+		Description:    aws.ToString(ca.AlarmDescription),
+		State:          string(ca.StateValue),
+		StateReason:    aws.ToString(ca.StateReason),
+		StateUpdatedAt: ca.StateUpdatedTimestamp,
+		AlarmRule:      aws.ToString(ca.AlarmRule),
+		ActionsEnabled: aws.ToBool(ca.ActionsEnabled),
+		Suppressor:     aws.ToString(ca.ActionsSuppressor),
+	}
+	composite.ChildAlarms = parseAlarmRule(composite.AlarmRule)
+
+	if err := s.resolveChildStates(ctx, client, composite.ChildAlarms); err != nil {
+		return nil, err
+	}
+
+	return composite, nil
+}
+
+// resolveChildStates fills in the current state of each child alarm
+func (s *CloudWatchAlarmsService) resolveChildStates(ctx context.Context, client *cloudwatch.Client, children []ChildAlarmRule) error {
+	if len(children) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(children))
+	seen := make(map[string]bool, len(children))
+	for _, child := range children {
+		name := alarmNameFromRef(child.AlarmName)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	type childState struct {
+		state     string
+		reason    string
+		composite bool
+	}
+	states := make(map[string]childState, len(names))
+
+	// DescribeAlarms accepts at most 100 names per call
+	for start := 0; start < len(names); start += 100 {
+		end := min(start+100, len(names))
+		result, err := client.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: names[start:end],
+			AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+			MaxRecords: aws.Int32(100),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe child alarms: %w", classifyAWSError(err, "cloudwatch:DescribeAlarms", ""))
+		}
+		for _, alarm := range result.MetricAlarms {
+			states[aws.ToString(alarm.AlarmName)] = childState{string(alarm.StateValue), aws.ToString(alarm.StateReason), false}
+		}
+		for _, alarm := range result.CompositeAlarms {
+			states[aws.ToString(alarm.AlarmName)] = childState{string(alarm.StateValue), aws.ToString(alarm.StateReason), true}
+		}
+	}
+
+	for i := range children {
+		state, ok := states[alarmNameFromRef(children[i].AlarmName)]
+		if !ok {
+			children[i].LookupMissing = true
+			continue
+		}
+		children[i].CurrentState = state.state
+		children[i].StateReason = state.reason
+		children[i].IsComposite = state.composite
+		children[i].MatchesNow = state.state == children[i].RuleState
+	}
+
+	return nil
+}
+
+// parseStateTransition extracts the old and new state from a StateUpdate history item
+func parseStateTransition(item types.AlarmHistoryItem) AlarmStateTransition {
+	transition := AlarmStateTransition{
+		Timestamp: aws.ToTime(item.Timestamp),
+		Reason:    aws.ToString(item.HistorySummary),
+	}
+
+	var data struct {
+		OldState struct {
+			StateValue  string `json:"stateValue"`
+			StateReason string `json:"stateReason"`
+		} `json:"oldState"`
+		NewState struct {
+			StateValue  string `json:"stateValue"`
+			StateReason string `json:"stateReason"`
+		} `json:"newState"`
+	}
+	if err := json.Unmarshal([]byte(aws.ToString(item.HistoryData)), &data); err == nil {
+		transition.OldState = data.OldState.StateValue
+		transition.NewState = data.NewState.StateValue
+		if data.NewState.StateReason != "" {
+			transition.Reason = data.NewState.StateReason
+		}
+	}
+
+	return transition
+}
+
+// parseAlarmRule lists the child alarm terms of a composite alarm rule in rule order
+func parseAlarmRule(rule string) []ChildAlarmRule {
+	matches := alarmRuleTermPattern.FindAllStringSubmatch(rule, -1)
+	children := make([]ChildAlarmRule, 0, len(matches))
+	for _, match := range matches {
+		name := match[2]
+		if name == "" {
+			name = match[3]
+		}
+		if name == "" {
+			name = match[4]
+		}
+		children = append(children, ChildAlarmRule{AlarmName: name, RuleState: match[1]})
+	}
+	return children
+}
+
+// alarmNameFromRef returns the alarm name from a name or alarm ARN
+// (arn:aws:cloudwatch:region:account:alarm:name)
+func alarmNameFromRef(ref string) string {
+	if i := strings.Index(ref, ":alarm:"); i >= 0 {
+		return ref[i+len(":alarm:"):]
+	}
+	return ref
+}
@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStateTransition(t *testing.T) {
+	at := time.Date(2025, 3, 4, 10, 15, 0, 0, time.UTC)
+	item := types.AlarmHistoryItem{
+		AlarmName:       aws.String("api-5xx"),
+		HistoryItemType: types.HistoryItemTypeStateUpdate,
+		HistorySummary:  aws.String("Alarm updated from OK to ALARM"),
+		Timestamp:       aws.Time(at),
+		HistoryData: aws.String(`{"version":"1.0","oldState":{"stateValue":"OK","stateReason":"Threshold Crossed: 1 datapoint [0.0] was not greater than the threshold (5.0)."},` +
+			`"newState":{"stateValue":"ALARM","stateReason":"Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0)."}}`),
+	}
+
+	transition := parseStateTransition(item)
+
+	assert.Equal(t, AlarmStateTransition{
+		Timestamp: at,
+		OldState:  "OK",
+		NewState:  "ALARM",
+		Reason:    "Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0).",
+	}, transition)
+}
+
+func TestParseStateTransitionFallsBackToSummary(t *testing.T) {
+	transition := parseStateTransition(types.AlarmHistoryItem{
+		HistorySummary: aws.String("Alarm updated from ALARM to OK"),
+		HistoryData:    aws.String("not json"),
+	})
+
+	assert.Equal(t, "Alarm updated from ALARM to OK", transition.Reason)
+	assert.Empty(t, transition.NewState)
+}
+
+func TestParseAlarmRule(t *testing.T) {
+	rule := `ALARM("api-5xx") AND (ALARM(api-latency) OR INSUFFICIENT_DATA('api-health')) AND NOT OK(arn:aws:cloudwatch:us-east-1:123456789012:alarm:db-cpu)`
+
+	children := parseAlarmRule(rule)
+
+	assert.Equal(t, []ChildAlarmRule{
+		{AlarmName: "api-5xx", RuleState: "ALARM"},
+		{AlarmName: "api-latency", RuleState: "ALARM"},
+		{AlarmName: "api-health", RuleState: "INSUFFICIENT_DATA"},
+		{AlarmName: "arn:aws:cloudwatch:us-east-1:123456789012:alarm:db-cpu", RuleState: "OK"},
+	}, children)
+	assert.Equal(t, "db-cpu", alarmNameFromRef(children[3].AlarmName))
+}
+
+func TestNewMetricAlarmSummary(t *testing.T) {
+	summary := newMetricAlarmSummary(types.MetricAlarm{
+		AlarmName:          aws.String("api-latency"),
+		Namespace:          aws.String("AWS/ApplicationELB"),
+		MetricName:         aws.String("TargetResponseTime"),
+		Dimensions:         []types.Dimension{{Name: aws.String("LoadBalancer"), Value: aws.String("app/api/123")}},
+		Statistic:          types.StatisticAverage,
+		ExtendedStatistic:  aws.String("p99"),
+		Threshold:          aws.Float64(1.5),
+		ComparisonOperator: types.ComparisonOperatorGreaterThanThreshold,
+		StateValue:         types.StateValueAlarm,
+		StateReason:        aws.String("Threshold Crossed"),
+	})
+
+	assert.Equal(t, "api-latency", summary.AlarmName)
+	assert.Equal(t, "MetricAlarm", summary.Type)
+	assert.Equal(t, "p99", summary.Statistic)
+	assert.Equal(t, 1.5, *summary.Threshold)
+	assert.Equal(t, "GreaterThanThreshold", summary.ComparisonOperator)
+	assert.Equal(t, map[string]string{"LoadBalancer": "app/api/123"}, summary.Dimensions)
+	assert.Equal(t, "ALARM", summary.State)
+}
+
+func TestParseAlarmState(t *testing.T) {
+	state, err := parseAlarmState("insufficient_data")
+	assert.NoError(t, err)
+	assert.Equal(t, types.StateValueInsufficientData, state)
+
+	_, err = parseAlarmState("FIRING")
+	assert.Error(t, err)
+}
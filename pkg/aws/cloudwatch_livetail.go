@@ -0,0 +1,239 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// Live tail status types
+const (
+	LiveTailStatusSessionStart = "session_start"
+	LiveTailStatusSampled      = "sampled"
+	LiveTailStatusSessionReset = "session_reset"
+	LiveTailStatusClosed       = "closed"
+	LiveTailStatusError        = "error"
+)
+
+// LiveTailStatus describes a non-event frame or state change of a live tail session
+type LiveTailStatus struct {
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp_ms"`
+}
+
+// LiveTailStream is a running StartLiveTail session.
+// Events is closed when the session ends; Statuses is safe to call at any time.
+type LiveTailStream struct {
+	Events <-chan LogEvent
+
+	mu       sync.Mutex
+	statuses []LiveTailStatus
+}
+
+// Statuses returns the status frames recorded so far
+func (s *LiveTailStream) Statuses() []LiveTailStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]LiveTailStatus, len(s.statuses))
+	copy(statuses, s.statuses)
+	return statuses
+}
+
+func (s *LiveTailStream) addStatus(statusType string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses = append(s.statuses, LiveTailStatus{
+		Type:      statusType,
+		Message:   message,
+		Timestamp: time.Now().UnixMilli(),
+	})
+}
+
+// StreamLogs starts a CloudWatch Logs live tail session for a log group.
+// Events are delivered until the returned cancel func is called or ctx is done.
+func (cw *CloudWatchService) StreamLogs(ctx context.Context, profileID string, logGroupName string, filterPattern string) (*LiveTailStream, context.CancelFunc, error) {
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// StartLiveTail only accepts log group ARNs
+	logGroupARN, err := resolveLogGroupARN(ctx, client, logGroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input := &cloudwatchlogs.StartLiveTailInput{
+		LogGroupIdentifiers: []string{logGroupARN},
+	}
+	if filterPattern != "" {
+		input.LogEventFilterPattern = aws.String(filterPattern)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	output, err := client.StartLiveTail(streamCtx, input)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to start live tail: %w", classifyAWSError(err, "logs:StartLiveTail", "log group "+logGroupName))
+	}
+
+	stream := output.GetStream()
+	events := make(chan LogEvent, 100)
+	liveTail := &LiveTailStream{Events: events}
+
+	go func() {
+		defer close(events)
+		defer stream.Close()
+
+		sampled := false
+		for {
+			select {
+			case <-streamCtx.Done():
+				liveTail.addStatus(LiveTailStatusClosed, describeTailStop(streamCtx.Err()))
+				return
+			case frame, ok := <-stream.Events():
+				if !ok {
+					if err := stream.Err(); err != nil {
+						liveTail.recordStreamError(err)
+					} else {
+						liveTail.addStatus(LiveTailStatusClosed, "live tail session ended")
+					}
+					return
+				}
+
+				switch v := frame.(type) {
+				case *types.StartLiveTailResponseStreamMemberSessionStart:
+					liveTail.addStatus(LiveTailStatusSessionStart, fmt.Sprintf("session %s started", aws.ToString(v.Value.SessionId)))
+				case *types.StartLiveTailResponseStreamMemberSessionUpdate:
+					if v.Value.SessionMetadata != nil && v.Value.SessionMetadata.Sampled && !sampled {
+						sampled = true
+						liveTail.addStatus(LiveTailStatusSampled, "event volume is high; CloudWatch is returning a sample of matching events")
+					}
+					for _, result := range v.Value.SessionResults {
+						event := LogEvent{
+							Timestamp:     aws.ToInt64(result.Timestamp),
+							Message:       aws.ToString(result.Message),
+							IngestionTime: aws.ToInt64(result.IngestionTime),
+						}
+						select {
+						case events <- event:
+						case <-streamCtx.Done():
+							liveTail.addStatus(LiveTailStatusClosed, describeTailStop(streamCtx.Err()))
+							return
+						}
+					}
+				}
+			}
+		}
+	}()
+
+	return liveTail, cancel, nil
+}
+
+// recordStreamError turns session reset frames into statuses and records anything else as an error status
+func (s *LiveTailStream) recordStreamError(err error) {
+	var timeoutErr *types.SessionTimeoutException
+	var streamingErr *types.SessionStreamingException
+	switch {
+	case errors.As(err, &timeoutErr):
+		s.addStatus(LiveTailStatusSessionReset, fmt.Sprintf("session timed out: %s", timeoutErr.ErrorMessage()))
+	case errors.As(err, &streamingErr):
+		s.addStatus(LiveTailStatusSessionReset, fmt.Sprintf("session was reset by CloudWatch: %s", streamingErr.ErrorMessage()))
+	default:
+		s.addStatus(LiveTailStatusError, err.Error())
+	}
+}
+
+func describeTailStop(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "tail duration elapsed"
+	}
+	return "tail canceled"
+}
+
+// LiveTailResult contains events collected from a bounded live tail session
+type LiveTailResult struct {
+	LogGroup        string           `json:"log_group"`
+	Events          []LogEvent       `json:"events"`
+	TotalReturned   int              `json:"total_returned"`
+	Truncated       bool             `json:"truncated"`
+	DurationSeconds int              `json:"duration_seconds"`
+	Statuses        []LiveTailStatus `json:"statuses"`
+}
+
+// TailLiveLogs collects live tail events for at most duration or until maxEvents are received
+func (cw *CloudWatchService) TailLiveLogs(ctx context.Context, profileID string, logGroupName string, filterPattern string, duration time.Duration, maxEvents int) (*LiveTailResult, error) {
+	tailCtx, cancelTimeout := context.WithTimeout(ctx, duration)
+	defer cancelTimeout()
+
+	stream, cancel, err := cw.StreamLogs(tailCtx, profileID, logGroupName, filterPattern)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	events := make([]LogEvent, 0)
+	truncated := false
+	for event := range stream.Events {
+		events = append(events, event)
+		if maxEvents > 0 && len(events) >= maxEvents {
+			truncated = true
+			cancel()
+			break
+		}
+	}
+
+	// Wait for the stream goroutine to finish so its final status is recorded
+	for range stream.Events {
+	}
+
+	return &LiveTailResult{
+		LogGroup:        logGroupName,
+		Events:          events,
+		TotalReturned:   len(events),
+		Truncated:       truncated,
+		DurationSeconds: int(duration.Seconds()),
+		Statuses:        stream.Statuses(),
+	}, nil
+}
+
+// resolveLogGroupARN returns the ARN of a log group given its name or ARN
+func resolveLogGroupARN(ctx context.Context, client *cloudwatchlogs.Client, logGroup string) (string, error) {
+	if strings.HasPrefix(logGroup, "arn:") {
+		return normalizeARN(logGroup), nil
+	}
+
+	result, err := client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(logGroup),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve log group %s: %w", logGroup, classifyAWSError(err, "logs:DescribeLogGroups", "log group "+logGroup))
+	}
+
+	for _, lg := range result.LogGroups {
+		if aws.ToString(lg.LogGroupName) != logGroup {
+			continue
+		}
+		return logGroupARN(lg), nil
+	}
+
+	return "", fmt.Errorf("log group %s not found", logGroup)
+}
+
+// logGroupARN returns the ARN of a log group without the ":*" suffix of its Arn field
+func logGroupARN(lg types.LogGroup) string {
+	if arn := aws.ToString(lg.LogGroupArn); arn != "" {
+		return normalizeARN(arn)
+	}
+	return normalizeARN(aws.ToString(lg.Arn))
+}
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordStreamError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedType    string
+		expectedMessage string
+	}{
+		{
+			name:            "session timeout is a reset",
+			err:             &types.SessionTimeoutException{Message: aws.String("session exceeded 3 hours")},
+			expectedType:    LiveTailStatusSessionReset,
+			expectedMessage: "session timed out: session exceeded 3 hours",
+		},
+		{
+			name:            "wrapped streaming exception is a reset",
+			err:             fmt.Errorf("stream failed: %w", &types.SessionStreamingException{Message: aws.String("internal error")}),
+			expectedType:    LiveTailStatusSessionReset,
+			expectedMessage: "session was reset by CloudWatch: internal error",
+		},
+		{
+			name:            "other errors are errors",
+			err:             errors.New("connection reset by peer"),
+			expectedType:    LiveTailStatusError,
+			expectedMessage: "connection reset by peer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &LiveTailStream{}
+			stream.recordStreamError(tc.err)
+
+			statuses := stream.Statuses()
+			if assert.Len(t, statuses, 1) {
+				assert.Equal(t, tc.expectedType, statuses[0].Type)
+				assert.Equal(t, tc.expectedMessage, statuses[0].Message)
+				assert.NotZero(t, statuses[0].Timestamp)
+			}
+		})
+	}
+}
+
+func TestDescribeTailStop(t *testing.T) {
+	assert.Equal(t, "tail duration elapsed", describeTailStop(context.DeadlineExceeded))
+	assert.Equal(t, "tail canceled", describeTailStop(context.Canceled))
+}
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// MetricTransformation is the metric a metric filter publishes for matching log events
+type MetricTransformation struct {
+	Namespace    string            `json:"namespace"`
+	MetricName   string            `json:"metric_name"`
+	MetricValue  string            `json:"metric_value"`
+	DefaultValue *float64          `json:"default_value,omitempty"`
+	Unit         string            `json:"unit,omitempty"`
+	Dimensions   map[string]string `json:"dimensions,omitempty"`
+}
+
+// MetricFilter is a CloudWatch Logs metric filter
+type MetricFilter struct {
+	FilterName      string                 `json:"filter_name"`
+	LogGroup        string                 `json:"log_group"`
+	FilterPattern   string                 `json:"filter_pattern"`
+	CreationTime    string                 `json:"creation_time,omitempty"`
+	Transformations []MetricTransformation `json:"metric_transformations"`
+}
+
+// ListMetricFilters lists the metric filters of a log group, or of every log group when
+// logGroupName is empty
+func (cw *CloudWatchService) ListMetricFilters(ctx context.Context, profileID string, logGroupName string) ([]MetricFilter, error) {
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &cloudwatchlogs.DescribeMetricFiltersInput{}
+	resource := ""
+	if logGroupName != "" {
+		input.LogGroupName = aws.String(logGroupName)
+		resource = "log group " + logGroupName
+	}
+
+	filters := make([]MetricFilter, 0)
+	paginator := cloudwatchlogs.NewDescribeMetricFiltersPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe metric filters: %w", classifyAWSError(err, "logs:DescribeMetricFilters", resource))
+		}
+		for _, f := range page.MetricFilters {
+			filters = append(filters, newMetricFilter(f))
+		}
+	}
+
+	return filters, nil
+}
+
+// newMetricFilter converts a metric filter returned by the API
+func newMetricFilter(f types.MetricFilter) MetricFilter {
+	filter := MetricFilter{
+		FilterName:      aws.ToString(f.FilterName),
+		LogGroup:        aws.ToString(f.LogGroupName),
+		FilterPattern:   aws.ToString(f.FilterPattern),
+		Transformations: make([]MetricTransformation, 0, len(f.MetricTransformations)),
+	}
+	if f.CreationTime != nil {
+		filter.CreationTime = time.UnixMilli(*f.CreationTime).UTC().Format(time.RFC3339)
+	}
+
+	for _, t := range f.MetricTransformations {
+		filter.Transformations = append(filter.Transformations, MetricTransformation{
+			Namespace:    aws.ToString(t.MetricNamespace),
+			MetricName:   aws.ToString(t.MetricName),
+			MetricValue:  aws.ToString(t.MetricValue),
+			DefaultValue: t.DefaultValue,
+			Unit:         string(t.Unit),
+			Dimensions:   t.Dimensions,
+		})
+	}
+
+	return filter
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricFilter(t *testing.T) {
+	filter := newMetricFilter(types.MetricFilter{
+		FilterName:    aws.String("api-5xx"),
+		LogGroupName:  aws.String("/ecs/api"),
+		FilterPattern: aws.String(`{ $.status >= 500 }`),
+		CreationTime:  aws.Int64(1736380800000),
+		MetricTransformations: []types.MetricTransformation{{
+			MetricNamespace: aws.String("Api"),
+			MetricName:      aws.String("ServerErrors"),
+			MetricValue:     aws.String("1"),
+			DefaultValue:    aws.Float64(0),
+			Unit:            types.StandardUnitCount,
+			Dimensions:      map[string]string{"Route": "$.route"},
+		}},
+	})
+
+	assert.Equal(t, MetricFilter{
+		FilterName:    "api-5xx",
+		LogGroup:      "/ecs/api",
+		FilterPattern: `{ $.status >= 500 }`,
+		CreationTime:  "2025-01-09T00:00:00Z",
+		Transformations: []MetricTransformation{{
+			Namespace:    "Api",
+			MetricName:   "ServerErrors",
+			MetricValue:  "1",
+			DefaultValue: aws.Float64(0),
+			Unit:         "Count",
+			Dimensions:   map[string]string{"Route": "$.route"},
+		}},
+	}, filter)
+}
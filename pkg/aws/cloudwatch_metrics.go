@@ -3,6 +3,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -29,11 +31,24 @@ type Metric struct {
 	Dimensions map[string]string
 }
 
-// MetricDataPoint represents a metric data point
+// MetricDataPoint represents a metric data point. Statistics holds every requested
+// statistic by name, e.g. "Average" or "p99"; Value is the average when it was requested,
+// otherwise the first available standard statistic.
 type MetricDataPoint struct {
-	Timestamp time.Time
-	Value     float64
-	Unit      string
+	Timestamp  time.Time
+	Value      float64
+	Unit       string
+	Statistics map[string]float64
+}
+
+// standardStatistics are the statistics GetMetricStatistics accepts in Statistics; any
+// other name, such as p99 or tm90, is an extended statistic
+var standardStatistics = map[string]bool{
+	string(types.StatisticAverage):     true,
+	string(types.StatisticSum):         true,
+	string(types.StatisticMinimum):     true,
+	string(types.StatisticMaximum):     true,
+	string(types.StatisticSampleCount): true,
 }
 
 // ListMetrics lists available CloudWatch metrics
@@ -53,7 +68,7 @@ func (cm *CloudWatchMetricsService) ListMetrics(ctx context.Context, profileID s
 
 	result, err := client.ListMetrics(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list metrics: %w", err)
+		return nil, fmt.Errorf("failed to list metrics: %w", classifyAWSError(err, "cloudwatch:ListMetrics", ""))
 	}
 
 	metrics := make([]Metric, 0, len(result.Metrics))
@@ -74,7 +89,10 @@ func (cm *CloudWatchMetricsService) ListMetrics(ctx context.Context, profileID s
 	return metrics, nil
 }
 
-// GetMetricStatistics gets statistics for a metric
+// GetMetricStatistics gets statistics for a metric. statistics may mix standard
+// statistics (Average, Sum, Minimum, Maximum, SampleCount) with extended ones such as
+// p50, p90 or p99; CloudWatch accepts only one kind per request, so a mix is fetched in
+// two calls and merged by timestamp.
 func (cm *CloudWatchMetricsService) GetMetricStatistics(ctx context.Context, profileID string, namespace string, metricName string, dimensions map[string]string, startTime time.Time, endTime time.Time, period int32, statistics []string) ([]MetricDataPoint, error) {
 	client, err := cm.clientManager.GetCloudWatchClient(profileID)
 	if err != nil {
@@ -90,51 +108,158 @@ func (cm *CloudWatchMetricsService) GetMetricStatistics(ctx context.Context, pro
 		})
 	}
 
-	// Convert statistics strings to types
-	cwStatistics := make([]types.Statistic, 0, len(statistics))
-	for _, stat := range statistics {
-		cwStatistics = append(cwStatistics, types.Statistic(stat))
+	standard, extended := splitStatistics(statistics)
+
+	inputs := make([]*cloudwatch.GetMetricStatisticsInput, 0, 2)
+	newInput := func() *cloudwatch.GetMetricStatisticsInput {
+		return &cloudwatch.GetMetricStatisticsInput{
+			Namespace:  aws.String(namespace),
+			MetricName: aws.String(metricName),
+			Dimensions: cwDimensions,
+			StartTime:  aws.Time(startTime),
+			EndTime:    aws.Time(endTime),
+			Period:     aws.Int32(period),
+		}
+	}
+	if len(standard) > 0 || len(extended) == 0 {
+		input := newInput()
+		input.Statistics = standard
+		inputs = append(inputs, input)
+	}
+	if len(extended) > 0 {
+		input := newInput()
+		input.ExtendedStatistics = extended
+		inputs = append(inputs, input)
 	}
 
-	input := &cloudwatch.GetMetricStatisticsInput{
-		Namespace:  aws.String(namespace),
-		MetricName: aws.String(metricName),
-		Dimensions: cwDimensions,
-		StartTime:  aws.Time(startTime),
-		EndTime:    aws.Time(endTime),
-		Period:     aws.Int32(period),
-		Statistics: cwStatistics,
+	var datapoints []types.Datapoint
+	for _, input := range inputs {
+		result, err := client.GetMetricStatistics(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metric statistics: %w", classifyAWSError(err, "cloudwatch:GetMetricStatistics", "metric "+namespace+"/"+metricName))
+		}
+		datapoints = append(datapoints, result.Datapoints...)
 	}
 
-	result, err := client.GetMetricStatistics(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get metric statistics: %w", err)
+	return mergeDatapoints(datapoints), nil
+}
+
+// splitStatistics separates standard statistic names from extended ones
+func splitStatistics(statistics []string) ([]types.Statistic, []string) {
+	var standard []types.Statistic
+	var extended []string
+	for _, stat := range statistics {
+		if standardStatistics[stat] {
+			standard = append(standard, types.Statistic(stat))
+		} else if stat != "" {
+			extended = append(extended, stat)
+		}
 	}
+	return standard, extended
+}
+
+// datapointStatistics returns every statistic set on a datapoint, keyed by name
+func datapointStatistics(dp types.Datapoint) map[string]float64 {
+	stats := make(map[string]float64, 5+len(dp.ExtendedStatistics))
+	if dp.Average != nil {
+		stats[string(types.StatisticAverage)] = *dp.Average
+	}
+	if dp.Sum != nil {
+		stats[string(types.StatisticSum)] = *dp.Sum
+	}
+	if dp.Maximum != nil {
+		stats[string(types.StatisticMaximum)] = *dp.Maximum
+	}
+	if dp.Minimum != nil {
+		stats[string(types.StatisticMinimum)] = *dp.Minimum
+	}
+	if dp.SampleCount != nil {
+		stats[string(types.StatisticSampleCount)] = *dp.SampleCount
+	}
+	for name, value := range dp.ExtendedStatistics {
+		stats[name] = value
+	}
+	return stats
+}
 
-	dataPoints := make([]MetricDataPoint, 0, len(result.Datapoints))
-	for _, dp := range result.Datapoints {
-		dataPoint := MetricDataPoint{
-			Timestamp: aws.ToTime(dp.Timestamp),
-			Unit:      string(dp.Unit),
+// mergeDatapoints converts datapoints to MetricDataPoints, combining the statistics of
+// datapoints that share a timestamp, ordered by time
+func mergeDatapoints(datapoints []types.Datapoint) []MetricDataPoint {
+	byTime := make(map[time.Time]int, len(datapoints))
+	dataPoints := make([]MetricDataPoint, 0, len(datapoints))
+	for _, dp := range datapoints {
+		timestamp := aws.ToTime(dp.Timestamp)
+		i, ok := byTime[timestamp]
+		if !ok {
+			i = len(dataPoints)
+			byTime[timestamp] = i
+			dataPoints = append(dataPoints, MetricDataPoint{
+				Timestamp:  timestamp,
+				Statistics: make(map[string]float64),
+			})
 		}
+		if dp.Unit != "" {
+			dataPoints[i].Unit = string(dp.Unit)
+		}
+		for name, value := range datapointStatistics(dp) {
+			dataPoints[i].Statistics[name] = value
+		}
+	}
 
-		// Get the first available statistic value
-		if dp.Average != nil {
-			dataPoint.Value = *dp.Average
-		} else if dp.Sum != nil {
-			dataPoint.Value = *dp.Sum
-		} else if dp.Maximum != nil {
-			dataPoint.Value = *dp.Maximum
-		} else if dp.Minimum != nil {
-			dataPoint.Value = *dp.Minimum
-		} else if dp.SampleCount != nil {
-			dataPoint.Value = *dp.SampleCount
+	for i := range dataPoints {
+		dataPoints[i].Value = primaryStatistic(dataPoints[i].Statistics)
+	}
+	sort.Slice(dataPoints, func(i, j int) bool { return dataPoints[i].Timestamp.Before(dataPoints[j].Timestamp) })
+	return dataPoints
+}
+
+// primaryStatistic returns the average when present, otherwise the first available of
+// Sum, Maximum, Minimum and SampleCount, otherwise the lowest-named extended statistic
+func primaryStatistic(stats map[string]float64) float64 {
+	for _, name := range []types.Statistic{types.StatisticAverage, types.StatisticSum, types.StatisticMaximum, types.StatisticMinimum, types.StatisticSampleCount} {
+		if value, ok := stats[string(name)]; ok {
+			return value
 		}
+	}
 
-		dataPoints = append(dataPoints, dataPoint)
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		return stats[names[0]]
+	}
+	return 0
+}
 
-	return dataPoints, nil
+// rdsMetricNames are the CloudWatch metrics reported for an RDS instance
+var rdsMetricNames = []string{
+	"CPUUtilization",
+	"CPUCreditBalance",
+	"DatabaseConnections",
+	"DiskQueueDepth",
+	"FreeableMemory",
+	"FreeStorageSpace",
+	"ReadLatency",
+	"WriteLatency",
+}
+
+// MetricPoint is a metric data point with its timestamp formatted as RFC3339
+type MetricPoint struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+	Unit      string  `json:"unit,omitempty"`
+}
+
+// RDSMetricsResult holds the metric series of an RDS instance over a time range
+type RDSMetricsResult struct {
+	DBInstanceIdentifier string                   `json:"db_instance_identifier"`
+	StartTime            string                   `json:"start_time"`
+	EndTime              string                   `json:"end_time"`
+	PeriodSeconds        int32                    `json:"period_seconds"`
+	Metrics              map[string][]MetricPoint `json:"metrics"`
+	Errors               map[string]string        `json:"errors,omitempty"`
 }
 
 // GetRDSMetrics gets common RDS metrics for a database instance
@@ -142,32 +267,139 @@ func (cm *CloudWatchMetricsService) GetRDSMetrics(ctx context.Context, profileID
 	endTime := time.Now()
 	startTime := endTime.Add(time.Duration(-hoursBack) * time.Hour)
 
-	dimensions := map[string]string{
-		"DBInstanceIdentifier": dbInstanceIdentifier,
+	metrics, _ := cm.getRDSMetrics(ctx, profileID, dbInstanceIdentifier, startTime, endTime, metricsPeriod(startTime, endTime))
+	return metrics, nil
+}
+
+// GetRDSMetricsInRange gets the common RDS metrics of an instance between startTime and
+// endTime as series sorted by time. Metrics that cannot be read are reported in Errors.
+func (cm *CloudWatchMetricsService) GetRDSMetricsInRange(ctx context.Context, profileID string, dbInstanceIdentifier string, startTime time.Time, endTime time.Time) (*RDSMetricsResult, error) {
+	if dbInstanceIdentifier == "" {
+		return nil, fmt.Errorf("identifier is required")
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time %s must be after start time %s", endTime.Format(time.RFC3339), startTime.Format(time.RFC3339))
 	}
 
-	metrics := map[string][]MetricDataPoint{}
+	period := metricsPeriod(startTime, endTime)
+	metrics, errs := cm.getRDSMetrics(ctx, profileID, dbInstanceIdentifier, startTime, endTime, period)
 
-	// Common RDS metrics
-	metricNames := []string{
-		"CPUUtilization",
-		"DatabaseConnections",
-		"FreeableMemory",
-		"FreeStorageSpace",
-		"ReadLatency",
-		"WriteLatency",
+	// Every metric failing usually means missing permissions or a bad profile, not no data
+	if len(metrics) == 0 && len(errs) > 0 {
+		return nil, errs[rdsMetricNames[0]]
 	}
 
-	for _, metricName := range metricNames {
-		dataPoints, err := cm.GetMetricStatistics(ctx, profileID, "AWS/RDS", metricName, dimensions, startTime, endTime, 300, []string{"Average"})
+	result := newRDSMetricsResult(dbInstanceIdentifier, startTime, endTime, period, metrics)
+	result.Errors = metricErrors(errs)
+	return result, nil
+}
+
+// getRDSMetrics reads each RDS metric's average per period, returning the series read and
+// the errors of those that failed, keyed by metric name
+func (cm *CloudWatchMetricsService) getRDSMetrics(ctx context.Context, profileID string, dbInstanceIdentifier string, startTime time.Time, endTime time.Time, period int32) (map[string][]MetricDataPoint, map[string]error) {
+	dimensions := map[string]string{
+		"DBInstanceIdentifier": dbInstanceIdentifier,
+	}
+
+	metrics := map[string][]MetricDataPoint{}
+	errs := map[string]error{}
+	for _, metricName := range rdsMetricNames {
+		dataPoints, err := cm.GetMetricStatistics(ctx, profileID, "AWS/RDS", metricName, dimensions, startTime, endTime, period, []string{"Average"})
 		if err != nil {
-			// Continue on error, just skip this metric
+			errs[metricName] = err
 			continue
 		}
 		metrics[metricName] = dataPoints
 	}
 
-	return metrics, nil
+	return metrics, errs
+}
+
+// newRDSMetricsResult builds the response of GetRDSMetricsInRange
+func newRDSMetricsResult(dbInstanceIdentifier string, startTime time.Time, endTime time.Time, period int32, metrics map[string][]MetricDataPoint) *RDSMetricsResult {
+	return &RDSMetricsResult{
+		DBInstanceIdentifier: dbInstanceIdentifier,
+		StartTime:            startTime.UTC().Format(time.RFC3339),
+		EndTime:              endTime.UTC().Format(time.RFC3339),
+		PeriodSeconds:        period,
+		Metrics:              metricSeries(metrics),
+	}
+}
+
+// metricSeries sorts each series by time and formats its timestamps as RFC3339
+func metricSeries(metrics map[string][]MetricDataPoint) map[string][]MetricPoint {
+	series := make(map[string][]MetricPoint, len(metrics))
+	for name, dataPoints := range metrics {
+		sorted := make([]MetricDataPoint, len(dataPoints))
+		copy(sorted, dataPoints)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+		points := make([]MetricPoint, 0, len(sorted))
+		for _, dp := range sorted {
+			points = append(points, MetricPoint{
+				Timestamp: dp.Timestamp.UTC().Format(time.RFC3339),
+				Value:     dp.Value,
+				Unit:      dp.Unit,
+			})
+		}
+		series[name] = points
+	}
+	return series
+}
+
+// metricErrors converts the errors of metrics that could not be read to messages, nil
+// when every metric was read
+func metricErrors(errs map[string]error) map[string]string {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make(map[string]string, len(errs))
+	for name, err := range errs {
+		messages[name] = err.Error()
+	}
+	return messages
+}
+
+// metricsPeriod returns the period for GetMetricStatistics over a range: 5 minutes, or
+// longer for wide ranges so a series stays within the 1440 data point limit
+func metricsPeriod(startTime time.Time, endTime time.Time) int32 {
+	const maxDataPoints = 1440
+	period := int32(300)
+	minutes := int32(math.Ceil(endTime.Sub(startTime).Minutes() / maxDataPoints))
+	if minutes*60 > period {
+		period = minutes * 60
+	}
+	return period
+}
+
+// CloudWatch namespaces of ECS service metrics
+const (
+	ecsNamespace                = "AWS/ECS"
+	containerInsightsNamespace  = "ECS/ContainerInsights"
+	containerInsightsNotEnabled = "no Container Insights data points: Container Insights may not be enabled on the cluster"
+)
+
+// ECSMetricsResult holds the metric series of an ECS service over a time range
+type ECSMetricsResult struct {
+	Cluster       string                   `json:"cluster"`
+	Service       string                   `json:"service"`
+	Namespace     string                   `json:"namespace"`
+	StartTime     string                   `json:"start_time"`
+	EndTime       string                   `json:"end_time"`
+	PeriodSeconds int32                    `json:"period_seconds"`
+	Metrics       map[string][]MetricPoint `json:"metrics"`
+	Errors        map[string]string        `json:"errors,omitempty"`
+	Note          string                   `json:"note,omitempty"`
+}
+
+// ecsMetricQueries returns the namespace and metric names read for an ECS service. The
+// standard metrics are the service's CPU and memory utilization percentages; Container
+// Insights adds the running task count and the CPU units and MiB of memory used.
+func ecsMetricQueries(useContainerInsights bool) (string, []string) {
+	if useContainerInsights {
+		return containerInsightsNamespace, []string{"CpuUtilized", "MemoryUtilized", "RunningTaskCount"}
+	}
+	return ecsNamespace, []string{"CPUUtilization", "MemoryUtilization"}
 }
 
 // GetECSMetrics gets common ECS metrics for a cluster/service
@@ -175,28 +407,443 @@ func (cm *CloudWatchMetricsService) GetECSMetrics(ctx context.Context, profileID
 	endTime := time.Now()
 	startTime := endTime.Add(time.Duration(-hoursBack) * time.Hour)
 
+	metrics, _ := cm.getECSMetrics(ctx, profileID, clusterName, serviceName, startTime, endTime, 300, false)
+	return metrics, nil
+}
+
+// GetECSMetricsInRange gets the metrics of an ECS service between startTime and endTime as
+// series sorted by time, from Container Insights when useContainerInsights is set. A
+// cluster without Container Insights has no data points rather than an error, so empty
+// Container Insights series are reported in Note.
+func (cm *CloudWatchMetricsService) GetECSMetricsInRange(ctx context.Context, profileID string, clusterName string, serviceName string, startTime time.Time, endTime time.Time, useContainerInsights bool) (*ECSMetricsResult, error) {
+	if clusterName == "" || serviceName == "" {
+		return nil, fmt.Errorf("cluster_name and service_name are required")
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time %s must be after start time %s", endTime.Format(time.RFC3339), startTime.Format(time.RFC3339))
+	}
+
+	namespace, metricNames := ecsMetricQueries(useContainerInsights)
+	period := metricsPeriod(startTime, endTime)
+	metrics, errs := cm.getECSMetrics(ctx, profileID, clusterName, serviceName, startTime, endTime, period, useContainerInsights)
+
+	// Every metric failing usually means missing permissions or a bad profile, not no data
+	if len(metrics) == 0 && len(errs) > 0 {
+		return nil, errs[metricNames[0]]
+	}
+
+	result := &ECSMetricsResult{
+		Cluster:       clusterName,
+		Service:       serviceName,
+		Namespace:     namespace,
+		StartTime:     startTime.UTC().Format(time.RFC3339),
+		EndTime:       endTime.UTC().Format(time.RFC3339),
+		PeriodSeconds: period,
+		Metrics:       metricSeries(metrics),
+	}
+	result.Errors = metricErrors(errs)
+	if useContainerInsights && !hasDataPoints(metrics) {
+		result.Note = containerInsightsNotEnabled
+	}
+	return result, nil
+}
+
+// getECSMetrics reads each ECS service metric's average per period, returning the series
+// read and the errors of those that failed, keyed by metric name
+func (cm *CloudWatchMetricsService) getECSMetrics(ctx context.Context, profileID string, clusterName string, serviceName string, startTime time.Time, endTime time.Time, period int32, useContainerInsights bool) (map[string][]MetricDataPoint, map[string]error) {
 	dimensions := map[string]string{
 		"ClusterName": clusterName,
 		"ServiceName": serviceName,
 	}
 
+	namespace, metricNames := ecsMetricQueries(useContainerInsights)
 	metrics := map[string][]MetricDataPoint{}
-
-	// Common ECS metrics
-	metricNames := []string{
-		"CPUUtilization",
-		"MemoryUtilization",
-	}
-
+	errs := map[string]error{}
 	for _, metricName := range metricNames {
-		dataPoints, err := cm.GetMetricStatistics(ctx, profileID, "AWS/ECS", metricName, dimensions, startTime, endTime, 300, []string{"Average"})
+		dataPoints, err := cm.GetMetricStatistics(ctx, profileID, namespace, metricName, dimensions, startTime, endTime, period, []string{"Average"})
 		if err != nil {
-			// Continue on error, just skip this metric
+			errs[metricName] = err
 			continue
 		}
 		metrics[metricName] = dataPoints
 	}
 
+	return metrics, errs
+}
+
+// hasDataPoints reports whether any series has at least one data point
+func hasDataPoints(metrics map[string][]MetricDataPoint) bool {
+	for _, dataPoints := range metrics {
+		if len(dataPoints) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ec2Metrics are the CloudWatch metrics reported for an EC2 instance, with the statistic
+// that summarizes each period: byte counters are summed and status checks take the worst
+var ec2Metrics = []struct {
+	name      string
+	statistic string
+}{
+	{"CPUUtilization", "Average"},
+	{"NetworkIn", "Sum"},
+	{"NetworkOut", "Sum"},
+	{"DiskReadBytes", "Sum"},
+	{"DiskWriteBytes", "Sum"},
+	{"StatusCheckFailed", "Maximum"},
+}
+
+// EC2MetricsResult holds the metric series of an EC2 instance over a time range
+type EC2MetricsResult struct {
+	InstanceID    string                   `json:"instance_id"`
+	StartTime     string                   `json:"start_time"`
+	EndTime       string                   `json:"end_time"`
+	PeriodSeconds int32                    `json:"period_seconds"`
+	Metrics       map[string][]MetricPoint `json:"metrics"`
+	Errors        map[string]string        `json:"errors,omitempty"`
+}
+
+// ec2Dimensions returns the dimensions selecting one instance's AWS/EC2 metrics
+func ec2Dimensions(instanceID string) map[string]string {
+	return map[string]string{
+		"InstanceId": instanceID,
+	}
+}
+
+// GetEC2Metrics gets common EC2 metrics for an instance
+func (cm *CloudWatchMetricsService) GetEC2Metrics(ctx context.Context, profileID string, instanceID string, hoursBack int) (map[string][]MetricDataPoint, error) {
+	endTime := time.Now()
+	startTime := endTime.Add(time.Duration(-hoursBack) * time.Hour)
+
+	metrics, _ := cm.getEC2Metrics(ctx, profileID, instanceID, startTime, endTime, metricsPeriod(startTime, endTime))
 	return metrics, nil
 }
 
+// GetEC2MetricsInRange gets the common EC2 metrics of an instance between startTime and
+// endTime as series sorted by time. Metrics that cannot be read are reported in Errors.
+// DiskReadBytes and DiskWriteBytes only cover instance store volumes; EBS volumes report
+// their own metrics.
+func (cm *CloudWatchMetricsService) GetEC2MetricsInRange(ctx context.Context, profileID string, instanceID string, startTime time.Time, endTime time.Time) (*EC2MetricsResult, error) {
+	if instanceID == "" {
+		return nil, fmt.Errorf("instance_id is required")
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time %s must be after start time %s", endTime.Format(time.RFC3339), startTime.Format(time.RFC3339))
+	}
+
+	period := metricsPeriod(startTime, endTime)
+	metrics, errs := cm.getEC2Metrics(ctx, profileID, instanceID, startTime, endTime, period)
+
+	// Every metric failing usually means missing permissions or a bad profile, not no data
+	if len(metrics) == 0 && len(errs) > 0 {
+		return nil, errs[ec2Metrics[0].name]
+	}
+
+	return &EC2MetricsResult{
+		InstanceID:    instanceID,
+		StartTime:     startTime.UTC().Format(time.RFC3339),
+		EndTime:       endTime.UTC().Format(time.RFC3339),
+		PeriodSeconds: period,
+		Metrics:       metricSeries(metrics),
+		Errors:        metricErrors(errs),
+	}, nil
+}
+
+// getEC2Metrics reads each EC2 metric per period, returning the series read and the
+// errors of those that failed, keyed by metric name
+func (cm *CloudWatchMetricsService) getEC2Metrics(ctx context.Context, profileID string, instanceID string, startTime time.Time, endTime time.Time, period int32) (map[string][]MetricDataPoint, map[string]error) {
+	dimensions := ec2Dimensions(instanceID)
+
+	metrics := map[string][]MetricDataPoint{}
+	errs := map[string]error{}
+	for _, metric := range ec2Metrics {
+		dataPoints, err := cm.GetMetricStatistics(ctx, profileID, "AWS/EC2", metric.name, dimensions, startTime, endTime, period, []string{metric.statistic})
+		if err != nil {
+			errs[metric.name] = err
+			continue
+		}
+		metrics[metric.name] = dataPoints
+	}
+
+	return metrics, errs
+}
+
+// LambdaMetricsSummary aggregates a Lambda function's invocation metrics over a time range
+type LambdaMetricsSummary struct {
+	Invocations      float64 `json:"invocations"`
+	Errors           float64 `json:"errors"`
+	Throttles        float64 `json:"throttles"`
+	ErrorRatePercent float64 `json:"error_rate_percent"`
+	DurationP50Ms    float64 `json:"duration_p50_ms"`
+	DurationP95Ms    float64 `json:"duration_p95_ms"`
+	DurationP99Ms    float64 `json:"duration_p99_ms"`
+	DurationMaxMs    float64 `json:"duration_max_ms"`
+	MaxConcurrency   float64 `json:"max_concurrency"`
+}
+
+// lambdaSummaryStats maps query IDs to the AWS/Lambda metric and statistic they read
+var lambdaSummaryStats = []struct{ id, metric, stat string }{
+	{"invocations", "Invocations", "Sum"},
+	{"errors", "Errors", "Sum"},
+	{"throttles", "Throttles", "Sum"},
+	{"p50", "Duration", "p50"},
+	{"p95", "Duration", "p95"},
+	{"p99", "Duration", "p99"},
+	{"max_duration", "Duration", "Maximum"},
+	{"concurrency", "ConcurrentExecutions", "Maximum"},
+}
+
+// GetLambdaMetrics summarizes the invocation, error, throttle, duration and concurrency
+// metrics of a Lambda function over a time range
+func (cm *CloudWatchMetricsService) GetLambdaMetrics(ctx context.Context, profileID string, functionName string, startTime time.Time, endTime time.Time) (*LambdaMetricsSummary, error) {
+	period := summaryPeriod(startTime, endTime, time.Now())
+	queries := make([]MetricDataQuery, 0, len(lambdaSummaryStats))
+	for _, s := range lambdaSummaryStats {
+		queries = append(queries, MetricDataQuery{
+			ID:         s.id,
+			Namespace:  "AWS/Lambda",
+			MetricName: s.metric,
+			Dimensions: map[string]string{"FunctionName": functionName},
+			Stat:       s.stat,
+			Period:     period,
+		})
+	}
+
+	result, err := cm.GetMetricData(ctx, profileID, queries, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeLambdaMetrics(result), nil
+}
+
+// summaryPeriod returns a period covering the whole range in as few points as possible;
+// GetMetricData periods must be a multiple of 60 seconds. CloudWatch keeps older data at
+// coarser resolution, so ranges starting more than 15 days before now need a multiple of
+// 5 minutes, and more than 63 days before now a multiple of 1 hour.
+func summaryPeriod(startTime time.Time, endTime time.Time, now time.Time) int32 {
+	minutes := int32(math.Ceil(endTime.Sub(startTime).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	period := minutes * 60
+
+	resolution := int32(60)
+	switch age := now.Sub(startTime); {
+	case age > 63*24*time.Hour:
+		resolution = 3600
+	case age > 15*24*time.Hour:
+		resolution = 300
+	}
+	return (period + resolution - 1) / resolution * resolution
+}
+
+// summarizeLambdaMetrics folds the series returned for lambdaSummaryStats into a summary.
+// Counts are summed across points; percentiles and maxima take the largest point, which
+// overstates rather than averages away a slow bucket when the range spans several.
+func summarizeLambdaMetrics(result *MetricDataResult) *LambdaMetricsSummary {
+	sum := func(id string) float64 {
+		total := 0.0
+		if series, ok := result.Series[id]; ok {
+			for _, p := range series.Points {
+				total += p.Value
+			}
+		}
+		return total
+	}
+	peak := func(id string) float64 {
+		highest := 0.0
+		if series, ok := result.Series[id]; ok {
+			for _, p := range series.Points {
+				highest = math.Max(highest, p.Value)
+			}
+		}
+		return highest
+	}
+
+	summary := &LambdaMetricsSummary{
+		Invocations:    sum("invocations"),
+		Errors:         sum("errors"),
+		Throttles:      sum("throttles"),
+		DurationP50Ms:  peak("p50"),
+		DurationP95Ms:  peak("p95"),
+		DurationP99Ms:  peak("p99"),
+		DurationMaxMs:  peak("max_duration"),
+		MaxConcurrency: peak("concurrency"),
+	}
+	if summary.Invocations > 0 {
+		summary.ErrorRatePercent = math.Round(summary.Errors/summary.Invocations*10000) / 100
+	}
+	return summary
+}
+
+// MetricDataQuery is one query in a GetMetricData call: either a metric stat
+// (namespace, metric_name, dimensions, stat, period) or a math expression over
+// other query IDs such as "SUM([m1,m2])" or "100*errors/requests"
+type MetricDataQuery struct {
+	ID         string            `json:"id"`
+	Expression string            `json:"expression,omitempty"`
+	Namespace  string            `json:"namespace,omitempty"`
+	MetricName string            `json:"metric_name,omitempty"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	Stat       string            `json:"stat,omitempty"`
+	Period     int32             `json:"period,omitempty"`
+	Label      string            `json:"label,omitempty"`
+	ReturnData *bool             `json:"return_data,omitempty"`
+}
+
+// MetricDataSeries is the result of one query, with points sorted chronologically
+type MetricDataSeries struct {
+	ID         string            `json:"id"`
+	Label      string            `json:"label"`
+	StatusCode string            `json:"status_code"`
+	Points     []MetricDataPoint `json:"points"`
+	Messages   []string          `json:"messages,omitempty"`
+}
+
+// MetricDataResult contains GetMetricData results keyed by query ID
+type MetricDataResult struct {
+	StartTime time.Time                    `json:"start_time"`
+	EndTime   time.Time                    `json:"end_time"`
+	Series    map[string]*MetricDataSeries `json:"series"`
+	Messages  []string                     `json:"messages,omitempty"`
+}
+
+// GetMetricData runs several metric and math-expression queries in one call
+func (cm *CloudWatchMetricsService) GetMetricData(ctx context.Context, profileID string, queries []MetricDataQuery, startTime time.Time, endTime time.Time) (*MetricDataResult, error) {
+	client, err := cm.clientManager.GetCloudWatchClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	cwQueries, err := toCloudWatchQueries(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MetricDataResult{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Series:    make(map[string]*MetricDataSeries),
+	}
+
+	input := &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: cwQueries,
+		StartTime:         aws.Time(startTime),
+		EndTime:           aws.Time(endTime),
+		ScanBy:            types.ScanByTimestampAscending,
+	}
+
+	paginator := cloudwatch.NewGetMetricDataPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metric data: %w", classifyAWSError(err, "cloudwatch:GetMetricData", ""))
+		}
+
+		for _, r := range page.MetricDataResults {
+			id := aws.ToString(r.Id)
+			series, ok := result.Series[id]
+			if !ok {
+				series = &MetricDataSeries{
+					ID:     id,
+					Label:  aws.ToString(r.Label),
+					Points: make([]MetricDataPoint, 0, len(r.Timestamps)),
+				}
+				result.Series[id] = series
+			}
+			series.StatusCode = string(r.StatusCode)
+
+			for i, ts := range r.Timestamps {
+				if i >= len(r.Values) {
+					break
+				}
+				series.Points = append(series.Points, MetricDataPoint{Timestamp: ts, Value: r.Values[i]})
+			}
+			for _, m := range r.Messages {
+				series.Messages = append(series.Messages, aws.ToString(m.Value))
+			}
+		}
+
+		for _, m := range page.Messages {
+			result.Messages = append(result.Messages, aws.ToString(m.Value))
+		}
+	}
+
+	for _, series := range result.Series {
+		sort.Slice(series.Points, func(i, j int) bool {
+			return series.Points[i].Timestamp.Before(series.Points[j].Timestamp)
+		})
+	}
+
+	return result, nil
+}
+
+// toCloudWatchQueries validates queries and converts them to the SDK type
+func toCloudWatchQueries(queries []MetricDataQuery) ([]types.MetricDataQuery, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("at least one query is required")
+	}
+	if len(queries) > 500 {
+		return nil, fmt.Errorf("too many queries: %d (max 500)", len(queries))
+	}
+
+	cwQueries := make([]types.MetricDataQuery, 0, len(queries))
+	for _, q := range queries {
+		if q.ID == "" {
+			return nil, fmt.Errorf("every query needs an id")
+		}
+
+		cwQuery := types.MetricDataQuery{
+			Id:         aws.String(q.ID),
+			ReturnData: q.ReturnData,
+		}
+		if q.Label != "" {
+			cwQuery.Label = aws.String(q.Label)
+		}
+
+		if q.Expression != "" {
+			cwQuery.Expression = aws.String(q.Expression)
+			if q.Period > 0 {
+				cwQuery.Period = aws.Int32(q.Period)
+			}
+			cwQueries = append(cwQueries, cwQuery)
+			continue
+		}
+
+		if q.Namespace == "" || q.MetricName == "" {
+			return nil, fmt.Errorf("query %s needs either an expression or namespace and metric_name", q.ID)
+		}
+
+		dimensions := make([]types.Dimension, 0, len(q.Dimensions))
+		for name, value := range q.Dimensions {
+			dimensions = append(dimensions, types.Dimension{
+				Name:  aws.String(name),
+				Value: aws.String(value),
+			})
+		}
+
+		stat := q.Stat
+		if stat == "" {
+			stat = "Average"
+		}
+		period := q.Period
+		if period <= 0 {
+			period = 300
+		}
+
+		cwQuery.MetricStat = &types.MetricStat{
+			Metric: &types.Metric{
+				Namespace:  aws.String(q.Namespace),
+				MetricName: aws.String(q.MetricName),
+				Dimensions: dimensions,
+			},
+			Period: aws.Int32(period),
+			Stat:   aws.String(stat),
+		}
+		cwQueries = append(cwQueries, cwQuery)
+	}
+
+	return cwQueries, nil
+}
@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRDSMetricsResultSortsAndFormats(t *testing.T) {
+	start := time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	metrics := map[string][]MetricDataPoint{
+		"CPUUtilization": {
+			{Timestamp: start.Add(10 * time.Minute), Value: 42.5, Unit: "Percent"},
+			{Timestamp: start.Add(5 * time.Minute), Value: 12.0, Unit: "Percent"},
+		},
+		"DiskQueueDepth": {},
+	}
+
+	result := newRDSMetricsResult("orders-db", start, end, 300, metrics)
+
+	assert.Equal(t, "orders-db", result.DBInstanceIdentifier)
+	assert.Equal(t, "2025-01-09T10:00:00Z", result.StartTime)
+	assert.Equal(t, "2025-01-09T11:00:00Z", result.EndTime)
+	assert.Equal(t, int32(300), result.PeriodSeconds)
+
+	cpu := result.Metrics["CPUUtilization"]
+	assert.Len(t, cpu, 2)
+	assert.Equal(t, "2025-01-09T10:05:00Z", cpu[0].Timestamp)
+	assert.Equal(t, 12.0, cpu[0].Value)
+	assert.Equal(t, "2025-01-09T10:10:00Z", cpu[1].Timestamp)
+	assert.Equal(t, "Percent", cpu[1].Unit)
+	// The input series keeps its order
+	assert.Equal(t, 42.5, metrics["CPUUtilization"][0].Value)
+
+	assert.Empty(t, result.Metrics["DiskQueueDepth"])
+}
+
+func TestMetricsPeriod(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, int32(300), metricsPeriod(start, start.Add(3*time.Hour)))
+	assert.Equal(t, int32(300), metricsPeriod(start, start.Add(5*24*time.Hour)))
+	// 30 days at one point per 30 minutes stays within 1440 points
+	assert.Equal(t, int32(1800), metricsPeriod(start, start.Add(30*24*time.Hour)))
+}
+
+func TestECSMetricQueries(t *testing.T) {
+	namespace, names := ecsMetricQueries(false)
+	assert.Equal(t, "AWS/ECS", namespace)
+	assert.Equal(t, []string{"CPUUtilization", "MemoryUtilization"}, names)
+
+	namespace, names = ecsMetricQueries(true)
+	assert.Equal(t, "ECS/ContainerInsights", namespace)
+	assert.Equal(t, []string{"CpuUtilized", "MemoryUtilized", "RunningTaskCount"}, names)
+}
+
+func TestHasDataPoints(t *testing.T) {
+	assert.False(t, hasDataPoints(nil))
+	// Container Insights not enabled: every series comes back empty
+	assert.False(t, hasDataPoints(map[string][]MetricDataPoint{"CpuUtilized": {}, "RunningTaskCount": nil}))
+	assert.True(t, hasDataPoints(map[string][]MetricDataPoint{"CpuUtilized": {}, "RunningTaskCount": {{Value: 2}}}))
+}
+
+func TestSplitStatistics(t *testing.T) {
+	standard, extended := splitStatistics([]string{"Average", "p50", "Maximum", "p99.9", "tm90"})
+	assert.Equal(t, []types.Statistic{types.StatisticAverage, types.StatisticMaximum}, standard)
+	assert.Equal(t, []string{"p50", "p99.9", "tm90"}, extended)
+
+	standard, extended = splitStatistics([]string{"p90"})
+	assert.Empty(t, standard)
+	assert.Equal(t, []string{"p90"}, extended)
+}
+
+func TestMergeDatapointsExtractsPercentiles(t *testing.T) {
+	t0 := time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(5 * time.Minute)
+
+	// Standard and extended statistics arrive from separate calls
+	points := mergeDatapoints([]types.Datapoint{
+		{Timestamp: aws.Time(t1), Average: aws.Float64(20), Maximum: aws.Float64(90), Unit: types.StandardUnitMilliseconds},
+		{Timestamp: aws.Time(t0), Average: aws.Float64(10), Unit: types.StandardUnitMilliseconds},
+		{Timestamp: aws.Time(t1), ExtendedStatistics: map[string]float64{"p50": 15, "p99": 85}},
+		{Timestamp: aws.Time(t0), ExtendedStatistics: map[string]float64{"p50": 8, "p99": 40}},
+	})
+
+	assert.Len(t, points, 2)
+	assert.Equal(t, t0, points[0].Timestamp)
+	assert.Equal(t, 10.0, points[0].Value)
+	assert.Equal(t, map[string]float64{"Average": 10, "p50": 8, "p99": 40}, points[0].Statistics)
+	assert.Equal(t, "Milliseconds", points[0].Unit)
+
+	assert.Equal(t, 20.0, points[1].Value)
+	assert.Equal(t, 85.0, points[1].Statistics["p99"])
+	assert.Equal(t, 90.0, points[1].Statistics["Maximum"])
+}
+
+func TestPrimaryStatistic(t *testing.T) {
+	assert.Equal(t, 5.0, primaryStatistic(map[string]float64{"Sum": 5, "Maximum": 3}))
+	// Only percentiles requested: the lowest-named one stands in for the value
+	assert.Equal(t, 12.0, primaryStatistic(map[string]float64{"p99": 30, "p50": 12}))
+	assert.Equal(t, 0.0, primaryStatistic(nil))
+}
+
+func TestEC2Dimensions(t *testing.T) {
+	assert.Equal(t, map[string]string{"InstanceId": "i-0123456789abcdef0"}, ec2Dimensions("i-0123456789abcdef0"))
+
+	// Every statistic is one GetMetricStatistics accepts as a standard statistic
+	for _, metric := range ec2Metrics {
+		standard, extended := splitStatistics([]string{metric.statistic})
+		assert.Len(t, standard, 1, metric.name)
+		assert.Empty(t, extended, metric.name)
+	}
+}
+
+func TestMetricErrors(t *testing.T) {
+	assert.Nil(t, metricErrors(map[string]error{}))
+	assert.Equal(t, map[string]string{"NetworkIn": "access denied"}, metricErrors(map[string]error{"NetworkIn": errors.New("access denied")}))
+}
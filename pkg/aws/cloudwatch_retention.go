@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// logStoragePricePerGBMonth is the CloudWatch Logs archived storage price (USD per GB-month)
+// in most commercial regions, used for savings estimates
+const logStoragePricePerGBMonth = 0.03
+
+// retentionAuditPolicies are the retention periods (in days) whose savings are estimated
+var retentionAuditPolicies = []int32{30, 90}
+
+// allowedRetentionDays are the retention periods (in days) CloudWatch Logs accepts
+var allowedRetentionDays = []int32{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653}
+
+// RetentionChange is the retention of a log group after it was changed
+type RetentionChange struct {
+	LogGroup      string `json:"log_group"`
+	RetentionDays int32  `json:"retention_days"`
+	NeverExpire   bool   `json:"never_expire"`
+}
+
+// RetentionRecommendation estimates the effect of applying a retention policy to a log group
+type RetentionRecommendation struct {
+	RetentionDays              int32   `json:"retention_days"`
+	EstimatedRetainedBytes     int64   `json:"estimated_retained_bytes"`
+	EstimatedMonthlySavingsUSD float64 `json:"estimated_monthly_savings_usd"`
+	Command                    string  `json:"command"`
+}
+
+// RetentionAuditEntry is a log group without a retention policy
+type RetentionAuditEntry struct {
+	LogGroup              string                    `json:"log_group"`
+	StoredBytes           int64                     `json:"stored_bytes"`
+	AgeDays               int                       `json:"age_days"`
+	MonthlyStorageCostUSD float64                   `json:"monthly_storage_cost_usd"`
+	Recommendations       []RetentionRecommendation `json:"recommendations"`
+}
+
+// RetentionAudit lists the log groups that keep their data forever, largest first
+type RetentionAudit struct {
+	LogGroupsScanned        int                   `json:"log_groups_scanned"`
+	UnlimitedRetentionCount int                   `json:"unlimited_retention_count"`
+	UnlimitedStoredBytes    int64                 `json:"unlimited_stored_bytes"`
+	MonthlyStorageCostUSD   float64               `json:"monthly_storage_cost_usd"`
+	EstimatedMonthlySavings map[string]float64    `json:"estimated_monthly_savings_usd"`
+	LogGroups               []RetentionAuditEntry `json:"log_groups"`
+	Assumptions             string                `json:"assumptions"`
+}
+
+// AuditLogRetention finds log groups with no retention policy and estimates the monthly
+// storage savings of a 30 or 90 day policy. Nothing is changed: each recommendation
+// carries the put-retention-policy command to apply it.
+func (cw *CloudWatchService) AuditLogRetention(ctx context.Context, profileID string, prefix string) (*RetentionAudit, error) {
+	listing, err := cw.ListLogGroups(ctx, profileID, prefix, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return buildRetentionAudit(listing.LogGroups, time.Now()), nil
+}
+
+// buildRetentionAudit builds the audit of log groups as of now. Without ingestion history,
+// data is assumed to have accumulated evenly since the group was created, so a policy of N
+// days would keep roughly the last N days' share of the stored bytes.
+func buildRetentionAudit(logGroups []LogGroup, now time.Time) *RetentionAudit {
+	audit := &RetentionAudit{
+		LogGroupsScanned:        len(logGroups),
+		EstimatedMonthlySavings: make(map[string]float64, len(retentionAuditPolicies)),
+		LogGroups:               []RetentionAuditEntry{},
+		Assumptions: fmt.Sprintf("Storage priced at $%.2f per GB-month; data assumed to have accumulated evenly since each log group was created",
+			logStoragePricePerGBMonth),
+	}
+
+	for _, lg := range logGroups {
+		if lg.RetentionDays > 0 {
+			continue
+		}
+
+		ageDays := int(now.Sub(time.UnixMilli(lg.CreationTime)).Hours() / 24)
+		entry := RetentionAuditEntry{
+			LogGroup:              lg.Name,
+			StoredBytes:           lg.StoredBytes,
+			AgeDays:               ageDays,
+			MonthlyStorageCostUSD: storageCostUSD(lg.StoredBytes),
+			Recommendations:       make([]RetentionRecommendation, 0, len(retentionAuditPolicies)),
+		}
+
+		for _, days := range retentionAuditPolicies {
+			retained := lg.StoredBytes
+			if ageDays > int(days) {
+				retained = int64(float64(lg.StoredBytes) * float64(days) / float64(ageDays))
+			}
+			savings := storageCostUSD(lg.StoredBytes - retained)
+			entry.Recommendations = append(entry.Recommendations, RetentionRecommendation{
+				RetentionDays:              days,
+				EstimatedRetainedBytes:     retained,
+				EstimatedMonthlySavingsUSD: savings,
+				Command:                    putRetentionPolicyCommand(lg.Name, days),
+			})
+			audit.EstimatedMonthlySavings[fmt.Sprintf("%d_days", days)] += savings
+		}
+
+		audit.UnlimitedRetentionCount++
+		audit.UnlimitedStoredBytes += lg.StoredBytes
+		audit.LogGroups = append(audit.LogGroups, entry)
+	}
+
+	sort.SliceStable(audit.LogGroups, func(i, j int) bool {
+		return audit.LogGroups[i].StoredBytes > audit.LogGroups[j].StoredBytes
+	})
+
+	audit.MonthlyStorageCostUSD = storageCostUSD(audit.UnlimitedStoredBytes)
+	for key, savings := range audit.EstimatedMonthlySavings {
+		audit.EstimatedMonthlySavings[key] = roundCents(savings)
+	}
+
+	return audit
+}
+
+// storageCostUSD returns the monthly storage cost of bytes, rounded to cents
+func storageCostUSD(bytes int64) float64 {
+	return roundCents(float64(bytes) / (1 << 30) * logStoragePricePerGBMonth)
+}
+
+// roundCents rounds a dollar amount to cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// putRetentionPolicyCommand returns the AWS CLI command that sets the retention of a log group
+func putRetentionPolicyCommand(logGroup string, days int32) string {
+	quoted := "'" + strings.ReplaceAll(logGroup, "'", `'\''`) + "'"
+	return fmt.Sprintf("aws logs put-retention-policy --log-group-name %s --retention-in-days %d", quoted, days)
+}
+
+// validateRetentionDays checks that days is a retention period CloudWatch Logs accepts
+func validateRetentionDays(days int32) error {
+	if slices.Contains(allowedRetentionDays, days) {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(allowedRetentionDays))
+	for _, d := range allowedRetentionDays {
+		allowed = append(allowed, fmt.Sprint(d))
+	}
+	return fmt.Errorf("invalid retention_days %d: must be 0 to never expire, or one of %s", days, strings.Join(allowed, ", "))
+}
+
+// PutRetentionPolicy sets how many days a log group keeps its events. days must be one
+// of the periods CloudWatch Logs accepts; use DeleteRetentionPolicy to keep events forever.
+func (cw *CloudWatchService) PutRetentionPolicy(ctx context.Context, profileID string, logGroupName string, retentionDays int32) (*RetentionChange, error) {
+	if logGroupName == "" {
+		return nil, fmt.Errorf("log_group is required")
+	}
+	if err := validateRetentionDays(retentionDays); err != nil {
+		return nil, err
+	}
+
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(logGroupName),
+		RetentionInDays: aws.Int32(retentionDays),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set retention policy: %w", classifyAWSError(err, "logs:PutRetentionPolicy", "log group "+logGroupName))
+	}
+
+	return &RetentionChange{LogGroup: logGroupName, RetentionDays: retentionDays}, nil
+}
+
+// DeleteRetentionPolicy removes the retention policy of a log group, so its events never expire
+func (cw *CloudWatchService) DeleteRetentionPolicy(ctx context.Context, profileID string, logGroupName string) (*RetentionChange, error) {
+	if logGroupName == "" {
+		return nil, fmt.Errorf("log_group is required")
+	}
+
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.DeleteRetentionPolicy(ctx, &cloudwatchlogs.DeleteRetentionPolicyInput{
+		LogGroupName: aws.String(logGroupName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete retention policy: %w", classifyAWSError(err, "logs:DeleteRetentionPolicy", "log group "+logGroupName))
+	}
+
+	return &RetentionChange{LogGroup: logGroupName, NeverExpire: true}, nil
+}
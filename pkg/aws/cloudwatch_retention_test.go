@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRetentionAudit(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	const gb = int64(1 << 30)
+
+	audit := buildRetentionAudit([]LogGroup{
+		{Name: "/aws/lambda/small", CreationTime: now.AddDate(0, 0, -360).UnixMilli(), StoredBytes: 12 * gb},
+		{Name: "/ecs/api", CreationTime: now.AddDate(0, 0, -360).UnixMilli(), StoredBytes: 120 * gb},
+		{Name: "/ecs/worker", CreationTime: now.AddDate(0, 0, -360).UnixMilli(), StoredBytes: 500 * gb, RetentionDays: 14},
+		{Name: "/aws/lambda/new", CreationTime: now.AddDate(0, 0, -10).UnixMilli(), StoredBytes: 2 * gb},
+	}, now)
+
+	assert.Equal(t, 4, audit.LogGroupsScanned)
+	assert.Equal(t, 3, audit.UnlimitedRetentionCount)
+	assert.Equal(t, 134*gb, audit.UnlimitedStoredBytes)
+	assert.Equal(t, 4.02, audit.MonthlyStorageCostUSD)
+
+	// Largest first; groups with a retention policy are left out
+	assert.Len(t, audit.LogGroups, 3)
+	assert.Equal(t, "/ecs/api", audit.LogGroups[0].LogGroup)
+	assert.Equal(t, "/aws/lambda/small", audit.LogGroups[1].LogGroup)
+	assert.Equal(t, "/aws/lambda/new", audit.LogGroups[2].LogGroup)
+
+	api := audit.LogGroups[0]
+	assert.Equal(t, 360, api.AgeDays)
+	assert.Equal(t, RetentionRecommendation{
+		RetentionDays:              30,
+		EstimatedRetainedBytes:     10 * gb,
+		EstimatedMonthlySavingsUSD: 3.3,
+		Command:                    "aws logs put-retention-policy --log-group-name '/ecs/api' --retention-in-days 30",
+	}, api.Recommendations[0])
+	assert.Equal(t, int64(30*gb), api.Recommendations[1].EstimatedRetainedBytes)
+
+	// A group younger than the policy keeps everything
+	assert.Equal(t, 0.0, audit.LogGroups[2].Recommendations[0].EstimatedMonthlySavingsUSD)
+
+	assert.Equal(t, map[string]float64{"30_days": 3.63, "90_days": 2.97}, audit.EstimatedMonthlySavings)
+}
+
+func TestPutRetentionPolicyCommandQuotesName(t *testing.T) {
+	assert.Equal(t, `aws logs put-retention-policy --log-group-name 'team'\''s logs' --retention-in-days 90`,
+		putRetentionPolicyCommand("team's logs", 90))
+}
+
+func TestValidateRetentionDays(t *testing.T) {
+	for _, days := range []int32{1, 7, 30, 365, 3653} {
+		assert.NoError(t, validateRetentionDays(days))
+	}
+
+	err := validateRetentionDays(45)
+	assert.EqualError(t, err, "invalid retention_days 45: must be 0 to never expire, or one of 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653")
+
+	// 0 means never expire and goes through DeleteRetentionPolicy instead
+	assert.Error(t, validateRetentionDays(0))
+	assert.Error(t, validateRetentionDays(-30))
+}
+
+func TestAuditPoliciesAreAllowed(t *testing.T) {
+	for _, days := range retentionAuditPolicies {
+		assert.NoError(t, validateRetentionDays(days))
+	}
+}
@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// SubscriptionFilter is a CloudWatch Logs subscription filter forwarding a log group's
+// events to Lambda, Kinesis, Firehose or a cross-account log destination
+type SubscriptionFilter struct {
+	FilterName      string `json:"filter_name"`
+	LogGroup        string `json:"log_group"`
+	DestinationARN  string `json:"destination_arn"`
+	DestinationType string `json:"destination_type"`
+	FilterPattern   string `json:"filter_pattern"`
+	Distribution    string `json:"distribution,omitempty"`
+	RoleARN         string `json:"role_arn,omitempty"`
+	CreationTime    string `json:"creation_time,omitempty"`
+}
+
+// ListSubscriptionFilters lists the subscription filters of a log group
+func (cw *CloudWatchService) ListSubscriptionFilters(ctx context.Context, profileID string, logGroupName string) ([]SubscriptionFilter, error) {
+	if logGroupName == "" {
+		return nil, fmt.Errorf("log_group is required")
+	}
+
+	client, err := cw.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]SubscriptionFilter, 0)
+	paginator := cloudwatchlogs.NewDescribeSubscriptionFiltersPaginator(client, &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+		LogGroupName: aws.String(logGroupName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe subscription filters: %w", classifyAWSError(err, "logs:DescribeSubscriptionFilters", "log group "+logGroupName))
+		}
+		for _, f := range page.SubscriptionFilters {
+			filters = append(filters, newSubscriptionFilter(f))
+		}
+	}
+
+	return filters, nil
+}
+
+// newSubscriptionFilter converts a subscription filter returned by the API
+func newSubscriptionFilter(f types.SubscriptionFilter) SubscriptionFilter {
+	destination := aws.ToString(f.DestinationArn)
+	filter := SubscriptionFilter{
+		FilterName:      aws.ToString(f.FilterName),
+		LogGroup:        aws.ToString(f.LogGroupName),
+		DestinationARN:  destination,
+		DestinationType: subscriptionDestinationType(destination),
+		FilterPattern:   aws.ToString(f.FilterPattern),
+		Distribution:    string(f.Distribution),
+		RoleARN:         aws.ToString(f.RoleArn),
+	}
+	if f.CreationTime != nil {
+		filter.CreationTime = time.UnixMilli(*f.CreationTime).UTC().Format(time.RFC3339)
+	}
+	return filter
+}
+
+// subscriptionDestinationType names the service a subscription forwards to from the
+// destination ARN: lambda, kinesis, firehose or logs for a cross-account destination
+func subscriptionDestinationType(destinationARN string) string {
+	parts := strings.SplitN(destinationARN, ":", 4)
+	if len(parts) < 4 || parts[0] != "arn" {
+		return "unknown"
+	}
+	return parts[2]
+}
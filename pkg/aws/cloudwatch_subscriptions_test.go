@@ -0,0 +1,38 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubscriptionFilter(t *testing.T) {
+	filter := newSubscriptionFilter(types.SubscriptionFilter{
+		FilterName:     aws.String("to-datadog"),
+		LogGroupName:   aws.String("/ecs/api"),
+		DestinationArn: aws.String("arn:aws:firehose:us-east-1:123456789012:deliverystream/datadog-logs"),
+		FilterPattern:  aws.String(""),
+		Distribution:   types.DistributionByLogStream,
+		RoleArn:        aws.String("arn:aws:iam::123456789012:role/CWLtoFirehose"),
+		CreationTime:   aws.Int64(1736380800000),
+	})
+
+	assert.Equal(t, SubscriptionFilter{
+		FilterName:      "to-datadog",
+		LogGroup:        "/ecs/api",
+		DestinationARN:  "arn:aws:firehose:us-east-1:123456789012:deliverystream/datadog-logs",
+		DestinationType: "firehose",
+		Distribution:    "ByLogStream",
+		RoleARN:         "arn:aws:iam::123456789012:role/CWLtoFirehose",
+		CreationTime:    "2025-01-09T00:00:00Z",
+	}, filter)
+}
+
+func TestSubscriptionDestinationType(t *testing.T) {
+	assert.Equal(t, "lambda", subscriptionDestinationType("arn:aws:lambda:us-east-1:123456789012:function:log-shipper"))
+	assert.Equal(t, "kinesis", subscriptionDestinationType("arn:aws:kinesis:us-east-1:123456789012:stream/logs"))
+	assert.Equal(t, "logs", subscriptionDestinationType("arn:aws-cn:logs:cn-north-1:123456789012:destination:central"))
+	assert.Equal(t, "unknown", subscriptionDestinationType("not-an-arn"))
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortEventsAscendingMergesStreams(t *testing.T) {
+	events := []LogEvent{
+		{Timestamp: 300, Message: "task b: done", LogStream: "ecs/api/b"},
+		{Timestamp: 100, Message: "task a: start", LogStream: "ecs/api/a"},
+		{Timestamp: 200, Message: "task a: request", LogStream: "ecs/api/a"},
+		{Timestamp: 100, Message: "task b: start", LogStream: "ecs/api/b"},
+	}
+
+	sortEventsAscending(events)
+
+	messages := make([]string, 0, len(events))
+	for _, event := range events {
+		messages = append(messages, event.Message)
+	}
+	assert.Equal(t, []string{"task a: start", "task b: start", "task a: request", "task b: done"}, messages)
+}
+
+// queryResultsSequence returns the given statuses in turn, repeating the last one
+func queryResultsSequence(statuses ...types.QueryStatus) (func(ctx context.Context) (*cloudwatchlogs.GetQueryResultsOutput, error), *int) {
+	calls := 0
+	return func(ctx context.Context) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return &cloudwatchlogs.GetQueryResultsOutput{
+			Status: status,
+			Results: [][]types.ResultField{
+				{{Field: aws.String("@message"), Value: aws.String("call " + string(status))}},
+			},
+		}, nil
+	}, &calls
+}
+
+func TestPollInsightsQueryStopsOnComplete(t *testing.T) {
+	getResults, calls := queryResultsSequence(types.QueryStatusScheduled, types.QueryStatusRunning, types.QueryStatusComplete, types.QueryStatusRunning)
+
+	result, timedOut, err := pollInsightsQuery(context.Background(), getResults, time.Minute, time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, timedOut)
+	assert.Equal(t, types.QueryStatusComplete, result.Status)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestPollInsightsQueryTimesOut(t *testing.T) {
+	getResults, calls := queryResultsSequence(types.QueryStatusRunning)
+
+	start := time.Now()
+	result, timedOut, err := pollInsightsQuery(context.Background(), getResults, 20*time.Millisecond, 5*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, timedOut)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.GreaterOrEqual(t, *calls, 2)
+
+	// The partial results of the last poll are kept
+	assert.Equal(t, types.QueryStatusRunning, result.Status)
+	assert.Len(t, result.Results, 1)
+}
+
+func TestPollInsightsQueryReturnsErrors(t *testing.T) {
+	_, _, err := pollInsightsQuery(context.Background(), func(ctx context.Context) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		return nil, errors.New("AccessDeniedException")
+	}, time.Minute, time.Millisecond)
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	getResults, _ := queryResultsSequence(types.QueryStatusRunning)
+	_, _, err = pollInsightsQuery(ctx, getResults, time.Minute, time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewInsightsQueryResult(t *testing.T) {
+	rows := [][]types.ResultField{
+		{{Field: aws.String("@message"), Value: aws.String("timeout calling payments")}},
+		{{Field: aws.String("@message"), Value: aws.String("timeout calling ledger")}},
+	}
+
+	result := newInsightsQueryResult("q-1", &cloudwatchlogs.GetQueryResultsOutput{
+		Status:     types.QueryStatusComplete,
+		Results:    rows,
+		Statistics: &types.QueryStatistics{RecordsMatched: 57, RecordsScanned: 12000, BytesScanned: 4096},
+	}, 2, false)
+
+	assert.True(t, result.Complete)
+	assert.False(t, result.TimedOut)
+	assert.Equal(t, 2, result.TotalRecords)
+	assert.Equal(t, 57.0, result.RecordsMatched)
+	assert.Equal(t, 12000.0, result.RecordsScanned)
+	assert.Equal(t, 4096.0, result.BytesScanned)
+	// A full page with more matches left means the rows were cut off by the limit
+	assert.True(t, result.Truncated)
+
+	// An aggregation returns fewer rows than the limit however many records matched
+	stats := newInsightsQueryResult("q-2", &cloudwatchlogs.GetQueryResultsOutput{
+		Status:     types.QueryStatusRunning,
+		Results:    rows[:1],
+		Statistics: &types.QueryStatistics{RecordsMatched: 57},
+	}, 100, true)
+	assert.False(t, stats.Truncated)
+	assert.False(t, stats.Complete)
+	assert.True(t, stats.TimedOut)
+}
@@ -3,28 +3,59 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/common"
 )
 
 // ProfileConfig represents an AWS profile configuration
 type ProfileConfig struct {
-	ID              string   `json:"id"`
-	AccessKeyID     string   `json:"access_key_id"`
-	SecretAccessKey string   `json:"secret_access_key"`
-	Region          string   `json:"region"`
-	Project         string   `json:"project"`
-	Environment     string   `json:"environment"`
-	Description     string   `json:"description"`
-	Tags            []string `json:"tags"`
+	ID                string   `json:"id"`
+	AccessKeyID       string   `json:"access_key_id"`
+	SecretAccessKey   string   `json:"secret_access_key"`
+	Region            string   `json:"region"`
+	Project           string   `json:"project"`
+	Environment       string   `json:"environment"`
+	Description       string   `json:"description"`
+	Tags              []string `json:"tags"`
+	CABundlePath      string   `json:"ca_bundle_path,omitempty"`      // PEM file with extra trusted CAs (e.g. TLS-intercepting proxies)
+	ProxyURL          string   `json:"proxy_url,omitempty"`           // Explicit HTTP/SOCKS5 proxy; overrides HTTP(S)_PROXY
+	AllowMutations    bool     `json:"allow_mutations,omitempty"`     // Registers tools that change resources (e.g. ECS scaling)
+	RevealLambdaEnv   bool     `json:"reveal_lambda_env,omitempty"`   // Returns Lambda environment variables unmasked
+	AllowSecretReads  bool     `json:"allow_secret_reads,omitempty"`  // Registers the tool that reads Secrets Manager secret values
+	AllowSecretWrites bool     `json:"allow_secret_writes,omitempty"` // Registers tools that create or change Secrets Manager secret values
+	RoleARN           string   `json:"role_arn,omitempty"`            // Role assumed with the base credentials before any role_chain hops
+	ExternalID        string   `json:"external_id,omitempty"`         // External ID required by the trust policy of RoleARN
+	SessionName       string   `json:"session_name,omitempty"`        // Role session name recorded in CloudTrail for every assumed role
+	SourceProfile     string   `json:"source_profile,omitempty"`      // Profile whose credentials are the base for the roles, instead of static keys
+	AWSProfileName    string   `json:"aws_profile_name,omitempty"`    // Named profile in ~/.aws/credentials and ~/.aws/config used instead of static keys
+	RoleChain         []string `json:"role_chain,omitempty"`          // Role ARNs assumed in order, each with the previous role's credentials
+}
+
+// hasStaticCredentials reports whether the profile carries its own access key
+func (p *ProfileConfig) hasStaticCredentials() bool {
+	return p.AccessKeyID != "" || p.SecretAccessKey != ""
+}
+
+// assumedRoles returns every role the profile assumes, in order
+func (p *ProfileConfig) assumedRoles() []string {
+	if p.RoleARN == "" {
+		return p.RoleChain
+	}
+	return append([]string{p.RoleARN}, p.RoleChain...)
 }
 
-// AWSConfig manages AWS SDK configuration
+// AWSConfig manages AWS SDK configuration. It is safe for concurrent use, so profiles
+// can be loaded in parallel.
 type AWSConfig struct {
 	profiles map[string]*ProfileConfig
 	configs  map[string]aws.Config
+	mu       sync.RWMutex
 }
 
 // NewAWSConfig creates a new AWS configuration manager
@@ -40,56 +71,132 @@ func (ac *AWSConfig) AddProfile(profile *ProfileConfig) error {
 	if profile.ID == "" {
 		return fmt.Errorf("profile ID cannot be empty")
 	}
-	if profile.AccessKeyID == "" {
-		return fmt.Errorf("access_key_id cannot be empty for profile %s", profile.ID)
-	}
-	if profile.SecretAccessKey == "" {
+	switch {
+	case profile.SourceProfile != "" && profile.AWSProfileName != "":
+		return fmt.Errorf("profile %s cannot set both source_profile and aws_profile_name", profile.ID)
+	case profile.AWSProfileName != "":
+		if profile.hasStaticCredentials() {
+			return fmt.Errorf("profile %s cannot set both aws_profile_name and static credentials", profile.ID)
+		}
+	case profile.SourceProfile != "":
+		if profile.hasStaticCredentials() {
+			return fmt.Errorf("profile %s cannot set both source_profile and static credentials", profile.ID)
+		}
+		if profile.SourceProfile == profile.ID {
+			return fmt.Errorf("profile %s cannot be its own source_profile", profile.ID)
+		}
+		if len(profile.assumedRoles()) == 0 {
+			return fmt.Errorf("profile %s sets source_profile but no role_arn or role_chain", profile.ID)
+		}
+	case profile.AccessKeyID == "":
+		return fmt.Errorf("access_key_id cannot be empty for profile %s (or set aws_profile_name, or source_profile and role_arn)", profile.ID)
+	case profile.SecretAccessKey == "":
 		return fmt.Errorf("secret_access_key cannot be empty for profile %s", profile.ID)
 	}
-	if profile.Region == "" {
-		profile.Region = "us-east-1" // Default region
+	if profile.RoleARN != "" {
+		if err := validateRoleARN(profile.RoleARN); err != nil {
+			return fmt.Errorf("invalid role_arn for profile %s: %w", profile.ID, err)
+		}
+	}
+	if profile.ExternalID != "" && profile.RoleARN == "" {
+		return fmt.Errorf("profile %s sets external_id without role_arn", profile.ID)
+	}
+	if err := validateRoleChain(profile.RoleChain); err != nil {
+		return fmt.Errorf("invalid role chain for profile %s: %w", profile.ID, err)
+	}
+	if profile.Region == "" && profile.AWSProfileName == "" {
+		profile.Region = "us-east-1" // Default region; shared profiles may set their own
 	}
 
+	ac.mu.Lock()
 	ac.profiles[profile.ID] = profile
+	ac.mu.Unlock()
 	return nil
 }
 
 // LoadProfile loads AWS configuration for a specific profile
 func (ac *AWSConfig) LoadProfile(ctx context.Context, profileID string) (aws.Config, error) {
-	// Check if already loaded
-	if cfg, exists := ac.configs[profileID]; exists {
+	// Check if already loaded. The lock is not held while loading, so two callers may
+	// load the same profile at once; both get an equivalent config.
+	ac.mu.RLock()
+	cfg, loaded := ac.configs[profileID]
+	profile, exists := ac.profiles[profileID]
+	ac.mu.RUnlock()
+	if loaded {
 		return cfg, nil
 	}
 
 	// Get profile configuration
-	profile, exists := ac.profiles[profileID]
 	if !exists {
 		return aws.Config{}, fmt.Errorf("profile %s not found", profileID)
 	}
 
-	// Create credentials provider from access key and secret
-	credsProvider := credentials.NewStaticCredentialsProvider(
-		profile.AccessKeyID,
-		profile.SecretAccessKey,
-		"", // session token (empty for long-term credentials)
-	)
+	// Base credentials come from the profile's own keys, a shared config profile or a
+	// source profile
+	var opts []func(*config.LoadOptions) error
+	switch {
+	case profile.AWSProfileName != "":
+		// The SDK resolves the named profile's keys, SSO session, credential_process or
+		// role settings from ~/.aws/credentials and ~/.aws/config
+		opts = append(opts, config.WithSharedConfigProfile(profile.AWSProfileName))
+	case profile.SourceProfile != "":
+		source, err := ac.GetProfile(profile.SourceProfile)
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("source_profile %s of profile %s not found", profile.SourceProfile, profileID)
+		}
+		if source.SourceProfile != "" {
+			return aws.Config{}, fmt.Errorf("source_profile %s of profile %s cannot itself use a source_profile", profile.SourceProfile, profileID)
+		}
+		sourceCfg, err := ac.LoadProfile(ctx, profile.SourceProfile)
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("failed to load source_profile %s of profile %s: %w", profile.SourceProfile, profileID, err)
+		}
+		opts = append(opts, config.WithCredentialsProvider(sourceCfg.Credentials))
+	default:
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			profile.AccessKeyID,
+			profile.SecretAccessKey,
+			"", // session token (empty for long-term credentials)
+		)))
+	}
+
+	// An explicit region wins over the shared profile's region
+	if profile.Region != "" {
+		opts = append(opts, config.WithRegion(profile.Region))
+	}
 
-	// Load AWS SDK config with credentials and region
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithCredentialsProvider(credsProvider),
-		config.WithRegion(profile.Region),
-	)
+	// Use an explicit HTTP client so proxy and CA settings apply to every service client
+	httpClient, err := newHTTPClient(profile)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to configure HTTP client for profile %s: %w", profileID, err)
+	}
+	opts = append(opts, config.WithHTTPClient(httpClient))
+
+	cfg, err = config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return aws.Config{}, fmt.Errorf("failed to load AWS config for profile %s: %w", profileID, err)
 	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	// Hop through the configured roles; service clients only see the last role's credentials
+	if len(profile.assumedRoles()) > 0 {
+		cfg.Credentials = assumeRoleChain(cfg, profile)
+	}
 
 	// Cache the configuration
+	ac.mu.Lock()
 	ac.configs[profileID] = cfg
+	ac.mu.Unlock()
 	return cfg, nil
 }
 
 // GetProfile returns a profile configuration by ID
 func (ac *AWSConfig) GetProfile(profileID string) (*ProfileConfig, error) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
 	profile, exists := ac.profiles[profileID]
 	if !exists {
 		return nil, fmt.Errorf("profile %s not found", profileID)
@@ -99,6 +206,9 @@ func (ac *AWSConfig) GetProfile(profileID string) (*ProfileConfig, error) {
 
 // ListProfiles returns all configured profile IDs
 func (ac *AWSConfig) ListProfiles() []string {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
 	profiles := make([]string, 0, len(ac.profiles))
 	for id := range ac.profiles {
 		profiles = append(profiles, id)
@@ -106,12 +216,33 @@ func (ac *AWSConfig) ListProfiles() []string {
 	return profiles
 }
 
+// FilterProfiles returns the profiles in the given environment that carry every given
+// tag, sorted by ID. An empty environment or no tags matches all.
+func (ac *AWSConfig) FilterProfiles(env string, tags []string) []*ProfileConfig {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	matches := make([]*ProfileConfig, 0)
+	for _, profile := range ac.profiles {
+		if common.MatchesLabels(profile.Environment, profile.Tags, env, tags) {
+			matches = append(matches, profile)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ID < matches[j].ID
+	})
+
+	return matches
+}
+
 // GetConfig returns the AWS SDK config for a profile
 func (ac *AWSConfig) GetConfig(profileID string) (aws.Config, error) {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
 	cfg, exists := ac.configs[profileID]
 	if !exists {
 		return aws.Config{}, fmt.Errorf("AWS config not loaded for profile %s", profileID)
 	}
 	return cfg, nil
 }
-
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddProfileSharedProfileName(t *testing.T) {
+	ac := NewAWSConfig()
+	profile := &ProfileConfig{ID: "dev", AWSProfileName: "dev-sso"}
+	assert.NoError(t, ac.AddProfile(profile))
+	assert.Equal(t, []string{"dev"}, ac.ListProfiles())
+	// The region is left to the shared profile
+	assert.Equal(t, "", profile.Region)
+
+	err := ac.AddProfile(&ProfileConfig{ID: "mixed", AWSProfileName: "dev-sso", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "both aws_profile_name and static credentials")
+
+	err = ac.AddProfile(&ProfileConfig{ID: "chained", AWSProfileName: "dev-sso", SourceProfile: "dev", RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "both source_profile and aws_profile_name")
+
+	// A shared profile can be the base for a role
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{ID: "prod", AWSProfileName: "dev-sso", RoleARN: "arn:aws:iam::222222222222:role/ReadOnly"}))
+}
+
+func TestLoadProfileSharedCredentials(t *testing.T) {
+	dir := t.TempDir()
+	credentialsFile := filepath.Join(dir, "credentials")
+	configFile := filepath.Join(dir, "config")
+	assert.NoError(t, os.WriteFile(credentialsFile, []byte("[reporting]\naws_access_key_id = AKIASHARED\naws_secret_access_key = shared-secret\n"), 0o600))
+	assert.NoError(t, os.WriteFile(configFile, []byte("[profile reporting]\nregion = eu-west-1\n"), 0o600))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_REGION", "")
+
+	ac := NewAWSConfig()
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{ID: "reporting", AWSProfileName: "reporting"}))
+
+	cfg, err := ac.LoadProfile(context.Background(), "reporting")
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", cfg.Region)
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "AKIASHARED", creds.AccessKeyID)
+
+	// An explicit region overrides the shared profile's
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{ID: "reporting-us", AWSProfileName: "reporting", Region: "us-west-2"}))
+	cfg, err = ac.LoadProfile(context.Background(), "reporting-us")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", cfg.Region)
+
+	// Unknown shared profiles fail to load
+	assert.NoError(t, ac.AddProfile(&ProfileConfig{ID: "missing", AWSProfileName: "does-not-exist"}))
+	_, err = ac.LoadProfile(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestFilterProfiles(t *testing.T) {
+	ac := NewAWSConfig()
+	for _, profile := range []*ProfileConfig{
+		{ID: "payments-prod", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret", Environment: "production", Tags: []string{"payments", "pci"}},
+		{ID: "payments-staging", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret", Environment: "staging", Tags: []string{"payments"}},
+		{ID: "data-prod", AccessKeyID: "AKIAEXAMPLE", SecretAccessKey: "secret", Environment: "production", Tags: []string{"analytics"}},
+	} {
+		assert.NoError(t, ac.AddProfile(profile))
+	}
+
+	ids := func(profiles []*ProfileConfig) []string {
+		result := make([]string, 0, len(profiles))
+		for _, profile := range profiles {
+			result = append(result, profile.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"data-prod", "payments-prod", "payments-staging"}, ids(ac.FilterProfiles("", nil)))
+	assert.Equal(t, []string{"data-prod", "payments-prod"}, ids(ac.FilterProfiles("PRODUCTION", nil)))
+	assert.Equal(t, []string{"payments-prod", "payments-staging"}, ids(ac.FilterProfiles("", []string{"payments"})))
+
+	// Every tag must match
+	assert.Equal(t, []string{"payments-prod"}, ids(ac.FilterProfiles("", []string{"payments", "pci"})))
+	assert.Empty(t, ac.FilterProfiles("staging", []string{"pci"}))
+}
@@ -0,0 +1,349 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxDynamoDBPeekItems is the hard cap on items returned by peek operations.
+// Scans read the whole table page by page, so keep samples small.
+const MaxDynamoDBPeekItems = 25
+
+// DynamoDBService provides DynamoDB operations
+type DynamoDBService struct {
+	clientManager *ClientManager
+}
+
+// NewDynamoDBService creates a new DynamoDB service
+func NewDynamoDBService(clientManager *ClientManager) *DynamoDBService {
+	return &DynamoDBService{
+		clientManager: clientManager,
+	}
+}
+
+// ItemSample represents a bounded sample of DynamoDB items
+type ItemSample struct {
+	TableName    string
+	Items        []map[string]interface{}
+	Count        int32
+	ScannedCount int32
+	Truncated    bool
+}
+
+// QueryTable runs a key condition query against a table and returns at most limit items.
+// expressionValues maps placeholders such as ":pk" to plain Go values.
+func (d *DynamoDBService) QueryTable(ctx context.Context, profileID string, tableName string, keyConditionExpression string, expressionValues map[string]interface{}, limit int32) (*ItemSample, error) {
+	client, err := d.clientManager.GetDynamoDBClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyConditionExpression == "" {
+		return nil, fmt.Errorf("key condition expression is required")
+	}
+
+	values, err := toAttributeValueMap(expressionValues)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String(keyConditionExpression),
+		Limit:                  aws.Int32(clampPeekLimit(limit)),
+	}
+	if len(values) > 0 {
+		input.ExpressionAttributeValues = values
+	}
+
+	result, err := client.Query(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table %s: %w", tableName, classifyAWSError(err, "dynamodb:Query", "table "+tableName))
+	}
+
+	return &ItemSample{
+		TableName:    tableName,
+		Items:        fromAttributeValueItems(result.Items),
+		Count:        result.Count,
+		ScannedCount: result.ScannedCount,
+		Truncated:    len(result.LastEvaluatedKey) > 0,
+	}, nil
+}
+
+// ScanTablePeek returns a small sample of items from a table using a single bounded scan
+func (d *DynamoDBService) ScanTablePeek(ctx context.Context, profileID string, tableName string, limit int32) (*ItemSample, error) {
+	client, err := d.clientManager.GetDynamoDBClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Scan(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(tableName),
+		Limit:     aws.Int32(clampPeekLimit(limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan table %s: %w", tableName, classifyAWSError(err, "dynamodb:Scan", "table "+tableName))
+	}
+
+	return &ItemSample{
+		TableName:    tableName,
+		Items:        fromAttributeValueItems(result.Items),
+		Count:        result.Count,
+		ScannedCount: result.ScannedCount,
+		Truncated:    len(result.LastEvaluatedKey) > 0,
+	}, nil
+}
+
+// TableDescription is the read-only metadata of a DynamoDB table
+type TableDescription struct {
+	TableName string
+	ARN       string `json:"arn"`
+	Status    string
+	// BillingMode is PROVISIONED or PAY_PER_REQUEST (on-demand)
+	BillingMode        string
+	ReadCapacityUnits  int64
+	WriteCapacityUnits int64
+	KeySchema          []KeyElement
+	// ItemCount and TableSizeBytes are estimates refreshed by AWS about every six hours
+	ItemCount              int64
+	TableSizeBytes         int64
+	GlobalSecondaryIndexes []SecondaryIndex
+	LocalSecondaryIndexes  []SecondaryIndex
+	StreamEnabled          bool
+	CreationDate           string
+}
+
+// KeyElement is one attribute of a table or index key
+type KeyElement struct {
+	AttributeName string
+	KeyType       string // HASH (partition key) or RANGE (sort key)
+	AttributeType string // S, N or B
+}
+
+// SecondaryIndex describes a global or local secondary index
+type SecondaryIndex struct {
+	IndexName          string
+	Status             string
+	KeySchema          []KeyElement
+	ProjectionType     string
+	ItemCount          int64
+	SizeBytes          int64
+	ReadCapacityUnits  int64
+	WriteCapacityUnits int64
+}
+
+// ListTables lists all DynamoDB table names, following pagination
+func (d *DynamoDBService) ListTables(ctx context.Context, profileID string) ([]string, error) {
+	client, err := d.clientManager.GetDynamoDBClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]string, 0)
+	paginator := dynamodb.NewListTablesPaginator(client, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tables: %w", classifyAWSError(err, "dynamodb:ListTables", ""))
+		}
+		tables = append(tables, page.TableNames...)
+	}
+
+	return tables, nil
+}
+
+// DescribeTable returns a table's key schema, indexes, capacity mode and size estimates
+func (d *DynamoDBService) DescribeTable(ctx context.Context, profileID string, tableName string) (*TableDescription, error) {
+	client, err := d.clientManager.GetDynamoDBClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table %s: %w", tableName, classifyAWSError(err, "dynamodb:DescribeTable", "table "+tableName))
+	}
+
+	if result.Table == nil {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+
+	return newTableDescription(*result.Table), nil
+}
+
+// newTableDescription converts an SDK table description
+func newTableDescription(table types.TableDescription) *TableDescription {
+	attributeTypes := make(map[string]string, len(table.AttributeDefinitions))
+	for _, def := range table.AttributeDefinitions {
+		attributeTypes[aws.ToString(def.AttributeName)] = string(def.AttributeType)
+	}
+
+	description := &TableDescription{
+		TableName:              aws.ToString(table.TableName),
+		ARN:                    aws.ToString(table.TableArn),
+		Status:                 string(table.TableStatus),
+		BillingMode:            string(types.BillingModeProvisioned),
+		KeySchema:              keyElements(table.KeySchema, attributeTypes),
+		ItemCount:              aws.ToInt64(table.ItemCount),
+		TableSizeBytes:         aws.ToInt64(table.TableSizeBytes),
+		GlobalSecondaryIndexes: make([]SecondaryIndex, 0, len(table.GlobalSecondaryIndexes)),
+		LocalSecondaryIndexes:  make([]SecondaryIndex, 0, len(table.LocalSecondaryIndexes)),
+	}
+
+	// Tables created as provisioned before billing modes existed have no summary
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != "" {
+		description.BillingMode = string(table.BillingModeSummary.BillingMode)
+	}
+	if table.ProvisionedThroughput != nil {
+		description.ReadCapacityUnits = aws.ToInt64(table.ProvisionedThroughput.ReadCapacityUnits)
+		description.WriteCapacityUnits = aws.ToInt64(table.ProvisionedThroughput.WriteCapacityUnits)
+	}
+	if table.StreamSpecification != nil {
+		description.StreamEnabled = aws.ToBool(table.StreamSpecification.StreamEnabled)
+	}
+	if table.CreationDateTime != nil {
+		description.CreationDate = table.CreationDateTime.Format(time.RFC3339)
+	}
+
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		index := SecondaryIndex{
+			IndexName: aws.ToString(gsi.IndexName),
+			Status:    string(gsi.IndexStatus),
+			KeySchema: keyElements(gsi.KeySchema, attributeTypes),
+			ItemCount: aws.ToInt64(gsi.ItemCount),
+			SizeBytes: aws.ToInt64(gsi.IndexSizeBytes),
+		}
+		if gsi.Projection != nil {
+			index.ProjectionType = string(gsi.Projection.ProjectionType)
+		}
+		if gsi.ProvisionedThroughput != nil {
+			index.ReadCapacityUnits = aws.ToInt64(gsi.ProvisionedThroughput.ReadCapacityUnits)
+			index.WriteCapacityUnits = aws.ToInt64(gsi.ProvisionedThroughput.WriteCapacityUnits)
+		}
+		description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, index)
+	}
+
+	for _, lsi := range table.LocalSecondaryIndexes {
+		index := SecondaryIndex{
+			IndexName: aws.ToString(lsi.IndexName),
+			KeySchema: keyElements(lsi.KeySchema, attributeTypes),
+			ItemCount: aws.ToInt64(lsi.ItemCount),
+			SizeBytes: aws.ToInt64(lsi.IndexSizeBytes),
+		}
+		if lsi.Projection != nil {
+			index.ProjectionType = string(lsi.Projection.ProjectionType)
+		}
+		description.LocalSecondaryIndexes = append(description.LocalSecondaryIndexes, index)
+	}
+
+	return description
+}
+
+// keyElements converts a key schema, attaching each attribute's type
+func keyElements(schema []types.KeySchemaElement, attributeTypes map[string]string) []KeyElement {
+	elements := make([]KeyElement, 0, len(schema))
+	for _, element := range schema {
+		name := aws.ToString(element.AttributeName)
+		elements = append(elements, KeyElement{
+			AttributeName: name,
+			KeyType:       string(element.KeyType),
+			AttributeType: attributeTypes[name],
+		})
+	}
+	return elements
+}
+
+// clampPeekLimit keeps peek limits within (0, MaxDynamoDBPeekItems]
+func clampPeekLimit(limit int32) int32 {
+	if limit <= 0 || limit > MaxDynamoDBPeekItems {
+		return MaxDynamoDBPeekItems
+	}
+	return limit
+}
+
+// toAttributeValueMap converts plain Go values into DynamoDB attribute values
+func toAttributeValueMap(values map[string]interface{}) (map[string]types.AttributeValue, error) {
+	result := make(map[string]types.AttributeValue, len(values))
+	for key, value := range values {
+		av, err := toAttributeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		result[key] = av
+	}
+	return result, nil
+}
+
+func toAttributeValue(value interface{}) (types.AttributeValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	case string:
+		return &types.AttributeValueMemberS{Value: v}, nil
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: v}, nil
+	case float64:
+		return &types.AttributeValueMemberN{Value: fmt.Sprintf("%v", v)}, nil
+	case int:
+		return &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", v)}, nil
+	case int64:
+		return &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", v)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// fromAttributeValueItems converts DynamoDB items into plain maps
+func fromAttributeValueItems(items []map[string]types.AttributeValue) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, fromAttributeValueMap(item))
+	}
+	return result
+}
+
+func fromAttributeValueMap(item map[string]types.AttributeValue) map[string]interface{} {
+	result := make(map[string]interface{}, len(item))
+	for key, value := range item {
+		result[key] = fromAttributeValue(value)
+	}
+	return result
+}
+
+func fromAttributeValue(value types.AttributeValue) interface{} {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberB:
+		return fmt.Sprintf("<binary %d bytes>", len(v.Value))
+	case *types.AttributeValueMemberSS:
+		return v.Value
+	case *types.AttributeValueMemberNS:
+		return v.Value
+	case *types.AttributeValueMemberBS:
+		return fmt.Sprintf("<binary set of %d>", len(v.Value))
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, 0, len(v.Value))
+		for _, elem := range v.Value {
+			list = append(list, fromAttributeValue(elem))
+		}
+		return list
+	case *types.AttributeValueMemberM:
+		return fromAttributeValueMap(v.Value)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTableDescriptionOnDemandWithGSI(t *testing.T) {
+	description := newTableDescription(types.TableDescription{
+		TableName:   aws.String("orders"),
+		TableStatus: types.TableStatusActive,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("pk"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("sk"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("pk"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("sk"), KeyType: types.KeyTypeRange},
+		},
+		BillingModeSummary: &types.BillingModeSummary{BillingMode: types.BillingModePayPerRequest},
+		ItemCount:          aws.Int64(1200),
+		TableSizeBytes:     aws.Int64(524288),
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{
+			{
+				IndexName:   aws.String("by-status"),
+				IndexStatus: types.IndexStatusActive,
+				KeySchema:   []types.KeySchemaElement{{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash}},
+				Projection:  &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly},
+			},
+		},
+	})
+
+	assert.Equal(t, "PAY_PER_REQUEST", description.BillingMode)
+	assert.Equal(t, int64(1200), description.ItemCount)
+	assert.Equal(t, int64(524288), description.TableSizeBytes)
+	assert.Equal(t, []KeyElement{
+		{AttributeName: "pk", KeyType: "HASH", AttributeType: "S"},
+		{AttributeName: "sk", KeyType: "RANGE", AttributeType: "S"},
+	}, description.KeySchema)
+	assert.Len(t, description.GlobalSecondaryIndexes, 1)
+	assert.Equal(t, "KEYS_ONLY", description.GlobalSecondaryIndexes[0].ProjectionType)
+}
+
+func TestNewTableDescriptionDefaultsToProvisioned(t *testing.T) {
+	description := newTableDescription(types.TableDescription{
+		TableName: aws.String("legacy"),
+		ProvisionedThroughput: &types.ProvisionedThroughputDescription{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(2),
+		},
+	})
+
+	assert.Equal(t, "PROVISIONED", description.BillingMode)
+	assert.Equal(t, int64(5), description.ReadCapacityUnits)
+	assert.Equal(t, int64(2), description.WriteCapacityUnits)
+}
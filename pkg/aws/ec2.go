@@ -3,6 +3,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -24,6 +26,7 @@ func NewEC2Service(clientManager *ClientManager) *EC2Service {
 // Instance represents an EC2 instance
 type Instance struct {
 	InstanceID       string
+	ARN              string `json:"arn"`
 	InstanceType     string
 	State            string
 	PrivateIP        string
@@ -45,14 +48,16 @@ func (e *EC2Service) ListInstances(ctx context.Context, profileID string) ([]Ins
 
 	result, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list instances: %w", err)
+		return nil, fmt.Errorf("failed to list instances: %w", classifyAWSError(err, "ec2:DescribeInstances", ""))
 	}
 
+	region := client.Options().Region
 	instances := make([]Instance, 0)
 	for _, reservation := range result.Reservations {
 		for _, inst := range reservation.Instances {
 			instance := Instance{
 				InstanceID:       aws.ToString(inst.InstanceId),
+				ARN:              buildARN("ec2", region, aws.ToString(reservation.OwnerId), "instance/"+aws.ToString(inst.InstanceId)),
 				InstanceType:     string(inst.InstanceType),
 				State:            string(inst.State.Name),
 				PrivateIP:        aws.ToString(inst.PrivateIpAddress),
@@ -98,16 +103,18 @@ func (e *EC2Service) DescribeInstance(ctx context.Context, profileID string, ins
 		InstanceIds: []string{instanceID},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe instance: %w", err)
+		return nil, fmt.Errorf("failed to describe instance: %w", classifyAWSError(err, "ec2:DescribeInstances", "instance "+instanceID))
 	}
 
 	if len(result.Reservations) == 0 || len(result.Reservations[0].Instances) == 0 {
 		return nil, fmt.Errorf("instance %s not found", instanceID)
 	}
 
-	inst := result.Reservations[0].Instances[0]
+	reservation := result.Reservations[0]
+	inst := reservation.Instances[0]
 	instance := &Instance{
 		InstanceID:       aws.ToString(inst.InstanceId),
+		ARN:              buildARN("ec2", client.Options().Region, aws.ToString(reservation.OwnerId), "instance/"+aws.ToString(inst.InstanceId)),
 		InstanceType:     string(inst.InstanceType),
 		State:            string(inst.State.Name),
 		PrivateIP:        aws.ToString(inst.PrivateIpAddress),
@@ -145,7 +152,7 @@ func (e *EC2Service) ListVPCs(ctx context.Context, profileID string) ([]map[stri
 
 	result, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list VPCs: %w", err)
+		return nil, fmt.Errorf("failed to list VPCs: %w", classifyAWSError(err, "ec2:DescribeVpcs", ""))
 	}
 
 	vpcs := make([]map[string]interface{}, 0, len(result.Vpcs))
@@ -188,7 +195,7 @@ func (e *EC2Service) ListSecurityGroups(ctx context.Context, profileID string, v
 
 	result, err := client.DescribeSecurityGroups(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list security groups: %w", err)
+		return nil, fmt.Errorf("failed to list security groups: %w", classifyAWSError(err, "ec2:DescribeSecurityGroups", ""))
 	}
 
 	securityGroups := make([]map[string]interface{}, 0, len(result.SecurityGroups))
@@ -212,3 +219,311 @@ func (e *EC2Service) ListSecurityGroups(ctx context.Context, profileID string, v
 	return securityGroups, nil
 }
 
+// sensitivePorts are administrative and database ports that should not be open to the internet
+var sensitivePorts = map[int32]string{
+	22:    "SSH",
+	3389:  "RDP",
+	1433:  "SQL Server",
+	3306:  "MySQL",
+	5432:  "PostgreSQL",
+	6379:  "Redis",
+	9200:  "Elasticsearch",
+	27017: "MongoDB",
+}
+
+// SecurityGroupRule is a single normalized security group rule. Exactly one of
+// CIDR, SecurityGroupID and PrefixListID is set.
+type SecurityGroupRule struct {
+	Direction       string `json:"direction"`
+	Protocol        string `json:"protocol"`
+	FromPort        *int32 `json:"from_port,omitempty"`
+	ToPort          *int32 `json:"to_port,omitempty"`
+	CIDR            string `json:"cidr,omitempty"`
+	SecurityGroupID string `json:"security_group_id,omitempty"`
+	PrefixListID    string `json:"prefix_list_id,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Warning         string `json:"warning,omitempty"`
+}
+
+// SecurityGroupRules is the full rule set of a security group
+type SecurityGroupRules struct {
+	GroupID   string              `json:"group_id"`
+	ARN       string              `json:"arn"`
+	GroupName string              `json:"group_name"`
+	VpcID     string              `json:"vpc_id"`
+	Ingress   []SecurityGroupRule `json:"ingress"`
+	Egress    []SecurityGroupRule `json:"egress"`
+	Warnings  int                 `json:"warnings"`
+}
+
+// DescribeSecurityGroupRules returns the ingress and egress rules of security groups,
+// one rule per protocol, port range and source or destination. Without group IDs it
+// returns every group, optionally limited to a VPC.
+func (e *EC2Service) DescribeSecurityGroupRules(ctx context.Context, profileID string, groupIDs []string, vpcID string) ([]SecurityGroupRules, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &ec2.DescribeSecurityGroupsInput{GroupIds: groupIDs}
+	if vpcID != "" {
+		input.Filters = []types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		}
+	}
+
+	groups := make([]SecurityGroupRules, 0)
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security groups: %w", classifyAWSError(err, "ec2:DescribeSecurityGroups", "security groups "+strings.Join(groupIDs, ",")))
+		}
+
+		for _, sg := range page.SecurityGroups {
+			group := SecurityGroupRules{
+				GroupID:   aws.ToString(sg.GroupId),
+				ARN:       securityGroupARN(client.Options().Region, sg),
+				GroupName: aws.ToString(sg.GroupName),
+				VpcID:     aws.ToString(sg.VpcId),
+				Ingress:   normalizePermissions("ingress", sg.IpPermissions),
+				Egress:    normalizePermissions("egress", sg.IpPermissionsEgress),
+			}
+			for _, rule := range group.Ingress {
+				if rule.Warning != "" {
+					group.Warnings++
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+// securityGroupARN returns the ARN of a security group, built from its owner when the
+// API does not return one
+func securityGroupARN(region string, sg types.SecurityGroup) string {
+	if arn := aws.ToString(sg.SecurityGroupArn); arn != "" {
+		return normalizeARN(arn)
+	}
+	return buildARN("ec2", region, aws.ToString(sg.OwnerId), "security-group/"+aws.ToString(sg.GroupId))
+}
+
+// normalizePermissions flattens IP permissions into one rule per source or destination
+func normalizePermissions(direction string, permissions []types.IpPermission) []SecurityGroupRule {
+	rules := make([]SecurityGroupRule, 0, len(permissions))
+	for _, perm := range permissions {
+		base := SecurityGroupRule{
+			Direction: direction,
+			Protocol:  normalizeProtocol(aws.ToString(perm.IpProtocol)),
+		}
+		// Port ranges only apply to TCP and UDP; -1 or nil means all ports
+		if base.Protocol == "tcp" || base.Protocol == "udp" {
+			if perm.FromPort != nil && *perm.FromPort >= 0 {
+				base.FromPort = perm.FromPort
+				base.ToPort = perm.ToPort
+			}
+		}
+
+		for _, r := range perm.IpRanges {
+			rule := base
+			rule.CIDR = aws.ToString(r.CidrIp)
+			rule.Description = aws.ToString(r.Description)
+			rule.Warning = permissiveRuleWarning(rule)
+			rules = append(rules, rule)
+		}
+		for _, r := range perm.Ipv6Ranges {
+			rule := base
+			rule.CIDR = aws.ToString(r.CidrIpv6)
+			rule.Description = aws.ToString(r.Description)
+			rule.Warning = permissiveRuleWarning(rule)
+			rules = append(rules, rule)
+		}
+		for _, pair := range perm.UserIdGroupPairs {
+			rule := base
+			rule.SecurityGroupID = aws.ToString(pair.GroupId)
+			rule.Description = aws.ToString(pair.Description)
+			rules = append(rules, rule)
+		}
+		for _, pl := range perm.PrefixListIds {
+			rule := base
+			rule.PrefixListID = aws.ToString(pl.PrefixListId)
+			rule.Description = aws.ToString(pl.Description)
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// normalizeProtocol converts protocol numbers used by the API to names
+func normalizeProtocol(protocol string) string {
+	switch protocol {
+	case "-1", "":
+		return "all"
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	case "58":
+		return "icmpv6"
+	}
+	return strings.ToLower(protocol)
+}
+
+// permissiveRuleWarning flags ingress rules open to the whole internet on a sensitive
+// port, or on all ports
+func permissiveRuleWarning(rule SecurityGroupRule) string {
+	if rule.Direction != "ingress" || (rule.CIDR != "0.0.0.0/0" && rule.CIDR != "::/0") {
+		return ""
+	}
+
+	if rule.Protocol == "all" || ((rule.Protocol == "tcp" || rule.Protocol == "udp") && rule.FromPort == nil) {
+		return fmt.Sprintf("all ports are open to %s", rule.CIDR)
+	}
+	if rule.Protocol != "tcp" && rule.Protocol != "udp" {
+		return ""
+	}
+
+	ports := make([]int32, 0)
+	for port := range sensitivePorts {
+		if port >= *rule.FromPort && port <= aws.ToInt32(rule.ToPort) {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) == 0 {
+		return ""
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	exposed := make([]string, 0, len(ports))
+	for _, port := range ports {
+		exposed = append(exposed, fmt.Sprintf("%d (%s)", port, sensitivePorts[port]))
+	}
+	return fmt.Sprintf("sensitive ports %s open to %s", strings.Join(exposed, ", "), rule.CIDR)
+}
+
+// InstanceStateChange is the outcome of a state-change request for one instance
+type InstanceStateChange struct {
+	InstanceID    string
+	PreviousState string
+	CurrentState  string
+	Error         string `json:"Error,omitempty"`
+}
+
+// StartInstances starts the given instances, reporting the result per instance
+func (e *EC2Service) StartInstances(ctx context.Context, profileID string, instanceIDs []string) ([]InstanceStateChange, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeInstanceStates(instanceIDs, func(instanceID string) (*types.InstanceStateChange, error) {
+		result, err := client.StartInstances(ctx, &ec2.StartInstancesInput{
+			InstanceIds: []string{instanceID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to start instance: %w", classifyAWSError(err, "ec2:StartInstances", "instance "+instanceID))
+		}
+		return firstStateChange(result.StartingInstances), nil
+	})
+}
+
+// StopInstances stops the given instances, reporting the result per instance.
+// force skips the guest OS shutdown, like the console's "Force stop".
+func (e *EC2Service) StopInstances(ctx context.Context, profileID string, instanceIDs []string, force bool) ([]InstanceStateChange, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeInstanceStates(instanceIDs, func(instanceID string) (*types.InstanceStateChange, error) {
+		result, err := client.StopInstances(ctx, &ec2.StopInstancesInput{
+			InstanceIds: []string{instanceID},
+			Force:       aws.Bool(force),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to stop instance: %w", classifyAWSError(err, "ec2:StopInstances", "instance "+instanceID))
+		}
+		return firstStateChange(result.StoppingInstances), nil
+	})
+}
+
+// RebootInstances reboots the given instances, reporting the result per instance.
+// A reboot does not change the instance state, so both states are the state
+// observed before the reboot request.
+func (e *EC2Service) RebootInstances(ctx context.Context, profileID string, instanceIDs []string) ([]InstanceStateChange, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeInstanceStates(instanceIDs, func(instanceID string) (*types.InstanceStateChange, error) {
+		described, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{instanceID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instance: %w", classifyAWSError(err, "ec2:DescribeInstances", "instance "+instanceID))
+		}
+		if len(described.Reservations) == 0 || len(described.Reservations[0].Instances) == 0 {
+			return nil, fmt.Errorf("instance %s not found", instanceID)
+		}
+		state := described.Reservations[0].Instances[0].State
+
+		if _, err := client.RebootInstances(ctx, &ec2.RebootInstancesInput{
+			InstanceIds: []string{instanceID},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to reboot instance: %w", classifyAWSError(err, "ec2:RebootInstances", "instance "+instanceID))
+		}
+		return &types.InstanceStateChange{
+			InstanceId:    aws.String(instanceID),
+			PreviousState: state,
+			CurrentState:  state,
+		}, nil
+	})
+}
+
+// changeInstanceStates applies change to each instance separately so one
+// invalid ID does not fail the whole batch; failures are reported per instance
+func changeInstanceStates(instanceIDs []string, change func(instanceID string) (*types.InstanceStateChange, error)) ([]InstanceStateChange, error) {
+	if len(instanceIDs) == 0 {
+		return nil, fmt.Errorf("at least one instance ID is required")
+	}
+
+	results := make([]InstanceStateChange, 0, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		result := InstanceStateChange{InstanceID: instanceID}
+
+		stateChange, err := change(instanceID)
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+		case stateChange == nil:
+			result.Error = "no state change returned for instance"
+		default:
+			if stateChange.PreviousState != nil {
+				result.PreviousState = string(stateChange.PreviousState.Name)
+			}
+			if stateChange.CurrentState != nil {
+				result.CurrentState = string(stateChange.CurrentState.Name)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// firstStateChange returns the first state change of an API response, if any
+func firstStateChange(changes []types.InstanceStateChange) *types.InstanceStateChange {
+	if len(changes) == 0 {
+		return nil
+	}
+	return &changes[0]
+}
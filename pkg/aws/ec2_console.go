@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// noConsoleOutput explains an empty console output, which is normal shortly after launch
+const noConsoleOutput = "no console output is available yet; output appears a few minutes after the instance boots"
+
+// ConsoleOutput is the serial console output of an EC2 instance
+type ConsoleOutput struct {
+	InstanceID string `json:"instance_id"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	Latest     bool   `json:"latest"`
+	Output     string `json:"output"`
+	Note       string `json:"note,omitempty"`
+}
+
+// GetConsoleOutput returns the serial console output of an instance as text, useful to
+// see why an instance fails to boot. latest asks for the most recent output instead of
+// the buffer captured at the last boot, which only Nitro instances support. An instance
+// without output yet returns an empty result with a note rather than an error.
+func (e *EC2Service) GetConsoleOutput(ctx context.Context, profileID string, instanceID string, latest bool) (*ConsoleOutput, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(instanceID),
+	}
+	if latest {
+		input.Latest = aws.Bool(true)
+	}
+
+	result, err := client.GetConsoleOutput(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get console output: %w", classifyAWSError(err, "ec2:GetConsoleOutput", "instance "+instanceID))
+	}
+
+	output, err := decodeConsoleOutput(aws.ToString(result.Output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode console output of instance %s: %w", instanceID, err)
+	}
+
+	console := &ConsoleOutput{
+		InstanceID: instanceID,
+		Latest:     latest,
+		Output:     output,
+	}
+	if result.Timestamp != nil {
+		console.Timestamp = result.Timestamp.Format(time.RFC3339)
+	}
+	if output == "" {
+		console.Note = noConsoleOutput
+	}
+	return console, nil
+}
+
+// decodeConsoleOutput decodes the base64 console output returned by the API into text
+// with Unix line endings
+func decodeConsoleOutput(encoded string) (string, error) {
+	if encoded == "" {
+		return "", nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(string(decoded), "\r\n", "\n"), nil
+}
@@ -0,0 +1,23 @@
+package aws
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeConsoleOutput(t *testing.T) {
+	boot := "[    0.000000] Linux version 6.1.0\r\n[    2.314159] EXT4-fs error (device nvme0n1p1): unable to read superblock\r\nKernel panic - not syncing: VFS: Unable to mount root fs\r\n"
+	output, err := decodeConsoleOutput(base64.StdEncoding.EncodeToString([]byte(boot)))
+	assert.NoError(t, err)
+	assert.Equal(t, "[    0.000000] Linux version 6.1.0\n[    2.314159] EXT4-fs error (device nvme0n1p1): unable to read superblock\nKernel panic - not syncing: VFS: Unable to mount root fs\n", output)
+
+	// No output yet
+	output, err = decodeConsoleOutput("")
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+
+	_, err = decodeConsoleOutput("not base64!")
+	assert.Error(t, err)
+}
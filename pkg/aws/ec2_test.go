@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeInstanceStatesReportsPartialFailures(t *testing.T) {
+	results, err := changeInstanceStates([]string{"i-0abc", "i-missing"}, func(instanceID string) (*types.InstanceStateChange, error) {
+		if instanceID == "i-missing" {
+			return nil, errors.New("failed to stop instance: InvalidInstanceID.NotFound")
+		}
+		return &types.InstanceStateChange{
+			InstanceId:    aws.String(instanceID),
+			PreviousState: &types.InstanceState{Name: types.InstanceStateNameRunning},
+			CurrentState:  &types.InstanceState{Name: types.InstanceStateNameStopping},
+		}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []InstanceStateChange{
+		{InstanceID: "i-0abc", PreviousState: "running", CurrentState: "stopping"},
+		{InstanceID: "i-missing", Error: "failed to stop instance: InvalidInstanceID.NotFound"},
+	}, results)
+}
+
+func TestChangeInstanceStatesRequiresInstanceIDs(t *testing.T) {
+	_, err := changeInstanceStates(nil, func(string) (*types.InstanceStateChange, error) {
+		t.Fatal("change should not be called without instance IDs")
+		return nil, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestNormalizePermissions(t *testing.T) {
+	rules := normalizePermissions("ingress", []types.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int32(22),
+			ToPort:     aws.Int32(22),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0"), Description: aws.String("ssh")}},
+			Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+		},
+		{
+			IpProtocol:       aws.String("tcp"),
+			FromPort:         aws.Int32(443),
+			ToPort:           aws.Int32(443),
+			IpRanges:         []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String("sg-0lb")}},
+		},
+		{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+		},
+	})
+
+	assert.Len(t, rules, 5)
+	assert.Equal(t, "0.0.0.0/0", rules[0].CIDR)
+	assert.Equal(t, "ssh", rules[0].Description)
+	assert.Equal(t, "sensitive ports 22 (SSH) open to 0.0.0.0/0", rules[0].Warning)
+	assert.Equal(t, "sensitive ports 22 (SSH) open to ::/0", rules[1].Warning)
+	assert.Empty(t, rules[2].Warning)
+	assert.Equal(t, "sg-0lb", rules[3].SecurityGroupID)
+	assert.Equal(t, "all", rules[4].Protocol)
+	assert.Empty(t, rules[4].Warning)
+}
+
+func TestPermissiveRuleWarning(t *testing.T) {
+	assert.Equal(t, "sensitive ports 3306 (MySQL), 3389 (RDP) open to 0.0.0.0/0", permissiveRuleWarning(SecurityGroupRule{
+		Direction: "ingress", Protocol: "tcp", FromPort: aws.Int32(3000), ToPort: aws.Int32(4000), CIDR: "0.0.0.0/0",
+	}))
+	assert.Equal(t, "all ports are open to 0.0.0.0/0", permissiveRuleWarning(SecurityGroupRule{
+		Direction: "ingress", Protocol: "all", CIDR: "0.0.0.0/0",
+	}))
+	assert.Empty(t, permissiveRuleWarning(SecurityGroupRule{
+		Direction: "egress", Protocol: "all", CIDR: "0.0.0.0/0",
+	}))
+	assert.Empty(t, permissiveRuleWarning(SecurityGroupRule{
+		Direction: "ingress", Protocol: "icmp", CIDR: "0.0.0.0/0",
+	}))
+}
+
+func TestNewVolume(t *testing.T) {
+	attached := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	volume := newVolume(types.Volume{
+		VolumeId:         aws.String("vol-0abc"),
+		Size:             aws.Int32(100),
+		VolumeType:       types.VolumeTypeGp3,
+		Iops:             aws.Int32(3000),
+		Throughput:       aws.Int32(125),
+		Encrypted:        aws.Bool(true),
+		State:            types.VolumeStateInUse,
+		AvailabilityZone: aws.String("us-east-1a"),
+		Attachments: []types.VolumeAttachment{{
+			InstanceId:          aws.String("i-0abc"),
+			Device:              aws.String("/dev/xvda"),
+			State:               types.VolumeAttachmentStateAttached,
+			AttachTime:          aws.Time(attached),
+			DeleteOnTermination: aws.Bool(true),
+		}},
+		Tags: []types.Tag{{Key: aws.String("Name"), Value: aws.String("api-root")}},
+	})
+
+	assert.Equal(t, Volume{
+		VolumeID:         "vol-0abc",
+		SizeGiB:          100,
+		VolumeType:       "gp3",
+		IOPS:             3000,
+		Throughput:       125,
+		Encrypted:        true,
+		State:            "in-use",
+		AvailabilityZone: "us-east-1a",
+		Attachments: []VolumeAttachment{
+			{InstanceID: "i-0abc", Device: "/dev/xvda", State: "attached", AttachTime: "2025-03-01T08:00:00Z", DeleteOnTermination: true},
+		},
+		Tags: map[string]string{"Name": "api-root"},
+	}, volume)
+
+	// Unattached volumes have an empty attachment list, not null
+	assert.Empty(t, newVolume(types.Volume{VolumeId: aws.String("vol-0def"), State: types.VolumeStateAvailable}).Attachments)
+}
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot := newSnapshot(types.Snapshot{
+		SnapshotId: aws.String("snap-0abc"),
+		VolumeId:   aws.String("vol-0abc"),
+		VolumeSize: aws.Int32(100),
+		State:      types.SnapshotStatePending,
+		Progress:   aws.String("42%"),
+		StartTime:  aws.Time(time.Date(2025, 3, 2, 1, 30, 0, 0, time.UTC)),
+		OwnerId:    aws.String("123456789012"),
+	})
+
+	assert.Equal(t, Snapshot{
+		SnapshotID:    "snap-0abc",
+		VolumeID:      "vol-0abc",
+		VolumeSizeGiB: 100,
+		State:         "pending",
+		Progress:      "42%",
+		StartTime:     "2025-03-02T01:30:00Z",
+		OwnerID:       "123456789012",
+	}, snapshot)
+}
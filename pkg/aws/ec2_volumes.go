@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// VolumeAttachment is the attachment of an EBS volume to an instance
+type VolumeAttachment struct {
+	InstanceID          string `json:"instance_id"`
+	Device              string `json:"device"`
+	State               string `json:"state"`
+	AttachTime          string `json:"attach_time,omitempty"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+}
+
+// Volume represents an EBS volume
+type Volume struct {
+	VolumeID         string             `json:"volume_id"`
+	SizeGiB          int32              `json:"size_gib"`
+	VolumeType       string             `json:"volume_type"`
+	IOPS             int32              `json:"iops,omitempty"`
+	Throughput       int32              `json:"throughput_mibps,omitempty"`
+	Encrypted        bool               `json:"encrypted"`
+	State            string             `json:"state"`
+	AvailabilityZone string             `json:"availability_zone"`
+	SnapshotID       string             `json:"snapshot_id,omitempty"`
+	CreateTime       string             `json:"create_time,omitempty"`
+	Attachments      []VolumeAttachment `json:"attachments"`
+	Tags             map[string]string  `json:"tags,omitempty"`
+}
+
+// Snapshot represents an EBS snapshot
+type Snapshot struct {
+	SnapshotID    string            `json:"snapshot_id"`
+	VolumeID      string            `json:"volume_id"`
+	VolumeSizeGiB int32             `json:"volume_size_gib"`
+	State         string            `json:"state"`
+	Progress      string            `json:"progress"`
+	StartTime     string            `json:"start_time,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Encrypted     bool              `json:"encrypted"`
+	OwnerID       string            `json:"owner_id"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// ListVolumes lists EBS volumes, only those attached to instanceID when it is set
+func (e *EC2Service) ListVolumes(ctx context.Context, profileID string, instanceID string) ([]Volume, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &ec2.DescribeVolumesInput{}
+	if instanceID != "" {
+		input.Filters = []types.Filter{
+			{Name: aws.String("attachment.instance-id"), Values: []string{instanceID}},
+		}
+	}
+
+	volumes := make([]Volume, 0)
+	paginator := ec2.NewDescribeVolumesPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list volumes: %w", classifyAWSError(err, "ec2:DescribeVolumes", ""))
+		}
+		for _, v := range page.Volumes {
+			volumes = append(volumes, newVolume(v))
+		}
+	}
+
+	return volumes, nil
+}
+
+// ListSnapshots lists EBS snapshots. With ownerSelf only snapshots owned by the account
+// are listed; otherwise every snapshot the account can restore, including ones shared
+// with it. Public snapshots, which number in the hundreds of thousands, are never listed.
+func (e *EC2Service) ListSnapshots(ctx context.Context, profileID string, ownerSelf bool) ([]Snapshot, error) {
+	client, err := e.clientManager.GetEC2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &ec2.DescribeSnapshotsInput{}
+	if ownerSelf {
+		input.OwnerIds = []string{"self"}
+	} else {
+		input.RestorableByUserIds = []string{"self"}
+	}
+
+	snapshots := make([]Snapshot, 0)
+	paginator := ec2.NewDescribeSnapshotsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", classifyAWSError(err, "ec2:DescribeSnapshots", ""))
+		}
+		for _, s := range page.Snapshots {
+			snapshots = append(snapshots, newSnapshot(s))
+		}
+	}
+
+	return snapshots, nil
+}
+
+// newVolume converts an EBS volume returned by the API
+func newVolume(v types.Volume) Volume {
+	volume := Volume{
+		VolumeID:         aws.ToString(v.VolumeId),
+		SizeGiB:          aws.ToInt32(v.Size),
+		VolumeType:       string(v.VolumeType),
+		IOPS:             aws.ToInt32(v.Iops),
+		Throughput:       aws.ToInt32(v.Throughput),
+		Encrypted:        aws.ToBool(v.Encrypted),
+		State:            string(v.State),
+		AvailabilityZone: aws.ToString(v.AvailabilityZone),
+		SnapshotID:       aws.ToString(v.SnapshotId),
+		Attachments:      make([]VolumeAttachment, 0, len(v.Attachments)),
+		Tags:             ec2Tags(v.Tags),
+	}
+	if v.CreateTime != nil {
+		volume.CreateTime = v.CreateTime.Format(time.RFC3339)
+	}
+
+	for _, a := range v.Attachments {
+		attachment := VolumeAttachment{
+			InstanceID:          aws.ToString(a.InstanceId),
+			Device:              aws.ToString(a.Device),
+			State:               string(a.State),
+			DeleteOnTermination: aws.ToBool(a.DeleteOnTermination),
+		}
+		if a.AttachTime != nil {
+			attachment.AttachTime = a.AttachTime.Format(time.RFC3339)
+		}
+		volume.Attachments = append(volume.Attachments, attachment)
+	}
+
+	return volume
+}
+
+// newSnapshot converts an EBS snapshot returned by the API
+func newSnapshot(s types.Snapshot) Snapshot {
+	snapshot := Snapshot{
+		SnapshotID:    aws.ToString(s.SnapshotId),
+		VolumeID:      aws.ToString(s.VolumeId),
+		VolumeSizeGiB: aws.ToInt32(s.VolumeSize),
+		State:         string(s.State),
+		Progress:      aws.ToString(s.Progress),
+		Description:   aws.ToString(s.Description),
+		Encrypted:     aws.ToBool(s.Encrypted),
+		OwnerID:       aws.ToString(s.OwnerId),
+		Tags:          ec2Tags(s.Tags),
+	}
+	if s.StartTime != nil {
+		snapshot.StartTime = s.StartTime.Format(time.RFC3339)
+	}
+	return snapshot
+}
+
+// ec2Tags converts EC2 tags to a map, nil when there are none
+func ec2Tags(tags []types.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return result
+}
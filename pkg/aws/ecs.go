@@ -2,10 +2,12 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 // ECSService provides ECS operations
@@ -22,7 +24,7 @@ func NewECSService(clientManager *ClientManager) *ECSService {
 
 // Cluster represents an ECS cluster
 type Cluster struct {
-	ARN                          string
+	ARN                          string `json:"arn"`
 	Name                         string
 	Status                       string
 	RegisteredContainerInstances int32
@@ -33,7 +35,7 @@ type Cluster struct {
 
 // Service represents an ECS service
 type Service struct {
-	ARN            string
+	ARN            string `json:"arn"`
 	Name           string
 	Status         string
 	DesiredCount   int32
@@ -42,11 +44,13 @@ type Service struct {
 	LaunchType     string
 	TaskDefinition string
 	ClusterARN     string
+	Deployments    []DeploymentRecord `json:",omitempty"`
+	Events         []ServiceEvent     `json:",omitempty"`
 }
 
 // Task represents an ECS task
 type Task struct {
-	ARN               string
+	ARN               string `json:"arn"`
 	ClusterARN        string
 	TaskDefinitionARN string
 	LastStatus        string
@@ -60,7 +64,7 @@ type Task struct {
 // Container represents a container in an ECS task
 type Container struct {
 	Name       string
-	ARN        string
+	ARN        string `json:"arn"`
 	LastStatus string
 	RuntimeID  string
 	ExitCode   *int32
@@ -75,7 +79,7 @@ func (e *ECSService) ListClusters(ctx context.Context, profileID string) ([]stri
 
 	result, err := client.ListClusters(ctx, &ecs.ListClustersInput{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list clusters: %w", err)
+		return nil, fmt.Errorf("failed to list clusters: %w", classifyAWSError(err, "ecs:ListClusters", ""))
 	}
 
 	return result.ClusterArns, nil
@@ -92,7 +96,7 @@ func (e *ECSService) DescribeCluster(ctx context.Context, profileID string, clus
 		Clusters: []string{clusterName},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe cluster: %w", err)
+		return nil, fmt.Errorf("failed to describe cluster: %w", classifyAWSError(err, "ecs:DescribeClusters", "cluster "+clusterName))
 	}
 
 	if len(result.Clusters) == 0 {
@@ -113,21 +117,61 @@ func (e *ECSService) DescribeCluster(ctx context.Context, profileID string, clus
 	return cluster, nil
 }
 
-// ListServices lists services in a cluster
+// ListServices lists services in a cluster, following pagination
 func (e *ECSService) ListServices(ctx context.Context, profileID string, clusterName string) ([]string, error) {
 	client, err := e.clientManager.GetECSClient(profileID)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := client.ListServices(ctx, &ecs.ListServicesInput{
-		Cluster: aws.String(clusterName),
+	serviceARNs := make([]string, 0)
+	paginator := ecs.NewListServicesPaginator(client, &ecs.ListServicesInput{
+		Cluster:    aws.String(clusterName),
+		MaxResults: aws.Int32(100),
 	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", classifyAWSError(err, "ecs:ListServices", "cluster "+clusterName))
+		}
+		serviceARNs = append(serviceARNs, page.ServiceArns...)
+	}
+
+	return serviceARNs, nil
+}
+
+// describeServicesBatch is the most services ecs:DescribeServices accepts per call
+const describeServicesBatch = 10
+
+// DescribeServices gets detailed information about several services of a cluster,
+// batching the calls as ecs:DescribeServices requires. Services that no longer exist
+// are left out.
+func (e *ECSService) DescribeServices(ctx context.Context, profileID string, clusterName string, serviceNames []string) ([]Service, error) {
+	client, err := e.clientManager.GetECSClient(profileID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list services: %w", err)
+		return nil, err
+	}
+
+	services := make([]Service, 0, len(serviceNames))
+	for start := 0; start < len(serviceNames); start += describeServicesBatch {
+		end := start + describeServicesBatch
+		if end > len(serviceNames) {
+			end = len(serviceNames)
+		}
+
+		result, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(clusterName),
+			Services: serviceNames[start:end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe services: %w", classifyAWSError(err, "ecs:DescribeServices", "cluster "+clusterName))
+		}
+		for _, s := range result.Services {
+			services = append(services, *newService(s))
+		}
 	}
 
-	return result.ServiceArns, nil
+	return services, nil
 }
 
 // DescribeService gets detailed information about a service
@@ -142,15 +186,59 @@ func (e *ECSService) DescribeService(ctx context.Context, profileID string, clus
 		Services: []string{serviceName},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe service: %w", err)
+		return nil, fmt.Errorf("failed to describe service: %w", classifyAWSError(err, "ecs:DescribeServices", "service "+serviceName+" in cluster "+clusterName))
 	}
 
 	if len(result.Services) == 0 {
 		return nil, fmt.Errorf("service %s not found in cluster %s", serviceName, clusterName)
 	}
 
-	s := result.Services[0]
-	service := &Service{
+	return newService(result.Services[0]), nil
+}
+
+// UpdateServiceDesiredCount sets the desired task count of a service and returns the updated service
+func (e *ECSService) UpdateServiceDesiredCount(ctx context.Context, profileID string, clusterName string, serviceName string, desiredCount int32) (*Service, error) {
+	if desiredCount < 0 {
+		return nil, fmt.Errorf("desired count must be non-negative, got %d", desiredCount)
+	}
+
+	client, err := e.clientManager.GetECSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Describe first so a missing service gets the same error as DescribeService
+	if _, err := e.DescribeService(ctx, profileID, clusterName, serviceName); err != nil {
+		return nil, err
+	}
+
+	result, err := client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:      aws.String(clusterName),
+		Service:      aws.String(serviceName),
+		DesiredCount: aws.Int32(desiredCount),
+	})
+	if err != nil {
+		var notFound *types.ServiceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("service %s not found in cluster %s", serviceName, clusterName)
+		}
+		return nil, fmt.Errorf("failed to update service: %w", classifyAWSError(err, "ecs:UpdateService", "service "+serviceName+" in cluster "+clusterName))
+	}
+
+	if result.Service == nil {
+		return nil, fmt.Errorf("service %s not found in cluster %s", serviceName, clusterName)
+	}
+
+	return newService(*result.Service), nil
+}
+
+func newService(s types.Service) *Service {
+	deployments := make([]DeploymentRecord, 0, len(s.Deployments))
+	for _, d := range s.Deployments {
+		deployments = append(deployments, newDeploymentRecord(d))
+	}
+
+	return &Service{
 		ARN:            aws.ToString(s.ServiceArn),
 		Name:           aws.ToString(s.ServiceName),
 		Status:         aws.ToString(s.Status),
@@ -160,9 +248,9 @@ func (e *ECSService) DescribeService(ctx context.Context, profileID string, clus
 		LaunchType:     string(s.LaunchType),
 		TaskDefinition: aws.ToString(s.TaskDefinition),
 		ClusterARN:     aws.ToString(s.ClusterArn),
+		Deployments:    deployments,
+		Events:         recentServiceEvents(s.Events, recentServiceEventCount),
 	}
-
-	return service, nil
 }
 
 // ListTasks lists tasks in a cluster, optionally filtered by service
@@ -181,7 +269,7 @@ func (e *ECSService) ListTasks(ctx context.Context, profileID string, clusterNam
 
 	result, err := client.ListTasks(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list tasks: %w", err)
+		return nil, fmt.Errorf("failed to list tasks: %w", classifyAWSError(err, "ecs:ListTasks", "cluster "+clusterName))
 	}
 
 	return result.TaskArns, nil
@@ -199,7 +287,7 @@ func (e *ECSService) DescribeTask(ctx context.Context, profileID string, cluster
 		Tasks:   []string{taskARN},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe task: %w", err)
+		return nil, fmt.Errorf("failed to describe task: %w", classifyAWSError(err, "ecs:DescribeTasks", "task "+taskARN))
 	}
 
 	if len(result.Tasks) == 0 {
@@ -244,12 +332,13 @@ func (e *ECSService) DescribeTaskDefinition(ctx context.Context, profileID strin
 		TaskDefinition: aws.String(taskDefinitionARN),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe task definition: %w", err)
+		return nil, fmt.Errorf("failed to describe task definition: %w", classifyAWSError(err, "ecs:DescribeTaskDefinition", "task definition "+taskDefinitionARN))
 	}
 
 	td := result.TaskDefinition
 	taskDef := map[string]interface{}{
 		"family":                  aws.ToString(td.Family),
+		"arn":                     aws.ToString(td.TaskDefinitionArn),
 		"taskDefinitionArn":       aws.ToString(td.TaskDefinitionArn),
 		"revision":                td.Revision,
 		"status":                  string(td.Status),
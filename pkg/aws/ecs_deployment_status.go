@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// recentServiceEventCount is how many service events are returned with a service
+const recentServiceEventCount = 10
+
+// stuckDeploymentAfter is how long a rollout may stay in progress before it is reported as stuck
+const stuckDeploymentAfter = 30 * time.Minute
+
+// Deployment states reported by GetServiceDeploymentStatus
+const (
+	DeploymentStateStable     = "stable"
+	DeploymentStateInProgress = "in_progress"
+	DeploymentStateStuck      = "stuck"
+	DeploymentStateFailed     = "failed"
+)
+
+// ServiceEvent is a message from an ECS service's event log
+type ServiceEvent struct {
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// DeploymentStatus summarizes the current rollout of an ECS service
+type DeploymentStatus struct {
+	Cluster      string             `json:"cluster"`
+	Service      string             `json:"service"`
+	Status       string             `json:"status"`
+	State        string             `json:"state"`
+	Summary      string             `json:"summary"`
+	DesiredCount int32              `json:"desired_count"`
+	RunningCount int32              `json:"running_count"`
+	PendingCount int32              `json:"pending_count"`
+	Primary      *DeploymentRecord  `json:"primary_deployment,omitempty"`
+	Deployments  []DeploymentRecord `json:"deployments"`
+	RecentEvents []ServiceEvent     `json:"recent_events"`
+	// TargetGroupARNs are the load balancer target groups of the service, for checking
+	// target health when tasks are reported unhealthy
+	TargetGroupARNs []string `json:"target_group_arns,omitempty"`
+}
+
+// GetServiceDeploymentStatus reports whether a service's rollout is stable, in progress,
+// stuck or failed, with every active deployment and the last 10 service events. A
+// PRIMARY deployment still IN_PROGRESS after 30 minutes is reported as stuck.
+func (e *ECSService) GetServiceDeploymentStatus(ctx context.Context, profileID string, clusterName string, serviceName string) (*DeploymentStatus, error) {
+	client, err := e.clientManager.GetECSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  aws.String(clusterName),
+		Services: []string{serviceName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe service: %w", classifyAWSError(err, "ecs:DescribeServices", "service "+serviceName+" in cluster "+clusterName))
+	}
+	if len(result.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found in cluster %s", serviceName, clusterName)
+	}
+
+	return newDeploymentStatus(clusterName, result.Services[0], time.Now()), nil
+}
+
+// newDeploymentStatus builds the deployment summary of a described service as of now
+func newDeploymentStatus(clusterName string, svc types.Service, now time.Time) *DeploymentStatus {
+	status := &DeploymentStatus{
+		Cluster:      clusterName,
+		Service:      aws.ToString(svc.ServiceName),
+		Status:       aws.ToString(svc.Status),
+		DesiredCount: svc.DesiredCount,
+		RunningCount: svc.RunningCount,
+		PendingCount: svc.PendingCount,
+		Deployments:  make([]DeploymentRecord, 0, len(svc.Deployments)),
+		RecentEvents: recentServiceEvents(svc.Events, recentServiceEventCount),
+	}
+
+	for _, lb := range svc.LoadBalancers {
+		if arn := aws.ToString(lb.TargetGroupArn); arn != "" {
+			status.TargetGroupARNs = append(status.TargetGroupARNs, arn)
+		}
+	}
+
+	var primary *types.Deployment
+	for i, d := range svc.Deployments {
+		record := newDeploymentRecord(d)
+		status.Deployments = append(status.Deployments, record)
+		if aws.ToString(d.Status) == "PRIMARY" {
+			primary = &svc.Deployments[i]
+			status.Primary = &status.Deployments[len(status.Deployments)-1]
+		}
+	}
+
+	if primary == nil {
+		status.State = DeploymentStateInProgress
+		status.Summary = "service has no PRIMARY deployment"
+		return status
+	}
+
+	revision := taskDefinitionRevision(aws.ToString(primary.TaskDefinition))
+	switch {
+	case primary.RolloutState == types.DeploymentRolloutStateFailed:
+		status.State = DeploymentStateFailed
+		status.Summary = fmt.Sprintf("deployment of %s failed: %s", revision, aws.ToString(primary.RolloutStateReason))
+	case primary.RolloutState == types.DeploymentRolloutStateInProgress ||
+		// Services without rollout state tracking are mid-rollout while old deployments remain
+		(primary.RolloutState == "" && len(svc.Deployments) > 1):
+		elapsed := time.Duration(0)
+		if primary.CreatedAt != nil {
+			elapsed = now.Sub(*primary.CreatedAt).Truncate(time.Second)
+		}
+		status.State = DeploymentStateInProgress
+		if elapsed >= stuckDeploymentAfter {
+			status.State = DeploymentStateStuck
+		}
+		status.Summary = fmt.Sprintf("deployment of %s in progress for %s: %d/%d tasks running, %d pending, %d failed",
+			revision, elapsed, primary.RunningCount, primary.DesiredCount, primary.PendingCount, primary.FailedTasks)
+		if status.State == DeploymentStateStuck {
+			status.Summary = "possibly stuck: " + status.Summary
+		}
+	default:
+		status.State = DeploymentStateStable
+		status.Summary = fmt.Sprintf("%s is deployed: %d/%d tasks running, %d pending", revision, primary.RunningCount, primary.DesiredCount, primary.PendingCount)
+	}
+
+	return status
+}
+
+// recentServiceEvents converts up to limit service events, which ECS returns newest first
+func recentServiceEvents(events []types.ServiceEvent, limit int) []ServiceEvent {
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	result := make([]ServiceEvent, 0, len(events))
+	for _, event := range events {
+		serviceEvent := ServiceEvent{
+			ID:      aws.ToString(event.Id),
+			Message: aws.ToString(event.Message),
+		}
+		if event.CreatedAt != nil {
+			serviceEvent.Timestamp = event.CreatedAt.Format(time.RFC3339)
+		}
+		result = append(result, serviceEvent)
+	}
+	return result
+}
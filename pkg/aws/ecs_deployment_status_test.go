@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeploymentStatusStuckRollout(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]types.ServiceEvent, 0, 12)
+	for i := 0; i < 12; i++ {
+		events = append(events, types.ServiceEvent{
+			Id:        aws.String(fmt.Sprintf("event-%d", i)),
+			CreatedAt: aws.Time(now.Add(-time.Duration(i) * time.Minute)),
+			Message:   aws.String(fmt.Sprintf("(service api) message %d", i)),
+		})
+	}
+
+	output := &ecs.DescribeServicesOutput{
+		Services: []types.Service{{
+			ServiceName:  aws.String("api"),
+			Status:       aws.String("ACTIVE"),
+			DesiredCount: 3,
+			RunningCount: 4,
+			PendingCount: 1,
+			Deployments: []types.Deployment{
+				{
+					Id:                 aws.String("ecs-svc/new"),
+					Status:             aws.String("PRIMARY"),
+					TaskDefinition:     aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:8"),
+					RolloutState:       types.DeploymentRolloutStateInProgress,
+					RolloutStateReason: aws.String("ECS deployment ecs-svc/new in progress."),
+					DesiredCount:       3,
+					RunningCount:       1,
+					PendingCount:       1,
+					FailedTasks:        4,
+					CreatedAt:          aws.Time(now.Add(-45 * time.Minute)),
+					UpdatedAt:          aws.Time(now.Add(-time.Minute)),
+				},
+				{
+					Id:             aws.String("ecs-svc/old"),
+					Status:         aws.String("ACTIVE"),
+					TaskDefinition: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:7"),
+					RolloutState:   types.DeploymentRolloutStateCompleted,
+					DesiredCount:   3,
+					RunningCount:   3,
+				},
+			},
+			Events: events,
+			LoadBalancers: []types.LoadBalancer{{
+				TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/6d0ecf831eec9f09"),
+				ContainerName:  aws.String("api"),
+				ContainerPort:  aws.Int32(8080),
+			}},
+		}},
+	}
+
+	status := newDeploymentStatus("prod", output.Services[0], now)
+
+	assert.Equal(t, "prod", status.Cluster)
+	assert.Equal(t, "api", status.Service)
+	assert.Equal(t, "ACTIVE", status.Status)
+	assert.Equal(t, DeploymentStateStuck, status.State)
+	assert.Equal(t, "possibly stuck: deployment of api:8 in progress for 45m0s: 1/3 tasks running, 1 pending, 4 failed", status.Summary)
+	assert.Equal(t, int32(3), status.DesiredCount)
+	assert.Equal(t, int32(4), status.RunningCount)
+	assert.Equal(t, int32(1), status.PendingCount)
+
+	assert.Len(t, status.Deployments, 2)
+	assert.Equal(t, "ecs-svc/new", status.Primary.ID)
+	assert.Equal(t, "IN_PROGRESS", status.Primary.RolloutState)
+	assert.Equal(t, "ECS deployment ecs-svc/new in progress.", status.Primary.RolloutStateReason)
+	assert.Equal(t, int32(1), status.Primary.PendingCount)
+	assert.Equal(t, "api:7", status.Deployments[1].Revision)
+
+	assert.Len(t, status.RecentEvents, 10)
+	assert.Equal(t, ServiceEvent{ID: "event-0", Timestamp: "2025-06-01T12:00:00Z", Message: "(service api) message 0"}, status.RecentEvents[0])
+	assert.Equal(t, []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/6d0ecf831eec9f09"}, status.TargetGroupARNs)
+}
+
+func TestNewDeploymentStatusStates(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	primary := func(state types.DeploymentRolloutState, age time.Duration) types.Deployment {
+		return types.Deployment{
+			Status:             aws.String("PRIMARY"),
+			TaskDefinition:     aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:8"),
+			RolloutState:       state,
+			RolloutStateReason: aws.String("tasks failed to start"),
+			DesiredCount:       2,
+			RunningCount:       2,
+			CreatedAt:          aws.Time(now.Add(-age)),
+		}
+	}
+
+	status := newDeploymentStatus("prod", types.Service{Deployments: []types.Deployment{primary(types.DeploymentRolloutStateCompleted, time.Hour)}}, now)
+	assert.Equal(t, DeploymentStateStable, status.State)
+	assert.Equal(t, "api:8 is deployed: 2/2 tasks running, 0 pending", status.Summary)
+
+	status = newDeploymentStatus("prod", types.Service{Deployments: []types.Deployment{primary(types.DeploymentRolloutStateInProgress, 5*time.Minute)}}, now)
+	assert.Equal(t, DeploymentStateInProgress, status.State)
+
+	status = newDeploymentStatus("prod", types.Service{Deployments: []types.Deployment{primary(types.DeploymentRolloutStateFailed, time.Hour)}}, now)
+	assert.Equal(t, DeploymentStateFailed, status.State)
+	assert.Equal(t, "deployment of api:8 failed: tasks failed to start", status.Summary)
+}
@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Deployment timeline entry kinds
+const (
+	TimelineTaskDefinitionRegistered = "task_definition_registered"
+	TimelineDeploymentStarted        = "deployment_started"
+	TimelineDeploymentCompleted      = "deployment_completed"
+	TimelineDeploymentFailed         = "deployment_failed"
+	TimelineSteadyState              = "steady_state"
+	TimelineRollback                 = "rollback"
+	TimelineServiceEvent             = "service_event"
+)
+
+// DeploymentRecord summarizes one ECS service deployment
+type DeploymentRecord struct {
+	ID                     string  `json:"id"`
+	Status                 string  `json:"status"`
+	TaskDefinition         string  `json:"task_definition"`
+	Revision               string  `json:"revision"`
+	RolloutState           string  `json:"rollout_state"`
+	RolloutStateReason     string  `json:"rollout_state_reason,omitempty"`
+	DesiredCount           int32   `json:"desired_count"`
+	RunningCount           int32   `json:"running_count"`
+	PendingCount           int32   `json:"pending_count"`
+	FailedTasks            int32   `json:"failed_tasks"`
+	CreatedAt              string  `json:"created_at"`
+	UpdatedAt              string  `json:"updated_at"`
+	RolloutDurationSeconds float64 `json:"rollout_duration_seconds,omitempty"`
+}
+
+// TimelineEntry is a single point in a deployment timeline
+type TimelineEntry struct {
+	Timestamp    string `json:"timestamp"`
+	Kind         string `json:"kind"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+	Revision     string `json:"revision,omitempty"`
+	Message      string `json:"message"`
+
+	at time.Time
+}
+
+// DeploymentHistory is a chronological view of recent deployments of a service
+type DeploymentHistory struct {
+	Cluster     string             `json:"cluster"`
+	Service     string             `json:"service"`
+	Deployments []DeploymentRecord `json:"deployments"`
+	Timeline    []TimelineEntry    `json:"timeline"`
+	Warnings    []string           `json:"warnings,omitempty"`
+}
+
+// GetDeploymentHistory assembles a timeline from a service's deployments, its
+// task definition revisions and the service event log. ECS keeps the last 100
+// service events, so the timeline covers roughly that window. limit caps the
+// number of timeline entries (most recent kept); 0 keeps all. Task definitions that
+// cannot be described are left out of the timeline and reported in Warnings.
+func (e *ECSService) GetDeploymentHistory(ctx context.Context, profileID string, clusterName string, serviceName string, limit int) (*DeploymentHistory, error) {
+	client, err := e.clientManager.GetECSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  aws.String(clusterName),
+		Services: []string{serviceName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe service: %w", classifyAWSError(err, "ecs:DescribeServices", "service "+serviceName+" in cluster "+clusterName))
+	}
+	if len(result.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found in cluster %s", serviceName, clusterName)
+	}
+
+	svc := result.Services[0]
+	history := &DeploymentHistory{
+		Cluster:     clusterName,
+		Service:     aws.ToString(svc.ServiceName),
+		Deployments: make([]DeploymentRecord, 0, len(svc.Deployments)),
+	}
+
+	timeline := make([]TimelineEntry, 0)
+	revisions := make(map[string]string)
+	for _, d := range svc.Deployments {
+		record := newDeploymentRecord(d)
+		history.Deployments = append(history.Deployments, record)
+		revisions[aws.ToString(d.TaskDefinition)] = record.Revision
+
+		if d.CreatedAt != nil {
+			timeline = append(timeline, newTimelineEntry(*d.CreatedAt, TimelineDeploymentStarted, record.ID, record.Revision,
+				fmt.Sprintf("deployment of %s started (desired %d)", record.Revision, record.DesiredCount)))
+		}
+		if d.UpdatedAt != nil {
+			switch d.RolloutState {
+			case types.DeploymentRolloutStateCompleted:
+				timeline = append(timeline, newTimelineEntry(*d.UpdatedAt, TimelineDeploymentCompleted, record.ID, record.Revision,
+					fmt.Sprintf("deployment of %s completed in %.0fs", record.Revision, record.RolloutDurationSeconds)))
+			case types.DeploymentRolloutStateFailed:
+				timeline = append(timeline, newTimelineEntry(*d.UpdatedAt, TimelineDeploymentFailed, record.ID, record.Revision,
+					fmt.Sprintf("deployment of %s failed after %.0fs: %s", record.Revision, record.RolloutDurationSeconds, record.RolloutStateReason)))
+			}
+		}
+	}
+
+	// Task definition registration times show when each revision was created
+	taskDefinitions := make([]string, 0, len(revisions))
+	for taskDefinition := range revisions {
+		taskDefinitions = append(taskDefinitions, taskDefinition)
+	}
+	sort.Strings(taskDefinitions)
+	for _, taskDefinition := range taskDefinitions {
+		revision := revisions[taskDefinition]
+		td, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(taskDefinition),
+		})
+		if err != nil {
+			history.Warnings = append(history.Warnings, fmt.Sprintf("registration of %s unavailable: %v", revision,
+				classifyAWSError(err, "ecs:DescribeTaskDefinition", "task definition "+taskDefinition)))
+			continue
+		}
+		if td.TaskDefinition == nil || td.TaskDefinition.RegisteredAt == nil {
+			continue
+		}
+		message := fmt.Sprintf("task definition %s registered", revision)
+		if by := aws.ToString(td.TaskDefinition.RegisteredBy); by != "" {
+			message = fmt.Sprintf("%s by %s", message, by)
+		}
+		timeline = append(timeline, newTimelineEntry(*td.TaskDefinition.RegisteredAt, TimelineTaskDefinitionRegistered, "", revision, message))
+	}
+
+	for _, event := range svc.Events {
+		if event.CreatedAt == nil {
+			continue
+		}
+		message := aws.ToString(event.Message)
+		timeline = append(timeline, newTimelineEntry(*event.CreatedAt, classifyServiceEvent(message), "", "", message))
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].at.Before(timeline[j].at)
+	})
+	if limit > 0 && len(timeline) > limit {
+		timeline = timeline[len(timeline)-limit:]
+	}
+	history.Timeline = timeline
+
+	return history, nil
+}
+
+func newDeploymentRecord(d types.Deployment) DeploymentRecord {
+	record := DeploymentRecord{
+		ID:                 aws.ToString(d.Id),
+		Status:             aws.ToString(d.Status),
+		TaskDefinition:     aws.ToString(d.TaskDefinition),
+		Revision:           taskDefinitionRevision(aws.ToString(d.TaskDefinition)),
+		RolloutState:       string(d.RolloutState),
+		RolloutStateReason: aws.ToString(d.RolloutStateReason),
+		DesiredCount:       d.DesiredCount,
+		RunningCount:       d.RunningCount,
+		PendingCount:       d.PendingCount,
+		FailedTasks:        d.FailedTasks,
+	}
+	if d.CreatedAt != nil {
+		record.CreatedAt = d.CreatedAt.Format(time.RFC3339)
+	}
+	if d.UpdatedAt != nil {
+		record.UpdatedAt = d.UpdatedAt.Format(time.RFC3339)
+	}
+	if d.CreatedAt != nil && d.UpdatedAt != nil && d.RolloutState != types.DeploymentRolloutStateInProgress {
+		record.RolloutDurationSeconds = d.UpdatedAt.Sub(*d.CreatedAt).Seconds()
+	}
+	return record
+}
+
+func newTimelineEntry(at time.Time, kind string, deploymentID string, revision string, message string) TimelineEntry {
+	return TimelineEntry{
+		Timestamp:    at.Format(time.RFC3339),
+		Kind:         kind,
+		DeploymentID: deploymentID,
+		Revision:     revision,
+		Message:      message,
+		at:           at,
+	}
+}
+
+// taskDefinitionRevision returns "family:revision" from a task definition ARN
+func taskDefinitionRevision(taskDefinitionARN string) string {
+	if idx := strings.LastIndex(taskDefinitionARN, "/"); idx >= 0 {
+		return taskDefinitionARN[idx+1:]
+	}
+	return taskDefinitionARN
+}
+
+// classifyServiceEvent maps an ECS service event message to a timeline kind
+func classifyServiceEvent(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "rolling back") || strings.Contains(lower, "rollback"):
+		return TimelineRollback
+	case strings.Contains(lower, "deployment failed") || strings.Contains(lower, "circuit breaker"):
+		return TimelineDeploymentFailed
+	case strings.Contains(lower, "deployment completed"):
+		return TimelineDeploymentCompleted
+	case strings.Contains(lower, "steady state"):
+		return TimelineSteadyState
+	default:
+		return TimelineServiceEvent
+	}
+}
@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyServiceEvent(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{"(service api) deployment ecs-svc/123 deployment failed: tasks failed to start.", TimelineDeploymentFailed},
+		{"(service api) (deployment ecs-svc/123) deployment circuit breaker: rolling back to deploymentId ecs-svc/456.", TimelineRollback},
+		{"(service api) rollback of deployment ecs-svc/123 started.", TimelineRollback},
+		{"(service api) (deployment ecs-svc/123) deployment completed.", TimelineDeploymentCompleted},
+		{"(service api) has reached a steady state.", TimelineSteadyState},
+		{"(service api) has started 2 tasks: (task abc) (task def).", TimelineServiceEvent},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, classifyServiceEvent(tc.message), tc.message)
+	}
+}
+
+func TestTaskDefinitionRevision(t *testing.T) {
+	tests := []struct {
+		arn      string
+		expected string
+	}{
+		{"arn:aws:ecs:eu-west-1:123456789012:task-definition/api:42", "api:42"},
+		{"api:42", "api:42"},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, taskDefinitionRevision(tc.arn), tc.arn)
+	}
+}
+
+func TestNewDeploymentRecord(t *testing.T) {
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(90 * time.Second)
+
+	tests := []struct {
+		name             string
+		rolloutState     types.DeploymentRolloutState
+		updatedAt        *time.Time
+		expectedDuration float64
+	}{
+		{"completed", types.DeploymentRolloutStateCompleted, aws.Time(updated), 90},
+		{"failed", types.DeploymentRolloutStateFailed, aws.Time(updated), 90},
+		{"in progress has no duration", types.DeploymentRolloutStateInProgress, aws.Time(updated), 0},
+		{"never updated has no duration", types.DeploymentRolloutStateCompleted, nil, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			record := newDeploymentRecord(types.Deployment{
+				Id:             aws.String("ecs-svc/123"),
+				Status:         aws.String("PRIMARY"),
+				TaskDefinition: aws.String("arn:aws:ecs:eu-west-1:123456789012:task-definition/api:42"),
+				RolloutState:   tc.rolloutState,
+				DesiredCount:   3,
+				RunningCount:   2,
+				CreatedAt:      aws.Time(created),
+				UpdatedAt:      tc.updatedAt,
+			})
+
+			assert.Equal(t, "api:42", record.Revision)
+			assert.Equal(t, string(tc.rolloutState), record.RolloutState)
+			assert.Equal(t, "2025-06-01T12:00:00Z", record.CreatedAt)
+			assert.Equal(t, tc.expectedDuration, record.RolloutDurationSeconds)
+			if tc.updatedAt == nil {
+				assert.Empty(t, record.UpdatedAt)
+			}
+		})
+	}
+}
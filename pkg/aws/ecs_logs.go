@@ -0,0 +1,295 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// maxStreamsPerFilter is the most log stream names FilterLogEvents accepts in one call
+const maxStreamsPerFilter = 100
+
+// TaskLogStream is the CloudWatch log stream written by one container of an ECS task
+type TaskLogStream struct {
+	TaskARN   string `json:"task_arn"`
+	TaskID    string `json:"task_id"`
+	Container string `json:"container"`
+	LogGroup  string `json:"log_group"`
+	LogStream string `json:"log_stream"`
+}
+
+// ServiceLogEvent is a log event attributed to the task and container that wrote it
+type ServiceLogEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+	TaskID    string `json:"task_id"`
+	Container string `json:"container"`
+	LogStream string `json:"log_stream"`
+}
+
+// ServiceLogsResult holds the merged recent log events of every running task of a service
+type ServiceLogsResult struct {
+	Cluster       string            `json:"cluster"`
+	Service       string            `json:"service"`
+	TaskCount     int               `json:"task_count"`
+	Streams       []TaskLogStream   `json:"streams"`
+	Events        []ServiceLogEvent `json:"events"`
+	TotalReturned int               `json:"total_returned"`
+	HasMore       bool              `json:"has_more"`
+	Unresolved    []string          `json:"unresolved,omitempty"`
+	StartTime     int64             `json:"start_time"`
+	EndTime       int64             `json:"end_time"`
+}
+
+// GetServiceLogs fetches recent log events from every running task of an ECS service
+// and merges them into one timeline, oldest first. Each task's streams are resolved from
+// the awslogs configuration of its task definition; containers using another log driver
+// or no stream prefix are listed in Unresolved. Every page of the window is read; when
+// there are more than limit events, the newest limit are kept and HasMore is set.
+func (e *ECSService) GetServiceLogs(ctx context.Context, profileID string, clusterName string, serviceName string, filterPattern string, startTime int64, endTime int64, limit int32) (*ServiceLogsResult, error) {
+	client, err := e.clientManager.GetECSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+	logsClient, err := e.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 200
+	}
+	limit = min(limit, 10000)
+
+	taskARNs, err := e.ListTasks(ctx, profileID, clusterName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceLogsResult{
+		Cluster:   clusterName,
+		Service:   serviceName,
+		TaskCount: len(taskARNs),
+		Streams:   []TaskLogStream{},
+		Events:    []ServiceLogEvent{},
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	if len(taskARNs) == 0 {
+		return result, nil
+	}
+
+	described, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   taskARNs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe tasks: %w", classifyAWSError(err, "ecs:DescribeTasks", "service "+serviceName+" in cluster "+clusterName))
+	}
+
+	definitions, err := describeTaskDefinitions(ctx, client, described.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Streams, result.Unresolved = taskLogStreams(described.Tasks, definitions, logsClient.Options().Region)
+
+	// Fetch each log group's streams together, then attribute events by stream name
+	streamsByGroup := make(map[string][]string)
+	owners := make(map[string]TaskLogStream)
+	for _, stream := range result.Streams {
+		streamsByGroup[stream.LogGroup] = append(streamsByGroup[stream.LogGroup], stream.LogStream)
+		owners[stream.LogGroup+"\x00"+stream.LogStream] = stream
+	}
+
+	groups := make([]string, 0, len(streamsByGroup))
+	for group := range streamsByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		streams := streamsByGroup[group]
+		for start := 0; start < len(streams); start += maxStreamsPerFilter {
+			input := &cloudwatchlogs.FilterLogEventsInput{
+				LogGroupName:   aws.String(group),
+				LogStreamNames: streams[start:min(start+maxStreamsPerFilter, len(streams))],
+				Limit:          aws.Int32(10000),
+			}
+			if filterPattern != "" {
+				input.FilterPattern = aws.String(filterPattern)
+			}
+			if startTime > 0 {
+				input.StartTime = aws.Int64(startTime)
+			}
+			if endTime > 0 {
+				input.EndTime = aws.Int64(endTime)
+			}
+
+			events, hasMore, err := newestLogEvents(ctx, func(token *string) ([]LogEvent, *string, error) {
+				input.NextToken = token
+				page, err := logsClient.FilterLogEvents(ctx, input)
+				if err != nil {
+					return nil, nil, err
+				}
+				events := make([]LogEvent, 0, len(page.Events))
+				for _, event := range page.Events {
+					events = append(events, LogEvent{
+						Timestamp:     aws.ToInt64(event.Timestamp),
+						Message:       aws.ToString(event.Message),
+						IngestionTime: aws.ToInt64(event.IngestionTime),
+						LogStream:     aws.ToString(event.LogStreamName),
+					})
+				}
+				return events, page.NextToken, nil
+			}, int(limit))
+			if err != nil {
+				return nil, fmt.Errorf("failed to query task logs: %w", classifyAWSError(err, "logs:FilterLogEvents", "log group "+group))
+			}
+			result.HasMore = result.HasMore || hasMore
+
+			for _, event := range events {
+				owner := owners[group+"\x00"+event.LogStream]
+				result.Events = append(result.Events, ServiceLogEvent{
+					Timestamp: event.Timestamp,
+					Message:   event.Message,
+					TaskID:    owner.TaskID,
+					Container: owner.Container,
+					LogStream: event.LogStream,
+				})
+			}
+		}
+	}
+
+	result.Events, result.HasMore = mergeServiceLogEvents(result.Events, int(limit), result.HasMore)
+	result.TotalReturned = len(result.Events)
+
+	return result, nil
+}
+
+// describeTaskDefinitions fetches the task definition of each task, once per revision,
+// keyed by task definition ARN
+func describeTaskDefinitions(ctx context.Context, client *ecs.Client, tasks []types.Task) (map[string]*types.TaskDefinition, error) {
+	definitions := make(map[string]*types.TaskDefinition)
+	for _, task := range tasks {
+		arn := aws.ToString(task.TaskDefinitionArn)
+		if _, ok := definitions[arn]; ok {
+			continue
+		}
+		td, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(arn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe task definition: %w", classifyAWSError(err, "ecs:DescribeTaskDefinition", "task definition "+arn))
+		}
+		definitions[arn] = td.TaskDefinition
+	}
+	return definitions, nil
+}
+
+// taskLogStreams resolves the awslogs stream of every container of the given tasks.
+// Streams are named <awslogs-stream-prefix>/<container>/<task id>; containers whose
+// stream cannot be named, or whose logs go to another region, are described in the
+// second return value instead.
+func taskLogStreams(tasks []types.Task, definitions map[string]*types.TaskDefinition, region string) ([]TaskLogStream, []string) {
+	streams := make([]TaskLogStream, 0)
+	var unresolved []string
+
+	for _, task := range tasks {
+		taskARN := aws.ToString(task.TaskArn)
+		taskID := taskARN[strings.LastIndex(taskARN, "/")+1:]
+
+		td := definitions[aws.ToString(task.TaskDefinitionArn)]
+		if td == nil {
+			unresolved = append(unresolved, fmt.Sprintf("task %s: task definition not found", taskID))
+			continue
+		}
+
+		for _, container := range td.ContainerDefinitions {
+			name := aws.ToString(container.Name)
+			logConfig := container.LogConfiguration
+			if logConfig == nil || logConfig.LogDriver != types.LogDriverAwslogs {
+				unresolved = append(unresolved, fmt.Sprintf("task %s container %s: not using the awslogs log driver", taskID, name))
+				continue
+			}
+
+			group := logConfig.Options["awslogs-group"]
+			prefix := logConfig.Options["awslogs-stream-prefix"]
+			if group == "" || prefix == "" {
+				unresolved = append(unresolved, fmt.Sprintf("task %s container %s: awslogs-group or awslogs-stream-prefix not set", taskID, name))
+				continue
+			}
+			if logRegion := logConfig.Options["awslogs-region"]; logRegion != "" && region != "" && logRegion != region {
+				unresolved = append(unresolved, fmt.Sprintf("task %s container %s: logs are in region %s", taskID, name, logRegion))
+				continue
+			}
+
+			streams = append(streams, TaskLogStream{
+				TaskARN:   taskARN,
+				TaskID:    taskID,
+				Container: name,
+				LogGroup:  group,
+				LogStream: fmt.Sprintf("%s/%s/%s", prefix, name, taskID),
+			})
+		}
+	}
+
+	return streams, unresolved
+}
+
+// newestLogEvents calls fetch once per page until the last page, like collectAll, and
+// returns the newest limit events oldest first. FilterLogEvents returns the oldest events
+// of the window first, so stopping after limit events would miss the recent ones of a
+// busy service. Only about 2*limit events are held at a time; the second return value
+// reports whether older events were dropped.
+func newestLogEvents(ctx context.Context, fetch func(token *string) ([]LogEvent, *string, error), limit int) ([]LogEvent, bool, error) {
+	kept := make([]LogEvent, 0)
+	dropped := false
+	keepNewest := func() {
+		sortEventsAscending(kept)
+		if len(kept) > limit {
+			kept = append(kept[:0:0], kept[len(kept)-limit:]...)
+			dropped = true
+		}
+	}
+
+	var token *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		events, next, err := fetch(token)
+		if err != nil {
+			return nil, false, err
+		}
+		kept = append(kept, events...)
+		if len(kept) > 2*limit {
+			keepNewest()
+		}
+
+		if aws.ToString(next) == "" {
+			keepNewest()
+			return kept, dropped, nil
+		}
+		token = next
+	}
+}
+
+// mergeServiceLogEvents orders events from several streams oldest first and keeps the
+// newest limit of them, reporting whether any were left out
+func mergeServiceLogEvents(events []ServiceLogEvent, limit int, hasMore bool) ([]ServiceLogEvent, bool) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+	if limit > 0 && len(events) > limit {
+		return events[len(events)-limit:], true
+	}
+	return events, hasMore
+}
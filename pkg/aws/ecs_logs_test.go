@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskLogStreams(t *testing.T) {
+	definitions := map[string]*types.TaskDefinition{
+		"arn:aws:ecs:us-east-1:123456789012:task-definition/api:7": {
+			ContainerDefinitions: []types.ContainerDefinition{
+				{
+					Name: aws.String("api"),
+					LogConfiguration: &types.LogConfiguration{
+						LogDriver: types.LogDriverAwslogs,
+						Options:   map[string]string{"awslogs-group": "/ecs/api", "awslogs-stream-prefix": "ecs", "awslogs-region": "us-east-1"},
+					},
+				},
+				{
+					Name:             aws.String("envoy"),
+					LogConfiguration: &types.LogConfiguration{LogDriver: types.LogDriverFluentd},
+				},
+				{
+					Name: aws.String("metrics"),
+					LogConfiguration: &types.LogConfiguration{
+						LogDriver: types.LogDriverAwslogs,
+						Options:   map[string]string{"awslogs-group": "/ecs/metrics", "awslogs-stream-prefix": "ecs", "awslogs-region": "eu-west-1"},
+					},
+				},
+			},
+		},
+	}
+	tasks := []types.Task{
+		{TaskArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task/prod/aaa111"), TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:7")},
+		{TaskArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task/prod/bbb222"), TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:7")},
+		{TaskArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task/prod/ccc333"), TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/api:6")},
+	}
+
+	streams, unresolved := taskLogStreams(tasks, definitions, "us-east-1")
+
+	assert.Equal(t, []TaskLogStream{
+		{TaskARN: "arn:aws:ecs:us-east-1:123456789012:task/prod/aaa111", TaskID: "aaa111", Container: "api", LogGroup: "/ecs/api", LogStream: "ecs/api/aaa111"},
+		{TaskARN: "arn:aws:ecs:us-east-1:123456789012:task/prod/bbb222", TaskID: "bbb222", Container: "api", LogGroup: "/ecs/api", LogStream: "ecs/api/bbb222"},
+	}, streams)
+	assert.Equal(t, []string{
+		"task aaa111 container envoy: not using the awslogs log driver",
+		"task aaa111 container metrics: logs are in region eu-west-1",
+		"task bbb222 container envoy: not using the awslogs log driver",
+		"task bbb222 container metrics: logs are in region eu-west-1",
+		"task ccc333: task definition not found",
+	}, unresolved)
+}
+
+func TestMergeServiceLogEventsKeepsNewest(t *testing.T) {
+	events := []ServiceLogEvent{
+		{Timestamp: 300, Message: "b3", TaskID: "b"},
+		{Timestamp: 100, Message: "a1", TaskID: "a"},
+		{Timestamp: 200, Message: "a2", TaskID: "a"},
+		{Timestamp: 150, Message: "b1", TaskID: "b"},
+	}
+
+	merged, hasMore := mergeServiceLogEvents(events, 3, false)
+	assert.True(t, hasMore)
+
+	messages := make([]string, 0, len(merged))
+	for _, event := range merged {
+		messages = append(messages, event.Message)
+	}
+	assert.Equal(t, []string{"b1", "a2", "b3"}, messages)
+
+	merged, hasMore = mergeServiceLogEvents(events, 10, false)
+	assert.False(t, hasMore)
+	assert.Len(t, merged, 4)
+}
+
+func TestNewestLogEventsReadsToTheEnd(t *testing.T) {
+	// Three pages of five events each, oldest first as FilterLogEvents returns them
+	pages := [][]LogEvent{}
+	for page := 0; page < 3; page++ {
+		events := []LogEvent{}
+		for i := 0; i < 5; i++ {
+			events = append(events, LogEvent{Timestamp: int64(page*5 + i)})
+		}
+		pages = append(pages, events)
+	}
+
+	calls := 0
+	events, hasMore, err := newestLogEvents(context.Background(), func(token *string) ([]LogEvent, *string, error) {
+		page := 0
+		if token != nil {
+			page, _ = strconv.Atoi(*token)
+		}
+		calls++
+		if page+1 < len(pages) {
+			return pages[page], aws.String(strconv.Itoa(page + 1)), nil
+		}
+		return pages[page], nil, nil
+	}, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.True(t, hasMore)
+
+	timestamps := make([]int64, 0, len(events))
+	for _, event := range events {
+		timestamps = append(timestamps, event.Timestamp)
+	}
+	assert.Equal(t, []int64{11, 12, 13, 14}, timestamps)
+
+	events, hasMore, err = newestLogEvents(context.Background(), func(token *string) ([]LogEvent, *string, error) {
+		return pages[0], nil, nil
+	}, 10)
+	assert.NoError(t, err)
+	assert.False(t, hasMore)
+	assert.Len(t, events, 5)
+}
@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// ContainerLogs holds the recent log events of one container of an ECS task
+type ContainerLogs struct {
+	Container string     `json:"container"`
+	LogGroup  string     `json:"log_group"`
+	LogStream string     `json:"log_stream"`
+	Events    []LogEvent `json:"events"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// TaskLogs holds the recent log events of every awslogs container of an ECS task
+type TaskLogs struct {
+	Cluster    string          `json:"cluster"`
+	TaskARN    string          `json:"task_arn"`
+	TaskID     string          `json:"task_id"`
+	Containers []ContainerLogs `json:"containers"`
+	Unresolved []string        `json:"unresolved,omitempty"`
+}
+
+// GetTaskLogs returns the most recent limit log events of each container of a task.
+// The log group and stream of each container are derived from the awslogs
+// logConfiguration of the task definition, so no log group needs to be known. A task
+// without any awslogs container is an error; a container whose stream cannot be read
+// reports its error without failing the others.
+func (e *ECSService) GetTaskLogs(ctx context.Context, profileID string, clusterName string, taskARN string, limit int32) (*TaskLogs, error) {
+	client, err := e.clientManager.GetECSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+	logsClient, err := e.clientManager.GetCloudWatchLogsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	described, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(clusterName),
+		Tasks:   []string{taskARN},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe task: %w", classifyAWSError(err, "ecs:DescribeTasks", "task "+taskARN))
+	}
+	if len(described.Tasks) == 0 {
+		return nil, fmt.Errorf("task %s not found in cluster %s", taskARN, clusterName)
+	}
+
+	definitions, err := describeTaskDefinitions(ctx, client, described.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	task := described.Tasks[0]
+	streams, unresolved := taskLogStreams(described.Tasks, definitions, logsClient.Options().Region)
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("cannot locate logs of task %s: no container logs to CloudWatch with the awslogs driver (%s)", taskARN, strings.Join(unresolved, "; "))
+	}
+
+	result := &TaskLogs{
+		Cluster:    clusterName,
+		TaskARN:    aws.ToString(task.TaskArn),
+		TaskID:     streams[0].TaskID,
+		Containers: make([]ContainerLogs, 0, len(streams)),
+		Unresolved: unresolved,
+	}
+
+	cloudwatch := NewCloudWatchService(e.clientManager)
+	for _, stream := range streams {
+		containerLogs := ContainerLogs{
+			Container: stream.Container,
+			LogGroup:  stream.LogGroup,
+			LogStream: stream.LogStream,
+			Events:    []LogEvent{},
+		}
+
+		events, err := cloudwatch.GetLogEventsByStream(ctx, profileID, stream.LogGroup, stream.LogStream, limit, false)
+		if err != nil {
+			containerLogs.Error = err.Error()
+		} else {
+			containerLogs.Events = events
+		}
+		result.Containers = append(result.Containers, containerLogs)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// ELBService provides Elastic Load Balancing (ALB and NLB) operations
+type ELBService struct {
+	clientManager *ClientManager
+}
+
+// NewELBService creates a new ELB service
+func NewELBService(clientManager *ClientManager) *ELBService {
+	return &ELBService{
+		clientManager: clientManager,
+	}
+}
+
+// TargetGroupRef is a target group a load balancer routes to
+type TargetGroupRef struct {
+	ARN        string `json:"arn"`
+	Name       string `json:"name"`
+	Protocol   string `json:"protocol,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	TargetType string `json:"target_type"`
+}
+
+// LoadBalancer represents an application, network or gateway load balancer
+type LoadBalancer struct {
+	Name         string           `json:"name"`
+	ARN          string           `json:"arn"`
+	Type         string           `json:"type"`
+	Scheme       string           `json:"scheme"`
+	DNSName      string           `json:"dns_name"`
+	State        string           `json:"state"`
+	StateReason  string           `json:"state_reason,omitempty"`
+	VPCID        string           `json:"vpc_id,omitempty"`
+	CreatedTime  string           `json:"created_time,omitempty"`
+	TargetGroups []TargetGroupRef `json:"target_groups"`
+}
+
+// TargetHealth is the health of one registered target
+type TargetHealth struct {
+	TargetID         string `json:"target_id"`
+	Port             int32  `json:"port,omitempty"`
+	AvailabilityZone string `json:"availability_zone,omitempty"`
+	State            string `json:"state"`
+	Healthy          bool   `json:"healthy"`
+	Reason           string `json:"reason,omitempty"`
+	Description      string `json:"description,omitempty"`
+}
+
+// TargetHealthResult is the health of every target of a target group
+type TargetHealthResult struct {
+	TargetGroupARN string         `json:"target_group_arn"`
+	HealthyCount   int            `json:"healthy_count"`
+	UnhealthyCount int            `json:"unhealthy_count"`
+	States         map[string]int `json:"states"`
+	Targets        []TargetHealth `json:"targets"`
+}
+
+// ListLoadBalancers lists the load balancers of the account with the target groups
+// each routes to
+func (e *ELBService) ListLoadBalancers(ctx context.Context, profileID string) ([]LoadBalancer, error) {
+	client, err := e.clientManager.GetELBv2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancers := make([]LoadBalancer, 0)
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(client, &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list load balancers: %w", classifyAWSError(err, "elasticloadbalancing:DescribeLoadBalancers", ""))
+		}
+		for _, lb := range page.LoadBalancers {
+			loadBalancers = append(loadBalancers, newLoadBalancer(lb))
+		}
+	}
+
+	// One listing of every target group is cheaper than a call per load balancer
+	targetGroups := make([]types.TargetGroup, 0)
+	groups := elbv2.NewDescribeTargetGroupsPaginator(client, &elbv2.DescribeTargetGroupsInput{})
+	for groups.HasMorePages() {
+		page, err := groups.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list target groups: %w", classifyAWSError(err, "elasticloadbalancing:DescribeTargetGroups", ""))
+		}
+		targetGroups = append(targetGroups, page.TargetGroups...)
+	}
+	attachTargetGroups(loadBalancers, targetGroups)
+
+	return loadBalancers, nil
+}
+
+// DescribeTargetHealth reports the health of every target registered in a target group
+func (e *ELBService) DescribeTargetHealth(ctx context.Context, profileID string, targetGroupARN string) (*TargetHealthResult, error) {
+	if targetGroupARN == "" {
+		return nil, fmt.Errorf("target_group_arn is required")
+	}
+
+	client, err := e.clientManager.GetELBv2Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeTargetHealth(ctx, &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe target health: %w", classifyAWSError(err, "elasticloadbalancing:DescribeTargetHealth", "target group "+targetGroupARN))
+	}
+
+	return newTargetHealthResult(targetGroupARN, result.TargetHealthDescriptions), nil
+}
+
+// newLoadBalancer converts a load balancer returned by the API
+func newLoadBalancer(lb types.LoadBalancer) LoadBalancer {
+	loadBalancer := LoadBalancer{
+		Name:         aws.ToString(lb.LoadBalancerName),
+		ARN:          aws.ToString(lb.LoadBalancerArn),
+		Type:         string(lb.Type),
+		Scheme:       string(lb.Scheme),
+		DNSName:      aws.ToString(lb.DNSName),
+		VPCID:        aws.ToString(lb.VpcId),
+		TargetGroups: make([]TargetGroupRef, 0),
+	}
+	if lb.State != nil {
+		loadBalancer.State = string(lb.State.Code)
+		loadBalancer.StateReason = aws.ToString(lb.State.Reason)
+	}
+	if lb.CreatedTime != nil {
+		loadBalancer.CreatedTime = lb.CreatedTime.Format(time.RFC3339)
+	}
+	return loadBalancer
+}
+
+// attachTargetGroups adds each target group to the load balancers that route to it
+func attachTargetGroups(loadBalancers []LoadBalancer, targetGroups []types.TargetGroup) {
+	byARN := make(map[string]*LoadBalancer, len(loadBalancers))
+	for i := range loadBalancers {
+		byARN[loadBalancers[i].ARN] = &loadBalancers[i]
+	}
+
+	for _, tg := range targetGroups {
+		ref := TargetGroupRef{
+			ARN:        aws.ToString(tg.TargetGroupArn),
+			Name:       aws.ToString(tg.TargetGroupName),
+			Protocol:   string(tg.Protocol),
+			Port:       aws.ToInt32(tg.Port),
+			TargetType: string(tg.TargetType),
+		}
+		for _, lbARN := range tg.LoadBalancerArns {
+			if lb, ok := byARN[lbARN]; ok {
+				lb.TargetGroups = append(lb.TargetGroups, ref)
+			}
+		}
+	}
+}
+
+// newTargetHealthResult converts the target health descriptions of a target group,
+// unhealthy targets first
+func newTargetHealthResult(targetGroupARN string, descriptions []types.TargetHealthDescription) *TargetHealthResult {
+	result := &TargetHealthResult{
+		TargetGroupARN: targetGroupARN,
+		States:         make(map[string]int),
+		Targets:        make([]TargetHealth, 0, len(descriptions)),
+	}
+
+	for _, d := range descriptions {
+		target := newTargetHealth(d)
+		result.States[target.State]++
+		if target.Healthy {
+			result.HealthyCount++
+		} else {
+			result.UnhealthyCount++
+		}
+		result.Targets = append(result.Targets, target)
+	}
+
+	sort.SliceStable(result.Targets, func(i, j int) bool {
+		return !result.Targets[i].Healthy && result.Targets[j].Healthy
+	})
+	return result
+}
+
+// newTargetHealth converts the health of one target. Only the healthy state counts as
+// healthy; initial, draining, unused and unavailable targets do not receive traffic.
+func newTargetHealth(d types.TargetHealthDescription) TargetHealth {
+	target := TargetHealth{State: "unknown"}
+	if d.Target != nil {
+		target.TargetID = aws.ToString(d.Target.Id)
+		target.Port = aws.ToInt32(d.Target.Port)
+		target.AvailabilityZone = aws.ToString(d.Target.AvailabilityZone)
+	}
+	if d.TargetHealth != nil {
+		if d.TargetHealth.State != "" {
+			target.State = string(d.TargetHealth.State)
+		}
+		target.Reason = string(d.TargetHealth.Reason)
+		target.Description = aws.ToString(d.TargetHealth.Description)
+	}
+	target.Healthy = target.State == string(types.TargetHealthStateEnumHealthy)
+	return target
+}
@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTargetHealth(t *testing.T) {
+	healthy := newTargetHealth(types.TargetHealthDescription{
+		Target:       &types.TargetDescription{Id: aws.String("10.0.1.15"), Port: aws.Int32(8080), AvailabilityZone: aws.String("eu-west-1a")},
+		TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumHealthy},
+	})
+	assert.Equal(t, TargetHealth{TargetID: "10.0.1.15", Port: 8080, AvailabilityZone: "eu-west-1a", State: "healthy", Healthy: true}, healthy)
+
+	unhealthy := newTargetHealth(types.TargetHealthDescription{
+		Target: &types.TargetDescription{Id: aws.String("10.0.2.31"), Port: aws.Int32(8080)},
+		TargetHealth: &types.TargetHealth{
+			State:       types.TargetHealthStateEnumUnhealthy,
+			Reason:      types.TargetHealthReasonEnumResponseCodeMismatch,
+			Description: aws.String("Health checks failed with these codes: [502]"),
+		},
+	})
+	assert.False(t, unhealthy.Healthy)
+	assert.Equal(t, "unhealthy", unhealthy.State)
+	assert.Equal(t, "Target.ResponseCodeMismatch", unhealthy.Reason)
+	assert.Equal(t, "Health checks failed with these codes: [502]", unhealthy.Description)
+
+	// Targets still in their first health checks or draining do not get traffic
+	for _, state := range []types.TargetHealthStateEnum{types.TargetHealthStateEnumInitial, types.TargetHealthStateEnumDraining, types.TargetHealthStateEnumUnused} {
+		target := newTargetHealth(types.TargetHealthDescription{TargetHealth: &types.TargetHealth{State: state}})
+		assert.False(t, target.Healthy, string(state))
+		assert.Equal(t, string(state), target.State)
+	}
+
+	assert.Equal(t, "unknown", newTargetHealth(types.TargetHealthDescription{}).State)
+}
+
+func TestNewTargetHealthResult(t *testing.T) {
+	result := newTargetHealthResult("arn:aws:elasticloadbalancing:eu-west-1:123456789012:targetgroup/api/abc", []types.TargetHealthDescription{
+		{Target: &types.TargetDescription{Id: aws.String("a")}, TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumHealthy}},
+		{Target: &types.TargetDescription{Id: aws.String("b")}, TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumUnhealthy}},
+		{Target: &types.TargetDescription{Id: aws.String("c")}, TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumHealthy}},
+		{Target: &types.TargetDescription{Id: aws.String("d")}, TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumDraining}},
+	})
+
+	assert.Equal(t, 2, result.HealthyCount)
+	assert.Equal(t, 2, result.UnhealthyCount)
+	assert.Equal(t, map[string]int{"healthy": 2, "unhealthy": 1, "draining": 1}, result.States)
+
+	ids := make([]string, 0, len(result.Targets))
+	for _, target := range result.Targets {
+		ids = append(ids, target.TargetID)
+	}
+	// Unhealthy targets first, otherwise in API order
+	assert.Equal(t, []string{"b", "d", "a", "c"}, ids)
+}
+
+func TestAttachTargetGroups(t *testing.T) {
+	loadBalancers := []LoadBalancer{
+		newLoadBalancer(types.LoadBalancer{LoadBalancerArn: aws.String("lb-1"), LoadBalancerName: aws.String("public")}),
+		newLoadBalancer(types.LoadBalancer{LoadBalancerArn: aws.String("lb-2"), LoadBalancerName: aws.String("internal")}),
+	}
+
+	attachTargetGroups(loadBalancers, []types.TargetGroup{
+		{TargetGroupArn: aws.String("tg-api"), TargetGroupName: aws.String("api"), Protocol: types.ProtocolEnumHttp, Port: aws.Int32(8080), TargetType: types.TargetTypeEnumIp, LoadBalancerArns: []string{"lb-1", "lb-2"}},
+		{TargetGroupArn: aws.String("tg-orphan"), TargetGroupName: aws.String("orphan")},
+	})
+
+	assert.Equal(t, []TargetGroupRef{{ARN: "tg-api", Name: "api", Protocol: "HTTP", Port: 8080, TargetType: "ip"}}, loadBalancers[0].TargetGroups)
+	assert.Len(t, loadBalancers[1].TargetGroups, 1)
+}
@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrorCategory is the broad kind of an AWS error, telling a caller whether to retry,
+// fix permissions or change the request
+type ErrorCategory string
+
+const (
+	// ErrorCategoryAccessDenied means IAM denied the call; retrying will not help
+	ErrorCategoryAccessDenied ErrorCategory = "AccessDenied"
+	// ErrorCategoryThrottling means AWS rate limited the call; retry later
+	ErrorCategoryThrottling ErrorCategory = "Throttling"
+	// ErrorCategoryNotFound means the resource does not exist
+	ErrorCategoryNotFound ErrorCategory = "NotFound"
+	// ErrorCategoryTimeout means the call did not complete in time
+	ErrorCategoryTimeout ErrorCategory = "Timeout"
+	// ErrorCategoryOther is any other failure
+	ErrorCategoryOther ErrorCategory = "Other"
+)
+
+// ServiceError is an AWS call failure with its category. The error text is that of the
+// wrapped error, so wrapping does not change messages; use Category to tell a missing
+// permission from throttling.
+type ServiceError struct {
+	Category ErrorCategory
+	Code     string
+	Message  string
+	Err      error
+}
+
+func (e *ServiceError) Error() string { return e.Err.Error() }
+
+func (e *ServiceError) Unwrap() error { return e.Err }
+
+// ResourceNotFoundError reports that an AWS resource does not exist. Callers can
+// retry with a different name or identifier.
+type ResourceNotFoundError struct {
+	Resource string
+	Code     string
+	Err      error
+}
+
+func (e *ResourceNotFoundError) Error() string {
+	resource := e.Resource
+	if resource == "" {
+		resource = "resource"
+	}
+	return fmt.Sprintf("%s not found (%s)", resource, e.Code)
+}
+
+func (e *ResourceNotFoundError) Unwrap() error { return e.Err }
+
+// AccessDeniedError reports that IAM denied an operation. Retrying will not help
+// until the profile is granted Permission.
+type AccessDeniedError struct {
+	Operation  string
+	Permission string
+	Code       string
+	Err        error
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("access denied for operation %s (missing permission %s)", e.Operation, e.Permission)
+}
+
+func (e *AccessDeniedError) Unwrap() error { return e.Err }
+
+// deniedActionPattern extracts the IAM action from messages such as
+// "User: arn:... is not authorized to perform: ecs:DescribeServices on resource: ..."
+var deniedActionPattern = regexp.MustCompile(`perform: ([A-Za-z0-9-]+:[A-Za-z0-9*]+)`)
+
+// classifyAWSError distinguishes missing resources and IAM denials in an AWS API
+// error and wraps every API error and timeout in a ServiceError carrying its category.
+// operation is the IAM action of the call (e.g. "rds:DescribeDBInstances") and
+// resource a readable name of its target (e.g. "DB instance orders-db").
+// Other errors are returned unchanged.
+func classifyAWSError(err error, operation string, resource string) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		if ClassifyError(err) == ErrorCategoryTimeout {
+			return &ServiceError{Category: ErrorCategoryTimeout, Message: err.Error(), Err: err}
+		}
+		return err
+	}
+
+	code := apiErr.ErrorCode()
+	classified := err
+	switch {
+	case isNotFoundCode(code):
+		classified = &ResourceNotFoundError{Resource: resource, Code: code, Err: err}
+	case isAccessDeniedCode(code):
+		permission := operation
+		if match := deniedActionPattern.FindStringSubmatch(apiErr.ErrorMessage()); match != nil {
+			permission = match[1]
+		}
+		classified = &AccessDeniedError{Operation: operation, Permission: permission, Code: code, Err: err}
+	}
+
+	return &ServiceError{Category: ClassifyError(err), Code: code, Message: apiErr.ErrorMessage(), Err: classified}
+}
+
+// ClassifyError returns the category of an error returned by an AWS call, from the
+// smithy.APIError code it wraps. Timeouts are recognized whether AWS reported them or
+// the request deadline passed. A nil error has no category.
+func ClassifyError(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Category
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		switch {
+		case isAccessDeniedCode(code):
+			return ErrorCategoryAccessDenied
+		case isThrottlingCode(code):
+			return ErrorCategoryThrottling
+		case isNotFoundCode(code):
+			return ErrorCategoryNotFound
+		case isTimeoutCode(code):
+			return ErrorCategoryTimeout
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorCategoryTimeout
+	}
+	return ErrorCategoryOther
+}
+
+// isNotFoundCode reports whether an AWS error code means the resource does not exist,
+// e.g. DBInstanceNotFound, ResourceNotFoundException, InvalidInstanceID.NotFound or NoSuchBucket
+func isNotFoundCode(code string) bool {
+	return strings.Contains(code, "NotFound") || strings.HasPrefix(code, "NoSuch")
+}
+
+// isAccessDeniedCode reports whether an AWS error code is an authorization failure
+func isAccessDeniedCode(code string) bool {
+	switch code {
+	case "AccessDenied", "AccessDeniedException", "UnauthorizedOperation", "UnauthorizedException",
+		"AuthorizationError", "AuthorizationErrorException", "Forbidden":
+		return true
+	}
+	return false
+}
+
+// isThrottlingCode reports whether an AWS error code means the call was rate limited.
+// These are the codes the SDK's standard retryer treats as throttling.
+func isThrottlingCode(code string) bool {
+	switch code {
+	case "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottledException",
+		"TooManyRequestsException", "ProvisionedThroughputExceededException", "TransactionInProgressException",
+		"RequestLimitExceeded", "BandwidthLimitExceeded", "LimitExceededException", "RequestThrottled",
+		"SlowDown", "PriorRequestNotComplete", "EC2ThrottledException":
+		return true
+	}
+	return false
+}
+
+// isTimeoutCode reports whether an AWS error code means the request timed out
+func isTimeoutCode(code string) bool {
+	return code == "RequestTimeout" || code == "RequestTimeoutException"
+}
+
+// IsResourceNotFound reports whether err was classified as a missing resource
+func IsResourceNotFound(err error) bool {
+	var notFound *ResourceNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// IsAccessDenied reports whether err was classified as an IAM denial
+func IsAccessDenied(err error) bool {
+	var denied *AccessDeniedError
+	return errors.As(err, &denied)
+}
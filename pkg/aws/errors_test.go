@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyAWSErrorNotFound(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "DBInstanceNotFound", Message: "DBInstance orders-db not found."}
+
+	err := fmt.Errorf("failed to describe DB instance: %w", classifyAWSError(apiErr, "rds:DescribeDBInstances", "DB instance orders-db"))
+
+	assert.True(t, IsResourceNotFound(err))
+	assert.False(t, IsAccessDenied(err))
+	assert.Equal(t, "failed to describe DB instance: DB instance orders-db not found (DBInstanceNotFound)", err.Error())
+	assert.True(t, errors.Is(err, apiErr))
+}
+
+func TestClassifyAWSErrorAccessDenied(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "User: arn:aws:iam::123456789012:user/mcp is not authorized to perform: ecs:DescribeServices on resource: arn:aws:ecs:us-east-1:123456789012:service/api",
+	}
+
+	err := classifyAWSError(apiErr, "ecs:DescribeServices", "service api in cluster main")
+
+	assert.True(t, IsAccessDenied(err))
+	assert.Equal(t, "access denied for operation ecs:DescribeServices (missing permission ecs:DescribeServices)", err.Error())
+}
+
+func TestClassifyAWSErrorAccessDeniedWithoutAction(t *testing.T) {
+	// EC2 does not name the denied action in its message
+	apiErr := &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "You are not authorized to perform this operation."}
+
+	err := classifyAWSError(apiErr, "ec2:StopInstances", "instance i-0abc")
+
+	assert.Equal(t, "access denied for operation ec2:StopInstances (missing permission ec2:StopInstances)", err.Error())
+}
+
+func TestClassifyAWSErrorPassesThroughOtherErrors(t *testing.T) {
+	// Other API errors keep their message and are only tagged with a category
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	err := classifyAWSError(throttled, "logs:FilterLogEvents", "log group /app")
+	assert.Equal(t, throttled.Error(), err.Error())
+	assert.True(t, errors.Is(err, throttled))
+	assert.Equal(t, ErrorCategoryThrottling, ClassifyError(err))
+
+	plain := errors.New("connection reset")
+	assert.Equal(t, plain, classifyAWSError(plain, "logs:FilterLogEvents", "log group /app"))
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		category ErrorCategory
+	}{
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}, ErrorCategoryAccessDenied},
+		{"ec2 unauthorized", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, ErrorCategoryAccessDenied},
+		{"throttling", &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}, ErrorCategoryThrottling},
+		{"ec2 request limit", &smithy.GenericAPIError{Code: "RequestLimitExceeded"}, ErrorCategoryThrottling},
+		{"lambda too many requests", &smithy.GenericAPIError{Code: "TooManyRequestsException"}, ErrorCategoryThrottling},
+		{"not found", &smithy.GenericAPIError{Code: "ResourceNotFoundException"}, ErrorCategoryNotFound},
+		{"s3 no such bucket", &smithy.GenericAPIError{Code: "NoSuchBucket"}, ErrorCategoryNotFound},
+		{"request timeout", &smithy.GenericAPIError{Code: "RequestTimeout"}, ErrorCategoryTimeout},
+		{"deadline exceeded", fmt.Errorf("operation error Logs: FilterLogEvents: %w", context.DeadlineExceeded), ErrorCategoryTimeout},
+		{"other api error", &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "bad filter"}, ErrorCategoryOther},
+		{"plain error", errors.New("connection reset"), ErrorCategoryOther},
+		{"wrapped", fmt.Errorf("failed to list log groups: %w", &smithy.GenericAPIError{Code: "Throttling"}), ErrorCategoryThrottling},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.category, ClassifyError(tt.err))
+		})
+	}
+
+	assert.Equal(t, ErrorCategory(""), ClassifyError(nil))
+}
+
+func TestClassifyAWSErrorServiceError(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "DBInstanceNotFound", Message: "DBInstance orders-db not found."}
+
+	err := fmt.Errorf("failed to describe DB instance: %w", classifyAWSError(apiErr, "rds:DescribeDBInstances", "DB instance orders-db"))
+
+	var serviceErr *ServiceError
+	assert.True(t, errors.As(err, &serviceErr))
+	assert.Equal(t, ErrorCategoryNotFound, serviceErr.Category)
+	assert.Equal(t, "DBInstanceNotFound", serviceErr.Code)
+	assert.Equal(t, "DBInstance orders-db not found.", serviceErr.Message)
+	// The specific error types are still reachable
+	assert.True(t, IsResourceNotFound(err))
+
+	// Deadlines passing before AWS answers are timeouts too
+	timeout := classifyAWSError(fmt.Errorf("operation error RDS: DescribeDBInstances: %w", context.DeadlineExceeded), "rds:DescribeDBInstances", "")
+	assert.True(t, errors.As(timeout, &serviceErr))
+	assert.Equal(t, ErrorCategoryTimeout, serviceErr.Category)
+}
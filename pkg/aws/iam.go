@@ -0,0 +1,300 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// roleLastUsedConcurrency bounds the GetRole calls ListRoles makes to fill in last-used
+// dates, keeping well under IAM's request rate limit
+const roleLastUsedConcurrency = 5
+
+// IAMService provides read-only IAM operations
+type IAMService struct {
+	clientManager *ClientManager
+}
+
+// NewIAMService creates a new IAM service
+func NewIAMService(clientManager *ClientManager) *IAMService {
+	return &IAMService{
+		clientManager: clientManager,
+	}
+}
+
+// IAMRole represents an IAM role
+type IAMRole struct {
+	Name           string `json:"name"`
+	ARN            string `json:"arn"`
+	RoleID         string `json:"role_id"`
+	Path           string `json:"path"`
+	Description    string `json:"description,omitempty"`
+	CreateDate     string `json:"create_date,omitempty"`
+	LastUsedDate   string `json:"last_used_date,omitempty"`
+	LastUsedRegion string `json:"last_used_region,omitempty"`
+}
+
+// IAMAttachedPolicy is a managed policy attached to a role
+type IAMAttachedPolicy struct {
+	Name string `json:"name"`
+	ARN  string `json:"arn"`
+}
+
+// IAMRoleDetail is an IAM role with its trust policy and permissions
+type IAMRoleDetail struct {
+	IAMRole
+	MaxSessionDurationSeconds int32               `json:"max_session_duration_seconds,omitempty"`
+	AssumeRolePolicyDocument  string              `json:"assume_role_policy_document,omitempty"`
+	PermissionsBoundary       string              `json:"permissions_boundary,omitempty"`
+	AttachedPolicies          []IAMAttachedPolicy `json:"attached_policies"`
+	InlinePolicies            []string            `json:"inline_policies"`
+	Tags                      map[string]string   `json:"tags,omitempty"`
+}
+
+// IAMUser represents an IAM user
+type IAMUser struct {
+	Name             string `json:"name"`
+	ARN              string `json:"arn"`
+	UserID           string `json:"user_id"`
+	Path             string `json:"path"`
+	CreateDate       string `json:"create_date,omitempty"`
+	PasswordLastUsed string `json:"password_last_used,omitempty"`
+}
+
+// IAMRoleList is the IAM roles of an account. Roles whose last-used date could not be
+// looked up are named in LastUsedUnavailable, with the first lookup error in LastUsedError,
+// so a missing date is not mistaken for a role never used.
+type IAMRoleList struct {
+	Roles               []IAMRole `json:"roles"`
+	LastUsedUnavailable []string  `json:"last_used_unavailable,omitempty"`
+	LastUsedError       string    `json:"last_used_error,omitempty"`
+}
+
+// ListRoles lists the IAM roles of the account. IAM's ListRoles does not return when a
+// role was last used, so it is looked up with GetRole for each role; it stays empty for
+// roles never used, and roles whose lookup failed (e.g. iam:GetRole is not allowed) are
+// reported in the result.
+func (s *IAMService) ListRoles(ctx context.Context, profileID string) (*IAMRoleList, error) {
+	client, err := s.clientManager.GetIAMClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]IAMRole, 0)
+	paginator := iam.NewListRolesPaginator(client, &iam.ListRolesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", classifyAWSError(err, "iam:ListRoles", ""))
+		}
+		for _, r := range page.Roles {
+			roles = append(roles, newIAMRole(r))
+		}
+	}
+
+	list := &IAMRoleList{Roles: roles}
+	unavailable, lookupErr := fillRoleLastUsed(roles, func(name string) (*types.Role, error) {
+		result, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(name)})
+		if err != nil {
+			return nil, classifyAWSError(err, "iam:GetRole", "role "+name)
+		}
+		return result.Role, nil
+	})
+	if lookupErr != nil {
+		list.LastUsedUnavailable = unavailable
+		list.LastUsedError = lookupErr.Error()
+	}
+	return list, nil
+}
+
+// fillRoleLastUsed sets the last-used date and region of each role from getRole, making
+// at most roleLastUsedConcurrency calls at a time. It returns the names of the roles
+// whose lookup failed, in list order, and the first of their errors.
+func fillRoleLastUsed(roles []IAMRole, getRole func(name string) (*types.Role, error)) ([]string, error) {
+	// Each goroutine writes only its own elements, so no lock is needed
+	errs := make([]error, len(roles))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, roleLastUsedConcurrency)
+	for i := range roles {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			role, err := getRole(roles[i].Name)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if role == nil {
+				return
+			}
+			lastUsed := newIAMRole(*role)
+			roles[i].LastUsedDate = lastUsed.LastUsedDate
+			roles[i].LastUsedRegion = lastUsed.LastUsedRegion
+		}(i)
+	}
+	wg.Wait()
+
+	var unavailable []string
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		unavailable = append(unavailable, roles[i].Name)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return unavailable, firstErr
+}
+
+// GetRole gets an IAM role with its trust policy, attached managed policies and the
+// names of its inline policies
+func (s *IAMService) GetRole(ctx context.Context, profileID string, roleName string) (*IAMRoleDetail, error) {
+	client, err := s.clientManager.GetIAMClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", classifyAWSError(err, "iam:GetRole", "role "+roleName))
+	}
+	if result.Role == nil {
+		return nil, fmt.Errorf("role %s not found", roleName)
+	}
+	detail := newIAMRoleDetail(*result.Role)
+
+	attached := iam.NewListAttachedRolePoliciesPaginator(client, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)})
+	for attached.HasMorePages() {
+		page, err := attached.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attached role policies: %w", classifyAWSError(err, "iam:ListAttachedRolePolicies", "role "+roleName))
+		}
+		for _, p := range page.AttachedPolicies {
+			detail.AttachedPolicies = append(detail.AttachedPolicies, IAMAttachedPolicy{
+				Name: aws.ToString(p.PolicyName),
+				ARN:  aws.ToString(p.PolicyArn),
+			})
+		}
+	}
+
+	inline := iam.NewListRolePoliciesPaginator(client, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	for inline.HasMorePages() {
+		page, err := inline.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list inline role policies: %w", classifyAWSError(err, "iam:ListRolePolicies", "role "+roleName))
+		}
+		detail.InlinePolicies = append(detail.InlinePolicies, page.PolicyNames...)
+	}
+
+	return &detail, nil
+}
+
+// ListUsers lists the IAM users of the account
+func (s *IAMService) ListUsers(ctx context.Context, profileID string) ([]IAMUser, error) {
+	client, err := s.clientManager.GetIAMClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]IAMUser, 0)
+	paginator := iam.NewListUsersPaginator(client, &iam.ListUsersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", classifyAWSError(err, "iam:ListUsers", ""))
+		}
+		for _, u := range page.Users {
+			users = append(users, newIAMUser(u))
+		}
+	}
+
+	return users, nil
+}
+
+// newIAMRole converts an IAM role returned by the API
+func newIAMRole(r types.Role) IAMRole {
+	role := IAMRole{
+		Name:        aws.ToString(r.RoleName),
+		ARN:         aws.ToString(r.Arn),
+		RoleID:      aws.ToString(r.RoleId),
+		Path:        aws.ToString(r.Path),
+		Description: aws.ToString(r.Description),
+	}
+	if r.CreateDate != nil {
+		role.CreateDate = r.CreateDate.Format(time.RFC3339)
+	}
+	if r.RoleLastUsed != nil {
+		if r.RoleLastUsed.LastUsedDate != nil {
+			role.LastUsedDate = r.RoleLastUsed.LastUsedDate.Format(time.RFC3339)
+		}
+		role.LastUsedRegion = aws.ToString(r.RoleLastUsed.Region)
+	}
+	return role
+}
+
+// newIAMRoleDetail converts an IAM role returned by GetRole; policies are filled in by
+// the caller
+func newIAMRoleDetail(r types.Role) IAMRoleDetail {
+	detail := IAMRoleDetail{
+		IAMRole:                   newIAMRole(r),
+		MaxSessionDurationSeconds: aws.ToInt32(r.MaxSessionDuration),
+		AssumeRolePolicyDocument:  decodePolicyDocument(aws.ToString(r.AssumeRolePolicyDocument)),
+		AttachedPolicies:          make([]IAMAttachedPolicy, 0),
+		InlinePolicies:            make([]string, 0),
+		Tags:                      iamTags(r.Tags),
+	}
+	if r.PermissionsBoundary != nil {
+		detail.PermissionsBoundary = aws.ToString(r.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	return detail
+}
+
+// newIAMUser converts an IAM user returned by the API
+func newIAMUser(u types.User) IAMUser {
+	user := IAMUser{
+		Name:   aws.ToString(u.UserName),
+		ARN:    aws.ToString(u.Arn),
+		UserID: aws.ToString(u.UserId),
+		Path:   aws.ToString(u.Path),
+	}
+	if u.CreateDate != nil {
+		user.CreateDate = u.CreateDate.Format(time.RFC3339)
+	}
+	if u.PasswordLastUsed != nil {
+		user.PasswordLastUsed = u.PasswordLastUsed.Format(time.RFC3339)
+	}
+	return user
+}
+
+// decodePolicyDocument returns a policy document as JSON. IAM returns policy documents
+// URL-encoded; a document that cannot be decoded is returned as is.
+func decodePolicyDocument(document string) string {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return document
+	}
+	return decoded
+}
+
+// iamTags converts IAM tags to a map, nil when there are none
+func iamTags(tags []types.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return result
+}
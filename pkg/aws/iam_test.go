@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIAMRole(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastUsed := time.Date(2025, 1, 9, 8, 30, 0, 0, time.UTC)
+
+	role := newIAMRole(types.Role{
+		RoleName:    aws.String("deploy"),
+		Arn:         aws.String("arn:aws:iam::123456789012:role/ci/deploy"),
+		RoleId:      aws.String("AROAEXAMPLE"),
+		Path:        aws.String("/ci/"),
+		Description: aws.String("CI deployments"),
+		CreateDate:  aws.Time(created),
+		RoleLastUsed: &types.RoleLastUsed{
+			LastUsedDate: aws.Time(lastUsed),
+			Region:       aws.String("eu-west-1"),
+		},
+	})
+
+	assert.Equal(t, IAMRole{
+		Name:           "deploy",
+		ARN:            "arn:aws:iam::123456789012:role/ci/deploy",
+		RoleID:         "AROAEXAMPLE",
+		Path:           "/ci/",
+		Description:    "CI deployments",
+		CreateDate:     "2024-03-01T12:00:00Z",
+		LastUsedDate:   "2025-01-09T08:30:00Z",
+		LastUsedRegion: "eu-west-1",
+	}, role)
+
+	// Never used: ListRoles and GetRole return no last-used date
+	unused := newIAMRole(types.Role{RoleName: aws.String("spare"), RoleLastUsed: &types.RoleLastUsed{}})
+	assert.Empty(t, unused.LastUsedDate)
+	assert.Empty(t, unused.LastUsedRegion)
+}
+
+func TestNewIAMRoleDetail(t *testing.T) {
+	detail := newIAMRoleDetail(types.Role{
+		RoleName:                 aws.String("deploy"),
+		MaxSessionDuration:       aws.Int32(3600),
+		AssumeRolePolicyDocument: aws.String("%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%5B%5D%7D"),
+		PermissionsBoundary:      &types.AttachedPermissionsBoundary{PermissionsBoundaryArn: aws.String("arn:aws:iam::123456789012:policy/boundary")},
+		Tags:                     []types.Tag{{Key: aws.String("team"), Value: aws.String("platform")}},
+	})
+
+	assert.Equal(t, "deploy", detail.Name)
+	assert.Equal(t, int32(3600), detail.MaxSessionDurationSeconds)
+	assert.Equal(t, `{"Version":"2012-10-17","Statement":[]}`, detail.AssumeRolePolicyDocument)
+	assert.Equal(t, "arn:aws:iam::123456789012:policy/boundary", detail.PermissionsBoundary)
+	assert.Equal(t, map[string]string{"team": "platform"}, detail.Tags)
+	assert.Empty(t, detail.AttachedPolicies)
+	assert.Empty(t, detail.InlinePolicies)
+}
+
+func TestNewIAMUser(t *testing.T) {
+	user := newIAMUser(types.User{
+		UserName:   aws.String("alice"),
+		Arn:        aws.String("arn:aws:iam::123456789012:user/alice"),
+		UserId:     aws.String("AIDAEXAMPLE"),
+		Path:       aws.String("/"),
+		CreateDate: aws.Time(time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)),
+	})
+
+	assert.Equal(t, "alice", user.Name)
+	assert.Equal(t, "2023-05-02T00:00:00Z", user.CreateDate)
+	// Users without a console password have never used one
+	assert.Empty(t, user.PasswordLastUsed)
+}
+
+func TestFillRoleLastUsedReportsFailures(t *testing.T) {
+	lastUsed := time.Date(2025, 1, 9, 8, 30, 0, 0, time.UTC)
+	roles := []IAMRole{{Name: "deploy"}, {Name: "locked"}, {Name: "spare"}, {Name: "audit"}}
+
+	denied := errors.New("access denied")
+	unavailable, err := fillRoleLastUsed(roles, func(name string) (*types.Role, error) {
+		switch name {
+		case "deploy":
+			return &types.Role{RoleName: aws.String(name), RoleLastUsed: &types.RoleLastUsed{LastUsedDate: aws.Time(lastUsed), Region: aws.String("eu-west-1")}}, nil
+		case "spare":
+			return &types.Role{RoleName: aws.String(name)}, nil
+		default:
+			return nil, denied
+		}
+	})
+
+	assert.Equal(t, denied, err)
+	assert.Equal(t, []string{"locked", "audit"}, unavailable)
+	assert.Equal(t, "2025-01-09T08:30:00Z", roles[0].LastUsedDate)
+	assert.Equal(t, "eu-west-1", roles[0].LastUsedRegion)
+	assert.Empty(t, roles[1].LastUsedDate)
+	assert.Empty(t, roles[2].LastUsedDate)
+
+	unavailable, err = fillRoleLastUsed(roles[:1], func(name string) (*types.Role, error) {
+		return &types.Role{RoleName: aws.String(name)}, nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, unavailable)
+}
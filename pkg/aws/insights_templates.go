@@ -0,0 +1,222 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kinds of Insights template parameter; each is validated before it is substituted so a
+// value cannot add query commands
+const (
+	templateParamField    = "field"    // a log field such as status or @duration
+	templateParamNumber   = "number"   // a positive integer
+	templateParamDuration = "duration" // a bin() period such as 5m or 1h
+	templateParamPattern  = "pattern"  // a /regex/ or literal text to match
+)
+
+var (
+	insightsFieldPattern    = regexp.MustCompile(`^@?[A-Za-z_][A-Za-z0-9_.\-]*$`)
+	insightsDurationPattern = regexp.MustCompile(`^[1-9][0-9]*(ms|s|m|h|d|w)$`)
+)
+
+// InsightsTemplateParam describes one parameter of an Insights query template
+type InsightsTemplateParam struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
+
+// InsightsTemplate is a built-in Logs Insights query selectable by name. Parameters
+// appear in the query as {{name}}.
+type InsightsTemplate struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Parameters  []InsightsTemplateParam `json:"parameters,omitempty"`
+	Query       string                  `json:"query"`
+}
+
+// insightsTemplates are the built-in templates, keyed by name
+var insightsTemplates = map[string]InsightsTemplate{
+	"top_errors": {
+		Name:        "top_errors",
+		Description: "Most frequent error messages, grouped by message text",
+		Parameters: []InsightsTemplateParam{
+			{Name: "error_pattern", Kind: templateParamPattern, Default: `/(?i)(error|exception|fatal)/`, Description: "Regex (/.../) or literal text identifying error lines"},
+			{Name: "limit", Kind: templateParamNumber, Default: "20", Description: "Number of messages to return"},
+		},
+		Query: `fields @message
+| filter @message like {{error_pattern}}
+| stats count(*) as occurrences, latest(@timestamp) as last_seen by @message
+| sort occurrences desc
+| limit {{limit}}`,
+	},
+	"latency_percentiles": {
+		Name:        "latency_percentiles",
+		Description: "Request count, average, p50/p90/p95/p99 and max latency per time bin",
+		Parameters: []InsightsTemplateParam{
+			{Name: "latency_field", Kind: templateParamField, Default: "@duration", Description: "Numeric field holding the latency (@duration for Lambda)"},
+			{Name: "bin", Kind: templateParamDuration, Default: "1h", Description: "Time bin such as 5m or 1h"},
+		},
+		Query: `filter ispresent({{latency_field}})
+| stats count(*) as requests,
+        avg({{latency_field}}) as avg_latency,
+        pct({{latency_field}}, 50) as p50,
+        pct({{latency_field}}, 90) as p90,
+        pct({{latency_field}}, 95) as p95,
+        pct({{latency_field}}, 99) as p99,
+        max({{latency_field}}) as max_latency
+  by bin({{bin}}) as period
+| sort period asc`,
+	},
+	"request_count_by_status": {
+		Name:        "request_count_by_status",
+		Description: "Request count per status code",
+		Parameters: []InsightsTemplateParam{
+			{Name: "status_field", Kind: templateParamField, Default: "status", Description: "Field holding the status code (e.g. status, elb_status_code)"},
+		},
+		Query: `filter ispresent({{status_field}})
+| stats count(*) as requests by {{status_field}}
+| sort requests desc`,
+	},
+	"slowest_requests": {
+		Name:        "slowest_requests",
+		Description: "Individual requests with the highest latency",
+		Parameters: []InsightsTemplateParam{
+			{Name: "latency_field", Kind: templateParamField, Default: "@duration", Description: "Numeric field holding the latency (@duration for Lambda)"},
+			{Name: "limit", Kind: templateParamNumber, Default: "20", Description: "Number of requests to return"},
+		},
+		Query: `fields @timestamp, @requestId, @logStream, {{latency_field}}, @message
+| filter ispresent({{latency_field}})
+| sort {{latency_field}} desc
+| limit {{limit}}`,
+	},
+}
+
+// InsightsTemplates returns the built-in Insights query templates sorted by name
+func InsightsTemplates() []InsightsTemplate {
+	templates := make([]InsightsTemplate, 0, len(insightsTemplates))
+	for _, template := range insightsTemplates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// InsightsTemplateNames returns the names of the built-in templates, sorted
+func InsightsTemplateNames() []string {
+	names := make([]string, 0, len(insightsTemplates))
+	for name := range insightsTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderInsightsTemplate fills in a template's parameters, using the defaults for any
+// that are empty or missing, and returns the Insights query
+func RenderInsightsTemplate(name string, params map[string]string) (string, error) {
+	template, ok := insightsTemplates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown insights template %q (available: %s)", name, strings.Join(InsightsTemplateNames(), ", "))
+	}
+
+	query := template.Query
+	for _, param := range template.Parameters {
+		value := strings.TrimSpace(params[param.Name])
+		if value == "" {
+			value = param.Default
+		}
+		rendered, err := renderTemplateParam(param, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s for template %s: %w", param.Name, name, err)
+		}
+		query = strings.ReplaceAll(query, "{{"+param.Name+"}}", rendered)
+	}
+
+	return query, nil
+}
+
+// renderTemplateParam validates a parameter value and formats it for the query
+func renderTemplateParam(param InsightsTemplateParam, value string) (string, error) {
+	switch param.Kind {
+	case templateParamField:
+		if !insightsFieldPattern.MatchString(value) {
+			return "", fmt.Errorf("%q is not a field name", value)
+		}
+		return value, nil
+	case templateParamNumber:
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 || n > 10000 {
+			return "", fmt.Errorf("%q is not a number between 1 and 10000", value)
+		}
+		return strconv.Itoa(n), nil
+	case templateParamDuration:
+		if !insightsDurationPattern.MatchString(value) {
+			return "", fmt.Errorf("%q is not a period such as 5m or 1h", value)
+		}
+		return value, nil
+	case templateParamPattern:
+		return insightsPatternLiteral(value)
+	default:
+		return "", fmt.Errorf("unsupported parameter kind %s", param.Kind)
+	}
+}
+
+// insightsPatternLiteral returns a /regex/ unchanged after checking that it is a single
+// regex literal, and quotes anything else as a literal string match
+func insightsPatternLiteral(value string) (string, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("pattern must be a single line")
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		inner := value[1 : len(value)-1]
+		if inner == "" {
+			return "", fmt.Errorf("empty regex")
+		}
+		for i := 0; i < len(inner); i++ {
+			if inner[i] == '\\' {
+				if i == len(inner)-1 {
+					return "", fmt.Errorf("regex %s ends with an escape", value)
+				}
+				i++
+				continue
+			}
+			if inner[i] == '/' {
+				return "", fmt.Errorf("unescaped / inside regex %s", value)
+			}
+		}
+		return value, nil
+	}
+	return strconv.Quote(value), nil
+}
+
+// InsightsTemplateResult is the result of running an Insights query template
+type InsightsTemplateResult struct {
+	Template string `json:"template"`
+	Query    string `json:"query"`
+	*InsightsQueryResult
+}
+
+// RunInsightsTemplate renders a built-in template and runs it as an Insights query
+func (cw *CloudWatchService) RunInsightsTemplate(ctx context.Context, profileID string, logGroupNames []string, templateName string, params map[string]string, startTime int64, endTime int64, limit int32) (*InsightsTemplateResult, error) {
+	query, err := RenderInsightsTemplate(templateName, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cw.RunInsightsQuery(ctx, profileID, logGroupNames, query, startTime, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InsightsTemplateResult{
+		Template:            templateName,
+		Query:               query,
+		InsightsQueryResult: result,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderInsightsTemplateDefaults(t *testing.T) {
+	for _, template := range InsightsTemplates() {
+		query, err := RenderInsightsTemplate(template.Name, nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, query, "{{", template.Name)
+	}
+
+	query, err := RenderInsightsTemplate("top_errors", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, query, "filter @message like /(?i)(error|exception|fatal)/")
+	assert.Contains(t, query, "| limit 20")
+}
+
+func TestRenderInsightsTemplateParams(t *testing.T) {
+	query, err := RenderInsightsTemplate("latency_percentiles", map[string]string{"latency_field": "response_time", "bin": "5m"})
+	assert.NoError(t, err)
+	assert.Contains(t, query, "pct(response_time, 99) as p99")
+	assert.Contains(t, query, "by bin(5m) as period")
+
+	// Literal text is quoted rather than spliced into the query
+	query, err = RenderInsightsTemplate("top_errors", map[string]string{"error_pattern": `Timeout "upstream"`, "limit": "5"})
+	assert.NoError(t, err)
+	assert.Contains(t, query, `like "Timeout \"upstream\""`)
+	assert.Contains(t, query, "| limit 5")
+
+	query, err = RenderInsightsTemplate("request_count_by_status", map[string]string{"status_field": "elb_status_code"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(query, "elb_status_code"))
+}
+
+func TestRenderInsightsTemplateRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		template string
+		params   map[string]string
+	}{
+		{"slowest_requests", map[string]string{"latency_field": "@duration | limit 1"}},
+		{"slowest_requests", map[string]string{"limit": "0"}},
+		{"slowest_requests", map[string]string{"limit": "ten"}},
+		{"latency_percentiles", map[string]string{"bin": "1h) | display @message"}},
+		{"top_errors", map[string]string{"error_pattern": "/a/ | stats count(*) by /b/"}},
+		{"top_errors", map[string]string{"error_pattern": `/trailing\/`}},
+		{"top_errors", map[string]string{"error_pattern": "line\n| limit 1"}},
+	}
+	for _, tt := range tests {
+		_, err := RenderInsightsTemplate(tt.template, tt.params)
+		assert.Error(t, err, "%s %v", tt.template, tt.params)
+	}
+
+	_, err := RenderInsightsTemplate("unknown", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "top_errors")
+}
@@ -3,9 +3,12 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
 // LambdaService provides Lambda operations
@@ -23,7 +26,7 @@ func NewLambdaService(clientManager *ClientManager) *LambdaService {
 // Function represents a Lambda function
 type Function struct {
 	FunctionName string
-	FunctionARN  string
+	FunctionARN  string `json:"arn"`
 	Runtime      string
 	Handler      string
 	CodeSize     int64
@@ -33,6 +36,11 @@ type Function struct {
 	LastModified string
 	Role         string
 	Environment  map[string]string
+	// State, StateReason and LastUpdateStatus are not returned by ListFunctions;
+	// LoadFunctionStates fills them in
+	State            string
+	StateReason      string
+	LastUpdateStatus string
 }
 
 // ListFunctions lists all Lambda functions
@@ -42,13 +50,19 @@ func (l *LambdaService) ListFunctions(ctx context.Context, profileID string) ([]
 		return nil, err
 	}
 
-	result, err := client.ListFunctions(ctx, &lambda.ListFunctionsInput{})
+	configurations, err := collectAll(ctx, func(marker *string) ([]types.FunctionConfiguration, *string, error) {
+		result, err := client.ListFunctions(ctx, &lambda.ListFunctionsInput{Marker: marker})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list functions: %w", classifyAWSError(err, "lambda:ListFunctions", ""))
+		}
+		return result.Functions, result.NextMarker, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list functions: %w", err)
+		return nil, err
 	}
 
-	functions := make([]Function, 0, len(result.Functions))
-	for _, fn := range result.Functions {
+	functions := make([]Function, 0, len(configurations))
+	for _, fn := range configurations {
 		function := Function{
 			FunctionName: aws.ToString(fn.FunctionName),
 			FunctionARN:  aws.ToString(fn.FunctionArn),
@@ -60,11 +74,12 @@ func (l *LambdaService) ListFunctions(ctx context.Context, profileID string) ([]
 			MemorySize:   aws.ToInt32(fn.MemorySize),
 			LastModified: aws.ToString(fn.LastModified),
 			Role:         aws.ToString(fn.Role),
+			State:        string(fn.State),
 		}
 
 		// Add environment variables
 		if fn.Environment != nil && fn.Environment.Variables != nil {
-			function.Environment = fn.Environment.Variables
+			function.Environment = l.environment(profileID, fn.Environment.Variables)
 		}
 
 		functions = append(functions, function)
@@ -84,30 +99,86 @@ func (l *LambdaService) GetFunction(ctx context.Context, profileID string, funct
 		FunctionName: aws.String(functionName),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get function: %w", err)
+		return nil, fmt.Errorf("failed to get function: %w", classifyAWSError(err, "lambda:GetFunction", "function "+functionName))
 	}
 
 	fn := result.Configuration
 	function := &Function{
-		FunctionName: aws.ToString(fn.FunctionName),
-		FunctionARN:  aws.ToString(fn.FunctionArn),
-		Runtime:      string(fn.Runtime),
-		Handler:      aws.ToString(fn.Handler),
-		CodeSize:     fn.CodeSize,
-		Description:  aws.ToString(fn.Description),
-		Timeout:      aws.ToInt32(fn.Timeout),
-		MemorySize:   aws.ToInt32(fn.MemorySize),
-		LastModified: aws.ToString(fn.LastModified),
-		Role:         aws.ToString(fn.Role),
+		FunctionName:     aws.ToString(fn.FunctionName),
+		FunctionARN:      aws.ToString(fn.FunctionArn),
+		Runtime:          string(fn.Runtime),
+		Handler:          aws.ToString(fn.Handler),
+		CodeSize:         fn.CodeSize,
+		Description:      aws.ToString(fn.Description),
+		Timeout:          aws.ToInt32(fn.Timeout),
+		MemorySize:       aws.ToInt32(fn.MemorySize),
+		LastModified:     aws.ToString(fn.LastModified),
+		Role:             aws.ToString(fn.Role),
+		State:            string(fn.State),
+		StateReason:      aws.ToString(fn.StateReason),
+		LastUpdateStatus: string(fn.LastUpdateStatus),
 	}
 
 	if fn.Environment != nil && fn.Environment.Variables != nil {
-		function.Environment = fn.Environment.Variables
+		function.Environment = l.environment(profileID, fn.Environment.Variables)
 	}
 
 	return function, nil
 }
 
+// functionStateConcurrency bounds the GetFunctionConfiguration calls LoadFunctionStates
+// makes at once
+const functionStateConcurrency = 5
+
+// LoadFunctionStates fills in the State, StateReason and LastUpdateStatus of functions
+// from ListFunctions, which leaves them empty, with one GetFunctionConfiguration call per
+// function. The first error is returned once all calls have finished.
+func (l *LambdaService) LoadFunctionStates(ctx context.Context, profileID string, functions []Function) error {
+	client, err := l.clientManager.GetLambdaClient(profileID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	slots := make(chan struct{}, functionStateConcurrency)
+	for i := range functions {
+		if functions[i].State != "" {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(fn *Function) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+				FunctionName: aws.String(fn.FunctionName),
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get function state: %w", classifyAWSError(err, "lambda:GetFunctionConfiguration", "function "+fn.FunctionName))
+				}
+				mu.Unlock()
+				return
+			}
+
+			// Each goroutine writes only its own element
+			fn.State = string(result.State)
+			fn.StateReason = aws.ToString(result.StateReason)
+			fn.LastUpdateStatus = string(result.LastUpdateStatus)
+		}(&functions[i])
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
 // GetFunctionConfiguration gets the configuration of a Lambda function
 func (l *LambdaService) GetFunctionConfiguration(ctx context.Context, profileID string, functionName string) (map[string]interface{}, error) {
 	client, err := l.clientManager.GetLambdaClient(profileID)
@@ -119,11 +190,12 @@ func (l *LambdaService) GetFunctionConfiguration(ctx context.Context, profileID
 		FunctionName: aws.String(functionName),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get function configuration: %w", err)
+		return nil, fmt.Errorf("failed to get function configuration: %w", classifyAWSError(err, "lambda:GetFunctionConfiguration", "function "+functionName))
 	}
 
 	config := map[string]interface{}{
 		"functionName": aws.ToString(result.FunctionName),
+		"arn":          aws.ToString(result.FunctionArn),
 		"functionArn":  aws.ToString(result.FunctionArn),
 		"runtime":      string(result.Runtime),
 		"handler":      aws.ToString(result.Handler),
@@ -137,8 +209,47 @@ func (l *LambdaService) GetFunctionConfiguration(ctx context.Context, profileID
 	}
 
 	if result.Environment != nil && result.Environment.Variables != nil {
-		config["environment"] = result.Environment.Variables
+		config["environment"] = l.environment(profileID, result.Environment.Variables)
 	}
 
 	return config, nil
 }
+
+// redactedValue replaces the value of sensitive environment variables
+const redactedValue = "********"
+
+// sensitiveEnvKeyPatterns are substrings that mark an environment variable as secret
+var sensitiveEnvKeyPatterns = []string{"SECRET", "PASSWORD", "TOKEN", "KEY"}
+
+// environment returns the function's environment variables, masking sensitive
+// values unless the profile sets reveal_lambda_env
+func (l *LambdaService) environment(profileID string, vars map[string]string) map[string]string {
+	if profile, err := l.clientManager.config.GetProfile(profileID); err == nil && profile.RevealLambdaEnv {
+		return vars
+	}
+	return redactEnvironment(vars)
+}
+
+// redactEnvironment returns a copy of vars with the values of sensitive keys masked
+func redactEnvironment(vars map[string]string) map[string]string {
+	redacted := make(map[string]string, len(vars))
+	for key, value := range vars {
+		if isSensitiveEnvKey(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// isSensitiveEnvKey reports whether an environment variable name matches a sensitive pattern
+func isSensitiveEnvKey(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, pattern := range sensitiveEnvKeyPatterns {
+		if strings.Contains(upper, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// coldStartQuery counts cold starts from the REPORT line Lambda writes after every
+// invocation; only cold starts carry an Init Duration
+const coldStartQuery = `filter @type = "REPORT"
+| stats count(*) as invocations,
+        sum(strcontains(@message, "Init Duration")) as cold_starts,
+        avg(@initDuration) as avg_init_ms,
+        max(@initDuration) as max_init_ms`
+
+// ColdStartStats summarizes cold starts found in a function's REPORT log lines
+type ColdStartStats struct {
+	LogGroup        string  `json:"log_group"`
+	Invocations     int64   `json:"invocations"`
+	ColdStarts      int64   `json:"cold_starts"`
+	ColdStartRate   float64 `json:"cold_start_rate_percent"`
+	AvgInitDuration float64 `json:"avg_init_duration_ms"`
+	MaxInitDuration float64 `json:"max_init_duration_ms"`
+}
+
+// LambdaPerformance is a performance report for one Lambda function over a time range
+type LambdaPerformance struct {
+	FunctionName string                `json:"function_name"`
+	StartTime    time.Time             `json:"start_time"`
+	EndTime      time.Time             `json:"end_time"`
+	Metrics      *LambdaMetricsSummary `json:"metrics"`
+	ColdStarts   *ColdStartStats       `json:"cold_starts,omitempty"`
+	Warnings     []string              `json:"warnings,omitempty"`
+}
+
+// GetPerformance reports duration percentiles, error rate and throttles from CloudWatch
+// metrics, and cold-start frequency and init duration from a Logs Insights query on the
+// function's log group (/aws/lambda/<function> unless logGroup is set). A failed log
+// query is reported as a warning so the metrics are still returned.
+func (l *LambdaService) GetPerformance(ctx context.Context, profileID string, functionName string, logGroup string, startTime time.Time, endTime time.Time) (*LambdaPerformance, error) {
+	metrics, err := NewCloudWatchMetricsService(l.clientManager).GetLambdaMetrics(ctx, profileID, functionName, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LambdaPerformance{
+		FunctionName: functionName,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Metrics:      metrics,
+	}
+
+	if logGroup == "" {
+		logGroup = "/aws/lambda/" + functionName
+	}
+	insights, err := NewCloudWatchService(l.clientManager).RunInsightsQuery(ctx, profileID, []string{logGroup}, coldStartQuery, startTime.UnixMilli(), endTime.UnixMilli(), 1)
+	switch {
+	case err != nil:
+		report.Warnings = append(report.Warnings, fmt.Sprintf("cold starts unavailable: %v", err))
+	case insights.Status != "Complete":
+		report.Warnings = append(report.Warnings, fmt.Sprintf("cold starts unavailable: insights query ended with status %s", insights.Status))
+	default:
+		stats := &ColdStartStats{LogGroup: logGroup}
+		if len(insights.Results) > 0 {
+			stats = parseColdStartRow(logGroup, insights.Results[0])
+		}
+		report.ColdStarts = stats
+	}
+
+	return report, nil
+}
+
+// parseColdStartRow converts the single row returned by coldStartQuery
+func parseColdStartRow(logGroup string, row map[string]string) *ColdStartStats {
+	number := func(field string) float64 {
+		value, err := strconv.ParseFloat(row[field], 64)
+		if err != nil {
+			return 0
+		}
+		return value
+	}
+
+	stats := &ColdStartStats{
+		LogGroup:        logGroup,
+		Invocations:     int64(number("invocations")),
+		ColdStarts:      int64(number("cold_starts")),
+		AvgInitDuration: math.Round(number("avg_init_ms")*100) / 100,
+		MaxInitDuration: number("max_init_ms"),
+	}
+	if stats.Invocations > 0 {
+		stats.ColdStartRate = math.Round(float64(stats.ColdStarts)/float64(stats.Invocations)*10000) / 100
+	}
+	return stats
+}
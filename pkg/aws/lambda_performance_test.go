@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeLambdaMetrics(t *testing.T) {
+	now := time.Now()
+	points := func(values ...float64) []MetricDataPoint {
+		result := make([]MetricDataPoint, 0, len(values))
+		for i, v := range values {
+			result = append(result, MetricDataPoint{Timestamp: now.Add(time.Duration(i) * time.Hour), Value: v})
+		}
+		return result
+	}
+
+	summary := summarizeLambdaMetrics(&MetricDataResult{Series: map[string]*MetricDataSeries{
+		"invocations": {Points: points(600, 200)},
+		"errors":      {Points: points(6, 0)},
+		"throttles":   {Points: points(0, 3)},
+		"p50":         {Points: points(40, 55)},
+		"p95":         {Points: points(180, 120)},
+		"p99":         {Points: points(900, 300)},
+		"concurrency": {Points: points(12, 20)},
+	}})
+
+	assert.Equal(t, 800.0, summary.Invocations)
+	assert.Equal(t, 6.0, summary.Errors)
+	assert.Equal(t, 3.0, summary.Throttles)
+	assert.Equal(t, 0.75, summary.ErrorRatePercent)
+	assert.Equal(t, 55.0, summary.DurationP50Ms)
+	assert.Equal(t, 180.0, summary.DurationP95Ms)
+	assert.Equal(t, 900.0, summary.DurationP99Ms)
+	assert.Equal(t, 0.0, summary.DurationMaxMs)
+	assert.Equal(t, 20.0, summary.MaxConcurrency)
+}
+
+func TestSummaryPeriod(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(48 * time.Hour)
+
+	assert.Equal(t, int32(86400), summaryPeriod(start, start.Add(24*time.Hour), now))
+	assert.Equal(t, int32(120), summaryPeriod(start, start.Add(61*time.Second), now))
+	assert.Equal(t, int32(60), summaryPeriod(start, start, now))
+}
+
+func TestSummaryPeriodOlderThan15Days(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(61 * time.Second)
+
+	// Exactly 15 days ago still has 1-minute data
+	assert.Equal(t, int32(120), summaryPeriod(start, end, start.Add(15*24*time.Hour)))
+
+	// Beyond that the period rounds up to a multiple of 5 minutes
+	now := start.Add(15*24*time.Hour + time.Second)
+	assert.Equal(t, int32(300), summaryPeriod(start, end, now))
+	assert.Equal(t, int32(600), summaryPeriod(start, start.Add(301*time.Second), now))
+	assert.Equal(t, int32(86400), summaryPeriod(start, start.Add(24*time.Hour), now))
+}
+
+func TestSummaryPeriodOlderThan63Days(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(61 * time.Second)
+
+	// Exactly 63 days ago still has 5-minute data
+	assert.Equal(t, int32(300), summaryPeriod(start, end, start.Add(63*24*time.Hour)))
+
+	// Beyond that the period rounds up to a multiple of 1 hour
+	now := start.Add(63*24*time.Hour + time.Second)
+	assert.Equal(t, int32(3600), summaryPeriod(start, end, now))
+	assert.Equal(t, int32(7200), summaryPeriod(start, start.Add(61*time.Minute), now))
+	assert.Equal(t, int32(86400), summaryPeriod(start, start.Add(24*time.Hour), now))
+}
+
+func TestParseColdStartRow(t *testing.T) {
+	stats := parseColdStartRow("/aws/lambda/checkout", map[string]string{
+		"invocations": "400",
+		"cold_starts": "14",
+		"avg_init_ms": "412.3456",
+		"max_init_ms": "980.11",
+	})
+
+	assert.Equal(t, "/aws/lambda/checkout", stats.LogGroup)
+	assert.Equal(t, int64(400), stats.Invocations)
+	assert.Equal(t, int64(14), stats.ColdStarts)
+	assert.Equal(t, 3.5, stats.ColdStartRate)
+	assert.Equal(t, 412.35, stats.AvgInitDuration)
+	assert.Equal(t, 980.11, stats.MaxInitDuration)
+
+	// No cold starts in range: init fields come back empty
+	stats = parseColdStartRow("/aws/lambda/checkout", map[string]string{"invocations": "50", "cold_starts": "0"})
+	assert.Equal(t, int64(0), stats.ColdStarts)
+	assert.Equal(t, 0.0, stats.ColdStartRate)
+	assert.Equal(t, 0.0, stats.AvgInitDuration)
+}
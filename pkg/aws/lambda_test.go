@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactEnvironment(t *testing.T) {
+	vars := map[string]string{
+		"DB_PASSWORD":       "hunter2",
+		"stripe_secret":     "sk_live_123",
+		"GITHUB_TOKEN":      "ghp_abc",
+		"API_KEY":           "abc123",
+		"LOG_LEVEL":         "debug",
+		"AWS_REGION_TARGET": "us-east-1",
+	}
+
+	redacted := redactEnvironment(vars)
+
+	assert.Equal(t, map[string]string{
+		"DB_PASSWORD":       redactedValue,
+		"stripe_secret":     redactedValue,
+		"GITHUB_TOKEN":      redactedValue,
+		"API_KEY":           redactedValue,
+		"LOG_LEVEL":         "debug",
+		"AWS_REGION_TARGET": "us-east-1",
+	}, redacted)
+
+	// The original map must not be modified
+	assert.Equal(t, "hunter2", vars["DB_PASSWORD"])
+}
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/smithy-go"
+)
+
+// OrganizationsService provides AWS Organizations operations
+type OrganizationsService struct {
+	clientManager *ClientManager
+}
+
+// NewOrganizationsService creates a new Organizations service
+func NewOrganizationsService(clientManager *ClientManager) *OrganizationsService {
+	return &OrganizationsService{
+		clientManager: clientManager,
+	}
+}
+
+// OrganizationAccount represents a member account of an AWS organization
+type OrganizationAccount struct {
+	ID           string
+	Name         string
+	Email        string
+	ARN          string `json:"arn"`
+	Status       string
+	JoinedMethod string
+	JoinedDate   string
+}
+
+// ListAccounts lists the accounts of the organization the profile belongs to. Only the
+// management account (or a delegated administrator) is allowed to call it.
+func (s *OrganizationsService) ListAccounts(ctx context.Context, profileID string) ([]OrganizationAccount, error) {
+	client, err := s.clientManager.GetOrganizationsClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]OrganizationAccount, 0)
+	paginator := organizations.NewListAccountsPaginator(client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, organizationsError(err, profileID)
+		}
+
+		for _, acc := range page.Accounts {
+			account := OrganizationAccount{
+				ID:           aws.ToString(acc.Id),
+				Name:         aws.ToString(acc.Name),
+				Email:        aws.ToString(acc.Email),
+				ARN:          aws.ToString(acc.Arn),
+				Status:       string(acc.Status),
+				JoinedMethod: string(acc.JoinedMethod),
+			}
+			if acc.JoinedTimestamp != nil {
+				account.JoinedDate = acc.JoinedTimestamp.String()
+			}
+			accounts = append(accounts, account)
+		}
+	}
+
+	return accounts, nil
+}
+
+// organizationsError explains the two usual reasons ListAccounts fails: the profile's
+// account is not part of an organization, or it is a member account (or lacks
+// organizations:ListAccounts) rather than the management account
+func organizationsError(err error, profileID string) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "AWSOrganizationsNotInUseException" {
+		return fmt.Errorf("failed to list accounts: the account of profile %s is not a member of an AWS organization: %w", profileID, err)
+	}
+
+	err = classifyAWSError(err, "organizations:ListAccounts", "")
+	if IsAccessDenied(err) {
+		return fmt.Errorf("failed to list accounts: %w; accounts can only be listed from the organization's management account or a delegated administrator", err)
+	}
+	return fmt.Errorf("failed to list accounts: %w", err)
+}
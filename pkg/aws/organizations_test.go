@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationsErrorNotInUse(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "AWSOrganizationsNotInUseException", Message: "Your account is not a member of an organization."}
+
+	err := organizationsError(apiErr, "dev")
+
+	assert.Contains(t, err.Error(), "the account of profile dev is not a member of an AWS organization")
+	assert.True(t, errors.Is(err, apiErr))
+}
+
+func TestOrganizationsErrorMemberAccount(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "You don't have permissions to access this resource.",
+	}
+
+	err := organizationsError(apiErr, "dev")
+
+	assert.True(t, IsAccessDenied(err))
+	assert.Equal(t, "failed to list accounts: access denied for operation organizations:ListAccounts (missing permission organizations:ListAccounts); accounts can only be listed from the organization's management account or a delegated administrator", err.Error())
+}
+
+func TestOrganizationsErrorPassesThroughOtherErrors(t *testing.T) {
+	throttled := &smithy.GenericAPIError{Code: "TooManyRequestsException", Message: "Rate exceeded"}
+
+	err := organizationsError(throttled, "dev")
+
+	assert.False(t, IsAccessDenied(err))
+	assert.True(t, errors.Is(err, throttled))
+}
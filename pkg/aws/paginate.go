@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// collectAll calls fetch once per page, passing the token returned by the previous call
+// (nil for the first page), until fetch returns no next token. It returns the items of
+// every page in order. An empty token is treated like nil, since some APIs return ""
+// on the last page.
+func collectAll[T any](ctx context.Context, fetch func(token *string) (items []T, next *string, err error)) ([]T, error) {
+	all := make([]T, 0)
+	var token *string
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		items, next, err := fetch(token)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if aws.ToString(next) == "" {
+			return all, nil
+		}
+		token = next
+	}
+}
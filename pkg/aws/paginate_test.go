@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectAllFollowsTokens(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  *string
+	}{
+		"":      {items: []int{1, 2}, next: aws.String("page2")},
+		"page2": {items: []int{3, 4}, next: aws.String("page3")},
+		"page3": {items: []int{5}, next: nil},
+	}
+
+	var tokens []string
+	items, err := collectAll(context.Background(), func(token *string) ([]int, *string, error) {
+		tokens = append(tokens, aws.ToString(token))
+		page := pages[aws.ToString(token)]
+		return page.items, page.next, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []string{"", "page2", "page3"}, tokens)
+}
+
+func TestCollectAllStopsOnEmptyToken(t *testing.T) {
+	calls := 0
+	items, err := collectAll(context.Background(), func(token *string) ([]string, *string, error) {
+		calls++
+		return []string{"only"}, aws.String(""), nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"only"}, items)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCollectAllReturnsFetchError(t *testing.T) {
+	fetchErr := errors.New("throttled")
+	items, err := collectAll(context.Background(), func(token *string) ([]int, *string, error) {
+		if token == nil {
+			return []int{1}, aws.String("page2"), nil
+		}
+		return nil, nil, fetchErr
+	})
+
+	assert.ErrorIs(t, err, fetchErr)
+	assert.Nil(t, items)
+}
+
+func TestCollectAllStopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := collectAll(ctx, func(token *string) ([]int, *string, error) {
+		calls++
+		cancel()
+		return []int{calls}, aws.String("more"), nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,32 @@
+package aws
+
+// The Unhealthy methods back the only_problems filter of the list tools: each reports
+// whether a resource is in a state that deserves a look during triage.
+
+// Unhealthy reports whether an RDS instance is in any status other than available,
+// including stopped, modifying, storage-full and failed
+func (i DBInstance) Unhealthy() bool {
+	return i.Status != "available"
+}
+
+// Unhealthy reports whether an ECS service is not running the number of tasks it wants
+func (s Service) Unhealthy() bool {
+	return s.RunningCount != s.DesiredCount
+}
+
+// Unhealthy reports whether an EC2 instance is not running. Terminated and
+// shutting-down instances are on their way out and are not reported.
+func (i Instance) Unhealthy() bool {
+	switch i.State {
+	case "running", "terminated", "shutting-down":
+		return false
+	default:
+		return true
+	}
+}
+
+// Unhealthy reports whether a Lambda function is in a state other than Active or its
+// last update failed. Functions whose state has not been loaded are not reported.
+func (f Function) Unhealthy() bool {
+	return (f.State != "" && f.State != "Active") || f.LastUpdateStatus == "Failed"
+}
@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBInstanceUnhealthy(t *testing.T) {
+	assert.False(t, DBInstance{Status: "available"}.Unhealthy())
+	assert.True(t, DBInstance{Status: "stopped"}.Unhealthy())
+	assert.True(t, DBInstance{Status: "storage-full"}.Unhealthy())
+}
+
+func TestServiceUnhealthy(t *testing.T) {
+	assert.False(t, Service{DesiredCount: 3, RunningCount: 3}.Unhealthy())
+	assert.False(t, Service{DesiredCount: 0, RunningCount: 0}.Unhealthy())
+	assert.True(t, Service{DesiredCount: 3, RunningCount: 1, PendingCount: 2}.Unhealthy())
+	assert.True(t, Service{DesiredCount: 0, RunningCount: 1}.Unhealthy())
+}
+
+func TestInstanceUnhealthy(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"running", false},
+		{"terminated", false},
+		{"shutting-down", false},
+		{"stopped", true},
+		{"stopping", true},
+		{"pending", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			assert.Equal(t, tt.want, Instance{State: tt.state}.Unhealthy())
+		})
+	}
+}
+
+func TestFunctionUnhealthy(t *testing.T) {
+	assert.False(t, Function{State: "Active", LastUpdateStatus: "Successful"}.Unhealthy())
+	assert.False(t, Function{}.Unhealthy(), "state not loaded")
+	assert.True(t, Function{State: "Failed"}.Unhealthy())
+	assert.True(t, Function{State: "Inactive"}.Unhealthy())
+	assert.True(t, Function{State: "Active", LastUpdateStatus: "Failed"}.Unhealthy())
+}
@@ -3,9 +3,11 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 // RDSService provides RDS operations
@@ -23,7 +25,7 @@ func NewRDSService(clientManager *ClientManager) *RDSService {
 // DBInstance represents an RDS database instance
 type DBInstance struct {
 	Identifier         string
-	ARN                string
+	ARN                string `json:"arn"`
 	Engine             string
 	EngineVersion      string
 	Status             string
@@ -39,12 +41,14 @@ type DBInstance struct {
 	DBName             string
 	VPCSecurityGroups  []string
 	DBSubnetGroup      string
+	DBClusterID        string
+	ReadReplicaSource  string
 }
 
 // DBSnapshot represents an RDS database snapshot
 type DBSnapshot struct {
 	Identifier       string
-	ARN              string
+	ARN              string `json:"arn"`
 	DBInstanceID     string
 	SnapshotType     string
 	Status           string
@@ -62,13 +66,19 @@ func (r *RDSService) ListDBInstances(ctx context.Context, profileID string) ([]D
 		return nil, err
 	}
 
-	result, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{})
+	dbInstances, err := collectAll(ctx, func(marker *string) ([]types.DBInstance, *string, error) {
+		result, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{Marker: marker})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list DB instances: %w", classifyAWSError(err, "rds:DescribeDBInstances", ""))
+		}
+		return result.DBInstances, result.Marker, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list DB instances: %w", err)
+		return nil, err
 	}
 
-	instances := make([]DBInstance, 0, len(result.DBInstances))
-	for _, db := range result.DBInstances {
+	instances := make([]DBInstance, 0, len(dbInstances))
+	for _, db := range dbInstances {
 		instance := DBInstance{
 			Identifier:         aws.ToString(db.DBInstanceIdentifier),
 			ARN:                aws.ToString(db.DBInstanceArn),
@@ -103,6 +113,9 @@ func (r *RDSService) ListDBInstances(ctx context.Context, profileID string) ([]D
 			instance.DBSubnetGroup = aws.ToString(db.DBSubnetGroup.DBSubnetGroupName)
 		}
 
+		instance.DBClusterID = aws.ToString(db.DBClusterIdentifier)
+		instance.ReadReplicaSource = aws.ToString(db.ReadReplicaSourceDBInstanceIdentifier)
+
 		instances = append(instances, instance)
 	}
 
@@ -120,7 +133,7 @@ func (r *RDSService) DescribeDBInstance(ctx context.Context, profileID string, i
 		DBInstanceIdentifier: aws.String(identifier),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe DB instance: %w", err)
+		return nil, fmt.Errorf("failed to describe DB instance: %w", classifyAWSError(err, "rds:DescribeDBInstances", "DB instance "+identifier))
 	}
 
 	if len(result.DBInstances) == 0 {
@@ -159,9 +172,114 @@ func (r *RDSService) DescribeDBInstance(ctx context.Context, profileID string, i
 		instance.DBSubnetGroup = aws.ToString(db.DBSubnetGroup.DBSubnetGroupName)
 	}
 
+	instance.DBClusterID = aws.ToString(db.DBClusterIdentifier)
+	instance.ReadReplicaSource = aws.ToString(db.ReadReplicaSourceDBInstanceIdentifier)
+
 	return instance, nil
 }
 
+// DBInstanceStateChange is the result of starting or stopping a DB instance
+type DBInstanceStateChange struct {
+	Identifier     string
+	PreviousStatus string
+	Status         string
+}
+
+// StartDBInstance starts a stopped DB instance
+func (r *RDSService) StartDBInstance(ctx context.Context, profileID string, identifier string) (*DBInstanceStateChange, error) {
+	client, err := r.clientManager.GetRDSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := r.DescribeDBInstance(ctx, profileID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStartable(instance); err != nil {
+		return nil, err
+	}
+
+	result, err := client.StartDBInstance(ctx, &rds.StartDBInstanceInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start DB instance: %w", classifyAWSError(err, "rds:StartDBInstance", "DB instance "+identifier))
+	}
+
+	change := &DBInstanceStateChange{
+		Identifier:     identifier,
+		PreviousStatus: instance.Status,
+	}
+	if result.DBInstance != nil {
+		change.Status = aws.ToString(result.DBInstance.DBInstanceStatus)
+	}
+	return change, nil
+}
+
+// StopDBInstance stops a running DB instance, optionally taking a snapshot first
+func (r *RDSService) StopDBInstance(ctx context.Context, profileID string, identifier string, snapshotIdentifier string) (*DBInstanceStateChange, error) {
+	client, err := r.clientManager.GetRDSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := r.DescribeDBInstance(ctx, profileID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStoppable(instance); err != nil {
+		return nil, err
+	}
+
+	input := &rds.StopDBInstanceInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	}
+	if snapshotIdentifier != "" {
+		input.DBSnapshotIdentifier = aws.String(snapshotIdentifier)
+	}
+
+	result, err := client.StopDBInstance(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop DB instance: %w", classifyAWSError(err, "rds:StopDBInstance", "DB instance "+identifier))
+	}
+
+	change := &DBInstanceStateChange{
+		Identifier:     identifier,
+		PreviousStatus: instance.Status,
+	}
+	if result.DBInstance != nil {
+		change.Status = aws.ToString(result.DBInstance.DBInstanceStatus)
+	}
+	return change, nil
+}
+
+// checkStoppable rejects instances that RDS cannot stop, before calling the API
+func checkStoppable(instance *DBInstance) error {
+	switch {
+	case instance.DBClusterID != "":
+		return fmt.Errorf("DB instance %s is a member of cluster %s; stop the cluster instead of the instance", instance.Identifier, instance.DBClusterID)
+	case instance.MultiAZ:
+		return fmt.Errorf("DB instance %s is a Multi-AZ deployment and cannot be stopped", instance.Identifier)
+	case instance.ReadReplicaSource != "":
+		return fmt.Errorf("DB instance %s is a read replica of %s and cannot be stopped", instance.Identifier, instance.ReadReplicaSource)
+	case instance.Status != "available":
+		return fmt.Errorf("DB instance %s is %s; only available instances can be stopped", instance.Identifier, instance.Status)
+	}
+	return nil
+}
+
+// checkStartable rejects instances that are not in a startable state
+func checkStartable(instance *DBInstance) error {
+	if instance.DBClusterID != "" {
+		return fmt.Errorf("DB instance %s is a member of cluster %s; start the cluster instead of the instance", instance.Identifier, instance.DBClusterID)
+	}
+	if instance.Status != "stopped" {
+		return fmt.Errorf("DB instance %s is %s; only stopped instances can be started", instance.Identifier, instance.Status)
+	}
+	return nil
+}
+
 // GetDBConnectionInfo returns connection information for a DB instance
 func (r *RDSService) GetDBConnectionInfo(ctx context.Context, profileID string, identifier string) (map[string]interface{}, error) {
 	instance, err := r.DescribeDBInstance(ctx, profileID, identifier)
@@ -196,7 +314,7 @@ func (r *RDSService) ListDBSnapshots(ctx context.Context, profileID string, iden
 
 	result, err := client.DescribeDBSnapshots(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list DB snapshots: %w", err)
+		return nil, fmt.Errorf("failed to list DB snapshots: %w", classifyAWSError(err, "rds:DescribeDBSnapshots", "DB instance "+identifier))
 	}
 
 	snapshots := make([]DBSnapshot, 0, len(result.DBSnapshots))
@@ -230,7 +348,7 @@ func (r *RDSService) ListDBClusters(ctx context.Context, profileID string) ([]ma
 
 	result, err := client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list DB clusters: %w", err)
+		return nil, fmt.Errorf("failed to list DB clusters: %w", classifyAWSError(err, "rds:DescribeDBClusters", ""))
 	}
 
 	clusters := make([]map[string]interface{}, 0, len(result.DBClusters))
@@ -267,3 +385,95 @@ func (r *RDSService) ListDBClusters(ctx context.Context, profileID string) ([]ma
 	return clusters, nil
 }
 
+// DBCluster represents an RDS (Aurora) cluster with its members' roles
+type DBCluster struct {
+	Identifier        string
+	ARN               string `json:"arn"`
+	Engine            string
+	EngineVersion     string
+	Status            string
+	WriterEndpoint    string
+	ReaderEndpoint    string
+	CustomEndpoints   []string
+	Port              int32
+	MultiAZ           bool
+	AvailabilityZones []string
+	// BacktrackWindow is the target backtrack window in seconds; 0 when backtracking is disabled
+	BacktrackWindow int64
+	Writer          string
+	Members         []DBClusterMember
+}
+
+// DBClusterMember is an instance in a cluster. PromotionTier is the failover
+// priority: lower tiers are promoted to writer first.
+type DBClusterMember struct {
+	Identifier           string
+	IsClusterWriter      bool
+	PromotionTier        int32
+	ParameterGroupStatus string
+}
+
+// DescribeDBCluster gets detailed information about an RDS cluster, including
+// which member is currently the writer
+func (r *RDSService) DescribeDBCluster(ctx context.Context, profileID string, identifier string) (*DBCluster, error) {
+	client, err := r.clientManager.GetRDSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe DB cluster: %w", classifyAWSError(err, "rds:DescribeDBClusters", "DB cluster "+identifier))
+	}
+
+	if len(result.DBClusters) == 0 {
+		return nil, fmt.Errorf("DB cluster %s not found", identifier)
+	}
+
+	return newDBCluster(result.DBClusters[0]), nil
+}
+
+// newDBCluster converts an SDK cluster, ordering members writer first and then
+// by failover priority
+func newDBCluster(c types.DBCluster) *DBCluster {
+	cluster := &DBCluster{
+		Identifier:        aws.ToString(c.DBClusterIdentifier),
+		ARN:               aws.ToString(c.DBClusterArn),
+		Engine:            aws.ToString(c.Engine),
+		EngineVersion:     aws.ToString(c.EngineVersion),
+		Status:            aws.ToString(c.Status),
+		WriterEndpoint:    aws.ToString(c.Endpoint),
+		ReaderEndpoint:    aws.ToString(c.ReaderEndpoint),
+		CustomEndpoints:   c.CustomEndpoints,
+		Port:              aws.ToInt32(c.Port),
+		MultiAZ:           aws.ToBool(c.MultiAZ),
+		AvailabilityZones: c.AvailabilityZones,
+		BacktrackWindow:   aws.ToInt64(c.BacktrackWindow),
+		Members:           make([]DBClusterMember, 0, len(c.DBClusterMembers)),
+	}
+
+	for _, m := range c.DBClusterMembers {
+		member := DBClusterMember{
+			Identifier:           aws.ToString(m.DBInstanceIdentifier),
+			IsClusterWriter:      aws.ToBool(m.IsClusterWriter),
+			PromotionTier:        aws.ToInt32(m.PromotionTier),
+			ParameterGroupStatus: aws.ToString(m.DBClusterParameterGroupStatus),
+		}
+		if member.IsClusterWriter {
+			cluster.Writer = member.Identifier
+		}
+		cluster.Members = append(cluster.Members, member)
+	}
+
+	sort.SliceStable(cluster.Members, func(i, j int) bool {
+		a, b := cluster.Members[i], cluster.Members[j]
+		if a.IsClusterWriter != b.IsClusterWriter {
+			return a.IsClusterWriter
+		}
+		return a.PromotionTier < b.PromotionTier
+	})
+
+	return cluster
+}
@@ -0,0 +1,343 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// maxSlowLogBytes caps how much of a log file GetSlowQueries downloads
+const maxSlowLogBytes = 20 * 1024 * 1024
+
+var (
+	// mysqlSlowLogStats matches the statistics line of a MySQL slow query log entry
+	mysqlSlowLogStats = regexp.MustCompile(`^# Query_time:\s*([\d.]+)\s+Lock_time:\s*([\d.]+)\s+Rows_sent:\s*(\d+)\s+Rows_examined:\s*(\d+)`)
+	// mysqlSlowLogUser matches "# User@Host: app[app] @ host [10.0.0.5]"
+	mysqlSlowLogUser = regexp.MustCompile(`^# User@Host:\s*(\S*?)\[[^\]]*\]\s*@\s*(\S*)\s*\[([^\]]*)\]`)
+	// postgresTimestamp matches the timestamp the default RDS log_line_prefix starts with
+	postgresTimestamp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)?(?: [A-Z]+)?)`)
+	// postgresDuration matches a statement logged by log_min_duration_statement
+	postgresDuration = regexp.MustCompile(`LOG:\s+duration: ([\d.]+) ms\s+(?:statement|execute [^:]*|parse [^:]*|bind [^:]*):\s?(.*)$`)
+	// postgresUserDB matches the %u@%d part of the default RDS log_line_prefix
+	postgresUserDB = regexp.MustCompile(`:([^:@\s]*)@([^:@\s]*):\[\d+\]:`)
+)
+
+// DBLogFile represents a log file of an RDS instance
+type DBLogFile struct {
+	Name        string
+	Size        int64
+	LastWritten string
+}
+
+// SlowQueryEntry is one statement from a slow query log
+type SlowQueryEntry struct {
+	Timestamp    string  `json:"timestamp,omitempty"`
+	DurationMs   float64 `json:"duration_ms"`
+	LockTimeMs   float64 `json:"lock_time_ms,omitempty"`
+	RowsSent     int64   `json:"rows_sent,omitempty"`
+	RowsExamined int64   `json:"rows_examined,omitempty"`
+	User         string  `json:"user,omitempty"`
+	Host         string  `json:"host,omitempty"`
+	Database     string  `json:"database,omitempty"`
+	Query        string  `json:"query"`
+}
+
+// SlowQueryLog is the parsed slow query log of an RDS instance, slowest statements first
+type SlowQueryLog struct {
+	Identifier   string           `json:"identifier"`
+	Engine       string           `json:"engine"`
+	LogFile      string           `json:"log_file"`
+	TotalEntries int              `json:"total_entries"`
+	Entries      []SlowQueryEntry `json:"entries"`
+	Truncated    bool             `json:"truncated,omitempty"`
+	Warnings     []string         `json:"warnings,omitempty"`
+}
+
+// ListDBLogFiles lists the log files of an RDS instance, optionally only those whose
+// name contains filenameContains
+func (r *RDSService) ListDBLogFiles(ctx context.Context, profileID string, identifier string, filenameContains string) ([]DBLogFile, error) {
+	client, err := r.clientManager.GetRDSClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &rds.DescribeDBLogFilesInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	}
+	if filenameContains != "" {
+		input.FilenameContains = aws.String(filenameContains)
+	}
+
+	files := make([]DBLogFile, 0)
+	for {
+		result, err := client.DescribeDBLogFiles(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DB log files: %w", classifyAWSError(err, "rds:DescribeDBLogFiles", "DB instance "+identifier))
+		}
+
+		for _, f := range result.DescribeDBLogFiles {
+			file := DBLogFile{
+				Name: aws.ToString(f.LogFileName),
+				Size: aws.ToInt64(f.Size),
+			}
+			if f.LastWritten != nil {
+				file.LastWritten = time.UnixMilli(*f.LastWritten).UTC().Format(time.RFC3339)
+			}
+			files = append(files, file)
+		}
+
+		if aws.ToString(result.Marker) == "" {
+			break
+		}
+		input.Marker = result.Marker
+	}
+
+	return files, nil
+}
+
+// DownloadDBLogFile downloads a log file of an RDS instance from the beginning, stopping
+// once maxBytes have been read; truncated reports whether more data was left
+func (r *RDSService) DownloadDBLogFile(ctx context.Context, profileID string, identifier string, logFile string, maxBytes int) (string, bool, error) {
+	client, err := r.clientManager.GetRDSClient(profileID)
+	if err != nil {
+		return "", false, err
+	}
+
+	var data strings.Builder
+	marker := "0"
+	for {
+		result, err := client.DownloadDBLogFilePortion(ctx, &rds.DownloadDBLogFilePortionInput{
+			DBInstanceIdentifier: aws.String(identifier),
+			LogFileName:          aws.String(logFile),
+			Marker:               aws.String(marker),
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to download DB log file: %w", classifyAWSError(err, "rds:DownloadDBLogFilePortion", "log file "+logFile+" of DB instance "+identifier))
+		}
+
+		data.WriteString(aws.ToString(result.LogFileData))
+
+		pending := aws.ToBool(result.AdditionalDataPending)
+		if !pending || aws.ToString(result.Marker) == "" {
+			return data.String(), false, nil
+		}
+		if data.Len() >= maxBytes {
+			return data.String(), true, nil
+		}
+		marker = aws.ToString(result.Marker)
+	}
+}
+
+// GetSlowQueries downloads and parses the slow query log of an RDS instance: the MySQL
+// slow query log for MySQL and MariaDB, or the PostgreSQL log statements written by
+// log_min_duration_statement. Without logFile, the most recently written slow query
+// log (MySQL) or error log (PostgreSQL) is used. Entries are sorted by duration,
+// slowest first, and at most limit are returned.
+func (r *RDSService) GetSlowQueries(ctx context.Context, profileID string, identifier string, logFile string, limit int) (*SlowQueryLog, error) {
+	instance, err := r.DescribeDBInstance(ctx, profileID, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	postgres := strings.Contains(instance.Engine, "postgres")
+	mysql := strings.Contains(instance.Engine, "mysql") || strings.Contains(instance.Engine, "mariadb")
+	if !postgres && !mysql {
+		return nil, fmt.Errorf("slow query logs are only supported for MySQL, MariaDB and PostgreSQL instances; %s runs %s", identifier, instance.Engine)
+	}
+
+	report := &SlowQueryLog{
+		Identifier: identifier,
+		Engine:     instance.Engine,
+		LogFile:    logFile,
+		Entries:    []SlowQueryEntry{},
+	}
+
+	if report.LogFile == "" {
+		pattern := "slowquery"
+		if postgres {
+			pattern = "postgresql.log"
+		}
+		files, err := r.ListDBLogFiles(ctx, profileID, identifier, pattern)
+		if err != nil {
+			return nil, err
+		}
+		latest := latestLogFile(files)
+		if latest == nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("no log file matching %q found; for MySQL set slow_query_log=1 and log_output=FILE, for PostgreSQL set log_min_duration_statement", pattern))
+			return report, nil
+		}
+		report.LogFile = latest.Name
+	}
+
+	data, truncated, err := r.DownloadDBLogFile(ctx, profileID, identifier, report.LogFile, maxSlowLogBytes)
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		report.Truncated = true
+		report.Warnings = append(report.Warnings, fmt.Sprintf("only the first %d MB of %s were parsed", maxSlowLogBytes/(1024*1024), report.LogFile))
+	}
+
+	var entries []SlowQueryEntry
+	if postgres {
+		entries = parsePostgresSlowLog(data)
+	} else {
+		entries = parseMySQLSlowLog(data)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DurationMs > entries[j].DurationMs
+	})
+	report.TotalEntries = len(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	report.Entries = append(report.Entries, entries...)
+
+	return report, nil
+}
+
+// latestLogFile returns the most recently written log file, or nil if there is none
+func latestLogFile(files []DBLogFile) *DBLogFile {
+	var latest *DBLogFile
+	for i := range files {
+		// LastWritten is RFC 3339 in UTC, so it sorts as text
+		if latest == nil || files[i].LastWritten > latest.LastWritten {
+			latest = &files[i]
+		}
+	}
+	return latest
+}
+
+// parseMySQLSlowLog parses a MySQL or MariaDB slow query log. Each entry is a block of
+// "# " header lines (Time, User@Host, Query_time ...) followed by the statement;
+// "use db;" and "SET timestamp=...;" lines are folded into the entry.
+func parseMySQLSlowLog(data string) []SlowQueryEntry {
+	entries := make([]SlowQueryEntry, 0)
+	var current *SlowQueryEntry
+	var query []string
+	lastTime := ""
+	database := ""
+
+	flush := func() {
+		if current != nil {
+			current.Query = strings.TrimSpace(strings.Join(query, "\n"))
+			if current.Query != "" {
+				entries = append(entries, *current)
+			}
+		}
+		current = nil
+		query = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "# Time:"):
+			flush()
+			lastTime = strings.TrimSpace(strings.TrimPrefix(line, "# Time:"))
+		case strings.HasPrefix(line, "# User@Host:"):
+			flush()
+			current = &SlowQueryEntry{Timestamp: lastTime, Database: database}
+			if m := mysqlSlowLogUser.FindStringSubmatch(line); m != nil {
+				current.User = m[1]
+				current.Host = m[3]
+				if current.Host == "" {
+					current.Host = m[2]
+				}
+			}
+		case strings.HasPrefix(line, "# Query_time:"):
+			if current == nil {
+				current = &SlowQueryEntry{Timestamp: lastTime, Database: database}
+			}
+			if m := mysqlSlowLogStats.FindStringSubmatch(line); m != nil {
+				queryTime, _ := strconv.ParseFloat(m[1], 64)
+				lockTime, _ := strconv.ParseFloat(m[2], 64)
+				current.DurationMs = queryTime * 1000
+				current.LockTimeMs = lockTime * 1000
+				current.RowsSent, _ = strconv.ParseInt(m[3], 10, 64)
+				current.RowsExamined, _ = strconv.ParseInt(m[4], 10, 64)
+			}
+		case strings.HasPrefix(line, "#"):
+			// Other header lines, e.g. MariaDB's "# Thread_id:" or "# Full_scan:"
+		case current == nil:
+			// Server startup banner between entries
+		case strings.HasPrefix(line, "SET timestamp="):
+			if current.Timestamp == "" {
+				epoch, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(line, "SET timestamp="), ";"), 10, 64)
+				if err == nil {
+					current.Timestamp = time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+				}
+			}
+		case strings.HasPrefix(strings.ToLower(line), "use ") && strings.HasSuffix(line, ";") && len(query) == 0:
+			database = strings.Trim(strings.TrimSuffix(line[4:], ";"), "` ")
+			current.Database = database
+		default:
+			query = append(query, line)
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// parsePostgresSlowLog parses the "duration: ... ms statement: ..." lines PostgreSQL
+// writes for log_min_duration_statement, with the default RDS log_line_prefix
+// (%t:%r:%u@%d:[%p]:). Statements continue on following lines indented by a tab.
+func parsePostgresSlowLog(data string) []SlowQueryEntry {
+	entries := make([]SlowQueryEntry, 0)
+	var current *SlowQueryEntry
+	var query []string
+
+	flush := func() {
+		if current != nil {
+			current.Query = strings.TrimSpace(strings.Join(query, "\n"))
+			if current.Query != "" {
+				entries = append(entries, *current)
+			}
+		}
+		current = nil
+		query = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if current != nil && strings.HasPrefix(line, "\t") {
+			query = append(query, strings.TrimPrefix(line, "\t"))
+			continue
+		}
+		flush()
+
+		m := postgresDuration.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		duration, _ := strconv.ParseFloat(m[1], 64)
+		current = &SlowQueryEntry{DurationMs: duration}
+		if ts := postgresTimestamp.FindStringSubmatch(line); ts != nil {
+			current.Timestamp = ts[1]
+		}
+		if userDB := postgresUserDB.FindStringSubmatch(line); userDB != nil {
+			current.User = userDB[1]
+			current.Database = userDB[2]
+		}
+		query = append(query, m[2])
+	}
+	flush()
+
+	return entries
+}
@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMySQLSlowLog(t *testing.T) {
+	data := `/rdsdbbin/mysql/bin/mysqld, Version: 8.0.35 (Source distribution). started with:
+Tcp port: 3306  Unix socket: /tmp/mysql.sock
+Time                 Id Command    Argument
+# Time: 2025-01-09T15:30:00.123456Z
+# User@Host: app[app] @  [10.0.0.5]  Id:    12
+# Query_time: 2.500000  Lock_time: 0.250000 Rows_sent: 1  Rows_examined: 250000
+use orders;
+SET timestamp=1736436600;
+SELECT COUNT(*)
+FROM order_items
+WHERE sku LIKE '%blue%';
+# Time: 2025-01-09T15:31:10.000000Z
+# User@Host: report[report] @ localhost []  Id:    40
+# Query_time: 7.250000  Lock_time: 0.000000 Rows_sent: 10  Rows_examined: 1200000
+SET timestamp=1736436670;
+SELECT customer_id, SUM(total) FROM orders GROUP BY customer_id ORDER BY 2 DESC LIMIT 10;
+`
+
+	entries := parseMySQLSlowLog(data)
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, SlowQueryEntry{
+		Timestamp:    "2025-01-09T15:30:00.123456Z",
+		DurationMs:   2500,
+		LockTimeMs:   250,
+		RowsSent:     1,
+		RowsExamined: 250000,
+		User:         "app",
+		Host:         "10.0.0.5",
+		Database:     "orders",
+		Query:        "SELECT COUNT(*)\nFROM order_items\nWHERE sku LIKE '%blue%';",
+	}, entries[0])
+	assert.Equal(t, 7250.0, entries[1].DurationMs)
+	assert.Equal(t, int64(1200000), entries[1].RowsExamined)
+	assert.Equal(t, "localhost", entries[1].Host)
+	assert.Equal(t, "orders", entries[1].Database)
+	assert.Equal(t, "SELECT customer_id, SUM(total) FROM orders GROUP BY customer_id ORDER BY 2 DESC LIMIT 10;", entries[1].Query)
+}
+
+func TestParseMySQLSlowLogWithoutTimeLine(t *testing.T) {
+	data := `# User@Host: app[app] @  [10.0.0.5]  Id:    12
+# Query_time: 1.000000  Lock_time: 0.000000 Rows_sent: 0  Rows_examined: 5
+SET timestamp=1736436600;
+DELETE FROM sessions WHERE expires_at < NOW();
+`
+
+	entries := parseMySQLSlowLog(data)
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "2025-01-09T15:30:00Z", entries[0].Timestamp)
+	assert.Equal(t, "DELETE FROM sessions WHERE expires_at < NOW();", entries[0].Query)
+}
+
+func TestParsePostgresSlowLog(t *testing.T) {
+	data := "2025-01-09 15:30:00 UTC:10.0.0.5(53412):app@orders:[4242]:LOG:  duration: 1523.412 ms  statement: SELECT *\n" +
+		"\tFROM orders\n" +
+		"\tWHERE status = 'pending'\n" +
+		"2025-01-09 15:30:01 UTC:10.0.0.5(53412):app@orders:[4242]:LOG:  connection authorized: user=app database=orders\n" +
+		"2025-01-09 15:30:02 UTC:10.0.0.6(53500):report@orders:[4300]:LOG:  duration: 8800.000 ms  execute <unnamed>: SELECT sum(total) FROM orders\n" +
+		"2025-01-09 15:30:03 UTC:10.0.0.6(53500):report@orders:[4300]:LOG:  duration: 0.051 ms\n"
+
+	entries := parsePostgresSlowLog(data)
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, SlowQueryEntry{
+		Timestamp:  "2025-01-09 15:30:00 UTC",
+		DurationMs: 1523.412,
+		User:       "app",
+		Database:   "orders",
+		Query:      "SELECT *\nFROM orders\nWHERE status = 'pending'",
+	}, entries[0])
+	assert.Equal(t, 8800.0, entries[1].DurationMs)
+	assert.Equal(t, "report", entries[1].User)
+	assert.Equal(t, "SELECT sum(total) FROM orders", entries[1].Query)
+}
+
+func TestLatestLogFile(t *testing.T) {
+	files := []DBLogFile{
+		{Name: "slowquery/mysql-slowquery.log.2", LastWritten: "2025-01-09T13:00:00Z"},
+		{Name: "slowquery/mysql-slowquery.log", LastWritten: "2025-01-09T15:59:00Z"},
+		{Name: "slowquery/mysql-slowquery.log.1", LastWritten: "2025-01-09T14:00:00Z"},
+	}
+
+	assert.Equal(t, "slowquery/mysql-slowquery.log", latestLogFile(files).Name)
+	assert.Nil(t, latestLogFile(nil))
+}
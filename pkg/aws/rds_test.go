@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStoppableRejectsMultiAZ(t *testing.T) {
+	// Shape of a DescribeDBInstance result for a Multi-AZ PostgreSQL instance
+	instance := &DBInstance{
+		Identifier: "orders-db",
+		Engine:     "postgres",
+		Status:     "available",
+		MultiAZ:    true,
+	}
+
+	err := checkStoppable(instance)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Multi-AZ")
+	assert.Contains(t, err.Error(), "orders-db")
+}
+
+func TestCheckStoppable(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance *DBInstance
+		wantErr  string
+	}{
+		{
+			name:     "single-AZ available instance",
+			instance: &DBInstance{Identifier: "reports-db", Status: "available"},
+		},
+		{
+			name:     "cluster member",
+			instance: &DBInstance{Identifier: "aurora-1", Status: "available", DBClusterID: "aurora-cluster"},
+			wantErr:  "member of cluster aurora-cluster",
+		},
+		{
+			name:     "read replica",
+			instance: &DBInstance{Identifier: "reports-replica", Status: "available", ReadReplicaSource: "reports-db"},
+			wantErr:  "read replica",
+		},
+		{
+			name:     "already stopped",
+			instance: &DBInstance{Identifier: "reports-db", Status: "stopped"},
+			wantErr:  "only available instances",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStoppable(tt.instance)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckStartable(t *testing.T) {
+	assert.NoError(t, checkStartable(&DBInstance{Identifier: "reports-db", Status: "stopped"}))
+	assert.Error(t, checkStartable(&DBInstance{Identifier: "reports-db", Status: "available"}))
+	assert.Error(t, checkStartable(&DBInstance{Identifier: "aurora-1", Status: "stopped", DBClusterID: "aurora-cluster"}))
+}
+
+func TestNewDBClusterOrdersWriterFirst(t *testing.T) {
+	cluster := newDBCluster(types.DBCluster{
+		DBClusterIdentifier: aws.String("orders-cluster"),
+		Endpoint:            aws.String("orders-cluster.cluster-abc.us-east-1.rds.amazonaws.com"),
+		ReaderEndpoint:      aws.String("orders-cluster.cluster-ro-abc.us-east-1.rds.amazonaws.com"),
+		BacktrackWindow:     aws.Int64(86400),
+		DBClusterMembers: []types.DBClusterMember{
+			{DBInstanceIdentifier: aws.String("orders-2"), IsClusterWriter: aws.Bool(false), PromotionTier: aws.Int32(2)},
+			{DBInstanceIdentifier: aws.String("orders-3"), IsClusterWriter: aws.Bool(false), PromotionTier: aws.Int32(1)},
+			{DBInstanceIdentifier: aws.String("orders-1"), IsClusterWriter: aws.Bool(true), PromotionTier: aws.Int32(1)},
+		},
+	})
+
+	assert.Equal(t, "orders-1", cluster.Writer)
+	assert.Equal(t, int64(86400), cluster.BacktrackWindow)
+	assert.Equal(t, "orders-cluster.cluster-ro-abc.us-east-1.rds.amazonaws.com", cluster.ReaderEndpoint)
+
+	order := make([]string, 0, len(cluster.Members))
+	for _, member := range cluster.Members {
+		order = append(order, member.Identifier)
+	}
+	assert.Equal(t, []string{"orders-1", "orders-3", "orders-2"}, order)
+}
@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+// Route53Service provides Route53 DNS operations
+type Route53Service struct {
+	clientManager *ClientManager
+}
+
+// NewRoute53Service creates a new Route53 service
+func NewRoute53Service(clientManager *ClientManager) *Route53Service {
+	return &Route53Service{
+		clientManager: clientManager,
+	}
+}
+
+// HostedZone represents a Route53 hosted zone
+type HostedZone struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Private     bool   `json:"private"`
+	RecordCount int64  `json:"record_count"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// AliasTarget is the AWS resource an alias record points to
+type AliasTarget struct {
+	DNSName              string `json:"dns_name"`
+	HostedZoneID         string `json:"hosted_zone_id"`
+	EvaluateTargetHealth bool   `json:"evaluate_target_health"`
+}
+
+// RecordSet represents a Route53 resource record set. Alias records have an
+// AliasTarget and no TTL or values.
+type RecordSet struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	TTL           int64        `json:"ttl,omitempty"`
+	Values        []string     `json:"values,omitempty"`
+	AliasTarget   *AliasTarget `json:"alias_target,omitempty"`
+	SetIdentifier string       `json:"set_identifier,omitempty"`
+	Weight        *int64       `json:"weight,omitempty"`
+	Region        string       `json:"region,omitempty"`
+	Failover      string       `json:"failover,omitempty"`
+	HealthCheckID string       `json:"health_check_id,omitempty"`
+}
+
+// ListHostedZones lists the public and private hosted zones of the account
+func (r *Route53Service) ListHostedZones(ctx context.Context, profileID string) ([]HostedZone, error) {
+	client, err := r.clientManager.GetRoute53Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]HostedZone, 0)
+	paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hosted zones: %w", classifyAWSError(err, "route53:ListHostedZones", ""))
+		}
+		for _, z := range page.HostedZones {
+			zones = append(zones, newHostedZone(z))
+		}
+	}
+
+	return zones, nil
+}
+
+// ListResourceRecordSets lists every record set of a hosted zone. zoneID may be given
+// with or without the "/hostedzone/" prefix.
+func (r *Route53Service) ListResourceRecordSets(ctx context.Context, profileID string, zoneID string) ([]RecordSet, error) {
+	zoneID = hostedZoneID(zoneID)
+	if zoneID == "" {
+		return nil, fmt.Errorf("zone_id is required")
+	}
+
+	client, err := r.clientManager.GetRoute53Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record sets are paged by the name, type and set identifier of the next record
+	// rather than a single token, so the SDK has no paginator for them
+	input := &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)}
+	records := make([]RecordSet, 0)
+	for {
+		page, err := client.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list record sets: %w", classifyAWSError(err, "route53:ListResourceRecordSets", "hosted zone "+zoneID))
+		}
+		for _, rs := range page.ResourceRecordSets {
+			records = append(records, newRecordSet(rs))
+		}
+
+		if !page.IsTruncated {
+			break
+		}
+		input.StartRecordName = page.NextRecordName
+		input.StartRecordType = page.NextRecordType
+		input.StartRecordIdentifier = page.NextRecordIdentifier
+	}
+
+	return records, nil
+}
+
+// newHostedZone converts a hosted zone returned by the API
+func newHostedZone(z types.HostedZone) HostedZone {
+	zone := HostedZone{
+		ID:          hostedZoneID(aws.ToString(z.Id)),
+		Name:        decodeDNSName(aws.ToString(z.Name)),
+		RecordCount: aws.ToInt64(z.ResourceRecordSetCount),
+	}
+	if z.Config != nil {
+		zone.Private = z.Config.PrivateZone
+		zone.Comment = aws.ToString(z.Config.Comment)
+	}
+	return zone
+}
+
+// newRecordSet converts a resource record set returned by the API
+func newRecordSet(rs types.ResourceRecordSet) RecordSet {
+	record := RecordSet{
+		Name:          decodeDNSName(aws.ToString(rs.Name)),
+		Type:          string(rs.Type),
+		TTL:           aws.ToInt64(rs.TTL),
+		SetIdentifier: aws.ToString(rs.SetIdentifier),
+		Weight:        rs.Weight,
+		Region:        string(rs.Region),
+		Failover:      string(rs.Failover),
+		HealthCheckID: aws.ToString(rs.HealthCheckId),
+	}
+	for _, rr := range rs.ResourceRecords {
+		record.Values = append(record.Values, aws.ToString(rr.Value))
+	}
+	if rs.AliasTarget != nil {
+		record.AliasTarget = &AliasTarget{
+			DNSName:              decodeDNSName(aws.ToString(rs.AliasTarget.DNSName)),
+			HostedZoneID:         aws.ToString(rs.AliasTarget.HostedZoneId),
+			EvaluateTargetHealth: rs.AliasTarget.EvaluateTargetHealth,
+		}
+	}
+	return record
+}
+
+// hostedZoneID strips the "/hostedzone/" prefix Route53 puts on hosted zone IDs
+func hostedZoneID(id string) string {
+	return strings.TrimPrefix(id, "/hostedzone/")
+}
+
+// decodeDNSName replaces the octal escapes Route53 uses for special characters in
+// names, such as "\052" for the "*" of a wildcard record
+func decodeDNSName(name string) string {
+	if !strings.Contains(name, `\`) {
+		return name
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) {
+			if c, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecordSetAlias(t *testing.T) {
+	record := newRecordSet(types.ResourceRecordSet{
+		Name: aws.String("api.example.com."),
+		Type: types.RRTypeA,
+		AliasTarget: &types.AliasTarget{
+			DNSName:              aws.String("dualstack.api-lb-123456.eu-west-1.elb.amazonaws.com."),
+			HostedZoneId:         aws.String("Z32O12XQLNTSW2"),
+			EvaluateTargetHealth: true,
+		},
+	})
+
+	assert.Equal(t, RecordSet{
+		Name: "api.example.com.",
+		Type: "A",
+		AliasTarget: &AliasTarget{
+			DNSName:              "dualstack.api-lb-123456.eu-west-1.elb.amazonaws.com.",
+			HostedZoneID:         "Z32O12XQLNTSW2",
+			EvaluateTargetHealth: true,
+		},
+	}, record)
+}
+
+func TestNewRecordSetValues(t *testing.T) {
+	record := newRecordSet(types.ResourceRecordSet{
+		Name: aws.String(`\052.example.com.`),
+		Type: types.RRTypeCname,
+		TTL:  aws.Int64(300),
+		ResourceRecords: []types.ResourceRecord{
+			{Value: aws.String("web-blue.example.com")},
+		},
+		SetIdentifier: aws.String("blue"),
+		Weight:        aws.Int64(90),
+	})
+
+	assert.Equal(t, "*.example.com.", record.Name)
+	assert.Equal(t, "CNAME", record.Type)
+	assert.Equal(t, int64(300), record.TTL)
+	assert.Equal(t, []string{"web-blue.example.com"}, record.Values)
+	assert.Equal(t, "blue", record.SetIdentifier)
+	assert.Equal(t, int64(90), *record.Weight)
+	assert.Nil(t, record.AliasTarget)
+}
+
+func TestNewHostedZone(t *testing.T) {
+	zone := newHostedZone(types.HostedZone{
+		Id:                     aws.String("/hostedzone/Z0123456789ABC"),
+		Name:                   aws.String("internal.example.com."),
+		ResourceRecordSetCount: aws.Int64(42),
+		Config:                 &types.HostedZoneConfig{PrivateZone: true, Comment: aws.String("VPC zone")},
+	})
+
+	assert.Equal(t, HostedZone{ID: "Z0123456789ABC", Name: "internal.example.com.", Private: true, RecordCount: 42, Comment: "VPC zone"}, zone)
+}
+
+func TestDecodeDNSName(t *testing.T) {
+	assert.Equal(t, "example.com.", decodeDNSName("example.com."))
+	assert.Equal(t, "*.example.com.", decodeDNSName(`\052.example.com.`))
+	assert.Equal(t, "_dmarc@x.", decodeDNSName(`_dmarc\100x.`))
+	// Not a complete escape: left as is
+	assert.Equal(t, `odd\05`, decodeDNSName(`odd\05`))
+}
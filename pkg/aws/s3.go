@@ -0,0 +1,186 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// defaultS3ObjectLimit caps ListObjects when no limit is given
+const defaultS3ObjectLimit = 1000
+
+// S3Service provides S3 operations
+type S3Service struct {
+	clientManager *ClientManager
+}
+
+// NewS3Service creates a new S3 service
+func NewS3Service(clientManager *ClientManager) *S3Service {
+	return &S3Service{
+		clientManager: clientManager,
+	}
+}
+
+// Bucket represents an S3 bucket
+type Bucket struct {
+	Name         string
+	ARN          string `json:"arn"`
+	CreationDate string
+}
+
+// S3Object represents an object in an S3 bucket
+type S3Object struct {
+	Key          string
+	Size         int64
+	LastModified string
+	StorageClass string
+}
+
+// ObjectListing is a page of objects from a bucket
+type ObjectListing struct {
+	Bucket                string
+	Prefix                string
+	Objects               []S3Object
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// ObjectMetadata describes an S3 object without its content
+type ObjectMetadata struct {
+	Bucket               string
+	Key                  string
+	ContentType          string
+	ContentLength        int64
+	ContentEncoding      string
+	ETag                 string
+	LastModified         string
+	StorageClass         string
+	VersionID            string
+	ServerSideEncryption string
+	Metadata             map[string]string
+}
+
+// ListBuckets lists all S3 buckets owned by the account
+func (s *S3Service) ListBuckets(ctx context.Context, profileID string) ([]Bucket, error) {
+	client, err := s.clientManager.GetS3Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", classifyAWSError(err, "s3:ListAllMyBuckets", ""))
+	}
+
+	buckets := make([]Bucket, 0, len(result.Buckets))
+	for _, b := range result.Buckets {
+		bucket := Bucket{
+			Name: aws.ToString(b.Name),
+			ARN:  s3BucketARN(client.Options().Region, aws.ToString(b.Name)),
+		}
+		if b.CreationDate != nil {
+			bucket.CreationDate = b.CreationDate.Format(time.RFC3339)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// ListObjects lists up to limit objects in a bucket under prefix, following
+// continuation tokens across pages. A non-empty continuationToken resumes a previous
+// listing from its NextContinuationToken.
+func (s *S3Service) ListObjects(ctx context.Context, profileID string, bucket string, prefix string, continuationToken string, limit int32) (*ObjectListing, error) {
+	client, err := s.clientManager.GetS3Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultS3ObjectLimit
+	}
+
+	listing := &ObjectListing{
+		Bucket:  bucket,
+		Prefix:  prefix,
+		Objects: make([]S3Object, 0),
+	}
+
+	var token *string
+	if continuationToken != "" {
+		token = aws.String(continuationToken)
+	}
+	for {
+		input := &s3.ListObjectsV2Input{
+			Bucket:            aws.String(bucket),
+			ContinuationToken: token,
+			MaxKeys:           aws.Int32(min(limit-int32(len(listing.Objects)), defaultS3ObjectLimit)),
+		}
+		if prefix != "" {
+			input.Prefix = aws.String(prefix)
+		}
+
+		result, err := client.ListObjectsV2(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", classifyAWSError(err, "s3:ListBucket", "bucket "+bucket))
+		}
+
+		for _, obj := range result.Contents {
+			object := S3Object{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				StorageClass: string(obj.StorageClass),
+			}
+			if obj.LastModified != nil {
+				object.LastModified = obj.LastModified.Format(time.RFC3339)
+			}
+			listing.Objects = append(listing.Objects, object)
+		}
+
+		listing.IsTruncated = aws.ToBool(result.IsTruncated)
+		listing.NextContinuationToken = aws.ToString(result.NextContinuationToken)
+		if !listing.IsTruncated || int32(len(listing.Objects)) >= limit {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	return listing, nil
+}
+
+// GetObjectMetadata returns an object's metadata using HeadObject, without downloading it
+func (s *S3Service) GetObjectMetadata(ctx context.Context, profileID string, bucket string, key string) (*ObjectMetadata, error) {
+	client, err := s.clientManager.GetS3Client(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object metadata: %w", classifyAWSError(err, "s3:GetObject", "object s3://"+bucket+"/"+key))
+	}
+
+	metadata := &ObjectMetadata{
+		Bucket:               bucket,
+		Key:                  key,
+		ContentType:          aws.ToString(result.ContentType),
+		ContentLength:        aws.ToInt64(result.ContentLength),
+		ContentEncoding:      aws.ToString(result.ContentEncoding),
+		ETag:                 aws.ToString(result.ETag),
+		StorageClass:         string(result.StorageClass),
+		VersionID:            aws.ToString(result.VersionId),
+		ServerSideEncryption: string(result.ServerSideEncryption),
+		Metadata:             result.Metadata,
+	}
+	if result.LastModified != nil {
+		metadata.LastModified = result.LastModified.Format(time.RFC3339)
+	}
+
+	return metadata, nil
+}
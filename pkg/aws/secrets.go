@@ -2,7 +2,13 @@ package aws
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
@@ -22,7 +28,7 @@ func NewSecretsService(clientManager *ClientManager) *SecretsService {
 
 // Secret represents a secret in Secrets Manager
 type Secret struct {
-	ARN              string
+	ARN              string `json:"arn"`
 	Name             string
 	Description      string
 	CreatedDate      string
@@ -40,7 +46,7 @@ func (s *SecretsService) ListSecrets(ctx context.Context, profileID string) ([]S
 
 	result, err := client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list secrets: %w", err)
+		return nil, fmt.Errorf("failed to list secrets: %w", classifyAWSError(err, "secretsmanager:ListSecrets", ""))
 	}
 
 	secrets := make([]Secret, 0, len(result.SecretList))
@@ -85,7 +91,7 @@ func (s *SecretsService) DescribeSecret(ctx context.Context, profileID string, s
 		SecretId: aws.String(secretName),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe secret: %w", err)
+		return nil, fmt.Errorf("failed to describe secret: %w", classifyAWSError(err, "secretsmanager:DescribeSecret", "secret "+secretName))
 	}
 
 	secretInfo := map[string]interface{}{
@@ -125,18 +131,284 @@ func (s *SecretsService) DescribeSecret(ctx context.Context, profileID string, s
 // GetSecretValue retrieves the actual secret value
 // Note: This should be used with caution and only when explicitly requested
 func (s *SecretsService) GetSecretValue(ctx context.Context, profileID string, secretName string) (string, error) {
-	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	result, err := s.getSecretValue(ctx, profileID, secretName)
 	if err != nil {
 		return "", err
 	}
 
+	return aws.ToString(result.SecretString), nil
+}
+
+// SecretValue is the current value of a secret. Unless the value was revealed, only its
+// SHA-256 fingerprint and length are set, which is enough to confirm that a secret exists
+// or has changed without exposing it.
+type SecretValue struct {
+	ARN           string   `json:"arn"`
+	Name          string   `json:"name"`
+	VersionID     string   `json:"version_id"`
+	VersionStages []string `json:"version_stages,omitempty"`
+	Binary        bool     `json:"binary,omitempty"`
+	Length        int      `json:"length"`
+	SHA256        string   `json:"sha256"`
+	Revealed      bool     `json:"revealed"`
+	Value         string   `json:"value,omitempty"`
+}
+
+// ReadSecret returns the current value of a secret, or only its fingerprint and length
+// unless reveal is set. Binary secrets are fingerprinted over their bytes and revealed
+// base64-encoded.
+func (s *SecretsService) ReadSecret(ctx context.Context, profileID string, secretID string, reveal bool) (*SecretValue, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("secret_id is required")
+	}
+
+	result, err := s.getSecretValue(ctx, profileID, secretID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &SecretValue{
+		ARN:           aws.ToString(result.ARN),
+		Name:          aws.ToString(result.Name),
+		VersionID:     aws.ToString(result.VersionId),
+		VersionStages: result.VersionStages,
+	}
+	if result.SecretString != nil {
+		setSecretValue(secret, []byte(*result.SecretString), false, reveal)
+	} else {
+		setSecretValue(secret, result.SecretBinary, true, reveal)
+	}
+	return secret, nil
+}
+
+// setSecretValue fingerprints a secret value and only copies it into secret when reveal is set
+func setSecretValue(secret *SecretValue, value []byte, binary bool, reveal bool) {
+	sum := sha256.Sum256(value)
+	secret.SHA256 = hex.EncodeToString(sum[:])
+	secret.Length = len(value)
+	secret.Binary = binary
+	secret.Revealed = reveal
+	secret.Value = ""
+	if !reveal {
+		return
+	}
+	if binary {
+		secret.Value = base64.StdEncoding.EncodeToString(value)
+	} else {
+		secret.Value = string(value)
+	}
+}
+
+// getSecretValue fetches the current version of a secret
+func (s *SecretsService) getSecretValue(ctx context.Context, profileID string, secretName string) (*secretsmanager.GetSecretValueOutput, error) {
+	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get secret value: %w", err)
+		return nil, fmt.Errorf("failed to get secret value: %w", classifyAWSError(err, "secretsmanager:GetSecretValue", "secret "+secretName))
 	}
 
-	return aws.ToString(result.SecretString), nil
+	return result, nil
+}
+
+// SecretWriteResult identifies the secret version created by CreateSecret or
+// PutSecretValue. It deliberately has no field for the secret value.
+type SecretWriteResult struct {
+	ARN       string `json:"arn"`
+	Name      string `json:"name"`
+	VersionID string `json:"version_id"`
 }
 
+// String describes the written secret version for logs, without its value
+func (r *SecretWriteResult) String() string {
+	return fmt.Sprintf("secret %s (version %s)", r.ARN, r.VersionID)
+}
+
+// CreateSecret creates a secret holding secretString and returns its ARN and first version
+func (s *SecretsService) CreateSecret(ctx context.Context, profileID string, name string, description string, secretString string) (*SecretWriteResult, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if secretString == "" {
+		return nil, fmt.Errorf("secret value is required")
+	}
+
+	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(secretString),
+	}
+	if description != "" {
+		input.Description = aws.String(description)
+	}
+
+	result, err := client.CreateSecret(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret: %w", redactSecretValue(classifyAWSError(err, "secretsmanager:CreateSecret", "secret "+name), secretString))
+	}
+
+	return &SecretWriteResult{
+		ARN:       aws.ToString(result.ARN),
+		Name:      aws.ToString(result.Name),
+		VersionID: aws.ToString(result.VersionId),
+	}, nil
+}
+
+// PutSecretValue stores secretString as the new current version of an existing secret
+func (s *SecretsService) PutSecretValue(ctx context.Context, profileID string, secretID string, secretString string) (*SecretWriteResult, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("secret_id is required")
+	}
+	if secretString == "" {
+		return nil, fmt.Errorf("secret value is required")
+	}
+
+	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretID),
+		SecretString: aws.String(secretString),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update secret: %w", redactSecretValue(classifyAWSError(err, "secretsmanager:PutSecretValue", "secret "+secretID), secretString))
+	}
+
+	return &SecretWriteResult{
+		ARN:       aws.ToString(result.ARN),
+		Name:      aws.ToString(result.Name),
+		VersionID: aws.ToString(result.VersionId),
+	}, nil
+}
+
+// RotationRules is the rotation schedule of a secret
+type RotationRules struct {
+	AutomaticallyAfterDays int64  `json:"automatically_after_days,omitempty"`
+	Duration               string `json:"duration,omitempty"`
+	ScheduleExpression     string `json:"schedule_expression,omitempty"`
+}
+
+// RotationStatus describes how and when a secret is rotated
+type RotationStatus struct {
+	ARN               string         `json:"arn"`
+	Name              string         `json:"name"`
+	RotationEnabled   bool           `json:"rotation_enabled"`
+	RotationLambdaARN string         `json:"rotation_lambda_arn,omitempty"`
+	RotationRules     *RotationRules `json:"rotation_rules,omitempty"`
+	LastRotatedDate   string         `json:"last_rotated_date,omitempty"`
+	NextRotationDate  string         `json:"next_rotation_date,omitempty"`
+}
+
+// configured reports whether the secret has a rotation function to invoke
+func (r *RotationStatus) configured() bool {
+	return r.RotationEnabled || r.RotationLambdaARN != ""
+}
+
+// GetRotationStatus returns whether rotation is enabled for a secret, its rotation
+// function and schedule, and when it was last rotated
+func (s *SecretsService) GetRotationStatus(ctx context.Context, profileID string, secretID string) (*RotationStatus, error) {
+	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe secret: %w", classifyAWSError(err, "secretsmanager:DescribeSecret", "secret "+secretID))
+	}
+
+	return newRotationStatus(result), nil
+}
+
+// newRotationStatus extracts the rotation settings of a described secret
+func newRotationStatus(result *secretsmanager.DescribeSecretOutput) *RotationStatus {
+	status := &RotationStatus{
+		ARN:               aws.ToString(result.ARN),
+		Name:              aws.ToString(result.Name),
+		RotationEnabled:   aws.ToBool(result.RotationEnabled),
+		RotationLambdaARN: aws.ToString(result.RotationLambdaARN),
+	}
+	if rules := result.RotationRules; rules != nil {
+		status.RotationRules = &RotationRules{
+			AutomaticallyAfterDays: aws.ToInt64(rules.AutomaticallyAfterDays),
+			Duration:               aws.ToString(rules.Duration),
+			ScheduleExpression:     aws.ToString(rules.ScheduleExpression),
+		}
+	}
+	if result.LastRotatedDate != nil {
+		status.LastRotatedDate = result.LastRotatedDate.Format(time.RFC3339)
+	}
+	if result.NextRotationDate != nil {
+		status.NextRotationDate = result.NextRotationDate.Format(time.RFC3339)
+	}
+	return status
+}
+
+// RotateSecret starts an immediate rotation of a secret with its configured rotation
+// function. Secrets without rotation configured are rejected before calling the API.
+func (s *SecretsService) RotateSecret(ctx context.Context, profileID string, secretID string) (*SecretWriteResult, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("secret_id is required")
+	}
+
+	status, err := s.GetRotationStatus(ctx, profileID, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if !status.configured() {
+		return nil, fmt.Errorf("rotation is not configured for secret %s: set up a rotation function and schedule before rotating", secretID)
+	}
+
+	client, err := s.clientManager.GetSecretsManagerClient(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.RotateSecret(ctx, &secretsmanager.RotateSecretInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate secret: %w", classifyAWSError(err, "secretsmanager:RotateSecret", "secret "+secretID))
+	}
+
+	return &SecretWriteResult{
+		ARN:       aws.ToString(result.ARN),
+		Name:      aws.ToString(result.Name),
+		VersionID: aws.ToString(result.VersionId),
+	}, nil
+}
+
+// redactSecretValue masks any occurrence of value in err's message, so a validation
+// error echoing the request cannot leak the secret into a response or log line. A
+// ServiceError keeps its category and code; the original error is not reachable from
+// the result, since it still holds the value.
+func redactSecretValue(err error, value string) error {
+	if err == nil || value == "" || !strings.Contains(err.Error(), value) {
+		return err
+	}
+	redacted := errors.New(strings.ReplaceAll(err.Error(), value, "********"))
+
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		return &ServiceError{
+			Category: serviceErr.Category,
+			Code:     serviceErr.Code,
+			Message:  strings.ReplaceAll(serviceErr.Message, value, "********"),
+			Err:      redacted,
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,135 @@
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretWriteResultOmitsValue(t *testing.T) {
+	const value = `{"password":"hunter2-s3cr3t"}`
+	result := &SecretWriteResult{
+		ARN:       "arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/api/db-AbCdEf",
+		Name:      "prod/api/db",
+		VersionID: "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111",
+	}
+
+	encoded, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encoded), "hunter2")
+
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(encoded, &fields))
+	assert.Len(t, fields, 3)
+	for _, field := range fields {
+		assert.NotEqual(t, value, field)
+	}
+
+	// The manager logs writes with %s
+	logLine := fmt.Sprintf("Updated %s for profile %s", result, "prod")
+	assert.Equal(t, "Updated secret arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/api/db-AbCdEf (version a1b2c3d4-5678-90ab-cdef-EXAMPLE11111) for profile prod", logLine)
+	assert.NotContains(t, logLine, "hunter2")
+}
+
+func TestRedactSecretValue(t *testing.T) {
+	const value = "hunter2-s3cr3t"
+
+	err := redactSecretValue(errors.New("ValidationException: invalid SecretString hunter2-s3cr3t"), value)
+	assert.EqualError(t, err, "ValidationException: invalid SecretString ********")
+
+	// Errors without the value are returned unchanged, keeping their chain
+	original := fmt.Errorf("wrapped: %w", errors.New("AccessDeniedException"))
+	assert.Same(t, original, redactSecretValue(original, value))
+
+	assert.NoError(t, redactSecretValue(nil, value))
+
+	// Classified errors keep their category and code without the value
+	classified := &ServiceError{
+		Category: ErrorCategoryAccessDenied,
+		Code:     "AccessDeniedException",
+		Message:  "not allowed to store hunter2-s3cr3t",
+		Err:      errors.New("AccessDeniedException: not allowed to store hunter2-s3cr3t"),
+	}
+	err = redactSecretValue(fmt.Errorf("failed to update secret: %w", classified), value)
+	var serviceErr *ServiceError
+	if assert.ErrorAs(t, err, &serviceErr) {
+		assert.Equal(t, ErrorCategoryAccessDenied, serviceErr.Category)
+		assert.Equal(t, "AccessDeniedException", serviceErr.Code)
+		assert.Equal(t, "not allowed to store ********", serviceErr.Message)
+	}
+	assert.EqualError(t, err, "failed to update secret: AccessDeniedException: not allowed to store ********")
+	assert.NotErrorIs(t, err, classified)
+}
+
+func TestSetSecretValueRedactsByDefault(t *testing.T) {
+	secret := &SecretValue{Name: "prod/api/db"}
+	setSecretValue(secret, []byte("hunter2"), false, false)
+
+	assert.False(t, secret.Revealed)
+	assert.Empty(t, secret.Value)
+	assert.Equal(t, 7, secret.Length)
+	assert.Equal(t, "f52fbd32b2b3b86ff88ef6c490628285f482af15ddcb29541f94bcf526a3f6c7", secret.SHA256)
+
+	encoded, err := json.Marshal(secret)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encoded), "hunter2")
+	assert.NotContains(t, string(encoded), `"value"`)
+}
+
+func TestSetSecretValueReveals(t *testing.T) {
+	secret := &SecretValue{}
+	setSecretValue(secret, []byte("hunter2"), false, true)
+	assert.True(t, secret.Revealed)
+	assert.Equal(t, "hunter2", secret.Value)
+	assert.Equal(t, "f52fbd32b2b3b86ff88ef6c490628285f482af15ddcb29541f94bcf526a3f6c7", secret.SHA256)
+
+	// Binary secrets are revealed base64-encoded
+	binary := &SecretValue{}
+	setSecretValue(binary, []byte{0xde, 0xad, 0xbe, 0xef}, true, true)
+	assert.True(t, binary.Binary)
+	assert.Equal(t, "3q2+7w==", binary.Value)
+	assert.Equal(t, 4, binary.Length)
+}
+
+func TestNewRotationStatus(t *testing.T) {
+	lastRotated := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := newRotationStatus(&secretsmanager.DescribeSecretOutput{
+		ARN:               aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/api/db-AbCdEf"),
+		Name:              aws.String("prod/api/db"),
+		RotationEnabled:   aws.Bool(true),
+		RotationLambdaARN: aws.String("arn:aws:lambda:us-east-1:123456789012:function:rotate-db"),
+		RotationRules: &types.RotationRulesType{
+			AutomaticallyAfterDays: aws.Int64(30),
+			ScheduleExpression:     aws.String("rate(30 days)"),
+		},
+		LastRotatedDate:  aws.Time(lastRotated),
+		NextRotationDate: aws.Time(lastRotated.Add(30 * 24 * time.Hour)),
+	})
+
+	assert.True(t, status.RotationEnabled)
+	assert.True(t, status.configured())
+	assert.Equal(t, "prod/api/db", status.Name)
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:rotate-db", status.RotationLambdaARN)
+	assert.Equal(t, &RotationRules{AutomaticallyAfterDays: 30, ScheduleExpression: "rate(30 days)"}, status.RotationRules)
+	assert.Equal(t, "2025-01-02T03:04:05Z", status.LastRotatedDate)
+	assert.Equal(t, "2025-02-01T03:04:05Z", status.NextRotationDate)
+}
+
+func TestNewRotationStatusNotConfigured(t *testing.T) {
+	status := newRotationStatus(&secretsmanager.DescribeSecretOutput{
+		ARN:  aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/api/key-AbCdEf"),
+		Name: aws.String("prod/api/key"),
+	})
+
+	assert.False(t, status.RotationEnabled)
+	assert.False(t, status.configured())
+	assert.Nil(t, status.RotationRules)
+	assert.Empty(t, status.LastRotatedDate)
+}
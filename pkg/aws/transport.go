@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+)
+
+// newHTTPClient builds the HTTP client shared by all SDK clients of a profile.
+// The transport is configured explicitly so proxy settings are never bypassed:
+// an explicit ProxyURL wins, otherwise HTTP_PROXY/HTTPS_PROXY/NO_PROXY are honored.
+func newHTTPClient(profile *ProfileConfig) (*awshttp.BuildableClient, error) {
+	proxy := http.ProxyFromEnvironment
+	if profile.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(profile.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	var rootCAs *x509.CertPool
+	if profile.CABundlePath != "" {
+		pool, err := loadCABundle(profile.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs = pool
+	}
+
+	client := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		tr.Proxy = proxy
+		if rootCAs != nil {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			tr.TLSClientConfig.RootCAs = rootCAs
+		}
+	})
+	return client, nil
+}
+
+// parseProxyURL validates an explicit proxy URL (http, https or socks5). Errors never
+// echo the URL's password, since proxy URLs often carry credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		// url.Error repeats the whole input, so report only the underlying cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid proxy_url: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy_url scheme %q (expected http, https or socks5)", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy_url %q: missing host", proxyURL.Redacted())
+	}
+	return proxyURL, nil
+}
+
+// loadCABundle returns the system cert pool extended with the PEM certificates in path
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", path, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid PEM certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateOrder selects how numeric dates such as 01/02/2025 are read
+type DateOrder string
+
+const (
+	// DateOrderAuto accepts numeric dates with a single valid reading and rejects
+	// ones that read as two different dates
+	DateOrderAuto DateOrder = ""
+	// DateOrderMDY prefers month first (US style, 01/02/2025 is January 2)
+	DateOrderMDY DateOrder = "MDY"
+	// DateOrderDMY prefers day first (01/02/2025 is February 1)
+	DateOrderDMY DateOrder = "DMY"
+)
+
+// numericDatePattern matches day/month/year dates in either order with "/", "-" or "."
+// separators and an optional time of day
+var numericDatePattern = regexp.MustCompile(`^(\d{1,2})([/.-])(\d{1,2})([/.-])(\d{4})(?:[ T](\d{1,2}:\d{2}(?::\d{2})?))?$`)
+
+var (
+	dateParsingMu     sync.RWMutex
+	customDateLayouts []string
+	numericDateOrder  = DateOrderAuto
+)
+
+// ConfigureDateParsing sets the extra Go time layouts ParseDateTime tries after the
+// ISO 8601 formats, separated by ";" (e.g. "Jan 2, 2006;2 Jan 2006 15:04"), and the
+// preferred order of numeric dates: "MDY", "DMY", or "" / "auto" to accept only
+// unambiguous ones. Invalid settings leave the current configuration unchanged.
+func ConfigureDateParsing(layouts string, order string) error {
+	var parsedOrder DateOrder
+	switch strings.ToUpper(strings.TrimSpace(order)) {
+	case "", "AUTO":
+		parsedOrder = DateOrderAuto
+	case "MDY":
+		parsedOrder = DateOrderMDY
+	case "DMY":
+		parsedOrder = DateOrderDMY
+	default:
+		return fmt.Errorf("invalid date order '%s': expected MDY, DMY or auto", order)
+	}
+
+	var parsedLayouts []string
+	for _, layout := range strings.Split(layouts, ";") {
+		if layout = strings.TrimSpace(layout); layout != "" {
+			parsedLayouts = append(parsedLayouts, layout)
+		}
+	}
+
+	dateParsingMu.Lock()
+	defer dateParsingMu.Unlock()
+	customDateLayouts = parsedLayouts
+	numericDateOrder = parsedOrder
+	return nil
+}
+
+// dateParsingSettings returns the configured custom layouts and numeric date order
+func dateParsingSettings() ([]string, DateOrder) {
+	dateParsingMu.RLock()
+	defer dateParsingMu.RUnlock()
+	return customDateLayouts, numericDateOrder
+}
+
+// parseNumericDate parses a numeric date such as 01/02/2025, 01-02-2025 or
+// 01.02.2025 15:30 in loc. The second result is false when input is not a numeric
+// date. When both the month-first and the day-first reading are valid and differ, the
+// configured order decides; without one the date is rejected as ambiguous.
+func parseNumericDate(input string, loc *time.Location, order DateOrder) (*time.Time, bool, error) {
+	match := numericDatePattern.FindStringSubmatch(input)
+	if match == nil || match[2] != match[4] {
+		return nil, false, nil
+	}
+
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[3])
+	year, _ := strconv.Atoi(match[5])
+
+	var clock time.Time
+	if match[6] != "" {
+		layout := "15:04"
+		if strings.Count(match[6], ":") == 2 {
+			layout = "15:04:05"
+		}
+		var err error
+		if clock, err = time.Parse(layout, match[6]); err != nil {
+			return nil, true, fmt.Errorf("invalid time of day in '%s': %w", input, err)
+		}
+	}
+
+	build := func(month, day int) *time.Time {
+		if month < 1 || month > 12 || day < 1 {
+			return nil
+		}
+		t := time.Date(year, time.Month(month), day, clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+		// time.Date normalizes overflowing days such as 31/04 into the next month
+		if t.Day() != day {
+			return nil
+		}
+		return &t
+	}
+	monthFirst := build(first, second)
+	dayFirst := build(second, first)
+
+	preferred, fallback := monthFirst, dayFirst
+	if order == DateOrderDMY {
+		preferred, fallback = dayFirst, monthFirst
+	}
+
+	switch {
+	case monthFirst == nil && dayFirst == nil:
+		return nil, true, fmt.Errorf("invalid date '%s': not a valid month/day or day/month date", input)
+	case monthFirst == nil || dayFirst == nil || monthFirst.Equal(*dayFirst):
+		if preferred != nil {
+			return preferred, true, nil
+		}
+		return fallback, true, nil
+	case order == DateOrderAuto:
+		return nil, true, fmt.Errorf("ambiguous date '%s': it reads as %s month first (MM%sDD%sYYYY) or %s day first (DD%sMM%sYYYY); use ISO 8601 (YYYY-MM-DD) or configure DATE_ORDER as MDY or DMY",
+			input, monthFirst.Format("2006-01-02"), match[2], match[2], dayFirst.Format("2006-01-02"), match[2], match[2])
+	default:
+		return preferred, true, nil
+	}
+}
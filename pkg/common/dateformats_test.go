@@ -0,0 +1,172 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// configureDateParsing applies a date parsing configuration for the duration of a test
+func configureDateParsing(t *testing.T, layouts string, order string) {
+	t.Helper()
+	if err := ConfigureDateParsing(layouts, order); err != nil {
+		t.Fatalf("ConfigureDateParsing(%q, %q) unexpected error: %v", layouts, order, err)
+	}
+	t.Cleanup(func() {
+		_ = ConfigureDateParsing("", "")
+	})
+}
+
+func TestConfigureDateParsingInvalidOrder(t *testing.T) {
+	configureDateParsing(t, "", "DMY")
+
+	if err := ConfigureDateParsing("", "YMD"); err == nil {
+		t.Fatal("ConfigureDateParsing with order YMD expected error, got nil")
+	}
+
+	// The previous configuration stays in effect
+	if _, order := dateParsingSettings(); order != DateOrderDMY {
+		t.Errorf("date order = %q after invalid configuration, want %q", order, DateOrderDMY)
+	}
+}
+
+func TestParseDateTimeNumericDates(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    string
+		input    string
+		expected time.Time
+		wantErr  string
+	}{
+		{
+			name:     "unambiguous month first",
+			input:    "12/25/2025",
+			expected: time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "unambiguous day first with dashes",
+			input:    "25-12-2025",
+			expected: time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "same date either way",
+			input:    "05/05/2025",
+			expected: time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "ambiguous without configured order",
+			input:   "01/02/2025",
+			wantErr: "ambiguous date '01/02/2025': it reads as 2025-01-02 month first (MM/DD/YYYY) or 2025-02-01 day first (DD/MM/YYYY)",
+		},
+		{
+			name:     "ambiguous with MDY",
+			order:    "MDY",
+			input:    "01/02/2025",
+			expected: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "ambiguous with DMY",
+			order:    "dmy",
+			input:    "01-02-2025",
+			expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DMY preference falls back to the only valid reading",
+			order:    "DMY",
+			input:    "12/25/2025",
+			expected: time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "dots with time of day",
+			order:    "DMY",
+			input:    "09.01.2025 15:30",
+			expected: time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "time with seconds",
+			input:    "12/25/2025 08:05:09",
+			expected: time.Date(2025, 12, 25, 8, 5, 9, 0, time.UTC),
+		},
+		{
+			name:    "invalid in both orders",
+			input:   "13/13/2025",
+			wantErr: "not a valid month/day or day/month date",
+		},
+		{
+			name:    "day overflow is not normalized",
+			input:   "04/31/2025",
+			wantErr: "not a valid month/day or day/month date",
+		},
+		{
+			name:    "invalid time of day",
+			input:   "12/25/2025 25:00",
+			wantErr: "invalid time of day",
+		},
+		{
+			name:    "mixed separators",
+			input:   "12/25-2025",
+			wantErr: "unable to parse date/time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configureDateParsing(t, "", tt.order)
+
+			result, err := ParseDateTime(tt.input)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("ParseDateTime(%q) = %v, want error containing %q", tt.input, result, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseDateTime(%q) error = %q, want it to contain %q", tt.input, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDateTime(%q) unexpected error: %v", tt.input, err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("ParseDateTime(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDateTimeCustomLayouts(t *testing.T) {
+	configureDateParsing(t, "Jan 2, 2006; 2 January 2006 15:04", "")
+
+	loc := time.FixedZone("UTC-5", -5*3600)
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Mar 4, 2025", time.Date(2025, 3, 4, 0, 0, 0, 0, loc)},
+		{"4 March 2025 09:15", time.Date(2025, 3, 4, 9, 15, 0, 0, loc)},
+		// ISO 8601 still comes first
+		{"2025-03-04", time.Date(2025, 3, 4, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseDateTimeInLocation(tt.input, loc)
+		if err != nil {
+			t.Errorf("ParseDateTimeInLocation(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if !result.Equal(tt.expected) {
+			t.Errorf("ParseDateTimeInLocation(%q) = %v, want %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestParseExplicitRangeNumericDates(t *testing.T) {
+	configureDateParsing(t, "", "DMY")
+
+	tr, err := ParseExplicitRange("01/02/2025..15/02/2025")
+	if err != nil {
+		t.Fatalf("ParseExplicitRange unexpected error: %v", err)
+	}
+	if !tr.Start.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) || !tr.End.Equal(time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseExplicitRange = %v..%v, want 2025-02-01..2025-02-15", tr.Start, tr.End)
+	}
+}
@@ -0,0 +1,27 @@
+package common
+
+import "strings"
+
+// MatchesLabels reports whether a configured connection or profile matches an
+// environment and tag filter. An empty environment matches any environment; otherwise
+// it must equal the configured one. Every wanted tag must be among the configured tags.
+// Both comparisons ignore case.
+func MatchesLabels(environment string, tags []string, wantEnvironment string, wantTags []string) bool {
+	if wantEnvironment != "" && !strings.EqualFold(environment, wantEnvironment) {
+		return false
+	}
+
+	for _, want := range wantTags {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesLabels(t *testing.T) {
+	tags := []string{"transactions", "critical"}
+
+	assert.True(t, MatchesLabels("production", tags, "", nil))
+	assert.True(t, MatchesLabels("production", tags, "Production", nil))
+	assert.False(t, MatchesLabels("staging", tags, "production", nil))
+	assert.False(t, MatchesLabels("", tags, "production", nil))
+
+	// Tags must all match
+	assert.True(t, MatchesLabels("production", tags, "", []string{"transactions"}))
+	assert.True(t, MatchesLabels("production", tags, "", []string{"CRITICAL", "transactions"}))
+	assert.False(t, MatchesLabels("production", tags, "", []string{"transactions", "billing"}))
+	assert.False(t, MatchesLabels("production", nil, "", []string{"transactions"}))
+
+	assert.True(t, MatchesLabels("production", tags, "production", []string{"critical"}))
+	assert.False(t, MatchesLabels("staging", tags, "production", []string{"critical"}))
+}
@@ -2,10 +2,16 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// relativeRangePattern matches dynamic ranges such as "last_45_minutes" or "last_2_hours"
+var relativeRangePattern = regexp.MustCompile(`^last_?(\d+)_?(minute|minutes|hour|hours|day|days)$`)
+
 // TimeRange represents a time range for queries
 type TimeRange struct {
 	Start time.Time
@@ -25,6 +31,10 @@ func (tr *TimeRange) EndMillis() int64 {
 // AvailableTimeRanges returns a list of available predefined time range names
 func AvailableTimeRanges() []string {
 	return []string{
+		"last_5_minutes",
+		"last_10_minutes",
+		"last_15_minutes",
+		"last_30_minutes",
 		"last_1_hour",
 		"last_3_hours",
 		"last_6_hours",
@@ -43,19 +53,62 @@ func AvailableTimeRanges() []string {
 		"last_week",
 		"this_month",
 		"last_month",
+		"this_quarter",
+		"last_quarter",
+		"this_year",
+		"last_year",
 	}
 }
 
 // ParseTimeRange parses a time range string and returns the corresponding TimeRange
-// It supports predefined ranges (e.g., "last_7_days") and custom epoch milliseconds
+// It supports predefined ranges (e.g., "last_7_days") and explicit ranges such as
+// "2025-01-01..2025-01-05" (see ParseExplicitRange). Calendar ranges such as "today"
+// use the local time zone, while dates without an offset in an explicit range are
+// read in UTC; see ParseTimeRangeInLocation.
 func ParseTimeRange(name string) (*TimeRange, error) {
+	return ParseTimeRangeInLocation(name, nil)
+}
+
+// ParseTimeRangeInLocation parses a time range string like ParseTimeRange, computing
+// the boundaries of calendar ranges ("today", "this_week", "last_month", ...) in loc,
+// so that "today" starts at midnight of loc's current day. Dates without an offset in an
+// explicit range are also read in loc. A nil loc means the local time zone for calendar
+// ranges and UTC for explicit ranges, matching ParseDateTime.
+func ParseTimeRangeInLocation(name string, loc *time.Location) (*TimeRange, error) {
 	if name == "" {
 		return nil, nil
 	}
+	if loc == nil {
+		if _, _, ok := splitExplicitRange(name); ok {
+			return ParseExplicitRange(name)
+		}
+		loc = time.Local
+	}
 
-	now := time.Now()
+	return parseTimeRangeAt(name, time.Now().In(loc))
+}
 
+// parseTimeRangeAt parses a time range relative to now, computing calendar boundaries
+// in now's location
+func parseTimeRangeAt(name string, now time.Time) (*TimeRange, error) {
 	switch strings.ToLower(strings.TrimSpace(name)) {
+	// Minutes-based ranges
+	case "last5minutes", "last_5_minutes":
+		start := now.Add(-5 * time.Minute)
+		return &TimeRange{Start: start, End: now}, nil
+
+	case "last10minutes", "last_10_minutes":
+		start := now.Add(-10 * time.Minute)
+		return &TimeRange{Start: start, End: now}, nil
+
+	case "last15minutes", "last_15_minutes":
+		start := now.Add(-15 * time.Minute)
+		return &TimeRange{Start: start, End: now}, nil
+
+	case "last30minutes", "last_30_minutes":
+		start := now.Add(-30 * time.Minute)
+		return &TimeRange{Start: start, End: now}, nil
+
 	// Hours-based ranges
 	case "last1hour", "last_1_hour", "lasthour", "last_hour":
 		start := now.Add(-1 * time.Hour)
@@ -113,20 +166,19 @@ func ParseTimeRange(name string) (*TimeRange, error) {
 
 	case "yesterday":
 		end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-		start := end.Add(-24 * time.Hour)
+		// AddDate keeps midnight across daylight saving changes, unlike subtracting 24h
+		start := end.AddDate(0, 0, -1)
 		return &TimeRange{Start: start, End: end}, nil
 
 	case "thisweek", "this_week":
 		weekday := int(now.Weekday())
-		start := now.Add(-time.Duration(weekday) * 24 * time.Hour)
-		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, now.Location())
+		start := time.Date(now.Year(), now.Month(), now.Day()-weekday, 0, 0, 0, 0, now.Location())
 		return &TimeRange{Start: start, End: now}, nil
 
 	case "lastweek", "last_week":
 		weekday := int(now.Weekday())
-		thisWeekStart := now.Add(-time.Duration(weekday) * 24 * time.Hour)
-		thisWeekStart = time.Date(thisWeekStart.Year(), thisWeekStart.Month(), thisWeekStart.Day(), 0, 0, 0, 0, now.Location())
-		lastWeekStart := thisWeekStart.Add(-7 * 24 * time.Hour)
+		thisWeekStart := time.Date(now.Year(), now.Month(), now.Day()-weekday, 0, 0, 0, 0, now.Location())
+		lastWeekStart := thisWeekStart.AddDate(0, 0, -7)
 		return &TimeRange{Start: lastWeekStart, End: thisWeekStart}, nil
 
 	case "thismonth", "this_month":
@@ -143,14 +195,116 @@ func ParseTimeRange(name string) (*TimeRange, error) {
 		}
 		return &TimeRange{Start: lastMonthStart, End: thisMonthStart}, nil
 
+	case "thisquarter", "this_quarter":
+		start := quarterStart(now)
+		return &TimeRange{Start: start, End: now}, nil
+
+	case "lastquarter", "last_quarter":
+		// In Q1 this steps back into October of the previous year
+		thisQuarterStart := quarterStart(now)
+		return &TimeRange{Start: thisQuarterStart.AddDate(0, -3, 0), End: thisQuarterStart}, nil
+
+	case "thisyear", "this_year":
+		start := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return &TimeRange{Start: start, End: now}, nil
+
+	case "lastyear", "last_year":
+		thisYearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return &TimeRange{Start: thisYearStart.AddDate(-1, 0, 0), End: thisYearStart}, nil
+
 	default:
-		return nil, fmt.Errorf("unknown time range: %s. Available ranges: %s", name, strings.Join(AvailableTimeRanges(), ", "))
+		if m := relativeRangePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(name))); m != nil {
+			return parseRelativeRange(name, m[1], m[2], now)
+		}
+		if _, _, ok := splitExplicitRange(name); ok {
+			return parseExplicitRangeInLocation(name, now.Location())
+		}
+		return nil, fmt.Errorf("unknown time range: %s. Available ranges: %s, last_N_minutes, last_N_hours, last_N_days, or an explicit range such as 2025-01-01..2025-01-05", name, strings.Join(AvailableTimeRanges(), ", "))
 	}
 }
 
+// quarterStart returns midnight of the first day of the calendar quarter containing t
+func quarterStart(t time.Time) time.Time {
+	firstMonth := time.Month((int(t.Month())-1)/3*3 + 1)
+	return time.Date(t.Year(), firstMonth, 1, 0, 0, 0, 0, t.Location())
+}
+
+// parseRelativeRange builds a last_N_<unit> range ending now
+func parseRelativeRange(name string, count string, unit string, now time.Time) (*TimeRange, error) {
+	var step time.Duration
+	switch strings.TrimSuffix(unit, "s") {
+	case "minute":
+		step = time.Minute
+	case "hour":
+		step = time.Hour
+	default:
+		step = 24 * time.Hour
+	}
+
+	n, err := strconv.ParseInt(count, 10, 64)
+	if err != nil || n > math.MaxInt64/int64(step) {
+		return nil, fmt.Errorf("invalid time range %s: %s is too large", name, count)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid time range %s: the number of %ss must be positive", name, strings.TrimSuffix(unit, "s"))
+	}
+
+	return &TimeRange{Start: now.Add(-time.Duration(n) * step), End: now}, nil
+}
+
+// ParseExplicitRange parses an explicit range of two date/times separated by ".." or
+// " to ", e.g. "2025-01-01..2025-01-05" or "from 2025-01-01 to 2025-01-05T12:00:00Z".
+// Each side accepts the formats of ParseDateTime, and the start must precede the end.
+func ParseExplicitRange(s string) (*TimeRange, error) {
+	return parseExplicitRangeInLocation(s, time.UTC)
+}
+
+// parseExplicitRangeInLocation parses an explicit range, reading dates without an
+// offset in loc
+func parseExplicitRangeInLocation(s string, loc *time.Location) (*TimeRange, error) {
+	from, to, ok := splitExplicitRange(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid time range '%s': expected 'START..END' or 'START to END'", s)
+	}
+
+	start, err := ParseDateTimeInLocation(from, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range start: %w", err)
+	}
+	end, err := ParseDateTimeInLocation(to, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range end: %w", err)
+	}
+	if start == nil || end == nil {
+		return nil, fmt.Errorf("invalid time range '%s': both a start and an end are required", s)
+	}
+	if !start.Before(*end) {
+		return nil, fmt.Errorf("invalid time range '%s': start %s is not before end %s", s, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	return &TimeRange{Start: *start, End: *end}, nil
+}
+
+// splitExplicitRange splits an explicit range into its start and end, dropping an
+// optional leading "from"
+func splitExplicitRange(s string) (string, string, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) > 5 && strings.EqualFold(s[:5], "from ") {
+		s = s[5:]
+	}
+
+	if i := strings.Index(s, ".."); i >= 0 {
+		return s[:i], s[i+2:], true
+	}
+	if i := strings.Index(strings.ToLower(s), " to "); i >= 0 {
+		return s[:i], s[i+4:], true
+	}
+	return "", "", false
+}
+
 // TimeRangeHelpText returns a help text describing available time range options
 func TimeRangeHelpText() string {
-	return `Human-readable time range. Options: last_1_hour, last_3_hours, last_6_hours, last_12_hours, last_24_hours, last_2_days, last_3_days, last_7_days, last_14_days, last_30_days, last_60_days, last_90_days, today, yesterday, this_week, last_week, this_month, last_month. Takes precedence over date/time parameters if provided.`
+	return `Human-readable time range. Options: last_5_minutes, last_10_minutes, last_15_minutes, last_30_minutes, last_1_hour, last_3_hours, last_6_hours, last_12_hours, last_24_hours, last_2_days, last_3_days, last_7_days, last_14_days, last_30_days, last_60_days, last_90_days, today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, any last_N_minutes, last_N_hours or last_N_days (e.g. last_45_minutes), or an explicit range such as 2025-01-01..2025-01-05 or '2025-01-01 to 2025-01-05T12:00:00Z'. Takes precedence over date/time parameters if provided.`
 }
 
 // ParseDateTime parses a date/time string in various formats and returns the time
@@ -158,9 +312,22 @@ func TimeRangeHelpText() string {
 //   - ISO 8601: "2025-01-09T15:30:00Z", "2025-01-09T15:30:00-05:00"
 //   - Date only: "2025-01-09" (assumes midnight UTC)
 //   - Date with time: "2025-01-09 15:30:00"
+//   - Layouts configured with ConfigureDateParsing
+//   - Numeric dates: "01/09/2025", "09-01-2025", "09.01.2025 15:30", read month or
+//     day first per the configured DateOrder
 //
 // Returns nil if the input is empty
 func ParseDateTime(input string) (*time.Time, error) {
+	return ParseDateTimeInLocation(input, time.UTC)
+}
+
+// ParseDateTimeInLocation parses a date/time string like ParseDateTime, but reads
+// inputs without an offset ("2025-01-09", "2025-01-09 15:30:00") in loc instead of
+// UTC. A nil loc means UTC.
+func ParseDateTimeInLocation(input string, loc *time.Location) (*time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return nil, nil
@@ -170,24 +337,37 @@ func ParseDateTime(input string) (*time.Time, error) {
 	formats := []string{
 		time.RFC3339,           // "2006-01-02T15:04:05Z07:00"
 		time.RFC3339Nano,       // "2006-01-02T15:04:05.999999999Z07:00"
-		"2006-01-02T15:04:05",  // ISO without timezone (assume UTC)
+		"2006-01-02T15:04:05",  // ISO without timezone (in loc)
 		"2006-01-02 15:04:05",  // Space-separated datetime
-		"2006-01-02",           // Date only (midnight UTC)
+		"2006-01-02",           // Date only (midnight in loc)
 	}
 
+	customLayouts, order := dateParsingSettings()
+	formats = append(formats, customLayouts...)
+
 	for _, format := range formats {
-		if t, err := time.Parse(format, input); err == nil {
+		if t, err := time.ParseInLocation(format, input, loc); err == nil {
 			return &t, nil
 		}
 	}
 
-	return nil, fmt.Errorf("unable to parse date/time '%s'. Supported formats: ISO 8601 (2025-01-09T15:30:00Z), date only (2025-01-09), or datetime (2025-01-09 15:30:00)", input)
+	if t, isNumeric, err := parseNumericDate(input, loc, order); isNumeric {
+		return t, err
+	}
+
+	return nil, fmt.Errorf("unable to parse date/time '%s'. Supported formats: ISO 8601 (2025-01-09T15:30:00Z), date only (2025-01-09), datetime (2025-01-09 15:30:00), or numeric dates (01/09/2025, 09-01-2025)", input)
 }
 
 // ParseDateTimeMillis parses a date/time string and returns epoch milliseconds
 // Returns 0 if the input is empty
 func ParseDateTimeMillis(input string) (int64, error) {
-	t, err := ParseDateTime(input)
+	return ParseDateTimeMillisInLocation(input, time.UTC)
+}
+
+// ParseDateTimeMillisInLocation parses a date/time string like ParseDateTimeInLocation
+// and returns epoch milliseconds, or 0 if the input is empty
+func ParseDateTimeMillisInLocation(input string, loc *time.Location) (int64, error) {
+	t, err := ParseDateTimeInLocation(input, loc)
 	if err != nil {
 		return 0, err
 	}
@@ -215,7 +215,7 @@ func TestTimeRangeHelpText(t *testing.T) {
 	}
 
 	// Verify it mentions some key ranges
-	expectedTerms := []string{"last_7_days", "last_30_days", "today", "this_month"}
+	expectedTerms := []string{"last_5_minutes", "last_7_days", "last_30_days", "today", "this_month", "last_N_minutes"}
 	for _, term := range expectedTerms {
 		if !containsString(help, term) {
 			t.Errorf("TimeRangeHelpText() should mention %q", term)
@@ -382,3 +382,358 @@ func TestParseDateTimeMillis(t *testing.T) {
 	}
 }
 
+
+func TestParseExplicitRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   bool
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "dates with double dot",
+			input:     "2025-01-01..2025-01-05",
+			wantStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "dates with to",
+			input:     "2025-01-01 to 2025-01-05",
+			wantStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "from prefix and mixed formats",
+			input:     "From 2025-01-01 10:00:00 TO 2025-01-01T12:30:00Z",
+			wantStart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "whitespace around delimiter",
+			input:     " 2025-01-01T10:00:00Z .. 2025-01-01T11:00:00Z ",
+			wantStart: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "start after end",
+			input:   "2025-01-05..2025-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "start equals end",
+			input:   "2025-01-05..2025-01-05",
+			wantErr: true,
+		},
+		{
+			name:    "missing end",
+			input:   "2025-01-01..",
+			wantErr: true,
+		},
+		{
+			name:    "invalid side",
+			input:   "2025-01-01..tomorrow",
+			wantErr: true,
+		},
+		{
+			name:    "no delimiter",
+			input:   "2025-01-01",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseExplicitRange(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseExplicitRange(%q) expected error, got %v", tt.input, result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseExplicitRange(%q) unexpected error: %v", tt.input, err)
+				return
+			}
+
+			if !result.Start.Equal(tt.wantStart) || !result.End.Equal(tt.wantEnd) {
+				t.Errorf("ParseExplicitRange(%q) = %v..%v, want %v..%v", tt.input, result.Start, result.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeExplicit(t *testing.T) {
+	result, err := ParseTimeRange("2025-01-01..2025-01-05")
+	if err != nil {
+		t.Fatalf("ParseTimeRange() unexpected error: %v", err)
+	}
+	if !result.Start.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || !result.End.Equal(time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseTimeRange() = %v..%v, want 2025-01-01..2025-01-05", result.Start, result.End)
+	}
+
+	if _, err := ParseTimeRange("2025-01-05 to 2025-01-01"); err == nil {
+		t.Error("ParseTimeRange() with start after end expected error, got nil")
+	}
+}
+
+func TestParseTimeRangeExplicitIgnoresLocalZone(t *testing.T) {
+	saved := time.Local
+	time.Local = time.FixedZone("UTC+9", 9*3600)
+	defer func() { time.Local = saved }()
+
+	for _, parse := range []func(string) (*TimeRange, error){
+		ParseTimeRange,
+		func(name string) (*TimeRange, error) { return ParseTimeRangeInLocation(name, nil) },
+	} {
+		result, err := parse("2025-01-01..2025-01-05")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !result.Start.Equal(want) {
+			t.Errorf("start = %v, want %v", result.Start, want)
+		}
+	}
+}
+
+func TestParseTimeRangeInLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	for _, name := range []string{"today", "yesterday", "this_week", "last_week", "this_month", "last_month"} {
+		t.Run(name, func(t *testing.T) {
+			inNewYork, err := ParseTimeRangeInLocation(name, newYork)
+			if err != nil {
+				t.Fatalf("ParseTimeRangeInLocation(%q, New York) unexpected error: %v", name, err)
+			}
+			inTokyo, err := ParseTimeRangeInLocation(name, tokyo)
+			if err != nil {
+				t.Fatalf("ParseTimeRangeInLocation(%q, Tokyo) unexpected error: %v", name, err)
+			}
+
+			// Boundaries fall on midnight of the zone's own calendar
+			for _, tr := range []struct {
+				start time.Time
+				loc   *time.Location
+			}{{inNewYork.Start, newYork}, {inTokyo.Start, tokyo}} {
+				local := tr.start.In(tr.loc)
+				if local.Hour() != 0 || local.Minute() != 0 || local.Second() != 0 {
+					t.Errorf("%s start %v is not midnight in %s", name, local, tr.loc)
+				}
+			}
+
+			if inNewYork.Start.Equal(inTokyo.Start) {
+				t.Errorf("%s starts at the same instant in New York and Tokyo: %v", name, inNewYork.Start)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeInLocationToday(t *testing.T) {
+	loc := time.FixedZone("UTC+14", 14*3600)
+
+	tr, err := ParseTimeRangeInLocation("today", loc)
+	if err != nil {
+		t.Fatalf("ParseTimeRangeInLocation() unexpected error: %v", err)
+	}
+
+	now := time.Now().In(loc)
+	want := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if !tr.Start.Equal(want) {
+		t.Errorf("today start = %v, want %v", tr.Start, want)
+	}
+	if tr.End.Sub(now) > time.Second {
+		t.Errorf("today end = %v, want about %v", tr.End, now)
+	}
+}
+
+func TestParseTimeRangeInLocationExplicit(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*3600)
+
+	tr, err := ParseTimeRangeInLocation("2025-01-01..2025-01-02T00:00:00Z", loc)
+	if err != nil {
+		t.Fatalf("ParseTimeRangeInLocation() unexpected error: %v", err)
+	}
+
+	// The date without an offset is midnight in loc; the one with an offset keeps it
+	if want := time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC); !tr.Start.Equal(want) {
+		t.Errorf("start = %v, want %v", tr.Start, want)
+	}
+	if want := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC); !tr.End.Equal(want) {
+		t.Errorf("end = %v, want %v", tr.End, want)
+	}
+}
+
+func TestParseDateTimeInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+
+	result, err := ParseDateTimeInLocation("2025-01-09 15:30:00", loc)
+	if err != nil {
+		t.Fatalf("ParseDateTimeInLocation() unexpected error: %v", err)
+	}
+	if want := time.Date(2025, 1, 9, 13, 30, 0, 0, time.UTC); !result.Equal(want) {
+		t.Errorf("ParseDateTimeInLocation() = %v, want %v", result, want)
+	}
+
+	millis, err := ParseDateTimeMillisInLocation("2025-01-09", nil)
+	if err != nil {
+		t.Fatalf("ParseDateTimeMillisInLocation() unexpected error: %v", err)
+	}
+	if want := time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC).UnixMilli(); millis != want {
+		t.Errorf("ParseDateTimeMillisInLocation() with nil location = %d, want %d (UTC)", millis, want)
+	}
+}
+
+func TestParseTimeRangeMinutes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"last_5_minutes", 5 * time.Minute},
+		{"last_10_minutes", 10 * time.Minute},
+		{"last_15_minutes", 15 * time.Minute},
+		{"last30minutes", 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			now := time.Now()
+			tr, err := ParseTimeRange(tt.input)
+			if err != nil {
+				t.Fatalf("ParseTimeRange(%q) unexpected error: %v", tt.input, err)
+			}
+
+			expectedStart := now.Add(-tt.want)
+			if tr.Start.Sub(expectedStart) >= time.Second || tr.End.Sub(now) >= time.Second {
+				t.Errorf("ParseTimeRange(%q) = %v..%v, want about %v..%v", tt.input, tr.Start, tr.End, expectedStart, now)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeDynamic(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		want    time.Duration
+	}{
+		{name: "minutes", input: "last_45_minutes", want: 45 * time.Minute},
+		{name: "single minute", input: "last_1_minute", want: time.Minute},
+		{name: "hours", input: "last_36_hours", want: 36 * time.Hour},
+		{name: "days", input: "last_5_days", want: 5 * 24 * time.Hour},
+		{name: "without underscores", input: "last2hours", want: 2 * time.Hour},
+		{name: "upper case", input: "LAST_20_MINUTES", want: 20 * time.Minute},
+		{name: "zero minutes", input: "last_0_minutes", wantErr: true},
+		{name: "zero days", input: "last_0_days", wantErr: true},
+		{name: "overflow", input: "last_99999999999999_days", wantErr: true},
+		{name: "unknown unit", input: "last_5_weeks", wantErr: true},
+		{name: "negative", input: "last_-5_minutes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			tr, err := ParseTimeRange(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseTimeRange(%q) expected error, got %v", tt.input, tr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseTimeRange(%q) unexpected error: %v", tt.input, err)
+			}
+			expectedStart := now.Add(-tt.want)
+			if tr.Start.Sub(expectedStart) >= time.Second || tr.End.Sub(now) >= time.Second {
+				t.Errorf("ParseTimeRange(%q) = %v..%v, want about %v..%v", tt.input, tr.Start, tr.End, expectedStart, now)
+			}
+		})
+	}
+}
+
+func TestParseTimeRangeQuartersAndYears(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "this_quarter in Q1",
+			input:     "this_quarter",
+			now:       time.Date(2025, 2, 14, 10, 0, 0, 0, time.UTC),
+			wantStart: date(2025, 1, 1),
+			wantEnd:   time.Date(2025, 2, 14, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "this_quarter on the last day of Q3",
+			input:     "this_quarter",
+			now:       time.Date(2025, 9, 30, 23, 0, 0, 0, time.UTC),
+			wantStart: date(2025, 7, 1),
+			wantEnd:   time.Date(2025, 9, 30, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "last_quarter in Q1 spans into the previous year",
+			input:     "last_quarter",
+			now:       time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+			wantStart: date(2024, 10, 1),
+			wantEnd:   date(2025, 1, 1),
+		},
+		{
+			name:      "last_quarter in Q2",
+			input:     "last_quarter",
+			now:       time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+			wantStart: date(2025, 1, 1),
+			wantEnd:   date(2025, 4, 1),
+		},
+		{
+			name:      "last_quarter in Q4",
+			input:     "last_quarter",
+			now:       time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC),
+			wantStart: date(2025, 7, 1),
+			wantEnd:   date(2025, 10, 1),
+		},
+		{
+			name:      "this_year",
+			input:     "this_year",
+			now:       time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC),
+			wantStart: date(2025, 1, 1),
+			wantEnd:   time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "last_year",
+			input:     "last_year",
+			now:       time.Date(2025, 1, 1, 0, 30, 0, 0, time.UTC),
+			wantStart: date(2024, 1, 1),
+			wantEnd:   date(2025, 1, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := parseTimeRangeAt(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("parseTimeRangeAt(%q) unexpected error: %v", tt.input, err)
+			}
+			if !tr.Start.Equal(tt.wantStart) || !tr.End.Equal(tt.wantEnd) {
+				t.Errorf("parseTimeRangeAt(%q, %v) = %v..%v, want %v..%v", tt.input, tt.now, tr.Start, tr.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
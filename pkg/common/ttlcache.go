@@ -0,0 +1,106 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a thread-safe in-memory cache whose entries expire after a time-to-live.
+// Expired entries are dropped lazily when they are read or overwritten.
+type TTLCache[T any] struct {
+	mu      sync.RWMutex
+	entries map[string]ttlCacheEntry[T]
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// ttlCacheEntry holds a cached value with its expiry time
+type ttlCacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// NewTTLCache creates a cache whose entries live for ttl unless set with their own TTL.
+// A ttl of zero or less disables caching: Set stores nothing and Get always misses.
+func NewTTLCache[T any](ttl time.Duration) *TTLCache[T] {
+	return &TTLCache[T]{
+		entries: make(map[string]ttlCacheEntry[T]),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// TTL returns the default time-to-live of the cache
+func (c *TTLCache[T]) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get returns the cached value for key if it exists and has not expired
+func (c *TTLCache[T]) Get(key string) (T, bool) {
+	c.mu.RLock()
+	entry, exists := c.entries[key]
+	c.mu.RUnlock()
+
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		c.mu.Lock()
+		// Another goroutine may have stored a fresh value in the meantime
+		if current, ok := c.entries[key]; ok && !c.now().Before(current.expiresAt) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Set stores a value under key for the default TTL
+func (c *TTLCache[T]) Set(key string, value T) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores a value under key for the given TTL; a TTL of zero or less removes
+// the key instead
+func (c *TTLCache[T]) SetWithTTL(key string, value T, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = ttlCacheEntry[T]{value: value, expiresAt: c.now().Add(ttl)}
+}
+
+// Invalidate removes key from the cache
+func (c *TTLCache[T]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// InvalidateFunc removes every key for which match returns true, e.g. all keys of a
+// profile when the cache key has other parts after the profile ID
+func (c *TTLCache[T]) InvalidateFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if match(key) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet dropped
+func (c *TTLCache[T]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.entries)
+}
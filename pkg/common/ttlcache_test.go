@@ -0,0 +1,111 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestCache returns a cache with a controllable clock
+func newTestCache(ttl time.Duration) (*TTLCache[string], *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewTTLCache[string](ttl)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	cache, now := newTestCache(time.Minute)
+
+	cache.Set("staging:log_groups", "cached")
+	if value, ok := cache.Get("staging:log_groups"); !ok || value != "cached" {
+		t.Fatalf("Get() = %q, %v; want cached, true", value, ok)
+	}
+
+	*now = now.Add(59 * time.Second)
+	if _, ok := cache.Get("staging:log_groups"); !ok {
+		t.Fatal("entry expired before its TTL")
+	}
+
+	*now = now.Add(time.Second)
+	if _, ok := cache.Get("staging:log_groups"); ok {
+		t.Fatal("entry still returned after its TTL")
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("expired entry not dropped on read, Len() = %d", cache.Len())
+	}
+}
+
+func TestTTLCachePerEntryTTL(t *testing.T) {
+	cache, now := newTestCache(time.Minute)
+
+	cache.SetWithTTL("short", "a", 10*time.Second)
+	cache.SetWithTTL("long", "b", 10*time.Minute)
+	cache.SetWithTTL("none", "c", 0)
+
+	*now = now.Add(5 * time.Minute)
+	if _, ok := cache.Get("short"); ok {
+		t.Error("short-lived entry should have expired")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Error("long-lived entry should still be cached")
+	}
+	if _, ok := cache.Get("none"); ok {
+		t.Error("entry set with a zero TTL should not be cached")
+	}
+}
+
+func TestTTLCacheDisabled(t *testing.T) {
+	cache := NewTTLCache[int](0)
+
+	cache.Set("key", 1)
+	if _, ok := cache.Get("key"); ok {
+		t.Fatal("cache with a zero TTL should never hit")
+	}
+}
+
+func TestTTLCacheInvalidation(t *testing.T) {
+	cache, _ := newTestCache(time.Minute)
+	cache.Set("staging:rds", "1")
+	cache.Set("staging:logs:/ecs", "2")
+	cache.Set("prod:rds", "3")
+
+	cache.Invalidate("staging:rds")
+	if _, ok := cache.Get("staging:rds"); ok {
+		t.Error("invalidated key still cached")
+	}
+
+	cache.InvalidateFunc(func(key string) bool { return strings.HasPrefix(key, "staging:") })
+	if _, ok := cache.Get("staging:logs:/ecs"); ok {
+		t.Error("key matching the invalidation function still cached")
+	}
+	if _, ok := cache.Get("prod:rds"); !ok {
+		t.Error("key of another profile was invalidated")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d after invalidation, want 1", cache.Len())
+	}
+}
+
+func TestTTLCacheConcurrentAccess(t *testing.T) {
+	cache := NewTTLCache[int](time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("key-%d", j%10)
+				cache.Set(key, i)
+				cache.Get(key)
+				if j%50 == 0 {
+					cache.Invalidate(key)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
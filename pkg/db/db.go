@@ -13,6 +13,8 @@ import (
 	// Import database drivers
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/microsoft/go-mssqldb"
+	_ "modernc.org/sqlite"
 )
 
 // Common database errors
@@ -43,7 +45,7 @@ type Config struct {
 	Port     int
 	User     string
 	Password string
-	Name     string
+	Name     string // Database name, or the file path (or ":memory:") for SQLite
 
 	// Additional PostgreSQL specific options
 	SSLMode            PostgresSSLMode
@@ -53,9 +55,15 @@ type Config struct {
 	ApplicationName    string
 	ConnectTimeout     int               // in seconds
 	QueryTimeout       int               // in seconds, default is 30 seconds
+	SchemaTimeout      int               // in seconds, default is 120 seconds; schema discovery scans the catalog
 	TargetSessionAttrs string            // for PostgreSQL 10+
 	Options            map[string]string // Extra connection options
 
+	// ReadOnly makes every session read-only in the database itself, so a statement that
+	// gets past the query validator still cannot write. SQL Server has no session-level
+	// equivalent; use a login without write permissions there.
+	ReadOnly bool
+
 	// Connection pool settings
 	MaxOpenConns    int
 	MaxIdleConns    int
@@ -86,6 +94,9 @@ func (c *Config) SetDefaults() {
 	if c.QueryTimeout == 0 {
 		c.QueryTimeout = 30 // Default 30 seconds
 	}
+	if c.SchemaTimeout == 0 {
+		c.SchemaTimeout = 120 // Default 2 minutes
+	}
 }
 
 // Database represents a generic database interface
@@ -107,6 +118,7 @@ type Database interface {
 	DriverName() string
 	ConnectionString() string
 	QueryTimeout() int
+	SchemaTimeout() int
 
 	// DB object access (for specific DB operations)
 	DB() *sql.DB
@@ -174,9 +186,45 @@ func buildPostgresConnStr(config Config) string {
 		}
 	}
 
+	// Unknown keys are sent as run-time parameters, so every transaction starts read-only
+	if config.ReadOnly {
+		params = append(params, "default_transaction_read_only=on")
+	}
+
 	return strings.Join(params, " ")
 }
 
+// buildSQLServerConnStr builds a SQL Server connection URL with all options
+func buildSQLServerConnStr(config Config) string {
+	port := config.Port
+	if port == 0 {
+		port = 1433
+	}
+
+	query := url.Values{}
+	if config.Name != "" {
+		query.Set("database", config.Name)
+	}
+	if config.ConnectTimeout > 0 {
+		query.Set("connection timeout", fmt.Sprintf("%d", config.ConnectTimeout))
+	}
+	// Application name for better identification in sys.dm_exec_sessions
+	if config.ApplicationName != "" {
+		query.Set("app name", config.ApplicationName)
+	}
+	for key, value := range config.Options {
+		query.Set(key, value)
+	}
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     fmt.Sprintf("%s:%d", config.Host, port),
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
 // NewDatabase creates a new database connection based on the provided configuration
 func NewDatabase(config Config) (Database, error) {
 	// Set default values for the configuration
@@ -191,9 +239,37 @@ func NewDatabase(config Config) (Database, error) {
 		driverName = "mysql"
 		dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
 			config.User, config.Password, config.Host, config.Port, config.Name)
+		if config.ReadOnly {
+			// The driver runs SET for unknown parameters on every new connection (MySQL 5.7.20+)
+			dsn += "&transaction_read_only=1"
+		}
 	case "postgres":
 		driverName = "postgres"
 		dsn = buildPostgresConnStr(config)
+	case "sqlite":
+		if config.Name == "" {
+			return nil, fmt.Errorf("sqlite database requires a file path in name")
+		}
+		driverName = "sqlite"
+		dsn = config.Name
+		if config.ReadOnly {
+			// query_only rejects every statement that would change the database file
+			separator := "?"
+			if strings.Contains(dsn, "?") {
+				separator = "&"
+			}
+			dsn += separator + "_pragma=query_only(1)"
+		}
+		if config.Name == ":memory:" {
+			// Every connection opens its own in-memory database, so keep a single one alive
+			config.MaxOpenConns = 1
+			config.MaxIdleConns = 1
+			config.ConnMaxLifetime = 0
+			config.ConnMaxIdleTime = 0
+		}
+	case "sqlserver":
+		driverName = "sqlserver"
+		dsn = buildSQLServerConnStr(config)
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
@@ -218,8 +294,8 @@ func (d *database) Connect() error {
 	db.SetConnMaxLifetime(d.config.ConnMaxLifetime)
 	db.SetConnMaxIdleTime(d.config.ConnMaxIdleTime)
 
-	// Verify connection is working
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Verify connection is working within the connect timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(d.config.ConnectTimeout)*time.Second)
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
@@ -231,7 +307,11 @@ func (d *database) Connect() error {
 	}
 
 	d.db = db
-	logger.Info("Connected to %s database at %s:%d/%s", d.config.Type, d.config.Host, d.config.Port, d.config.Name)
+	if d.config.Type == "sqlite" {
+		logger.Info("Connected to sqlite database %s", d.config.Name)
+	} else {
+		logger.Info("Connected to %s database at %s:%d/%s", d.config.Type, d.config.Host, d.config.Port, d.config.Name)
+	}
 
 	return nil
 }
@@ -324,6 +404,12 @@ func (d *database) ConnectionString() string {
 		}
 
 		return strings.Join(params, " ")
+	case "sqlite":
+		// A file path holds no credentials
+		return d.config.Name
+	case "sqlserver":
+		return fmt.Sprintf("sqlserver://%s:***@%s:%d?database=%s",
+			d.config.User, d.config.Host, d.config.Port, d.config.Name)
 	default:
 		return "unknown"
 	}
@@ -333,3 +419,8 @@ func (d *database) ConnectionString() string {
 func (d *database) QueryTimeout() int {
 	return d.config.QueryTimeout
 }
+
+// SchemaTimeout returns the configured schema discovery timeout in seconds
+func (d *database) SchemaTimeout() int {
+	return d.config.SchemaTimeout
+}
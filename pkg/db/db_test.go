@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"database/sql"
+	"net/url"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -78,6 +80,135 @@ func TestConfigSetDefaults(t *testing.T) {
 	assert.Equal(t, 25, config.MaxOpenConns)
 	assert.Equal(t, 5, config.MaxIdleConns)
 	assert.Equal(t, 5*time.Minute, config.ConnMaxLifetime)
+	assert.Equal(t, 10, config.ConnectTimeout)
+	assert.Equal(t, 30, config.QueryTimeout)
+	assert.Equal(t, 120, config.SchemaTimeout)
+}
+
+func TestSQLiteInMemoryDatabase(t *testing.T) {
+	database, err := NewDatabase(Config{Type: "sqlite", Name: ":memory:"})
+	assert.NoError(t, err)
+	assert.NoError(t, database.Connect())
+	defer database.Close()
+
+	assert.Equal(t, "sqlite", database.DriverName())
+	assert.Equal(t, ":memory:", database.ConnectionString())
+
+	// The table must still be visible on the next statement, i.e. the pool keeps one connection
+	ctx := context.Background()
+	_, err = database.Exec(ctx, "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)")
+	assert.NoError(t, err)
+	_, err = database.Exec(ctx, "INSERT INTO notes (body) VALUES (?)", "hello")
+	assert.NoError(t, err)
+
+	var body string
+	assert.NoError(t, database.QueryRow(ctx, "SELECT body FROM notes").Scan(&body))
+	assert.Equal(t, "hello", body)
+}
+
+func TestNewDatabaseSQLiteRequiresPath(t *testing.T) {
+	_, err := NewDatabase(Config{Type: "sqlite"})
+	assert.Error(t, err)
+}
+
+func TestLoadConfigSQLite(t *testing.T) {
+	manager := NewDBManager()
+
+	// No host, port or user is needed for a SQLite file
+	err := manager.LoadConfig([]byte(`{"connections": [{"id": "local", "type": "sqlite", "name": "/var/lib/app/app.db"}]}`))
+	assert.NoError(t, err)
+
+	dbType, err := manager.GetDatabaseType("local")
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlite", dbType)
+
+	err = NewDBManager().LoadConfig([]byte(`{"connections": [{"id": "local", "type": "sqlite"}]}`))
+	assert.Error(t, err)
+}
+
+func TestBuildSQLServerConnStr(t *testing.T) {
+	dsn := buildSQLServerConnStr(Config{
+		Host:            "mssql.internal",
+		User:            "reader",
+		Password:        "p@ss word",
+		Name:            "sales",
+		ConnectTimeout:  10,
+		ApplicationName: "infra-mcp",
+		Options:         map[string]string{"encrypt": "true"},
+	})
+
+	u, err := url.Parse(dsn)
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlserver", u.Scheme)
+	assert.Equal(t, "mssql.internal:1433", u.Host)
+	password, _ := u.User.Password()
+	assert.Equal(t, "p@ss word", password)
+	assert.Equal(t, "sales", u.Query().Get("database"))
+	assert.Equal(t, "10", u.Query().Get("connection timeout"))
+	assert.Equal(t, "infra-mcp", u.Query().Get("app name"))
+	assert.Equal(t, "true", u.Query().Get("encrypt"))
+}
+
+func TestLoadConfigSQLServer(t *testing.T) {
+	manager := NewDBManager()
+	err := manager.LoadConfig([]byte(`{"connections": [{"id": "erp", "type": "sqlserver", "host": "mssql.internal", "user": "reader", "name": "sales"}]}`))
+	assert.NoError(t, err)
+
+	dbType, err := manager.GetDatabaseType("erp")
+	assert.NoError(t, err)
+	assert.Equal(t, "sqlserver", dbType)
+
+	err = NewDBManager().LoadConfig([]byte(`{"connections": [{"id": "erp", "type": "sqlserver", "name": "sales"}]}`))
+	assert.Error(t, err)
+}
+
+func TestReadOnlySessionDSN(t *testing.T) {
+	assert.Contains(t, buildPostgresConnStr(Config{Host: "pg.internal", Port: 5432, User: "reader", ReadOnly: true}), "default_transaction_read_only=on")
+	assert.NotContains(t, buildPostgresConnStr(Config{Host: "pg.internal", Port: 5432, User: "reader"}), "default_transaction_read_only")
+
+	mysqlDB, err := NewDatabase(Config{Type: "mysql", Host: "mysql.internal", Port: 3306, User: "reader", Name: "app", ReadOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "reader:@tcp(mysql.internal:3306)/app?parseTime=true&transaction_read_only=1", mysqlDB.(*database).dsn)
+
+	sqliteDB, err := NewDatabase(Config{Type: "sqlite", Name: "file:app.db?cache=shared", ReadOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "file:app.db?cache=shared&_pragma=query_only(1)", sqliteDB.(*database).dsn)
+}
+
+func TestReadOnlySessionDefault(t *testing.T) {
+	off := false
+	on := true
+	assert.True(t, DatabaseConnectionConfig{}.ReadOnlySession())
+	assert.False(t, DatabaseConnectionConfig{SessionReadOnly: &off}.ReadOnlySession())
+	assert.True(t, DatabaseConnectionConfig{SessionReadOnly: &on}.ReadOnlySession())
+	// Connections on the write allowlist are never read-only
+	assert.False(t, DatabaseConnectionConfig{AllowWrites: true}.ReadOnlySession())
+	assert.True(t, buildConfig(DatabaseConnectionConfig{Type: "postgres"}).ReadOnly)
+}
+
+func TestSQLiteReadOnlySession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.db")
+	ctx := context.Background()
+
+	writable, err := NewDatabase(Config{Type: "sqlite", Name: path})
+	assert.NoError(t, err)
+	assert.NoError(t, writable.Connect())
+	_, err = writable.Exec(ctx, "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)")
+	assert.NoError(t, err)
+	assert.NoError(t, writable.Close())
+
+	readOnly, err := NewDatabase(Config{Type: "sqlite", Name: path, ReadOnly: true})
+	assert.NoError(t, err)
+	assert.NoError(t, readOnly.Connect())
+	defer readOnly.Close()
+
+	// Writes fail in the database even though no validator runs here
+	_, err = readOnly.Exec(ctx, "INSERT INTO notes (body) VALUES ('hello')")
+	assert.Error(t, err)
+
+	var count int
+	assert.NoError(t, readOnly.QueryRow(ctx, "SELECT COUNT(*) FROM notes").Scan(&count))
+	assert.Equal(t, 0, count)
 }
 
 // MockDatabase implements Database interface for testing
@@ -143,6 +274,14 @@ func (m *MockDatabase) ConnectionString() string {
 	return m.dsnVal
 }
 
+func (m *MockDatabase) QueryTimeout() int {
+	return 30
+}
+
+func (m *MockDatabase) SchemaTimeout() int {
+	return 60
+}
+
 func (m *MockDatabase) DB() *sql.DB {
 	return m.dbInstance
 }
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	intLogger "github.com/FreePeak/infra-mcp-server/internal/logger"
+	"github.com/FreePeak/infra-mcp-server/pkg/logger"
+)
+
+// TestMain initializes the loggers before any test runs; Connect logs through them,
+// and logging uninitialized panics
+func TestMain(m *testing.M) {
+	intLogger.Initialize("error")
+	logger.Initialize("error")
+	os.Exit(m.Run())
+}
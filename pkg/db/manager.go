@@ -1,23 +1,26 @@
 package db
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/FreePeak/infra-mcp-server/pkg/common"
 	"github.com/FreePeak/infra-mcp-server/pkg/logger"
 )
 
 // DatabaseConnectionConfig represents a single database connection configuration
 type DatabaseConnectionConfig struct {
 	ID       string `json:"id"`   // Unique identifier for this connection (short, used for tool names)
-	Type     string `json:"type"` // mysql or postgres
+	Type     string `json:"type"` // mysql, postgres, sqlite or sqlserver
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
 	User     string `json:"user"`
 	Password string `json:"password"`
-	Name     string `json:"name"`
+	Name     string `json:"name"` // Database name; for sqlite, the database file path or ":memory:"
 
 	// Display metadata (for MCP client context)
 	DisplayName string   `json:"display_name,omitempty"` // Full descriptive name (e.g., "Transaction Service Production Database")
@@ -26,6 +29,14 @@ type DatabaseConnectionConfig struct {
 	Description string   `json:"description,omitempty"`  // Detailed description
 	Tags        []string `json:"tags,omitempty"`         // Tags for categorization
 
+	// Write access (connections are read-only unless explicitly opted in)
+	AllowWrites bool `json:"allow_writes,omitempty"` // Allow INSERT/UPDATE/DELETE through dbExecute
+	AllowDDL    bool `json:"allow_ddl,omitempty"`    // Additionally allow DDL and any other non-DML statement
+	// SessionReadOnly makes the database itself reject writes on every session. It defaults
+	// to on for connections without allow_writes; set it to false for servers that reject
+	// the setting (e.g. MariaDB before 11.1 or a PgBouncer that filters startup parameters).
+	SessionReadOnly *bool `json:"session_read_only,omitempty"`
+
 	// PostgreSQL specific options
 	SSLMode            string            `json:"ssl_mode,omitempty"`
 	SSLCert            string            `json:"ssl_cert,omitempty"`
@@ -33,7 +44,8 @@ type DatabaseConnectionConfig struct {
 	SSLRootCert        string            `json:"ssl_root_cert,omitempty"`
 	ApplicationName    string            `json:"application_name,omitempty"`
 	ConnectTimeout     int               `json:"connect_timeout,omitempty"`
-	QueryTimeout       int               `json:"query_timeout,omitempty"` // in seconds
+	QueryTimeout       int               `json:"query_timeout,omitempty"`  // in seconds
+	SchemaTimeout      int               `json:"schema_timeout,omitempty"` // in seconds
 	TargetSessionAttrs string            `json:"target_session_attrs,omitempty"`
 	Options            map[string]string `json:"options,omitempty"`
 
@@ -54,6 +66,18 @@ type Manager struct {
 	mu          sync.RWMutex
 	connections map[string]Database
 	configs     map[string]DatabaseConnectionConfig
+	failed      map[string]error // last connection error of databases that are not connected
+
+	// newDatabase creates database instances; tests replace it with a stub
+	newDatabase func(Config) (Database, error)
+}
+
+// ReadOnlySession reports whether sessions should be read-only at the database level
+func (c DatabaseConnectionConfig) ReadOnlySession() bool {
+	if c.AllowWrites {
+		return false
+	}
+	return c.SessionReadOnly == nil || *c.SessionReadOnly
 }
 
 // GetMetadata returns the metadata for a database connection
@@ -69,6 +93,8 @@ func NewDBManager() *Manager {
 	return &Manager{
 		connections: make(map[string]Database),
 		configs:     make(map[string]DatabaseConnectionConfig),
+		failed:      make(map[string]error),
+		newDatabase: NewDatabase,
 	}
 }
 
@@ -84,7 +110,18 @@ func (m *Manager) LoadConfig(configJSON []byte) error {
 		if conn.ID == "" {
 			return fmt.Errorf("database connection ID cannot be empty")
 		}
-		if conn.Type != "mysql" && conn.Type != "postgres" {
+		switch conn.Type {
+		case "mysql", "postgres":
+		case "sqlserver":
+			if conn.Host == "" {
+				return fmt.Errorf("database connection %s: sqlserver requires a host", conn.ID)
+			}
+		case "sqlite":
+			// SQLite opens a local file, so host, port and user do not apply
+			if conn.Name == "" {
+				return fmt.Errorf("database connection %s: sqlite requires the database file path in name", conn.ID)
+			}
+		default:
 			return fmt.Errorf("unsupported database type for connection %s: %s", conn.ID, conn.Type)
 		}
 		m.configs[conn.ID] = conn
@@ -96,96 +133,243 @@ func (m *Manager) LoadConfig(configJSON []byte) error {
 // Connect establishes connections to all configured databases
 // Returns error only if NO databases could be connected
 func (m *Manager) Connect() error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.ConnectWithRetry(1, 0)
+}
 
-	var failedConnections []string
-	successCount := 0
+// ConnectWithRetry connects to all configured databases, retrying the ones that failed
+// up to maxAttempts times in total. The wait between attempts starts at backoff and
+// doubles after each retry. Like Connect, it returns an error only if NO databases
+// could be connected; the IDs that still failed are available from FailedDatabases.
+func (m *Manager) ConnectWithRetry(maxAttempts int, backoff time.Duration) error {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
 
-	// Connect to each database
-	for id, cfg := range m.configs {
-		// Skip if already connected
-		if _, exists := m.connections[id]; exists {
-			successCount++
-			continue
-		}
+	failedConnections := m.connectPending(false)
+	delay := backoff
+	for attempt := 2; attempt <= maxAttempts && len(failedConnections) > 0; attempt++ {
+		logger.Info("Retrying %d database connection(s) in %s (attempt %d of %d)", len(failedConnections), delay, attempt, maxAttempts)
+		time.Sleep(delay)
+		delay *= 2
+		failedConnections = m.connectPending(true)
+	}
 
-		// Create database configuration
-		dbConfig := Config{
-			Type:     cfg.Type,
-			Host:     cfg.Host,
-			Port:     cfg.Port,
-			User:     cfg.User,
-			Password: cfg.Password,
-			Name:     cfg.Name,
-		}
+	m.mu.RLock()
+	successCount := len(m.connections)
+	configCount := len(m.configs)
+	m.mu.RUnlock()
 
-		// Set PostgreSQL-specific options if this is a PostgreSQL database
-		if cfg.Type == "postgres" {
-			dbConfig.SSLMode = PostgresSSLMode(cfg.SSLMode)
-			dbConfig.SSLCert = cfg.SSLCert
-			dbConfig.SSLKey = cfg.SSLKey
-			dbConfig.SSLRootCert = cfg.SSLRootCert
-			dbConfig.ApplicationName = cfg.ApplicationName
-			dbConfig.ConnectTimeout = cfg.ConnectTimeout
-			dbConfig.QueryTimeout = cfg.QueryTimeout
-			dbConfig.TargetSessionAttrs = cfg.TargetSessionAttrs
-			dbConfig.Options = cfg.Options
-		} else if cfg.Type == "mysql" {
-			// Set MySQL-specific options
-			dbConfig.ConnectTimeout = cfg.ConnectTimeout
-			dbConfig.QueryTimeout = cfg.QueryTimeout
-		}
+	// Log summary
+	if len(failedConnections) > 0 {
+		logger.Warn("Failed to connect to %d database(s): %v", len(failedConnections), failedConnections)
+	}
 
-		// Connection pool settings
-		if cfg.MaxOpenConns > 0 {
-			dbConfig.MaxOpenConns = cfg.MaxOpenConns
-		}
-		if cfg.MaxIdleConns > 0 {
-			dbConfig.MaxIdleConns = cfg.MaxIdleConns
-		}
-		if cfg.ConnMaxLifetime > 0 {
-			dbConfig.ConnMaxLifetime = time.Duration(cfg.ConnMaxLifetime) * time.Second
-		}
-		if cfg.ConnMaxIdleTime > 0 {
-			dbConfig.ConnMaxIdleTime = time.Duration(cfg.ConnMaxIdleTime) * time.Second
+	logger.Info("Successfully connected to %d out of %d configured databases", successCount, configCount)
+
+	// Only return error if NO databases connected
+	if successCount == 0 {
+		return fmt.Errorf("failed to connect to any databases: all %d connection attempts failed", configCount)
+	}
+
+	return nil
+}
+
+// StartReconnectLoop periodically retries the databases that failed to connect until ctx
+// is cancelled, so a database that comes up after server start becomes usable without a
+// restart. A non-positive interval disables the loop.
+func (m *Manager) StartReconnectLoop(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go m.reconnectLoop(ctx, interval)
+}
+
+// reconnectLoop retries failed connections every interval until ctx is cancelled
+func (m *Manager) reconnectLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if len(m.FailedDatabases()) == 0 {
+				continue
+			}
+			if stillFailed := m.connectPending(true); len(stillFailed) > 0 {
+				logger.Debug("Reconnect attempt left %d database(s) unavailable: %v", len(stillFailed), stillFailed)
+			}
 		}
+	}
+}
+
+// FailedDatabases returns the last connection error of each database that is configured
+// but could not be connected, keyed by ID
+func (m *Manager) FailedDatabases() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	failed := make(map[string]string, len(m.failed))
+	for id, err := range m.failed {
+		failed[id] = err.Error()
+	}
 
-		// Create and connect to database
-		db, err := NewDatabase(dbConfig)
-		if err != nil {
-			logger.Warn("Failed to create database instance for %s: %v", id, err)
-			failedConnections = append(failedConnections, fmt.Sprintf("%s (create failed)", id))
+	return failed
+}
+
+// connectPending makes one connection attempt for every configured database that is not
+// connected, or only for those whose previous attempt failed when failedOnly is set. The
+// lock is not held while connecting so slow hosts do not block GetDatabase. It returns a
+// description of each connection that failed.
+func (m *Manager) connectPending(failedOnly bool) []string {
+	m.mu.RLock()
+	pending := make(map[string]DatabaseConnectionConfig)
+	for id, cfg := range m.configs {
+		if _, connected := m.connections[id]; connected {
+			continue
+		}
+		if _, failed := m.failed[id]; failedOnly && !failed {
 			continue
 		}
+		pending[id] = cfg
+	}
+	m.mu.RUnlock()
 
-		if err := db.Connect(); err != nil {
+	var failedConnections []string
+	for id, cfg := range pending {
+		if err := m.connectDatabase(id, cfg); err != nil {
 			logger.Warn("Failed to connect to database %s: %v", id, err)
-			failedConnections = append(failedConnections, fmt.Sprintf("%s (connection failed)", id))
-			continue
+			failedConnections = append(failedConnections, fmt.Sprintf("%s (%v)", id, err))
 		}
+	}
 
-		// Store connected database
-		m.connections[id] = db
-		successCount++
-		logger.Info("Connected to database %s (%s at %s:%d/%s)", id, cfg.Type, cfg.Host, cfg.Port, cfg.Name)
+	return failedConnections
+}
+
+// Reconnect closes a database connection, if open, and connects it again from its stored
+// configuration, e.g. after a database restart or credential rotation. A failed attempt
+// leaves the database disconnected and is retried by the reconnect loop.
+func (m *Manager) Reconnect(id string) error {
+	m.mu.Lock()
+	cfg, exists := m.configs[id]
+	if !exists {
+		m.mu.Unlock()
+		return fmt.Errorf("database configuration %s not found", id)
 	}
+	db, connected := m.connections[id]
+	delete(m.connections, id)
+	m.mu.Unlock()
 
-	// Log summary
-	if len(failedConnections) > 0 {
-		logger.Warn("Failed to connect to %d database(s): %v", len(failedConnections), failedConnections)
+	// A broken connection may fail to close; it is replaced either way
+	if connected {
+		if err := db.Close(); err != nil {
+			logger.Warn("Failed to close database %s before reconnecting: %v", id, err)
+		}
 	}
 
-	logger.Info("Successfully connected to %d out of %d configured databases", successCount, len(m.configs))
+	if err := m.connectDatabase(id, cfg); err != nil {
+		return fmt.Errorf("failed to reconnect database %s: %w", id, err)
+	}
+	return nil
+}
 
-	// Only return error if NO databases connected
-	if successCount == 0 {
-		return fmt.Errorf("failed to connect to any databases: all %d connection attempts failed", len(m.configs))
+// connectDatabase creates and connects one database and stores the connection. Failures
+// are recorded for FailedDatabases and the reconnect loop.
+func (m *Manager) connectDatabase(id string, cfg DatabaseConnectionConfig) error {
+	db, err := m.newDatabase(buildConfig(cfg))
+	if err != nil {
+		m.recordFailure(id, err)
+		return fmt.Errorf("create failed: %w", err)
+	}
+
+	if err := db.Connect(); err != nil {
+		m.recordFailure(id, err)
+		return fmt.Errorf("connection failed: %w", err)
+	}
+
+	// Store connected database, unless a concurrent attempt got there first
+	m.mu.Lock()
+	if _, exists := m.connections[id]; exists {
+		m.mu.Unlock()
+		_ = db.Close()
+		return nil
 	}
+	m.connections[id] = db
+	delete(m.failed, id)
+	m.mu.Unlock()
 
+	if cfg.Type == "sqlite" {
+		logger.Info("Connected to database %s (sqlite at %s)", id, cfg.Name)
+	} else {
+		logger.Info("Connected to database %s (%s at %s:%d/%s)", id, cfg.Type, cfg.Host, cfg.Port, cfg.Name)
+	}
 	return nil
 }
 
+// recordFailure remembers the last connection error for a database
+func (m *Manager) recordFailure(id string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[id] = err
+}
+
+// buildConfig converts a connection configuration into the driver configuration
+func buildConfig(cfg DatabaseConnectionConfig) Config {
+	// Create database configuration
+	dbConfig := Config{
+		Type:     cfg.Type,
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		User:     cfg.User,
+		Password: cfg.Password,
+		Name:     cfg.Name,
+		ReadOnly: cfg.ReadOnlySession(),
+	}
+
+	// Set PostgreSQL-specific options if this is a PostgreSQL database
+	if cfg.Type == "postgres" {
+		dbConfig.SSLMode = PostgresSSLMode(cfg.SSLMode)
+		dbConfig.SSLCert = cfg.SSLCert
+		dbConfig.SSLKey = cfg.SSLKey
+		dbConfig.SSLRootCert = cfg.SSLRootCert
+		dbConfig.ApplicationName = cfg.ApplicationName
+		dbConfig.ConnectTimeout = cfg.ConnectTimeout
+		dbConfig.QueryTimeout = cfg.QueryTimeout
+		dbConfig.SchemaTimeout = cfg.SchemaTimeout
+		dbConfig.TargetSessionAttrs = cfg.TargetSessionAttrs
+		dbConfig.Options = cfg.Options
+	} else if cfg.Type == "sqlserver" {
+		// Set SQL Server options; Options carries driver settings such as encrypt
+		dbConfig.ApplicationName = cfg.ApplicationName
+		dbConfig.ConnectTimeout = cfg.ConnectTimeout
+		dbConfig.QueryTimeout = cfg.QueryTimeout
+		dbConfig.SchemaTimeout = cfg.SchemaTimeout
+		dbConfig.Options = cfg.Options
+	} else if cfg.Type == "mysql" || cfg.Type == "sqlite" {
+		// Set MySQL and SQLite options
+		dbConfig.ConnectTimeout = cfg.ConnectTimeout
+		dbConfig.QueryTimeout = cfg.QueryTimeout
+		dbConfig.SchemaTimeout = cfg.SchemaTimeout
+	}
+
+	// Connection pool settings
+	if cfg.MaxOpenConns > 0 {
+		dbConfig.MaxOpenConns = cfg.MaxOpenConns
+	}
+	if cfg.MaxIdleConns > 0 {
+		dbConfig.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		dbConfig.ConnMaxLifetime = time.Duration(cfg.ConnMaxLifetime) * time.Second
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		dbConfig.ConnMaxIdleTime = time.Duration(cfg.ConnMaxIdleTime) * time.Second
+	}
+
+	return dbConfig
+}
+
 // GetDatabase retrieves a database connection by ID
 func (m *Manager) GetDatabase(id string) (Database, error) {
 	m.mu.RLock()
@@ -231,6 +415,8 @@ func (m *Manager) CloseAll() error {
 		}
 		delete(m.connections, id)
 	}
+	// Closed databases are not reconnected
+	m.failed = make(map[string]error)
 
 	return firstErr
 }
@@ -270,6 +456,25 @@ func (m *Manager) ListDatabases() []string {
 	return ids
 }
 
+// FilterDatabases returns the configurations of the databases in the given environment
+// that carry every given tag, sorted by ID. An empty environment or no tags matches all.
+func (m *Manager) FilterDatabases(env string, tags []string) []DatabaseConnectionConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matches := make([]DatabaseConnectionConfig, 0)
+	for _, cfg := range m.configs {
+		if common.MatchesLabels(cfg.Environment, cfg.Tags, env, tags) {
+			matches = append(matches, cfg)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ID < matches[j].ID
+	})
+
+	return matches
+}
+
 // GetConnectedDatabases returns a list of all connected databases
 func (m *Manager) GetConnectedDatabases() []string {
 	m.mu.RLock()
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyDatabase fails to connect until failuresLeft reaches zero
+type flakyDatabase struct {
+	*MockDatabase
+	mu           sync.Mutex
+	failuresLeft int
+	attempts     int
+}
+
+func (f *flakyDatabase) Connect() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.failuresLeft > 0 {
+		f.failuresLeft--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (f *flakyDatabase) Close() error {
+	return nil
+}
+
+func (f *flakyDatabase) connectAttempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+// newFlakyManager returns a manager whose "primary" database fails to connect failures times
+func newFlakyManager(t *testing.T, failures int) (*Manager, *flakyDatabase) {
+	t.Helper()
+
+	stub := &flakyDatabase{MockDatabase: NewMockDatabase(), failuresLeft: failures}
+	manager := NewDBManager()
+	manager.newDatabase = func(Config) (Database, error) { return stub, nil }
+	assert.NoError(t, manager.LoadConfig([]byte(`{"connections": [{"id": "primary", "type": "postgres", "host": "db.internal", "name": "app"}]}`)))
+
+	return manager, stub
+}
+
+func TestConnectWithRetryTransientFailure(t *testing.T) {
+	manager, stub := newFlakyManager(t, 1)
+
+	assert.NoError(t, manager.ConnectWithRetry(3, time.Millisecond))
+	assert.Equal(t, 2, stub.connectAttempts())
+	assert.Empty(t, manager.FailedDatabases())
+
+	database, err := manager.GetDatabase("primary")
+	assert.NoError(t, err)
+	assert.Equal(t, stub, database)
+}
+
+func TestConnectWithRetryGivesUp(t *testing.T) {
+	manager, stub := newFlakyManager(t, 5)
+
+	err := manager.ConnectWithRetry(2, time.Millisecond)
+	assert.Error(t, err)
+	assert.Equal(t, 2, stub.connectAttempts())
+	assert.Equal(t, map[string]string{"primary": "connection refused"}, manager.FailedDatabases())
+
+	// Connect makes a single attempt
+	manager, stub = newFlakyManager(t, 1)
+	assert.Error(t, manager.Connect())
+	assert.Equal(t, 1, stub.connectAttempts())
+}
+
+func TestReconnectLoop(t *testing.T) {
+	manager, stub := newFlakyManager(t, 2)
+	assert.Error(t, manager.Connect())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.StartReconnectLoop(ctx, 5*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		_, err := manager.GetDatabase("primary")
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 3, stub.connectAttempts())
+	assert.Empty(t, manager.FailedDatabases())
+}
+
+func TestReconnect(t *testing.T) {
+	manager, stub := newFlakyManager(t, 0)
+	assert.NoError(t, manager.Connect())
+	assert.Equal(t, []string{"primary"}, manager.GetConnectedDatabases())
+
+	// Closing disconnects the database until it is reconnected
+	assert.NoError(t, manager.Close("primary"))
+	assert.Empty(t, manager.GetConnectedDatabases())
+	assert.NoError(t, manager.Reconnect("primary"))
+	assert.Equal(t, []string{"primary"}, manager.GetConnectedDatabases())
+
+	// A connected database is replaced; a failed reconnect leaves it disconnected
+	stub.mu.Lock()
+	stub.failuresLeft = 1
+	stub.mu.Unlock()
+	err := manager.Reconnect("primary")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Empty(t, manager.GetConnectedDatabases())
+	assert.Equal(t, map[string]string{"primary": "connection refused"}, manager.FailedDatabases())
+
+	assert.NoError(t, manager.Reconnect("primary"))
+	assert.Equal(t, []string{"primary"}, manager.GetConnectedDatabases())
+	assert.Empty(t, manager.FailedDatabases())
+	assert.Equal(t, 4, stub.connectAttempts())
+
+	err = manager.Reconnect("missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database configuration missing not found")
+}
+
+func TestFilterDatabases(t *testing.T) {
+	manager := NewDBManager()
+	assert.NoError(t, manager.LoadConfig([]byte(`{"connections": [
+		{"id": "tx_prod", "type": "sqlite", "name": ":memory:", "environment": "production", "tags": ["transactions", "critical"]},
+		{"id": "tx_staging", "type": "sqlite", "name": ":memory:", "environment": "staging", "tags": ["transactions"]},
+		{"id": "users_prod", "type": "sqlite", "name": ":memory:", "environment": "production", "tags": ["users", "critical"]}
+	]}`)))
+
+	ids := func(configs []DatabaseConnectionConfig) []string {
+		result := make([]string, 0, len(configs))
+		for _, cfg := range configs {
+			result = append(result, cfg.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"tx_prod", "tx_staging", "users_prod"}, ids(manager.FilterDatabases("", nil)))
+	assert.Equal(t, []string{"tx_prod", "users_prod"}, ids(manager.FilterDatabases("production", nil)))
+	assert.Equal(t, []string{"tx_prod", "tx_staging"}, ids(manager.FilterDatabases("", []string{"transactions"})))
+
+	// Every tag must match
+	assert.Equal(t, []string{"tx_prod"}, ids(manager.FilterDatabases("", []string{"transactions", "critical"})))
+	assert.Equal(t, []string{"tx_prod"}, ids(manager.FilterDatabases("production", []string{"transactions"})))
+	assert.Empty(t, manager.FilterDatabases("staging", []string{"critical"}))
+	assert.Empty(t, manager.FilterDatabases("dev", nil))
+}
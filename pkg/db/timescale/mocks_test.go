@@ -194,6 +194,11 @@ func (m *MockDB) QueryTimeout() int {
 	return 30
 }
 
+// SchemaTimeout implements db.Database.SchemaTimeout
+func (m *MockDB) SchemaTimeout() int {
+	return 120
+}
+
 // MockResult implements sql.Result
 type MockResult struct{}
 
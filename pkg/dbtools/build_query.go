@@ -0,0 +1,402 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// defaultBuildQueryLimit is applied when a query spec does not set a limit
+const defaultBuildQueryLimit = 100
+
+// QuerySpec is a structured SELECT specification validated against the schema
+type QuerySpec struct {
+	Table   string        `json:"table"`
+	Columns []string      `json:"columns"`
+	Filters []QueryFilter `json:"filters"`
+	OrderBy []OrderBy     `json:"order_by"`
+	Limit   int           `json:"limit"`
+}
+
+// QueryFilter is a single WHERE condition; filters are combined with AND
+type QueryFilter struct {
+	Column   string      `json:"column"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// tableNamePattern restricts table names to plain identifiers, matching how the schema explorer looks tables up
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// filterOperators are the comparison operators accepted in a QueryFilter
+var filterOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, ">": true, "<=": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "ILIKE": true,
+	"IN": true, "NOT IN": true, "IS NULL": true, "IS NOT NULL": true,
+}
+
+// createBuildQueryTool creates a tool for building schema-checked, parameterized queries
+func createBuildQueryTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "db_build_query",
+		Description: "Build a parameterized, correctly quoted SELECT from a structured spec checked against the table's columns (does not execute it)",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Table to select from",
+				},
+				"columns": map[string]interface{}{
+					"type":        "array",
+					"description": "Columns to select (default: all columns of the table)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"filters": map[string]interface{}{
+					"type":        "array",
+					"description": "WHERE conditions, combined with AND",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"column": map[string]interface{}{
+								"type": "string",
+							},
+							"operator": map[string]interface{}{
+								"type": "string",
+								"enum": []string{"=", "!=", "<>", "<", ">", "<=", ">=", "LIKE", "NOT LIKE", "ILIKE", "IN", "NOT IN", "IS NULL", "IS NOT NULL"},
+							},
+							"value": map[string]interface{}{
+								"description": "Comparison value; an array for IN / NOT IN, omitted for IS NULL / IS NOT NULL",
+							},
+						},
+						"required": []string{"column", "operator"},
+					},
+				},
+				"order_by": map[string]interface{}{
+					"type":        "array",
+					"description": "Sort order",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"column": map[string]interface{}{
+								"type": "string",
+							},
+							"direction": map[string]interface{}{
+								"type": "string",
+								"enum": []string{"ASC", "DESC"},
+							},
+						},
+						"required": []string{"column"},
+					},
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum rows (default: %d)", defaultBuildQueryLimit),
+				},
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID whose schema the spec is checked against",
+				},
+			},
+			Required: []string{"table", "database"},
+		},
+		Handler: handleBuildQuery,
+	}
+}
+
+// handleBuildQuery handles the db_build_query tool execution
+func handleBuildQuery(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	spec, err := parseQuerySpec(params)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(db.QueryTimeout())*time.Second)
+	defer cancel()
+
+	columnsResult, err := getColumns(timeoutCtx, db, spec.Table)
+	if err != nil {
+		return nil, err
+	}
+	knownColumns := columnNames(columnsResult)
+	if len(knownColumns) == 0 {
+		return nil, fmt.Errorf("table %s not found or has no columns", spec.Table)
+	}
+
+	query, args, err := buildParameterizedQuery(db.DriverName(), spec, knownColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"query":    query,
+		"params":   args,
+		"database": databaseID,
+		"driver":   db.DriverName(),
+		"note":     "Query was not executed; review it and run it with dbQuery using the returned params",
+	}, nil
+}
+
+// parseQuerySpec reads a QuerySpec from tool parameters
+func parseQuerySpec(params map[string]interface{}) (*QuerySpec, error) {
+	table, ok := getStringParam(params, "table")
+	if !ok || strings.TrimSpace(table) == "" {
+		return nil, fmt.Errorf("table parameter is required")
+	}
+	spec := &QuerySpec{Table: strings.TrimSpace(table)}
+	if !tableNamePattern.MatchString(spec.Table) {
+		return nil, fmt.Errorf("invalid table name %q", spec.Table)
+	}
+
+	if columns, ok := getArrayParam(params, "columns"); ok {
+		for _, c := range columns {
+			name, ok := c.(string)
+			if !ok {
+				return nil, fmt.Errorf("columns must be strings")
+			}
+			spec.Columns = append(spec.Columns, name)
+		}
+	}
+
+	if filters, ok := getArrayParam(params, "filters"); ok {
+		for i, f := range filters {
+			filterMap, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("filter %d must be an object", i)
+			}
+			column, _ := filterMap["column"].(string)
+			operator, _ := filterMap["operator"].(string)
+			spec.Filters = append(spec.Filters, QueryFilter{
+				Column:   column,
+				Operator: operator,
+				Value:    filterMap["value"],
+			})
+		}
+	}
+
+	if orders, ok := getArrayParam(params, "order_by"); ok {
+		for i, o := range orders {
+			switch order := o.(type) {
+			case string:
+				spec.OrderBy = append(spec.OrderBy, OrderBy{Column: order, Direction: "ASC"})
+			case map[string]interface{}:
+				column, _ := order["column"].(string)
+				direction, _ := order["direction"].(string)
+				spec.OrderBy = append(spec.OrderBy, OrderBy{Column: column, Direction: direction})
+			default:
+				return nil, fmt.Errorf("order_by entry %d must be a column name or an object", i)
+			}
+		}
+	}
+
+	if limit, ok := getIntParam(params, "limit"); ok {
+		spec.Limit = limit
+	}
+
+	return spec, nil
+}
+
+// buildParameterizedQuery validates a spec against the table's columns and renders
+// a SELECT with quoted identifiers and driver-specific placeholders
+func buildParameterizedQuery(driver string, spec *QuerySpec, knownColumns []string) (string, []interface{}, error) {
+	resolve := func(column string) (string, error) {
+		return resolveColumn(spec.Table, column, knownColumns)
+	}
+
+	selected := make([]string, 0, len(spec.Columns))
+	columns := spec.Columns
+	if len(columns) == 0 {
+		columns = knownColumns
+	}
+	for _, column := range columns {
+		name, err := resolve(column)
+		if err != nil {
+			return "", nil, err
+		}
+		selected = append(selected, quoteIdentifier(driver, name))
+	}
+
+	var query strings.Builder
+	var args []interface{}
+
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(selected, ", "))
+	query.WriteString(" FROM ")
+	query.WriteString(quoteIdentifier(driver, spec.Table))
+
+	for i, filter := range spec.Filters {
+		name, err := resolve(filter.Column)
+		if err != nil {
+			return "", nil, err
+		}
+
+		operator := strings.ToUpper(strings.Join(strings.Fields(filter.Operator), " "))
+		if !filterOperators[operator] {
+			return "", nil, fmt.Errorf("unsupported operator %q for column %s", filter.Operator, name)
+		}
+		if operator == "ILIKE" && driver != "postgres" {
+			return "", nil, fmt.Errorf("ILIKE is only supported on PostgreSQL")
+		}
+
+		if i == 0 {
+			query.WriteString(" WHERE ")
+		} else {
+			query.WriteString(" AND ")
+		}
+		query.WriteString(quoteIdentifier(driver, name))
+		query.WriteString(" ")
+		query.WriteString(operator)
+
+		switch operator {
+		case "IS NULL", "IS NOT NULL":
+			continue
+		case "IN", "NOT IN":
+			values, ok := filter.Value.([]interface{})
+			if !ok || len(values) == 0 {
+				return "", nil, fmt.Errorf("%s on column %s needs a non-empty array value", operator, name)
+			}
+			placeholders := make([]string, 0, len(values))
+			for _, v := range values {
+				args = append(args, v)
+				placeholders = append(placeholders, placeholder(driver, len(args)))
+			}
+			query.WriteString(" (" + strings.Join(placeholders, ", ") + ")")
+		default:
+			if filter.Value == nil {
+				return "", nil, fmt.Errorf("%s on column %s needs a value (use IS NULL to match NULL)", operator, name)
+			}
+			args = append(args, filter.Value)
+			query.WriteString(" " + placeholder(driver, len(args)))
+		}
+	}
+
+	if len(spec.OrderBy) > 0 {
+		orders := make([]string, 0, len(spec.OrderBy))
+		for _, order := range spec.OrderBy {
+			name, err := resolve(order.Column)
+			if err != nil {
+				return "", nil, err
+			}
+			direction := strings.ToUpper(order.Direction)
+			if direction == "" {
+				direction = "ASC"
+			}
+			if direction != "ASC" && direction != "DESC" {
+				return "", nil, fmt.Errorf("ORDER BY direction must be ASC or DESC")
+			}
+			orders = append(orders, quoteIdentifier(driver, name)+" "+direction)
+		}
+		query.WriteString(" ORDER BY ")
+		query.WriteString(strings.Join(orders, ", "))
+	}
+
+	limit := spec.Limit
+	if limit <= 0 {
+		limit = defaultBuildQueryLimit
+	}
+	if driver == "sqlserver" {
+		// SQL Server has no LIMIT, and OFFSET ... FETCH needs an ORDER BY
+		if len(spec.OrderBy) == 0 {
+			query.WriteString(" ORDER BY (SELECT NULL)")
+		}
+		query.WriteString(fmt.Sprintf(" OFFSET 0 ROWS FETCH NEXT %d ROWS ONLY", limit))
+	} else {
+		query.WriteString(fmt.Sprintf(" LIMIT %d", limit))
+	}
+
+	return query.String(), args, nil
+}
+
+// resolveColumn maps a requested column to its schema name, matching case-insensitively
+// when there is no exact match, and lists the table's columns when it doesn't exist
+func resolveColumn(table string, column string, knownColumns []string) (string, error) {
+	var folded []string
+	for _, known := range knownColumns {
+		if known == column {
+			return known, nil
+		}
+		if strings.EqualFold(known, column) {
+			folded = append(folded, known)
+		}
+	}
+	if len(folded) == 1 {
+		return folded[0], nil
+	}
+
+	available := make([]string, len(knownColumns))
+	copy(available, knownColumns)
+	sort.Strings(available)
+	return "", fmt.Errorf("column %q does not exist in table %s (available: %s)", column, table, strings.Join(available, ", "))
+}
+
+// columnNames extracts column names from a getColumns result
+func columnNames(columnsResult interface{}) []string {
+	resultMap, ok := columnsResult.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	rows, ok := resultMap["columns"].([]map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		// information_schema aliases differ in case between engines; SHOW COLUMNS uses Field
+		for _, key := range []string{"column_name", "COLUMN_NAME", "Field"} {
+			if name, ok := row[key].(string); ok && name != "" {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// quoteIdentifier quotes a (possibly schema-qualified) identifier for the driver
+func quoteIdentifier(driver string, name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if driver == "mysql" {
+			parts[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+		} else {
+			parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// placeholder returns the n-th (1-based) bind parameter placeholder for the driver
+func placeholder(driver string, n int) string {
+	switch driver {
+	case "postgres":
+		return fmt.Sprintf("$%d", n)
+	case "sqlserver":
+		return fmt.Sprintf("@p%d", n)
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,111 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var usersColumns = []string{"id", "email", "status", "created_at"}
+
+func TestBuildParameterizedQueryPostgres(t *testing.T) {
+	spec := &QuerySpec{
+		Table:   "users",
+		Columns: []string{"id", "email"},
+		Filters: []QueryFilter{
+			{Column: "status", Operator: "in", Value: []interface{}{"active", "trial"}},
+			{Column: "created_at", Operator: ">=", Value: "2025-01-01"},
+			{Column: "email", Operator: "is not null"},
+		},
+		OrderBy: []OrderBy{{Column: "created_at", Direction: "desc"}},
+		Limit:   20,
+	}
+
+	query, args, err := buildParameterizedQuery("postgres", spec, usersColumns)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "id", "email" FROM "users" WHERE "status" IN ($1, $2) AND "created_at" >= $3 AND "email" IS NOT NULL ORDER BY "created_at" DESC LIMIT 20`, query)
+	assert.Equal(t, []interface{}{"active", "trial", "2025-01-01"}, args)
+}
+
+func TestBuildParameterizedQueryMySQLDefaults(t *testing.T) {
+	spec := &QuerySpec{
+		Table:   "users",
+		Filters: []QueryFilter{{Column: "STATUS", Operator: "=", Value: "active"}},
+	}
+
+	query, args, err := buildParameterizedQuery("mysql", spec, usersColumns)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `id`, `email`, `status`, `created_at` FROM `users` WHERE `status` = ? LIMIT 100", query)
+	assert.Equal(t, []interface{}{"active"}, args)
+}
+
+func TestBuildParameterizedQuerySQLServer(t *testing.T) {
+	spec := &QuerySpec{
+		Table:   "users",
+		Columns: []string{"id"},
+		Filters: []QueryFilter{{Column: "status", Operator: "=", Value: "active"}},
+		Limit:   10,
+	}
+
+	query, args, err := buildParameterizedQuery("sqlserver", spec, usersColumns)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "id" FROM "users" WHERE "status" = @p1 ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY`, query)
+	assert.Equal(t, []interface{}{"active"}, args)
+
+	spec.OrderBy = []OrderBy{{Column: "id"}}
+	query, _, err = buildParameterizedQuery("sqlserver", spec, usersColumns)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "id" FROM "users" WHERE "status" = @p1 ORDER BY "id" ASC OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY`, query)
+}
+
+func TestBuildParameterizedQueryRejectsInvalidSpecs(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *QuerySpec
+	}{
+		{
+			name: "unknown column",
+			spec: &QuerySpec{Table: "users", Columns: []string{"id", "username"}},
+		},
+		{
+			name: "unknown filter column",
+			spec: &QuerySpec{Table: "users", Filters: []QueryFilter{{Column: "deleted", Operator: "=", Value: true}}},
+		},
+		{
+			name: "unsupported operator",
+			spec: &QuerySpec{Table: "users", Filters: []QueryFilter{{Column: "id", Operator: "; DROP", Value: 1}}},
+		},
+		{
+			name: "IN without array",
+			spec: &QuerySpec{Table: "users", Filters: []QueryFilter{{Column: "id", Operator: "IN", Value: "1,2"}}},
+		},
+		{
+			name: "comparison without value",
+			spec: &QuerySpec{Table: "users", Filters: []QueryFilter{{Column: "email", Operator: "="}}},
+		},
+		{
+			name: "ILIKE on MySQL",
+			spec: &QuerySpec{Table: "users", Filters: []QueryFilter{{Column: "email", Operator: "ILIKE", Value: "%@example.com"}}},
+		},
+		{
+			name: "invalid order direction",
+			spec: &QuerySpec{Table: "users", OrderBy: []OrderBy{{Column: "id", Direction: "sideways"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := buildParameterizedQuery("mysql", tt.spec, usersColumns)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseQuerySpecRejectsInvalidTableName(t *testing.T) {
+	_, err := parseQuerySpec(map[string]interface{}{"table": "users; DROP TABLE users"})
+	assert.Error(t, err)
+}
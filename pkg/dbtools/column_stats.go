@@ -0,0 +1,135 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// columnStatsTopValues is the number of most frequent values dbColumnStats returns
+const columnStatsTopValues = 20
+
+// columnStatsQueries are the two queries profiling a column: one row with the total,
+// distinct and null counts, and the most frequent values with their counts
+type columnStatsQueries struct {
+	summary   queryWithArgs
+	topValues queryWithArgs
+}
+
+// newColumnStatsQueries builds the column statistics queries for dialects with a LIMIT
+// clause. Table and column are quoted as identifiers for driver.
+func newColumnStatsQueries(driver string, table string, column string) columnStatsQueries {
+	quotedTable := quoteIdentifier(driver, table)
+	quotedColumn := quoteIdentifier(driver, column)
+	return columnStatsQueries{
+		summary: queryWithArgs{query: fmt.Sprintf(
+			"SELECT COUNT(*) AS total_count, COUNT(DISTINCT %[1]s) AS distinct_count, COUNT(*) - COUNT(%[1]s) AS null_count FROM %[2]s",
+			quotedColumn, quotedTable)},
+		topValues: queryWithArgs{query: fmt.Sprintf(
+			"SELECT %[1]s AS value, COUNT(*) AS count FROM %[2]s GROUP BY %[1]s ORDER BY 2 DESC LIMIT %[3]d",
+			quotedColumn, quotedTable, columnStatsTopValues)},
+	}
+}
+
+// createColumnStatsTool creates a tool for profiling the values of a column
+func createColumnStatsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbColumnStats",
+		Description: "Profile a column: its row, distinct and null counts and its most frequent values. Scans the whole table, so it can be slow on large tables",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to use",
+				},
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Table holding the column, optionally schema-qualified (e.g. 'users' or 'sales.orders')",
+				},
+				"column": map[string]interface{}{
+					"type":        "string",
+					"description": "Column to profile",
+				},
+			},
+			Required: []string{"database", "table", "column"},
+		},
+		Handler: handleColumnStats,
+	}
+}
+
+// handleColumnStats handles the column statistics tool execution
+func handleColumnStats(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	table, _ := getStringParam(params, "table")
+	table = strings.TrimSpace(table)
+	if table == "" {
+		return nil, fmt.Errorf("table parameter is required")
+	}
+
+	column, _ := getStringParam(params, "column")
+	column = strings.TrimSpace(column)
+	if column == "" {
+		return nil, fmt.Errorf("column parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	stats := NewDatabaseStrategy(db.DriverName()).GetColumnStatsQueries(table, column)
+	for _, q := range []queryWithArgs{stats.summary, stats.topValues} {
+		if err := validateReadOnlyQuery(q.query); err != nil {
+			return nil, err
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(db.QueryTimeout())*time.Second)
+	defer cancel()
+
+	var totalCount, distinctCount, nullCount int64
+	if err := db.QueryRow(timeoutCtx, stats.summary.query, stats.summary.args...).Scan(&totalCount, &distinctCount, &nullCount); err != nil {
+		if queryTimedOut(ctx, timeoutCtx) {
+			return nil, fmt.Errorf("profiling column %s did not finish within %ds; the table is likely too large to scan", column, db.QueryTimeout())
+		}
+		return nil, fmt.Errorf("failed to count column values: %w", err)
+	}
+
+	rows, err := db.Query(timeoutCtx, stats.topValues.query, stats.topValues.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top column values: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	topValues, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read top column values: %w", err)
+	}
+	if topValues == nil {
+		topValues = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"database":       databaseID,
+		"table":          table,
+		"column":         column,
+		"total_count":    totalCount,
+		"distinct_count": distinctCount,
+		"null_count":     nullCount,
+		"top_values":     topValues,
+	}, nil
+}
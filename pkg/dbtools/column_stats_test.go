@@ -0,0 +1,36 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetColumnStatsQueriesQuotesIdentifiers(t *testing.T) {
+	stats := NewDatabaseStrategy("postgres").GetColumnStatsQueries("sales.orders", "status")
+	assert.Equal(t, `SELECT COUNT(*) AS total_count, COUNT(DISTINCT "status") AS distinct_count, COUNT(*) - COUNT("status") AS null_count FROM "sales"."orders"`, stats.summary.query)
+	assert.Equal(t, `SELECT "status" AS value, COUNT(*) AS count FROM "sales"."orders" GROUP BY "status" ORDER BY 2 DESC LIMIT 20`, stats.topValues.query)
+
+	stats = NewDatabaseStrategy("mysql").GetColumnStatsQueries("orders", "status")
+	assert.Equal(t, "SELECT `status` AS value, COUNT(*) AS count FROM `orders` GROUP BY `status` ORDER BY 2 DESC LIMIT 20", stats.topValues.query)
+
+	stats = NewDatabaseStrategy("sqlserver").GetColumnStatsQueries("orders", "status")
+	assert.Equal(t, `SELECT TOP (20) "status" AS value, COUNT_BIG(*) AS count FROM "orders" GROUP BY "status" ORDER BY 2 DESC`, stats.topValues.query)
+	assert.Contains(t, stats.summary.query, `COUNT_BIG(DISTINCT "status")`)
+}
+
+func TestGetColumnStatsQueriesEscapesColumn(t *testing.T) {
+	// A column name cannot close its quotes and add SQL
+	stats := NewDatabaseStrategy("postgres").GetColumnStatsQueries("users", `email") FROM users; DROP TABLE users; --`)
+	assert.Contains(t, stats.topValues.query, `"email"") FROM users; DROP TABLE users; --"`)
+	assert.Error(t, validateReadOnlyQuery(stats.topValues.query))
+
+	stats = NewDatabaseStrategy("mysql").GetColumnStatsQueries("users", "email`")
+	assert.Contains(t, stats.summary.query, "COUNT(DISTINCT `email```)")
+
+	for _, driver := range []string{"postgres", "mysql", "sqlite", "sqlserver"} {
+		stats = NewDatabaseStrategy(driver).GetColumnStatsQueries("users", "email")
+		assert.NoError(t, validateReadOnlyQuery(stats.summary.query), driver)
+		assert.NoError(t, validateReadOnlyQuery(stats.topValues.query), driver)
+	}
+}
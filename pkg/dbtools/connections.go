@@ -0,0 +1,192 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// DatabaseConnectionState describes a configured database and whether it is connected
+type DatabaseConnectionState struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	DisplayName string   `json:"display_name,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Environment string   `json:"environment,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Connected   bool     `json:"connected"`
+	LastError   string   `json:"last_error,omitempty"`
+}
+
+// createConnectionsTool creates a tool for listing configured connections and their state
+func createConnectionsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbConnections",
+		Description: "List every configured database with its type, display metadata and whether it is currently connected, including the last connection error of disconnected ones",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+		Handler: handleConnections,
+	}
+}
+
+// createReconnectTool creates a tool for re-establishing one database connection
+func createReconnectTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbReconnect",
+		Description: "Close a database connection and connect it again from its stored configuration, e.g. after a database restart or credential rotation",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to reconnect",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handleReconnect,
+	}
+}
+
+// createFilterDatabasesTool creates a tool for finding databases by environment and tags
+func createFilterDatabasesTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "listDatabases",
+		Description: "List the configured databases in an environment and/or carrying tags, with their display metadata, so a database can be chosen without knowing its ID",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"environment": map[string]interface{}{
+					"type":        "string",
+					"description": "Only databases in this environment, e.g. production (case-insensitive; default: any)",
+				},
+				"tag": map[string]interface{}{
+					"type":        "string",
+					"description": "Comma-separated tags the databases must all carry (case-insensitive; default: any)",
+				},
+			},
+		},
+		Handler: handleFilterDatabases,
+	}
+}
+
+// handleConnections handles the connections tool execution
+func handleConnections(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	connections := listConnectionStates(dbManager)
+
+	connected := 0
+	for _, state := range connections {
+		if state.Connected {
+			connected++
+		}
+	}
+
+	return map[string]interface{}{
+		"connections":  connections,
+		"count":        len(connections),
+		"connected":    connected,
+		"disconnected": len(connections) - connected,
+	}, nil
+}
+
+// handleReconnect handles the reconnect tool execution
+func handleReconnect(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	if err := dbManager.Reconnect(databaseID); err != nil {
+		return nil, err
+	}
+
+	return connectionState(dbManager, databaseID, dbManager.FailedDatabases()), nil
+}
+
+// handleFilterDatabases handles the listDatabases tool execution
+func handleFilterDatabases(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	environment, _ := getStringParam(params, "environment")
+	tag, _ := getStringParam(params, "tag")
+
+	failed := dbManager.FailedDatabases()
+	databases := make([]DatabaseConnectionState, 0)
+	for _, cfg := range dbManager.FilterDatabases(environment, splitTags(tag)) {
+		databases = append(databases, connectionState(dbManager, cfg.ID, failed))
+	}
+
+	return map[string]interface{}{
+		"databases": databases,
+		"count":     len(databases),
+	}, nil
+}
+
+// splitTags splits a comma-separated tag filter, dropping empty entries
+func splitTags(list string) []string {
+	parts := strings.Split(list, ",")
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if tag := strings.TrimSpace(part); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// listConnectionStates returns the state of every configured database, sorted by ID
+func listConnectionStates(manager *db.Manager) []DatabaseConnectionState {
+	ids := manager.ListDatabases()
+	sort.Strings(ids)
+	failed := manager.FailedDatabases()
+
+	states := make([]DatabaseConnectionState, 0, len(ids))
+	for _, id := range ids {
+		states = append(states, connectionState(manager, id, failed))
+	}
+	return states
+}
+
+// connectionState reports one configured database; failed holds the last connection
+// errors from Manager.FailedDatabases
+func connectionState(manager *db.Manager, id string, failed map[string]string) DatabaseConnectionState {
+	state := DatabaseConnectionState{ID: id, LastError: failed[id]}
+	if cfg, ok := manager.GetMetadata(id); ok {
+		state.Type = cfg.Type
+		state.DisplayName = cfg.DisplayName
+		state.Project = cfg.Project
+		state.Environment = cfg.Environment
+		state.Description = cfg.Description
+		state.Tags = cfg.Tags
+	}
+	for _, connectedID := range manager.GetConnectedDatabases() {
+		if connectedID == id {
+			state.Connected = true
+			break
+		}
+	}
+	return state
+}
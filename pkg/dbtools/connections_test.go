@@ -0,0 +1,45 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionStateTransitions(t *testing.T) {
+	manager := newHealthTestManager(t)
+
+	states := listConnectionStates(manager)
+	assert.Len(t, states, 2)
+	assert.Equal(t, "local", states[0].ID)
+	assert.Equal(t, "sqlite", states[0].Type)
+	assert.True(t, states[0].Connected)
+	assert.Empty(t, states[0].LastError)
+	assert.Equal(t, "unreachable", states[1].ID)
+	assert.False(t, states[1].Connected)
+	assert.NotEmpty(t, states[1].LastError)
+
+	// Closed connections show as disconnected until reconnected
+	assert.NoError(t, manager.Close("local"))
+	state := connectionState(manager, "local", manager.FailedDatabases())
+	assert.False(t, state.Connected)
+
+	assert.NoError(t, manager.Reconnect("local"))
+	state = connectionState(manager, "local", manager.FailedDatabases())
+	assert.True(t, state.Connected)
+
+	database, err := manager.GetDatabase("local")
+	assert.NoError(t, err)
+	assert.NoError(t, database.Ping(context.Background()))
+
+	// Reconnecting an unreachable database fails and keeps its error
+	assert.Error(t, manager.Reconnect("unreachable"))
+	state = connectionState(manager, "unreachable", manager.FailedDatabases())
+	assert.False(t, state.Connected)
+	assert.NotEmpty(t, state.LastError)
+
+	err = manager.Reconnect("missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
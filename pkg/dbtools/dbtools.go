@@ -3,7 +3,9 @@ package dbtools
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,7 +19,7 @@ import (
 )
 
 // TODO: Refactor database connection management to support connection pooling
-// TODO: Add support for connection retries and circuit breaking
+// TODO: Add circuit breaking for repeatedly failing connections
 // TODO: Implement comprehensive metrics collection for database operations
 // TODO: Consider using a context-aware connection management system
 // TODO: Add support for database migrations and versioning
@@ -31,6 +33,10 @@ const (
 	MySQL DatabaseType = "mysql"
 	// Postgres database type
 	Postgres DatabaseType = "postgres"
+	// SQLite database type; Name holds the database file path
+	SQLite DatabaseType = "sqlite"
+	// SQLServer database type
+	SQLServer DatabaseType = "sqlserver"
 )
 
 // Config represents database configuration
@@ -55,6 +61,11 @@ type ConnectionConfig struct {
 	Environment string   `json:"environment,omitempty"`
 	Description string   `json:"description,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
+
+	// Write access (read-only unless explicitly enabled)
+	AllowWrites     bool  `json:"allow_writes,omitempty"`
+	AllowDDL        bool  `json:"allow_ddl,omitempty"`
+	SessionReadOnly *bool `json:"session_read_only,omitempty"`
 }
 
 // MultiDBConfig represents configuration for multiple database connections
@@ -65,6 +76,16 @@ type MultiDBConfig struct {
 // Database connection manager (singleton)
 var (
 	dbManager *db.Manager
+	// stopReconnect cancels the background reconnect loop started by InitDatabase
+	stopReconnect context.CancelFunc
+)
+
+// Connection retry defaults, overridable with DB_CONNECT_ATTEMPTS, DB_CONNECT_BACKOFF
+// and DB_RECONNECT_INTERVAL
+const (
+	defaultConnectAttempts   = 3
+	defaultConnectBackoff    = time.Second
+	defaultReconnectInterval = 30 * time.Second
 )
 
 // DatabaseConnectionInfo represents detailed information about a database connection
@@ -146,8 +167,8 @@ func InitDatabase(cfg *Config) error {
 		dbPassword := os.Getenv("DB_PASSWORD")
 		dbName := os.Getenv("DB_NAME")
 
-		// If we have basic connection details, create a config
-		if dbHost != "" && dbUser != "" {
+		// If we have basic connection details, create a config. SQLite only needs the file path.
+		if (dbHost != "" && dbUser != "") || (dbType == string(SQLite) && dbName != "") {
 			dbPort, err := strconv.Atoi(dbPortStr)
 			if err != nil || dbPort == 0 {
 				dbPort = 3306 // Default MySQL port
@@ -185,8 +206,14 @@ func InitDatabase(cfg *Config) error {
 		return fmt.Errorf("failed to load database config: %w", err)
 	}
 
+	// Retry databases that fail at startup, then keep retrying them in the background so
+	// one that comes up later becomes usable without a restart
+	reconnectCtx, cancel := context.WithCancel(context.Background())
+	stopReconnect = cancel
+	dbManager.StartReconnectLoop(reconnectCtx, getDurationEnv("DB_RECONNECT_INTERVAL", defaultReconnectInterval))
+
 	// Connect to all databases
-	if err := dbManager.Connect(); err != nil {
+	if err := dbManager.ConnectWithRetry(getIntEnv("DB_CONNECT_ATTEMPTS", defaultConnectAttempts), getDurationEnv("DB_CONNECT_BACKOFF", defaultConnectBackoff)); err != nil {
 		return fmt.Errorf("failed to connect to databases: %w", err)
 	}
 
@@ -202,6 +229,9 @@ func CloseDatabase() error {
 	if dbManager == nil {
 		return nil
 	}
+	if stopReconnect != nil {
+		stopReconnect()
+	}
 	return dbManager.CloseAll()
 }
 
@@ -262,8 +292,10 @@ func GetDetailedSchema(dbID string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("invalid schema format")
 	}
 
-	// Cache the result
-	cache.Set(dbID, schemaMap)
+	// Cache the result unless some components failed to load
+	if !isPartialSchema(schemaMap) {
+		cache.Set(dbID, schemaMap)
+	}
 
 	return schemaMap, nil
 }
@@ -356,17 +388,94 @@ func RegisterDatabaseTools(registry *tools.Registry) error {
 						"type": "string",
 					},
 				},
+				"named_params": map[string]interface{}{
+					"type":        "object",
+					"description": "Values for :name references in the query, used instead of params. An array expands to a list, e.g. WHERE id IN (:ids) with {\"ids\": [1, 2, 3]}",
+				},
 				"timeout": map[string]interface{}{
 					"type":        "integer",
 					"description": "Query timeout in milliseconds (default: 5000)",
 				},
+				"include_stats": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include rows examined vs returned where the engine exposes it (MySQL)",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Result format: json (default) or csv (first result set only, columns in query order)",
+					"enum":        []string{"json", "csv"},
+				},
+				"include_columns": map[string]interface{}{
+					"type":        "boolean",
+					"description": "For json format, also return \"columns\": the column names in query order (first result set only)",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Return at most this many rows and report hasMore/nextOffset for the next page (single statement only, capped at DB_MAX_ROWS)",
+				},
+				"offset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Rows to skip before the page (default: 0)",
+				},
 			},
 			Required: []string{"query"},
 		},
 		Handler: handleQuery,
 	})
 
-	// dbExecute tool removed - read-only mode only
+	// Register execute tool (only succeeds on connections with allow_writes enabled)
+	registry.RegisterTool(createExecuteTool())
+
+	// Register explain tool (read-only)
+	registry.RegisterTool(createExplainTool())
+
+	// Register table row sampling (read-only)
+	registry.RegisterTool(createSampleRowsTool())
+
+	// Register column value profiling (read-only)
+	registry.RegisterTool(createColumnStatsTool())
+
+	// Register compact single-table schema (read-only)
+	registry.RegisterTool(createTableSchemaTool())
+
+	// Register result size estimate (counts rows, returns none)
+	registry.RegisterTool(createQueryEstimateTool())
+
+	// Register schema-checked query builder (builds SQL, never executes it)
+	registry.RegisterTool(createBuildQueryTool())
+
+	// Register server-side database discovery (read-only)
+	registry.RegisterTool(createListDatabasesTool())
+
+	// Register schema drift detection against the schema cache (read-only)
+	registry.RegisterTool(createSchemaDriftTool())
+
+	// Register schema comparison between two configured databases (read-only)
+	registry.RegisterTool(createSchemaDiffTool())
+
+	// Register schema export as CREATE statements (read-only)
+	registry.RegisterTool(createSchemaDDLTool())
+
+	// Register foreign key dependencies of a table in both directions (read-only)
+	registry.RegisterTool(createTableDependentsTool())
+
+	// Register live server configuration reader (read-only)
+	registry.RegisterTool(createSettingsTool())
+
+	// Register connection health checks (ping only, no queries)
+	registry.RegisterTool(createPingTool())
+	registry.RegisterTool(createHealthAllTool())
+
+	// Register runtime connection state and reconnect
+	registry.RegisterTool(createConnectionsTool())
+	registry.RegisterTool(createFilterDatabasesTool())
+	registry.RegisterTool(createReconnectTool())
+
+	// Register aggregated query latency and timeout statistics
+	registry.RegisterTool(createQueryStatsTool())
+
+	// Register the report of recent slow queries
+	registry.RegisterTool(createSlowQueriesTool())
 
 	// Register list databases tool
 	registry.RegisterTool(&tools.Tool{
@@ -442,8 +551,16 @@ func PingDatabase(db *sql.DB) error {
 	return db.Ping()
 }
 
-// rowsToMaps converts sql.Rows to a slice of maps
+// rowsToMaps converts sql.Rows to a slice of maps.
+// If iteration fails part-way (e.g. a context deadline), the rows scanned so far
+// are returned alongside the error.
 func rowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	return rowsToMapsMax(rows, 0)
+}
+
+// rowsToMapsMax is rowsToMaps reading at most maxRows rows (0 for no cap). When the
+// result has more, the first maxRows are returned with errRowLimitReached.
+func rowsToMapsMax(rows *sql.Rows, maxRows int) ([]map[string]interface{}, error) {
 	// Get column names
 	columns, err := rows.Columns()
 	if err != nil {
@@ -464,10 +581,14 @@ func rowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
 
 	// Fetch rows
 	for rows.Next() {
+		if maxRows > 0 && len(results) >= maxRows {
+			return results, errRowLimitReached
+		}
+
 		// Scan the result into the pointers
 		err := rows.Scan(valueRefs...)
 		if err != nil {
-			return nil, err
+			return results, err
 		}
 
 		// Create a map for this row
@@ -493,12 +614,172 @@ func rowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return results, err
 	}
 
 	return results, nil
 }
 
+// OrderedRows holds a result set with its columns in query order. Unlike the maps
+// returned by rowsToMaps, it lets clients render tables deterministically.
+type OrderedRows struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// rowsToOrdered converts the current result set of sql.Rows to OrderedRows, with values
+// converted as in rowsToMaps. If reading fails part-way, the rows read so far are
+// returned alongside the error.
+func rowsToOrdered(rows *sql.Rows) (*OrderedRows, error) {
+	return rowsToOrderedMax(rows, 0)
+}
+
+// rowsToOrderedMax is rowsToOrdered reading at most maxRows rows (0 for no cap). When
+// the result has more, the first maxRows are returned with errRowLimitReached.
+func rowsToOrderedMax(rows *sql.Rows, maxRows int) (*OrderedRows, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	valueRefs := make([]interface{}, len(columns))
+	for i := range columns {
+		valueRefs[i] = &values[i]
+	}
+
+	ordered := &OrderedRows{Columns: columns, Rows: [][]interface{}{}}
+	for rows.Next() {
+		if maxRows > 0 && len(ordered.Rows) >= maxRows {
+			return ordered, errRowLimitReached
+		}
+		if err := rows.Scan(valueRefs...); err != nil {
+			return ordered, err
+		}
+
+		row := make([]interface{}, len(columns))
+		for i, val := range values {
+			// Convert bytes to string for easier JSON serialization
+			if b, ok := val.([]byte); ok {
+				row[i] = string(b)
+			} else {
+				row[i] = val
+			}
+		}
+		ordered.Rows = append(ordered.Rows, row)
+	}
+
+	return ordered, rows.Err()
+}
+
+// rowsToResultSets converts every result set in sql.Rows to a slice of maps.
+// Multi-statement queries and stored procedures can return several sets; if
+// reading fails part-way, the sets read so far (the last one possibly partial)
+// are returned alongside the error. The result always holds at least one set.
+func rowsToResultSets(rows *sql.Rows) ([][]map[string]interface{}, error) {
+	return rowsToResultSetsMax(rows, 0)
+}
+
+// rowsToResultSetsMax is rowsToResultSets reading at most maxRows rows of each result
+// set (0 for no cap). Sets cut short by the cap make it return errRowLimitReached once
+// every set has been read.
+func rowsToResultSetsMax(rows *sql.Rows, maxRows int) ([][]map[string]interface{}, error) {
+	var resultSets [][]map[string]interface{}
+	limitReached := false
+	for {
+		results, err := rowsToMapsMax(rows, maxRows)
+		resultSets = append(resultSets, results)
+		if errors.Is(err, errRowLimitReached) {
+			limitReached = true
+		} else if err != nil {
+			return resultSets, err
+		}
+
+		// NextResultSet discards the rest of a set cut short by the cap
+		if !rows.NextResultSet() {
+			if err := rows.Err(); err != nil {
+				return resultSets, err
+			}
+			if limitReached {
+				return resultSets, errRowLimitReached
+			}
+			return resultSets, nil
+		}
+	}
+}
+
+// rowsToCSV renders the current result set of sql.Rows as CSV, quoting fields per
+// RFC 4180. Records end in \n so newlines inside values are kept as-is. The header
+// follows the column order of the query; NULL becomes an empty field. On error the
+// rows rendered so far are returned along with their count.
+func rowsToCSV(rows *sql.Rows) (string, int, error) {
+	return rowsToCSVMax(rows, 0)
+}
+
+// rowsToCSVMax is rowsToCSV rendering at most maxRows rows (0 for no cap). When the
+// result has more, the first maxRows are returned with errRowLimitReached.
+func rowsToCSVMax(rows *sql.Rows, maxRows int) (string, int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", 0, err
+	}
+
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return "", 0, err
+	}
+
+	values := make([]interface{}, len(columns))
+	valueRefs := make([]interface{}, len(columns))
+	for i := range columns {
+		valueRefs[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	rowCount := 0
+	for rows.Next() {
+		if maxRows > 0 && rowCount >= maxRows {
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return buf.String(), rowCount, err
+			}
+			return buf.String(), rowCount, errRowLimitReached
+		}
+		if err := rows.Scan(valueRefs...); err != nil {
+			writer.Flush()
+			return buf.String(), rowCount, err
+		}
+		for i, val := range values {
+			record[i] = csvValue(val)
+		}
+		if err := writer.Write(record); err != nil {
+			return buf.String(), rowCount, err
+		}
+		rowCount++
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return buf.String(), rowCount, err
+	}
+	return buf.String(), rowCount, rows.Err()
+}
+
+// csvValue formats a scanned column value as a CSV field
+func csvValue(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // getStringParam safely extracts a string parameter from the params map
 func getStringParam(params map[string]interface{}, key string) (string, bool) {
 	if val, ok := params[key].(string); ok {
@@ -524,6 +805,19 @@ func getIntParam(params map[string]interface{}, key string) (int, bool) {
 	return 0, false
 }
 
+// getBoolParam safely extracts a bool parameter from the params map
+func getBoolParam(params map[string]interface{}, key string) (bool, bool) {
+	switch v := params[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, true
+		}
+	}
+	return false, false
+}
+
 // getArrayParam safely extracts an array parameter from the params map
 func getArrayParam(params map[string]interface{}, key string) ([]interface{}, bool) {
 	if val, ok := params[key].([]interface{}); ok {
@@ -580,8 +874,8 @@ func _getEnv(key, defaultValue string) string {
 	return value
 }
 
-// _getIntEnv gets an environment variable as an integer or returns a default value (currently unused)
-func _getIntEnv(key string, defaultValue int) int {
+// getIntEnv gets an environment variable as an integer or returns a default value
+func getIntEnv(key string, defaultValue int) int {
 	value := os.Getenv(key)
 	if value == "" {
 		return defaultValue
@@ -593,6 +887,21 @@ func _getIntEnv(key string, defaultValue int) int {
 	return intValue
 }
 
+// getDurationEnv gets an environment variable as a duration such as "500ms" or "1m" or
+// returns a default value
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warn("Warning: invalid duration %q in %s, using %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
 // _loadConfigFromEnv loads database configuration from the environment (currently unused)
 func _loadConfigFromEnv() (*db.MultiDBConfig, error) {
 	// Check if DB_CONFIG environment variable is set
@@ -611,7 +920,7 @@ func _loadConfigFromEnv() (*db.MultiDBConfig, error) {
 	// Load database configuration from environment variables
 	dbType := _getEnv("DB_TYPE", "mysql")
 	dbHost := _getEnv("DB_HOST", "localhost")
-	dbPort := _getIntEnv("DB_PORT", 3306)
+	dbPort := getIntEnv("DB_PORT", 3306)
 	dbUser := _getEnv("DB_USER", "")
 	dbPass := _getEnv("DB_PASSWORD", "")
 	dbName := _getEnv("DB_NAME", "")
@@ -958,6 +1267,10 @@ func handleSchemaForDatabase(ctx context.Context, params map[string]interface{},
 		dbType = "mysql"
 	case "postgres":
 		dbType = "postgres"
+	case "sqlite":
+		dbType = "sqlite"
+	case "sqlserver":
+		dbType = "sqlserver"
 	default:
 		dbType = "unknown"
 	}
@@ -990,6 +1303,8 @@ func getBasicSchemaInfo(ctx context.Context, db db.Database, dbID, dbType string
 		query = "SHOW TABLES"
 	case "postgres":
 		query = "SELECT tablename AS TABLE_NAME FROM pg_catalog.pg_tables WHERE schemaname NOT IN ('pg_catalog', 'information_schema')"
+	case "sqlserver":
+		query = "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE = 'BASE TABLE'"
 	default:
 		// Generic query that might work
 		query = "SELECT name FROM sqlite_master WHERE type='table'"
@@ -0,0 +1,144 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// largeResultRows is the row count above which an estimate suggests narrowing the query
+const largeResultRows = 10000
+
+// createQueryEstimateTool creates a tool for counting the rows a query would return
+func createQueryEstimateTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "db_query_estimate",
+		Description: "Count the rows a read-only query would return, without fetching them, to decide whether to add a LIMIT or narrower filters before running it with dbQuery",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Read-only SELECT query whose result size should be counted",
+				},
+				"params": map[string]interface{}{
+					"type":        "array",
+					"description": "Parameters for the query (for prepared statements)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: the database's query timeout)",
+				},
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to use",
+				},
+			},
+			Required: []string{"query", "database"},
+		},
+		Handler: handleQueryEstimate,
+	}
+}
+
+// handleQueryEstimate handles the query estimate tool execution
+func handleQueryEstimate(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	query, ok := getStringParam(params, "query")
+	if !ok {
+		return nil, fmt.Errorf("query parameter is required")
+	}
+
+	// The count runs the query in full, so it gets the same guard as dbQuery
+	if err := validateReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+	countQuery, err := buildCountQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeout := db.QueryTimeout() * 1000
+	if timeoutParam, ok := getIntParam(params, "timeout"); ok {
+		timeout = timeoutParam
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	var queryParams []interface{}
+	if paramsArray, ok := getArrayParam(params, "params"); ok {
+		queryParams = make([]interface{}, len(paramsArray))
+		copy(queryParams, paramsArray)
+	}
+
+	start := time.Now()
+	var rowCount int64
+	if err := db.QueryRow(timeoutCtx, countQuery, queryParams...).Scan(&rowCount); err != nil {
+		if queryTimedOut(ctx, timeoutCtx) {
+			return nil, fmt.Errorf("counting the query's rows did not finish within %dms; the full query is likely expensive, so narrow it or add a LIMIT before running it", timeout)
+		}
+		return nil, countQueryError(db.DriverName(), err)
+	}
+
+	result := map[string]interface{}{
+		"query":        query,
+		"database":     databaseID,
+		"row_count":    rowCount,
+		"execution_ms": float64(time.Since(start).Microseconds()) / 1000.0,
+	}
+	if rowCount > largeResultRows {
+		result["hint"] = fmt.Sprintf("The query returns %d rows; add a LIMIT, narrower filters or an aggregate before running it with dbQuery", rowCount)
+	}
+	return result, nil
+}
+
+// buildCountQuery wraps a single SELECT statement in a row count. Trailing semicolons
+// are dropped; queries with several statements are rejected because only the last one
+// could be counted.
+func buildCountQuery(query string) (string, error) {
+	trimmed := trimStatement(query)
+	if trimmed == "" {
+		return "", fmt.Errorf("query parameter is required")
+	}
+	if strings.Contains(trimmed, ";") {
+		return "", fmt.Errorf("only a single statement can be estimated")
+	}
+
+	// The newline keeps a trailing line comment in the query from swallowing the alias
+	return fmt.Sprintf("SELECT COUNT(*) FROM (%s\n) sub", trimmed), nil
+}
+
+// countQueryError explains a failed row count. MySQL and SQL Server reject derived
+// tables whose columns are duplicated or unnamed, so a query like "SELECT a.id, b.id"
+// or "SELECT COUNT(*)" runs on its own but cannot be wrapped in the count.
+func countQueryError(driver string, err error) error {
+	if driver == "mysql" || driver == "sqlserver" {
+		msg := err.Error()
+		if strings.Contains(msg, "Duplicate column name") ||
+			strings.Contains(msg, "No column name was specified") ||
+			strings.Contains(msg, "was specified multiple times") {
+			return fmt.Errorf("failed to count query rows: %s cannot count a query whose selected columns are duplicated or unnamed; give every selected column a unique alias (e.g. a.id AS a_id) and retry: %w", driver, err)
+		}
+	}
+	return fmt.Errorf("failed to count query rows: %w", err)
+}
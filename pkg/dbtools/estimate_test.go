@@ -0,0 +1,57 @@
+package dbtools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCountQuery(t *testing.T) {
+	countQuery, err := buildCountQuery("  SELECT id FROM orders WHERE total > ?;;\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT id FROM orders WHERE total > ?\n) sub", countQuery)
+
+	// A trailing line comment must not comment out the closing parenthesis
+	countQuery, err = buildCountQuery("SELECT id FROM orders -- all orders")
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT id FROM orders -- all orders\n) sub", countQuery)
+
+	_, err = buildCountQuery("SELECT 1; SELECT 2")
+	assert.Error(t, err)
+
+	_, err = buildCountQuery(" ; ")
+	assert.Error(t, err)
+}
+
+func TestCountQueryError(t *testing.T) {
+	duplicate := errors.New("Error 1060 (42S21): Duplicate column name 'id'")
+	assert.ErrorContains(t, countQueryError("mysql", duplicate), "unique alias")
+	assert.ErrorIs(t, countQueryError("mysql", duplicate), duplicate)
+
+	unnamed := errors.New("mssql: No column name was specified for column 1 of 'sub'.")
+	assert.ErrorContains(t, countQueryError("sqlserver", unnamed), "unique alias")
+
+	other := errors.New("Table 'shop.missing' doesn't exist")
+	err := countQueryError("mysql", other)
+	assert.NotContains(t, err.Error(), "unique alias")
+	assert.ErrorIs(t, err, other)
+}
+
+func TestBuildCountQuerySQLite(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+	ctx := context.Background()
+
+	_, err := database.Exec(ctx, `INSERT INTO users (id, email) VALUES (1, 'a@example.com'), (2, 'b@example.com')`)
+	assert.NoError(t, err)
+	_, err = database.Exec(ctx, `INSERT INTO orders (id, user_id, total) VALUES (1, 1, 10), (2, 1, 25), (3, 2, 40)`)
+	assert.NoError(t, err)
+
+	countQuery, err := buildCountQuery("SELECT o.id, u.email FROM orders o JOIN users u ON u.id = o.user_id WHERE o.total > ?")
+	assert.NoError(t, err)
+
+	var rowCount int64
+	assert.NoError(t, database.QueryRow(ctx, countQuery, 20).Scan(&rowCount))
+	assert.Equal(t, int64(2), rowCount)
+}
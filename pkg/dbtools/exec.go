@@ -3,18 +3,17 @@ package dbtools
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/FreePeak/infra-mcp-server/pkg/tools"
 )
 
 // createExecuteTool creates a tool for executing database statements that don't return rows
-//
-//nolint:unused // Retained for future use
 func createExecuteTool() *tools.Tool {
 	return &tools.Tool{
 		Name:        "dbExecute",
-		Description: "Execute a database statement that doesn't return results (INSERT, UPDATE, DELETE, etc.)",
+		Description: "Execute a write statement (INSERT, UPDATE, DELETE) on a connection with allow_writes enabled",
 		Category:    "database",
 		InputSchema: tools.ToolInputSchema{
 			Type: "object",
@@ -64,6 +63,15 @@ func handleExecute(ctx context.Context, params map[string]interface{}) (interfac
 		return nil, fmt.Errorf("database parameter is required")
 	}
 
+	// Enforce the connection's write permissions before touching the database
+	connConfig, ok := dbManager.GetMetadata(databaseID)
+	if !ok {
+		return nil, fmt.Errorf("database connection %s not found", databaseID)
+	}
+	if err := ValidateWriteStatement(statement, connConfig.AllowWrites, connConfig.AllowDDL); err != nil {
+		return nil, fmt.Errorf("statement rejected for %s: %w", databaseID, err)
+	}
+
 	// Get database instance
 	db, err := dbManager.GetDatabase(databaseID)
 	if err != nil {
@@ -128,3 +136,134 @@ func handleExecute(ctx context.Context, params map[string]interface{}) (interfac
 
 	return result, nil
 }
+
+// writeKeywords are the statement types allow_writes permits; every other statement,
+// including procedure calls and dynamic SQL (EXEC, CALL, DO, COPY, ...), needs allow_ddl
+var writeKeywords = []string{
+	"INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE",
+}
+
+// ValidateWriteStatement checks a statement against a connection's write permissions.
+// INSERT, UPDATE, DELETE, MERGE and REPLACE (also as the main statement of a WITH)
+// require allowWrites; any other statement additionally requires allowDDL. Only a single
+// statement may be executed per call.
+func ValidateWriteStatement(statement string, allowWrites bool, allowDDL bool) error {
+	trimmed := strings.TrimSpace(statement)
+	if trimmed == "" {
+		return fmt.Errorf("statement cannot be empty")
+	}
+
+	if !allowWrites {
+		return fmt.Errorf("write operations are disabled for this connection (set allow_writes to enable)")
+	}
+
+	// Classify the statement without its comments and quoted text, so "/* x */ DROP" is
+	// still seen as DROP and a semicolon inside 'a;b' is not a statement separator
+	masked := strings.TrimSpace(maskSQLText(trimmed))
+	if masked == "" {
+		return fmt.Errorf("statement cannot be empty")
+	}
+
+	// A single trailing semicolon is fine; anything after it is another statement
+	if strings.Contains(strings.TrimRight(masked, "; \t\r\n"), ";") {
+		return fmt.Errorf("multiple statements are not allowed; execute one statement per call")
+	}
+
+	if allowDDL {
+		return nil
+	}
+	statementType := mainStatementKeyword(masked)
+	for _, keyword := range writeKeywords {
+		if statementType == keyword {
+			return nil
+		}
+	}
+	if statementType == "" {
+		statementType = "no INSERT, UPDATE, DELETE, MERGE or REPLACE"
+	}
+	return fmt.Errorf("only INSERT, UPDATE, DELETE, MERGE and REPLACE statements are allowed for this connection: detected %s (set allow_ddl to enable other statements)", statementType)
+}
+
+// mainStatementKeyword returns the first keyword of a masked statement in upper case. For
+// a WITH statement it is the keyword of the statement following the common table
+// expressions, whose bodies sit in parentheses, or "" when there is none.
+func mainStatementKeyword(masked string) string {
+	upper := strings.ToUpper(masked)
+	isWithClause := false
+	depth := 0
+	for i := 0; i < len(upper); {
+		c := upper[i]
+		switch {
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+		case c >= 'A' && c <= 'Z' || c == '_':
+			end := i
+			for end < len(upper) && (upper[end] >= 'A' && upper[end] <= 'Z' || upper[end] >= '0' && upper[end] <= '9' || upper[end] == '_') {
+				end++
+			}
+			word := upper[i:end]
+			i = end
+			switch {
+			case depth != 0:
+			case !isWithClause && word != "WITH":
+				return word
+			case !isWithClause:
+				isWithClause = true
+			case word == "SELECT" || word == "VALUES" || word == "TABLE":
+				return word
+			default:
+				for _, keyword := range writeKeywords {
+					if word == keyword {
+						return word
+					}
+				}
+			}
+		default:
+			i++
+		}
+	}
+	return ""
+}
+
+// maskSQLText replaces comments with a space and empties quoted strings and identifiers,
+// leaving the statement's keywords and separators. The body of a MySQL executable
+// comment (/*! ... */) is kept, since MySQL runs it.
+func maskSQLText(statement string) string {
+	var out strings.Builder
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			out.WriteByte(c)
+			out.WriteByte(c)
+			i = closingQuote(statement, i, false)
+		case c == '-' && strings.HasPrefix(statement[i:], "--"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = len(statement) - i
+			}
+			out.WriteByte(' ')
+			i += end
+		case c == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement) - i - 2
+			}
+			if strings.HasPrefix(statement[i:], "/*!") {
+				body := strings.TrimLeft(statement[i+3:i+2+end], "0123456789")
+				out.WriteString(" " + maskSQLText(body) + " ")
+			} else {
+				out.WriteByte(' ')
+			}
+			i += end + 4
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,194 @@
+package dbtools
+
+import (
+	"testing"
+)
+
+func TestValidateWriteStatement(t *testing.T) {
+	tests := []struct {
+		name        string
+		statement   string
+		allowWrites bool
+		allowDDL    bool
+		expectError bool
+	}{
+		{
+			name:        "UPDATE rejected when writes disabled",
+			statement:   "UPDATE users SET name = 'Jane' WHERE id = 1",
+			allowWrites: false,
+			expectError: true,
+		},
+		{
+			name:        "UPDATE accepted when writes enabled",
+			statement:   "UPDATE users SET name = 'Jane' WHERE id = 1",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "INSERT with trailing semicolon accepted",
+			statement:   "INSERT INTO users (name) VALUES ('John');",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "DROP rejected without allow_ddl",
+			statement:   "DROP TABLE users",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "ALTER on new line rejected without allow_ddl",
+			statement:   "alter\nTABLE users ADD COLUMN age INT",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "TRUNCATE accepted with allow_ddl",
+			statement:   "TRUNCATE TABLE sessions",
+			allowWrites: true,
+			allowDDL:    true,
+			expectError: false,
+		},
+		{
+			name:        "Multiple statements rejected",
+			statement:   "DELETE FROM sessions; DROP TABLE users",
+			allowWrites: true,
+			allowDDL:    true,
+			expectError: true,
+		},
+		{
+			name:        "DROP after a block comment rejected without allow_ddl",
+			statement:   "/* x */ DROP TABLE t",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "DROP after a line comment rejected without allow_ddl",
+			statement:   "-- cleanup\nDROP TABLE t",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "DROP in a MySQL executable comment rejected without allow_ddl",
+			statement:   "/*!50000 DROP TABLE t */",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "Semicolon inside a string literal accepted",
+			statement:   "INSERT INTO notes (body) VALUES ('a;b')",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "Semicolon inside a comment accepted",
+			statement:   "UPDATE users SET active = 0 -- a; b\nWHERE id = 1;",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "Statement after a quoted semicolon rejected",
+			statement:   "INSERT INTO notes (body) VALUES ('a;b'); DROP TABLE notes",
+			allowWrites: true,
+			allowDDL:    true,
+			expectError: true,
+		},
+		{
+			name:        "EXEC of dynamic SQL rejected without allow_ddl",
+			statement:   `EXEC('DROP TABLE users')`,
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "DO block rejected without allow_ddl",
+			statement:   `DO 'BEGIN EXECUTE ''DROP TABLE users''; END'`,
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "CALL rejected without allow_ddl",
+			statement:   "CALL drop_all()",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "EXECUTE of a prepared statement rejected without allow_ddl",
+			statement:   "EXECUTE stmt",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "COPY to a program rejected without allow_ddl",
+			statement:   `COPY users TO PROGRAM 'rm -rf /tmp/x'`,
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "GRANT rejected without allow_ddl",
+			statement:   "GRANT ALL ON users TO public",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "SELECT INTO rejected without allow_ddl",
+			statement:   "SELECT * INTO users_copy FROM users",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "CALL accepted with allow_ddl",
+			statement:   "CALL drop_all()",
+			allowWrites: true,
+			allowDDL:    true,
+			expectError: false,
+		},
+		{
+			name:        "MERGE accepted when writes enabled",
+			statement:   "MERGE INTO users u USING staging s ON u.id = s.id WHEN MATCHED THEN UPDATE SET name = s.name",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "REPLACE accepted when writes enabled",
+			statement:   `REPLACE INTO users (id, name) VALUES (1, 'Jane')`,
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "WITH ending in DELETE accepted when writes enabled",
+			statement:   "WITH stale AS (SELECT id FROM sessions WHERE expires_at < now()) DELETE FROM sessions WHERE id IN (SELECT id FROM stale)",
+			allowWrites: true,
+			expectError: false,
+		},
+		{
+			name:        "WITH ending in SELECT rejected without allow_ddl",
+			statement:   "WITH gone AS (DELETE FROM sessions RETURNING id) SELECT count(*) FROM gone",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "Comment-only statement rejected",
+			statement:   "/* nothing */",
+			allowWrites: true,
+			expectError: true,
+		},
+		{
+			name:        "Empty statement rejected",
+			statement:   "   ",
+			allowWrites: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWriteStatement(tt.statement, tt.allowWrites, tt.allowDDL)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for statement: %s", tt.statement)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error for statement: %s, error: %v", tt.statement, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package dbtools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/logger"
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// createExplainTool creates a tool for showing a query's execution plan
+func createExplainTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbExplain",
+		Description: "Show the query planner's execution plan for a read-only query. The query is not run unless analyze is set",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Read-only SQL query to explain (without the EXPLAIN prefix)",
+				},
+				"params": map[string]interface{}{
+					"type":        "array",
+					"description": "Parameters for the query (for prepared statements)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"analyze": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Run the query to report actual row counts and timing (PostgreSQL and MySQL 8.0.18+). It runs in a read-only transaction that is always rolled back (default: false)",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: the database's query timeout)",
+				},
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to use",
+				},
+			},
+			Required: []string{"query", "database"},
+		},
+		Handler: handleExplain,
+	}
+}
+
+// handleExplain handles the explain tool execution
+func handleExplain(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	query, ok := getStringParam(params, "query")
+	if !ok {
+		return nil, fmt.Errorf("query parameter is required")
+	}
+
+	// EXPLAIN does not run the query on most engines, but some variants do and analyze
+	// always does; keep the read-only guard
+	if err := validateReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+
+	analyze, _ := getBoolParam(params, "analyze")
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeout := db.QueryTimeout() * 1000
+	if timeoutParam, ok := getIntParam(params, "timeout"); ok {
+		timeout = timeoutParam
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	var queryParams []interface{}
+	if paramsArray, ok := getArrayParam(params, "params"); ok {
+		queryParams = make([]interface{}, len(paramsArray))
+		copy(queryParams, paramsArray)
+	}
+
+	strategy := NewDatabaseStrategy(db.DriverName())
+	explain := strategy.GetExplainQuery(query)
+	if analyze {
+		explain = strategy.GetExplainAnalyzeQuery(query)
+		if explain.query == "" {
+			return nil, fmt.Errorf("analyze is not supported for %s databases", db.DriverName())
+		}
+	}
+	args := append(explain.args, queryParams...)
+
+	var planRows []map[string]interface{}
+	if analyze {
+		planRows, err = explainAnalyze(timeoutCtx, db.DB(), explain.query, args)
+		if err != nil {
+			return nil, err
+		}
+	} else if db.DriverName() == "sqlserver" {
+		planRows, err = explainSQLServer(timeoutCtx, db.DB(), explain.query, args)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rows, err := db.Query(timeoutCtx, explain.query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to explain query: %w", err)
+		}
+		defer cleanupRows(rows)
+
+		planRows, err = rowsToMaps(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read explain output: %w", err)
+		}
+	}
+
+	return map[string]interface{}{
+		"query":    query,
+		"database": databaseID,
+		"driver":   db.DriverName(),
+		"analyze":  analyze,
+		"plan":     parsePlanRows(planRows),
+	}, nil
+}
+
+// parsePlanRows turns EXPLAIN output into structured JSON. A single row holding a
+// JSON document (PostgreSQL FORMAT JSON) is decoded; tabular plans (MySQL) are
+// returned as rows.
+func parsePlanRows(planRows []map[string]interface{}) interface{} {
+	if len(planRows) == 1 && len(planRows[0]) == 1 {
+		for _, value := range planRows[0] {
+			text, ok := value.(string)
+			if !ok {
+				break
+			}
+			var plan interface{}
+			if err := json.Unmarshal([]byte(text), &plan); err == nil {
+				return plan
+			}
+		}
+	}
+
+	if planRows == nil {
+		return []map[string]interface{}{}
+	}
+	return planRows
+}
+
+// explainSQLServer returns the estimated plan for a SQL Server query. SHOWPLAN_XML is a
+// session setting that must be the only statement in its batch, so the plan is read on a
+// dedicated connection, which is discarded if the setting cannot be switched back off.
+func explainSQLServer(ctx context.Context, sqlDB *sql.DB, query string, args []interface{}) ([]map[string]interface{}, error) {
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() {
+		if cerr := conn.Close(); cerr != nil {
+			logger.Warn("Error closing connection: %v", cerr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+		return nil, fmt.Errorf("failed to enable SHOWPLAN_XML: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so a timed-out explain still resets the session
+		resetCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(resetCtx, "SET SHOWPLAN_XML OFF"); err != nil {
+			logger.Warn("Failed to disable SHOWPLAN_XML, discarding connection: %v", err)
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	planRows, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read explain output: %w", err)
+	}
+	return planRows, nil
+}
+
+// explainAnalyze runs an EXPLAIN ANALYZE, which executes the query, in a read-only
+// transaction that is always rolled back, so a write the read-only guard missed (such as
+// a data-modifying CTE) is never committed
+func explainAnalyze(ctx context.Context, sqlDB *sql.DB, query string, args []interface{}) ([]map[string]interface{}, error) {
+	tx, err := sqlDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// A cancelled context has already rolled the transaction back
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Warn("Failed to roll back EXPLAIN ANALYZE transaction: %v", err)
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain query: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	planRows, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read explain output: %w", err)
+	}
+	return planRows, nil
+}
+
+// trimStatement strips surrounding whitespace and trailing semicolons so the
+// query can be embedded in another statement
+func trimStatement(query string) string {
+	return strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+}
@@ -0,0 +1,133 @@
+package dbtools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTxConn is a minimal driver connection that records transaction boundaries and
+// queries, and answers every query with a one-row plan
+type recordingTxConn struct {
+	events   []string
+	queryErr error
+}
+
+func (c *recordingTxConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *recordingTxConn) Close() error { return nil }
+func (c *recordingTxConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingTxConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.ReadOnly {
+		c.events = append(c.events, "begin read-only")
+	} else {
+		c.events = append(c.events, "begin")
+	}
+	return recordingTx{c}, nil
+}
+
+func (c *recordingTxConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.events = append(c.events, "query "+query)
+	if c.queryErr != nil {
+		return nil, c.queryErr
+	}
+	return &multiResultRows{
+		sets: []fakeResultSet{
+			{columns: []string{"QUERY PLAN"}, rows: [][]driver.Value{{[]byte(`[{"Plan": {"Actual Rows": 3}}]`)}}},
+		},
+	}, nil
+}
+
+type recordingTx struct{ conn *recordingTxConn }
+
+func (tx recordingTx) Commit() error {
+	tx.conn.events = append(tx.conn.events, "commit")
+	return nil
+}
+
+func (tx recordingTx) Rollback() error {
+	tx.conn.events = append(tx.conn.events, "rollback")
+	return nil
+}
+
+type recordingTxConnector struct{ conn *recordingTxConn }
+
+func (c recordingTxConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c recordingTxConnector) Driver() driver.Driver                        { return nil }
+
+func TestPostgresStrategyGetExplainQuery(t *testing.T) {
+	strategy := NewDatabaseStrategy("postgres")
+
+	explain := strategy.GetExplainQuery("  SELECT id FROM users WHERE status = $1;\n")
+
+	assert.Equal(t, "EXPLAIN (FORMAT JSON) SELECT id FROM users WHERE status = $1", explain.query)
+	assert.Empty(t, explain.args)
+}
+
+func TestMySQLStrategyGetExplainQuery(t *testing.T) {
+	strategy := NewDatabaseStrategy("mysql")
+
+	explain := strategy.GetExplainQuery("SELECT id FROM users WHERE status = ?")
+
+	assert.Equal(t, "EXPLAIN SELECT id FROM users WHERE status = ?", explain.query)
+}
+
+func TestParsePlanRows(t *testing.T) {
+	// PostgreSQL FORMAT JSON returns a single row with the plan document
+	plan := parsePlanRows([]map[string]interface{}{
+		{"QUERY PLAN": `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "users"}}]`},
+	})
+	nodes, ok := plan.([]interface{})
+	assert.True(t, ok)
+	assert.Len(t, nodes, 1)
+
+	// MySQL returns one tabular row per table in the plan
+	rows := []map[string]interface{}{
+		{"id": int64(1), "select_type": "SIMPLE", "table": "users", "type": "ALL"},
+	}
+	assert.Equal(t, rows, parsePlanRows(rows))
+}
+
+func TestGetExplainAnalyzeQuery(t *testing.T) {
+	explain := NewDatabaseStrategy("postgres").GetExplainAnalyzeQuery("SELECT id FROM users;")
+	assert.Equal(t, "EXPLAIN (ANALYZE, FORMAT JSON) SELECT id FROM users", explain.query)
+
+	explain = NewDatabaseStrategy("mysql").GetExplainAnalyzeQuery("SELECT id FROM users")
+	assert.Equal(t, "EXPLAIN ANALYZE SELECT id FROM users", explain.query)
+
+	// Unsupported drivers return no query
+	assert.Empty(t, NewDatabaseStrategy("sqlite").GetExplainAnalyzeQuery("SELECT 1").query)
+	assert.Empty(t, NewDatabaseStrategy("sqlserver").GetExplainAnalyzeQuery("SELECT 1").query)
+}
+
+func TestExplainAnalyzeRollsBack(t *testing.T) {
+	conn := &recordingTxConn{}
+	sqlDB := sql.OpenDB(recordingTxConnector{conn})
+	defer sqlDB.Close()
+
+	planRows, err := explainAnalyze(context.Background(), sqlDB, "EXPLAIN (ANALYZE, FORMAT JSON) SELECT id FROM users", nil)
+	assert.NoError(t, err)
+	assert.Len(t, planRows, 1)
+	assert.Equal(t, []string{
+		"begin read-only",
+		"query EXPLAIN (ANALYZE, FORMAT JSON) SELECT id FROM users",
+		"rollback",
+	}, conn.events)
+
+	// A failed query is rolled back too
+	conn = &recordingTxConn{queryErr: errors.New("permission denied")}
+	sqlDB = sql.OpenDB(recordingTxConnector{conn})
+	defer sqlDB.Close()
+
+	_, err = explainAnalyze(context.Background(), sqlDB, "EXPLAIN ANALYZE SELECT id FROM users", nil)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"begin read-only", "query EXPLAIN ANALYZE SELECT id FROM users", "rollback"}, conn.events)
+}
@@ -0,0 +1,186 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// defaultPingTimeout bounds a health check so an unreachable host cannot hang the tool
+const defaultPingTimeout = 5 * time.Second
+
+// Health check statuses
+const (
+	healthStatusOK           = "ok"
+	healthStatusError        = "error"
+	healthStatusNotConnected = "not_connected"
+)
+
+// DatabaseHealth is the result of pinging one database connection
+type DatabaseHealth struct {
+	Database  string  `json:"database"`
+	Status    string  `json:"status"`
+	Driver    string  `json:"driver,omitempty"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// createPingTool creates a tool for checking that one database is reachable
+func createPingTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbPing",
+		Description: "Check that a configured database is reachable by pinging its connection, without running a query. Returns the status, latency in milliseconds and driver.",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to ping",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: 5000)",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handlePing,
+	}
+}
+
+// createHealthAllTool creates a tool for checking every configured database at once
+func createHealthAllTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbHealthAll",
+		Description: "Ping every configured database concurrently and return the status, latency and driver of each, including connections that failed at startup",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout per database in milliseconds (default: 5000)",
+				},
+			},
+		},
+		Handler: handleHealthAll,
+	}
+}
+
+// handlePing handles the ping tool execution
+func handlePing(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	if _, err := dbManager.GetDatabaseConfig(databaseID); err != nil {
+		return nil, err
+	}
+
+	return checkDatabaseHealth(ctx, dbManager, databaseID, pingTimeout(params)), nil
+}
+
+// handleHealthAll handles the health check tool execution for all databases
+func handleHealthAll(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	results := checkAllDatabasesHealth(ctx, dbManager, pingTimeout(params))
+
+	healthy := 0
+	for _, health := range results {
+		if health.Status == healthStatusOK {
+			healthy++
+		}
+	}
+
+	return map[string]interface{}{
+		"databases": results,
+		"count":     len(results),
+		"healthy":   healthy,
+		"unhealthy": len(results) - healthy,
+	}, nil
+}
+
+// pingTimeout returns the timeout parameter in milliseconds, or defaultPingTimeout
+func pingTimeout(params map[string]interface{}) time.Duration {
+	if timeoutMs, ok := getIntParam(params, "timeout"); ok && timeoutMs > 0 {
+		return time.Duration(timeoutMs) * time.Millisecond
+	}
+	return defaultPingTimeout
+}
+
+// checkDatabaseHealth pings one configured database. Connections that failed to connect
+// are reported as not connected with their last error; the manager's reconnect loop, not
+// the health check, retries them.
+func checkDatabaseHealth(ctx context.Context, manager *db.Manager, databaseID string, timeout time.Duration) DatabaseHealth {
+	health := DatabaseHealth{Database: databaseID}
+
+	database, err := manager.GetDatabase(databaseID)
+	if err != nil {
+		health.Status = healthStatusNotConnected
+		if dbType, typeErr := manager.GetDatabaseType(databaseID); typeErr == nil {
+			health.Driver = dbType
+		}
+		health.Error = err.Error()
+		if connectErr, failed := manager.FailedDatabases()[databaseID]; failed {
+			health.Error = connectErr
+		}
+		return health
+	}
+	health.Driver = database.DriverName()
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err = database.Ping(pingCtx)
+	health.LatencyMs = float64(time.Since(start).Microseconds()) / 1000.0
+	if err != nil {
+		health.Status = healthStatusError
+		if pingCtx.Err() == context.DeadlineExceeded {
+			health.Error = fmt.Sprintf("ping did not complete within %s", timeout)
+		} else {
+			health.Error = err.Error()
+		}
+		return health
+	}
+
+	health.Status = healthStatusOK
+	return health
+}
+
+// checkAllDatabasesHealth pings every configured database concurrently, keyed by ID
+func checkAllDatabasesHealth(ctx context.Context, manager *db.Manager, timeout time.Duration) map[string]DatabaseHealth {
+	ids := manager.ListDatabases()
+	results := make(map[string]DatabaseHealth, len(ids))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			health := checkDatabaseHealth(ctx, manager, id, timeout)
+			mu.Lock()
+			results[id] = health
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,68 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+)
+
+// newHealthTestManager returns a manager with a reachable SQLite database ("local") and
+// a PostgreSQL connection to a closed port ("unreachable")
+func newHealthTestManager(t *testing.T) *db.Manager {
+	t.Helper()
+
+	manager := db.NewDBManager()
+	configJSON := fmt.Sprintf(`{"connections": [
+		{"id": "local", "type": "sqlite", "name": %q},
+		{"id": "unreachable", "type": "postgres", "host": "127.0.0.1", "port": 1, "user": "nobody", "name": "none", "connect_timeout": 1}
+	]}`, filepath.Join(t.TempDir(), "health.db"))
+	assert.NoError(t, manager.LoadConfig([]byte(configJSON)))
+	// Connect only fails when no database could be reached
+	assert.NoError(t, manager.Connect())
+	t.Cleanup(func() { _ = manager.CloseAll() })
+
+	return manager
+}
+
+func TestCheckDatabaseHealth(t *testing.T) {
+	manager := newHealthTestManager(t)
+
+	health := checkDatabaseHealth(context.Background(), manager, "local", time.Second)
+	assert.Equal(t, healthStatusOK, health.Status)
+	assert.Equal(t, "sqlite", health.Driver)
+	assert.Empty(t, health.Error)
+
+	health = checkDatabaseHealth(context.Background(), manager, "unreachable", time.Second)
+	assert.Equal(t, healthStatusNotConnected, health.Status)
+	assert.Equal(t, "postgres", health.Driver)
+	assert.NotEmpty(t, health.Error)
+
+	// A connection that dies after startup reports the ping error
+	database, err := manager.GetDatabase("local")
+	assert.NoError(t, err)
+	assert.NoError(t, database.Close())
+	health = checkDatabaseHealth(context.Background(), manager, "local", time.Second)
+	assert.Equal(t, healthStatusError, health.Status)
+	assert.Contains(t, health.Error, "closed")
+}
+
+func TestCheckAllDatabasesHealth(t *testing.T) {
+	manager := newHealthTestManager(t)
+
+	results := checkAllDatabasesHealth(context.Background(), manager, time.Second)
+	assert.Len(t, results, 2)
+	assert.Equal(t, healthStatusOK, results["local"].Status)
+	assert.Equal(t, healthStatusNotConnected, results["unreachable"].Status)
+}
+
+func TestPingTimeout(t *testing.T) {
+	assert.Equal(t, defaultPingTimeout, pingTimeout(map[string]interface{}{}))
+	assert.Equal(t, 250*time.Millisecond, pingTimeout(map[string]interface{}{"timeout": float64(250)}))
+	assert.Equal(t, defaultPingTimeout, pingTimeout(map[string]interface{}{"timeout": float64(0)}))
+}
@@ -0,0 +1,133 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// systemDatabases lists the built-in databases hidden by default, per driver
+var systemDatabases = map[string][]string{
+	"postgres":  {"template0", "template1"},
+	"mysql":     {"information_schema", "mysql", "performance_schema", "sys"},
+	"sqlite":    {"temp"},
+	"sqlserver": {"master", "model", "msdb", "tempdb"},
+}
+
+// createListDatabasesTool creates a tool for listing the databases on the connected server
+func createListDatabasesTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "db_list_databases",
+		Description: "List the other databases on the same server as a configured connection, limited to those the connection can see",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID whose server should be inspected",
+				},
+				"include_system": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include template and system databases (default: false)",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handleListDatabases,
+	}
+}
+
+// handleListDatabases handles the list databases tool execution
+func handleListDatabases(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+	includeSystem, _ := getBoolParam(params, "include_system")
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(db.QueryTimeout())*time.Second)
+	defer cancel()
+
+	strategy := NewDatabaseStrategy(db.DriverName())
+	rows, err := executeWithFallbacks(timeoutCtx, db, strategy.GetDatabasesQueries(), "listDatabases")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read databases: %w", err)
+	}
+
+	databases := filterDatabases(db.DriverName(), results, includeSystem)
+
+	return map[string]interface{}{
+		"database":  databaseID,
+		"driver":    db.DriverName(),
+		"databases": databases,
+		"count":     len(databases),
+	}, nil
+}
+
+// filterDatabases normalizes the database name to a "name" key and drops
+// template and system databases unless includeSystem is set
+func filterDatabases(driver string, rows []map[string]interface{}, includeSystem bool) []map[string]interface{} {
+	databases := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		name := databaseName(row)
+		if name == "" {
+			continue
+		}
+		if !includeSystem && isSystemDatabase(driver, name, row) {
+			continue
+		}
+
+		entry := make(map[string]interface{}, len(row))
+		for key, value := range row {
+			entry[key] = value
+		}
+		// SHOW DATABASES names its only column "Database"
+		delete(entry, "Database")
+		entry["name"] = name
+		databases = append(databases, entry)
+	}
+	return databases
+}
+
+// databaseName extracts the database name from a listing row
+func databaseName(row map[string]interface{}) string {
+	for _, key := range []string{"name", "Database", "datname", "schema_name", "SCHEMA_NAME"} {
+		if name, ok := row[key].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// isSystemDatabase reports whether a database is a template or built-in system database
+func isSystemDatabase(driver, name string, row map[string]interface{}) bool {
+	if isTemplate, ok := row["is_template"].(bool); ok && isTemplate {
+		return true
+	}
+	for _, system := range systemDatabases[driver] {
+		if strings.EqualFold(name, system) {
+			return true
+		}
+	}
+	return false
+}
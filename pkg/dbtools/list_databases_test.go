@@ -0,0 +1,53 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterDatabasesPostgres(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"name": "app", "owner": "app_owner", "is_template": false, "is_current": true},
+		{"name": "postgres", "owner": "postgres", "is_template": false, "is_current": false},
+		{"name": "reporting", "owner": "app_owner", "is_template": false, "is_current": false},
+		{"name": "template1", "owner": "postgres", "is_template": true, "is_current": false},
+		{"name": "template_postgis", "owner": "postgres", "is_template": true, "is_current": false},
+	}
+
+	databases := filterDatabases("postgres", rows, false)
+
+	names := make([]string, 0, len(databases))
+	for _, database := range databases {
+		names = append(names, database["name"].(string))
+	}
+	assert.Equal(t, []string{"app", "postgres", "reporting"}, names)
+
+	assert.Len(t, filterDatabases("postgres", rows, true), 5)
+}
+
+func TestFilterDatabasesMySQL(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"Database": "information_schema"},
+		{"Database": "orders"},
+		{"Database": "mysql"},
+		{"Database": "performance_schema"},
+		{"Database": "sys"},
+	}
+
+	databases := filterDatabases("mysql", rows, false)
+
+	assert.Equal(t, []map[string]interface{}{{"name": "orders"}}, databases)
+	assert.Len(t, filterDatabases("mysql", rows, true), 5)
+}
+
+func TestPostgresDatabasesQueriesCheckConnectPrivilege(t *testing.T) {
+	queries := (&PostgresStrategy{}).GetDatabasesQueries()
+	assert.Len(t, queries, 2)
+
+	// The fallback has no is_template column, so it must drop templates itself
+	for _, q := range queries {
+		assert.Contains(t, q.query, "has_database_privilege(datname, 'CONNECT')")
+	}
+	assert.Contains(t, queries[1].query, "NOT datistemplate")
+}
@@ -0,0 +1,17 @@
+package dbtools
+
+import (
+	"os"
+	"testing"
+
+	"github.com/FreePeak/infra-mcp-server/internal/logger"
+	pkgLogger "github.com/FreePeak/infra-mcp-server/pkg/logger"
+)
+
+// TestMain initializes the loggers before any test runs; logging through them
+// uninitialized panics, and the schema cache and database connections log
+func TestMain(m *testing.M) {
+	logger.Initialize("error")
+	pkgLogger.Initialize("error")
+	os.Exit(m.Run())
+}
@@ -0,0 +1,154 @@
+package dbtools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// notInListSuffix matches query text ending just inside a NOT IN list
+var notInListSuffix = regexp.MustCompile(`(?i)\bNOT\s+IN\s*\(\s*$`)
+
+// expandNamedParams rewrites :name references in a query to the driver's positional
+// placeholders and returns the matching arguments. An array value expands to one
+// placeholder per element, so "id IN (:ids)" works for any list length; an empty array
+// becomes NULL, which matches no rows in an IN list. Names inside string literals (including
+// MySQL backslash escapes and PostgreSQL dollar-quoted strings), quoted identifiers and
+// comments, and PostgreSQL :: casts, are left alone.
+func expandNamedParams(driver string, query string, named map[string]interface{}) (string, []interface{}, error) {
+	var out strings.Builder
+	var args []interface{}
+	used := make(map[string]bool, len(named))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(query, i, driver == "mysql")
+			out.WriteString(query[i:end])
+			i = end
+		case c == '$' && driver == "postgres" && dollarQuoteEnd(query, i) > 0:
+			end := dollarQuoteEnd(query, i)
+			out.WriteString(query[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			out.WriteString(query[i : i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			out.WriteString(query[i : i+end])
+			i += end
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			out.WriteString("::")
+			i += 2
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+			value, ok := named[name]
+			if !ok {
+				return "", nil, fmt.Errorf("named parameter :%s has no value in named_params", name)
+			}
+			used[name] = true
+
+			if list, isList := value.([]interface{}); isList {
+				if len(list) == 0 {
+					if notInListSuffix.MatchString(out.String()) {
+						return "", nil, fmt.Errorf("named parameter :%s is an empty list in NOT IN, which would match no rows; remove the condition instead", name)
+					}
+					out.WriteString("NULL")
+				} else {
+					placeholders := make([]string, 0, len(list))
+					for _, item := range list {
+						args = append(args, item)
+						placeholders = append(placeholders, placeholder(driver, len(args)))
+					}
+					out.WriteString(strings.Join(placeholders, ", "))
+				}
+			} else {
+				args = append(args, value)
+				out.WriteString(placeholder(driver, len(args)))
+			}
+			i = end
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	for _, name := range sortedKeys(named) {
+		if !used[name] {
+			return "", nil, fmt.Errorf("named parameter %s is not used in the query", name)
+		}
+	}
+
+	return out.String(), args, nil
+}
+
+// closingQuote returns the index just past the quoted section starting at start,
+// treating a doubled quote character as an escaped one. With backslashEscapes, as in
+// MySQL strings, a backslash also escapes the next character; backtick-quoted
+// identifiers never use backslash escapes.
+func closingQuote(query string, start int, backslashEscapes bool) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if backslashEscapes && quote != '`' && query[i] == '\\' {
+			i++
+			continue
+		}
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+// dollarQuoteEnd returns the index just past the PostgreSQL dollar-quoted string ($$...$$
+// or $tag$...$tag$) starting at start, or -1 when none starts there, as for the
+// positional parameter $1 or a $ inside an identifier
+func dollarQuoteEnd(query string, start int) int {
+	if start > 0 && (isNamePart(query[start-1]) || query[start-1] == '$') {
+		return -1
+	}
+
+	tagEnd := start + 1
+	if tagEnd < len(query) && isNameStart(query[tagEnd]) {
+		for tagEnd < len(query) && isNamePart(query[tagEnd]) {
+			tagEnd++
+		}
+	}
+	if tagEnd >= len(query) || query[tagEnd] != '$' {
+		return -1
+	}
+
+	tag := query[start : tagEnd+1]
+	end := strings.Index(query[tagEnd+1:], tag)
+	if end < 0 {
+		return len(query)
+	}
+	return tagEnd + 1 + end + len(tag)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,87 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandNamedParamsPostgres(t *testing.T) {
+	query, args, err := expandNamedParams("postgres",
+		"SELECT id, created_at::date FROM orders WHERE id IN (:ids) AND status = :status AND note <> ':ids' -- :ids\nORDER BY id",
+		map[string]interface{}{
+			"ids":    []interface{}{float64(3), float64(5), float64(8)},
+			"status": "paid",
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, created_at::date FROM orders WHERE id IN ($1, $2, $3) AND status = $4 AND note <> ':ids' -- :ids\nORDER BY id", query)
+	assert.Equal(t, []interface{}{float64(3), float64(5), float64(8), "paid"}, args)
+}
+
+func TestExpandNamedParamsMySQL(t *testing.T) {
+	query, args, err := expandNamedParams("mysql",
+		"SELECT * FROM `users:all` WHERE region = :region AND id IN (:ids) AND manager_id IN (:ids)",
+		map[string]interface{}{
+			"region": "eu",
+			"ids":    []interface{}{"a", "b"},
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `users:all` WHERE region = ? AND id IN (?, ?) AND manager_id IN (?, ?)", query)
+	assert.Equal(t, []interface{}{"eu", "a", "b", "a", "b"}, args)
+}
+
+func TestExpandNamedParamsMySQLBackslashEscapes(t *testing.T) {
+	// The escaped quote does not end the string, so :ids inside it stays text
+	query, args, err := expandNamedParams("mysql",
+		`SELECT * FROM notes WHERE body <> 'it\'s :ids' AND title <> "say \":ids\"" AND id IN (:ids)`,
+		map[string]interface{}{
+			"ids": []interface{}{"a", "b"},
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM notes WHERE body <> 'it\'s :ids' AND title <> "say \":ids\"" AND id IN (?, ?)`, query)
+	assert.Equal(t, []interface{}{"a", "b"}, args)
+}
+
+func TestExpandNamedParamsPostgresDollarQuotes(t *testing.T) {
+	query, args, err := expandNamedParams("postgres",
+		"SELECT $$it's :ids$$, $body$ :ids $$ :ids $body$ FROM orders WHERE id IN (:ids) AND note = $1",
+		map[string]interface{}{
+			"ids": []interface{}{float64(3), float64(5)},
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT $$it's :ids$$, $body$ :ids $$ :ids $body$ FROM orders WHERE id IN ($1, $2) AND note = $1", query)
+	assert.Equal(t, []interface{}{float64(3), float64(5)}, args)
+}
+
+func TestExpandNamedParamsEmptyList(t *testing.T) {
+	for _, driver := range []string{"postgres", "mysql"} {
+		query, args, err := expandNamedParams(driver, "SELECT * FROM orders WHERE id IN ( :ids )", map[string]interface{}{
+			"ids": []interface{}{},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT * FROM orders WHERE id IN ( NULL )", query)
+		assert.Empty(t, args)
+	}
+
+	// NOT IN (NULL) matches nothing instead of everything, so it is rejected
+	_, _, err := expandNamedParams("postgres", "SELECT * FROM orders WHERE id NOT IN (:ids)", map[string]interface{}{
+		"ids": []interface{}{},
+	})
+	assert.Error(t, err)
+}
+
+func TestExpandNamedParamsErrors(t *testing.T) {
+	_, _, err := expandNamedParams("postgres", "SELECT * FROM orders WHERE id = :id", map[string]interface{}{})
+	assert.Error(t, err)
+
+	_, _, err = expandNamedParams("postgres", "SELECT * FROM orders WHERE id = :id", map[string]interface{}{
+		"id":     float64(1),
+		"status": "paid",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package dbtools
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultMaxRows is the most rows dbQuery returns when DB_MAX_ROWS is not set
+const defaultMaxRows = 10000
+
+// errRowLimitReached is returned by the capped row readers when the result has more
+// rows than the cap; the rows read up to the cap are returned alongside it
+var errRowLimitReached = errors.New("row limit reached")
+
+var (
+	// trailingLimitPattern matches a LIMIT clause (with optional OFFSET) ending a query
+	trailingLimitPattern = regexp.MustCompile(`(?is)\bLIMIT\s+[^\s()]+(\s*,\s*[^\s()]+|\s+OFFSET\s+[^\s()]+)?$`)
+	// trailingOffsetPattern matches a standard OFFSET ... ROWS or FETCH ... ROWS ONLY clause
+	// ending a query
+	trailingOffsetPattern = regexp.MustCompile(`(?is)\b(OFFSET\s+[^\s()]+\s+ROWS?|ROWS?\s+ONLY)$`)
+	// leadingTopPattern matches a SQL Server SELECT TOP, which cannot be combined with OFFSET
+	leadingTopPattern = regexp.MustCompile(`(?is)^SELECT\s+((ALL|DISTINCT)\s+)?TOP\b`)
+	orderByPattern    = regexp.MustCompile(`(?i)\bORDER\s+BY\b`)
+)
+
+// maxResultRows returns the server-side cap on rows returned by one query, from
+// DB_MAX_ROWS; zero or negative values fall back to the default
+func maxResultRows() int {
+	maxRows := getIntEnv("DB_MAX_ROWS", defaultMaxRows)
+	if maxRows <= 0 {
+		return defaultMaxRows
+	}
+	return maxRows
+}
+
+// paginateQuery restricts a single SELECT statement to limit rows starting at offset.
+// The clause is appended where the query has none of its own; a query that already
+// ends in a row limit (LIMIT, OFFSET ... ROWS or FETCH ... ROWS ONLY), or a SQL Server
+// query starting with SELECT TOP, is wrapped in a subquery so both limits apply.
+func paginateQuery(driverName, query string, limit, offset int) (string, error) {
+	trimmed := trimStatement(query)
+	if trimmed == "" {
+		return "", fmt.Errorf("query parameter is required")
+	}
+	// Semicolons inside quoted text or comments do not separate statements
+	masked := strings.TrimSpace(maskSQLText(trimmed))
+	if strings.Contains(masked, ";") {
+		return "", fmt.Errorf("limit and offset can only be used with a single statement")
+	}
+	if limit <= 0 {
+		return "", fmt.Errorf("limit must be positive")
+	}
+	if offset < 0 {
+		return "", fmt.Errorf("offset cannot be negative")
+	}
+
+	// The newlines keep a trailing line comment in the query from swallowing the clause
+	if driverName == "sqlserver" {
+		page := fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+		switch {
+		case trailingOffsetPattern.MatchString(trimmed), leadingTopPattern.MatchString(masked):
+			return fmt.Sprintf("SELECT * FROM (%s\n) paged ORDER BY (SELECT NULL) %s", trimmed, page), nil
+		case hasTrailingOrderBy(trimmed):
+			return fmt.Sprintf("%s\n%s", trimmed, page), nil
+		default:
+			// OFFSET/FETCH requires ORDER BY; keep the order the server returns rows in
+			return fmt.Sprintf("%s\nORDER BY (SELECT NULL) %s", trimmed, page), nil
+		}
+	}
+
+	page := fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	if trailingLimitPattern.MatchString(trimmed) || trailingOffsetPattern.MatchString(trimmed) {
+		return fmt.Sprintf("SELECT * FROM (%s\n) paged %s", trimmed, page), nil
+	}
+	return fmt.Sprintf("%s\n%s", trimmed, page), nil
+}
+
+// hasTrailingOrderBy reports whether the query's last ORDER BY applies to the outer
+// query rather than to a subquery or window that closes after it
+func hasTrailingOrderBy(query string) bool {
+	matches := orderByPattern.FindAllStringIndex(query, -1)
+	if len(matches) == 0 {
+		return false
+	}
+
+	depth := 0
+	for _, ch := range query[matches[len(matches)-1][1]:] {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginateQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   string
+		query    string
+		expected string
+	}{
+		{
+			name:     "appends limit",
+			driver:   "postgres",
+			query:    "SELECT * FROM users ORDER BY id;",
+			expected: "SELECT * FROM users ORDER BY id\nLIMIT 10 OFFSET 20",
+		},
+		{
+			name:     "wraps an existing limit",
+			driver:   "mysql",
+			query:    "SELECT * FROM users LIMIT 100",
+			expected: "SELECT * FROM (SELECT * FROM users LIMIT 100\n) paged LIMIT 10 OFFSET 20",
+		},
+		{
+			name:     "subquery limit is not the query's own",
+			driver:   "sqlite",
+			query:    "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders LIMIT 5)",
+			expected: "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders LIMIT 5)\nLIMIT 10 OFFSET 20",
+		},
+		{
+			name:     "sqlserver keeps the query's order",
+			driver:   "sqlserver",
+			query:    "SELECT * FROM users ORDER BY email",
+			expected: "SELECT * FROM users ORDER BY email\nOFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name:     "sqlserver adds an order when there is none",
+			driver:   "sqlserver",
+			query:    "SELECT * FROM users WHERE id IN (SELECT TOP 5 user_id FROM orders ORDER BY total)",
+			expected: "SELECT * FROM users WHERE id IN (SELECT TOP 5 user_id FROM orders ORDER BY total)\nORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name:     "sqlserver wraps an existing offset",
+			driver:   "sqlserver",
+			query:    "SELECT * FROM users ORDER BY id OFFSET 5 ROWS",
+			expected: "SELECT * FROM (SELECT * FROM users ORDER BY id OFFSET 5 ROWS\n) paged ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name:     "sqlserver wraps a top query",
+			driver:   "sqlserver",
+			query:    "SELECT DISTINCT TOP 100 email FROM users ORDER BY email",
+			expected: "SELECT * FROM (SELECT DISTINCT TOP 100 email FROM users ORDER BY email\n) paged ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name:     "wraps an existing fetch first",
+			driver:   "postgres",
+			query:    "SELECT * FROM users ORDER BY id FETCH FIRST 100 ROWS ONLY",
+			expected: "SELECT * FROM (SELECT * FROM users ORDER BY id FETCH FIRST 100 ROWS ONLY\n) paged LIMIT 10 OFFSET 20",
+		},
+		{
+			name:     "semicolon inside a string literal",
+			driver:   "postgres",
+			query:    "SELECT * FROM notes WHERE body = 'a;b';",
+			expected: "SELECT * FROM notes WHERE body = 'a;b'\nLIMIT 10 OFFSET 20",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := paginateQuery(tc.driver, tc.query, 10, 20)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+
+	_, err := paginateQuery("mysql", "SELECT 1; SELECT 2", 10, 0)
+	assert.Error(t, err)
+	_, err = paginateQuery("mysql", "SELECT 1", 10, -1)
+	assert.Error(t, err)
+}
+
+// useQueryTestManager points the package manager at a SQLite database holding
+// rowCount users for the duration of the test. The session stays writable so the
+// fixture rows can be inserted.
+func useQueryTestManager(t *testing.T, rowCount int) {
+	t.Helper()
+
+	manager := db.NewDBManager()
+	configJSON := fmt.Sprintf(`{"connections": [{"id": "local", "type": "sqlite", "name": %q, "session_read_only": false}]}`,
+		filepath.Join(t.TempDir(), "query.db"))
+	require.NoError(t, manager.LoadConfig([]byte(configJSON)))
+	require.NoError(t, manager.Connect())
+
+	database, err := manager.GetDatabase("local")
+	require.NoError(t, err)
+	_, err = database.Exec(context.Background(), "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+	require.NoError(t, err)
+	for i := 1; i <= rowCount; i++ {
+		_, err = database.Exec(context.Background(), "INSERT INTO users (id, name) VALUES (?, ?)", i, fmt.Sprintf("user%d", i))
+		require.NoError(t, err)
+	}
+
+	previous := dbManager
+	dbManager = manager
+	t.Cleanup(func() {
+		dbManager = previous
+		_ = manager.CloseAll()
+	})
+}
+
+func TestQueryRowCapTruncates(t *testing.T) {
+	t.Setenv("DB_MAX_ROWS", "3")
+	useQueryTestManager(t, 5)
+
+	for _, format := range []string{"json", "csv"} {
+		result, err := handleQuery(context.Background(), map[string]interface{}{
+			"database": "local",
+			"query":    "SELECT id FROM users ORDER BY id",
+			"format":   format,
+		})
+		require.NoError(t, err)
+
+		response := result.(map[string]interface{})
+		assert.Equal(t, 3, response["rowCount"], format)
+		assert.Equal(t, true, response["truncated"], format)
+		assert.Contains(t, response["warning"], "DB_MAX_ROWS", format)
+	}
+
+	// Results within the cap are complete
+	result, err := handleQuery(context.Background(), map[string]interface{}{
+		"database": "local",
+		"query":    "SELECT id FROM users WHERE id <= 3",
+	})
+	require.NoError(t, err)
+	response := result.(map[string]interface{})
+	assert.Equal(t, 3, response["rowCount"])
+	assert.Equal(t, false, response["truncated"])
+	assert.NotContains(t, response, "warning")
+}
+
+func TestQueryPagination(t *testing.T) {
+	useQueryTestManager(t, 5)
+
+	page := func(offset float64) map[string]interface{} {
+		result, err := handleQuery(context.Background(), map[string]interface{}{
+			"database": "local",
+			"query":    "SELECT id FROM users ORDER BY id",
+			"limit":    float64(2),
+			"offset":   offset,
+		})
+		require.NoError(t, err)
+		return result.(map[string]interface{})
+	}
+
+	first := page(0)
+	assert.Equal(t, []map[string]interface{}{{"id": int64(1)}, {"id": int64(2)}}, first["results"])
+	assert.Equal(t, true, first["hasMore"])
+	assert.Equal(t, 2, first["nextOffset"])
+	assert.Equal(t, false, first["truncated"])
+
+	last := page(4)
+	assert.Equal(t, []map[string]interface{}{{"id": int64(5)}}, last["results"])
+	assert.Equal(t, false, last["hasMore"])
+	assert.NotContains(t, last, "nextOffset")
+}
+
+func TestRowsToMapsMax(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+	for i := 1; i <= 3; i++ {
+		_, err := database.Exec(context.Background(), "INSERT INTO users (id, email) VALUES (?, ?)", i, fmt.Sprintf("u%d@example.com", i))
+		assert.NoError(t, err)
+	}
+
+	rows, err := database.Query(context.Background(), "SELECT id FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	results, err := rowsToMapsMax(rows, 2)
+	assert.ErrorIs(t, err, errRowLimitReached)
+	assert.Len(t, results, 2)
+
+	// The in-memory database has a single connection, so release it before the next query
+	cleanupRows(rows)
+
+	// A cap equal to the row count reads everything without reporting the limit
+	rows2, err := database.Query(context.Background(), "SELECT id FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer cleanupRows(rows2)
+
+	results, err = rowsToMapsMax(rows2, 3)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+}
+
+func TestQueryIncludeColumns(t *testing.T) {
+	useQueryTestManager(t, 2)
+
+	result, err := handleQuery(context.Background(), map[string]interface{}{
+		"database":        "local",
+		"query":           "SELECT name, id FROM users ORDER BY id",
+		"include_columns": true,
+	})
+	require.NoError(t, err)
+
+	response := result.(map[string]interface{})
+	assert.Equal(t, []string{"name", "id"}, response["columns"])
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "user1", "id": int64(1)},
+		{"name": "user2", "id": int64(2)},
+	}, response["results"])
+	assert.NotContains(t, response, "rows")
+}
@@ -2,9 +2,11 @@ package dbtools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/FreePeak/infra-mcp-server/pkg/logger"
@@ -23,9 +25,12 @@ type QueryMetrics struct {
 
 // PerformanceAnalyzer tracks query performance and provides optimization suggestions
 type PerformanceAnalyzer struct {
+	mu            sync.Mutex
 	slowThreshold time.Duration
 	queryHistory  []QueryRecord
 	maxHistory    int
+	shapes        map[string]*shapeStats
+	slowQueries   *slowQueryLog
 }
 
 // QueryRecord stores information about a query execution
@@ -35,6 +40,8 @@ type QueryRecord struct {
 	Duration   time.Duration `json:"duration"`
 	StartTime  time.Time     `json:"startTime"`
 	Error      string        `json:"error,omitempty"`
+	TimedOut   bool          `json:"timedOut,omitempty"`
+	Canceled   bool          `json:"canceled,omitempty"`
 	Optimized  bool          `json:"optimized"`
 	Suggestion string        `json:"suggestion,omitempty"`
 }
@@ -58,15 +65,17 @@ func GetPerformanceAnalyzer() *PerformanceAnalyzer {
 // NewPerformanceAnalyzer creates a new performance analyzer
 func NewPerformanceAnalyzer() *PerformanceAnalyzer {
 	return &PerformanceAnalyzer{
-		slowThreshold: 500 * time.Millisecond, // Default: 500ms
+		slowThreshold: slowQueryThreshold(), // Default: 1000ms, from DB_SLOW_QUERY_MS
 		queryHistory:  make([]QueryRecord, 0),
 		maxHistory:    100, // Default: store last 100 queries
+		shapes:        make(map[string]*shapeStats),
+		slowQueries:   newSlowQueryLog(slowQueryCapacity),
 	}
 }
 
 // LogSlowQuery logs a warning if a query takes longer than the slow query threshold
 func (pa *PerformanceAnalyzer) LogSlowQuery(query string, params []interface{}, duration time.Duration) {
-	if duration >= pa.slowThreshold {
+	if duration >= pa.GetSlowThreshold() {
 		paramStr := formatParams(params)
 		logger.Warn("Slow query detected (%.2fms): %s [params: %s]",
 			float64(duration.Microseconds())/1000.0,
@@ -77,6 +86,12 @@ func (pa *PerformanceAnalyzer) LogSlowQuery(query string, params []interface{},
 
 // TrackQuery tracks the execution of a query and logs slow queries
 func (pa *PerformanceAnalyzer) TrackQuery(ctx context.Context, query string, params []interface{}, exec func() (interface{}, error)) (interface{}, error) {
+	result, _, err := pa.TrackQueryTimed(ctx, query, params, exec)
+	return result, err
+}
+
+// TrackQueryTimed is like TrackQuery but also returns the measured execution time
+func (pa *PerformanceAnalyzer) TrackQueryTimed(ctx context.Context, query string, params []interface{}, exec func() (interface{}, error)) (interface{}, time.Duration, error) {
 	startTime := time.Now()
 	result, err := exec()
 	duration := time.Since(startTime)
@@ -90,7 +105,8 @@ func (pa *PerformanceAnalyzer) TrackQuery(ctx context.Context, query string, par
 	}
 
 	// Check if query is slow
-	if duration >= pa.slowThreshold {
+	slow := duration >= pa.GetSlowThreshold()
+	if slow {
 		pa.LogSlowQuery(query, params, duration)
 		record.Suggestion = "Query execution time exceeds threshold"
 	}
@@ -100,13 +116,35 @@ func (pa *PerformanceAnalyzer) TrackQuery(ctx context.Context, query string, par
 		record.Error = err.Error()
 	}
 
+	// Handlers may return partial results on timeout without an error, so check the context too
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		record.TimedOut = true
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		record.Canceled = true
+	}
+
+	if slow {
+		pa.slowQueries.add(SlowQuery{
+			Query:      queryShape(query),
+			DurationMs: durationMs(duration),
+			Timestamp:  startTime,
+			Error:      record.Error,
+			TimedOut:   record.TimedOut,
+		})
+	}
+
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
 	// Add to history (keeping max size)
 	pa.queryHistory = append(pa.queryHistory, record)
 	if len(pa.queryHistory) > pa.maxHistory {
 		pa.queryHistory = pa.queryHistory[1:]
 	}
+	pa.recordShape(record)
 
-	return result, err
+	return result, duration, err
 }
 
 // SQLIssueDetector methods
@@ -229,6 +267,9 @@ func StripComments(input string) string {
 
 // GetAllMetrics returns all collected metrics
 func (pa *PerformanceAnalyzer) GetAllMetrics() []*QueryMetrics {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
 	// Group query history by normalized query text
 	queryMap := make(map[string]*QueryMetrics)
 
@@ -275,16 +316,27 @@ func (pa *PerformanceAnalyzer) GetAllMetrics() []*QueryMetrics {
 
 // Reset clears all collected metrics
 func (pa *PerformanceAnalyzer) Reset() {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
 	pa.queryHistory = make([]QueryRecord, 0)
+	pa.shapes = make(map[string]*shapeStats)
+	pa.slowQueries.reset()
 }
 
 // GetSlowThreshold returns the current slow query threshold
 func (pa *PerformanceAnalyzer) GetSlowThreshold() time.Duration {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
 	return pa.slowThreshold
 }
 
 // SetSlowThreshold sets the slow query threshold
 func (pa *PerformanceAnalyzer) SetSlowThreshold(threshold time.Duration) {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
 	pa.slowThreshold = threshold
 }
 
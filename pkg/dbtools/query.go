@@ -3,6 +3,7 @@ package dbtools
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -37,6 +38,18 @@ func createQueryTool() *tools.Tool {
 					"type":        "integer",
 					"description": "Query timeout in milliseconds (default: 5000)",
 				},
+				"include_stats": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include rows examined vs returned where the engine exposes it (MySQL)",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum rows to return for this page (capped at DB_MAX_ROWS)",
+				},
+				"offset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Rows to skip before the page (default: 0)",
+				},
 				"database": map[string]interface{}{
 					"type":        "string",
 					"description": "Database ID to use (optional if only one database is configured)",
@@ -96,39 +109,274 @@ func handleQuery(ctx context.Context, params map[string]interface{}) (interface{
 		copy(queryParams, paramsArray)
 	}
 
+	// Named parameters are rewritten to positional placeholders, expanding arrays into lists
+	if named, ok := params["named_params"].(map[string]interface{}); ok && len(named) > 0 {
+		if len(queryParams) > 0 {
+			return nil, fmt.Errorf("use either params or named_params, not both")
+		}
+		query, queryParams, err = expandNamedParams(db.DriverName(), query, named)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	includeStats, _ := getBoolParam(params, "include_stats")
+
+	format, _ := getStringParam(params, "format")
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return nil, fmt.Errorf("invalid format %q: must be json or csv", format)
+	}
+	includeColumns, _ := getBoolParam(params, "include_columns")
+
+	// Results are capped at DB_MAX_ROWS; limit and offset page through larger ones
+	maxRows := maxResultRows()
+	rowCap := maxRows
+	limit, paginate := getIntParam(params, "limit")
+	offset, hasOffset := getIntParam(params, "offset")
+	if paginate && limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	if paginate || hasOffset {
+		if !paginate || limit > maxRows {
+			limit = maxRows
+		}
+		paginate = true
+
+		// Fetch one row past the page to learn whether another page follows
+		query, err = paginateQuery(db.DriverName(), query, limit+1, offset)
+		if err != nil {
+			return nil, err
+		}
+		rowCap = limit
+	}
+
+	// Row-scan counters are per session, so pin one connection for the counters and the query
+	run := queryFunc(db.Query)
+	var statsConn *sql.Conn
+	if includeStats && db.DriverName() == "mysql" {
+		statsConn, err = db.DB().Conn(timeoutCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire connection: %w", err)
+		}
+		defer func() {
+			if closeErr := statsConn.Close(); closeErr != nil {
+				logger.Error("error closing connection: %v", closeErr)
+			}
+		}()
+		run = statsConn.QueryContext
+	}
+
 	// Get the performance analyzer
 	analyzer := GetPerformanceAnalyzer()
 
 	// Execute query with performance tracking
 	var result interface{}
+	var duration time.Duration
+	var readsBefore int64
+	var readsErr error
+	var limitReached bool
 
-	result, err = analyzer.TrackQuery(timeoutCtx, query, queryParams, func() (interface{}, error) {
+	// checkRowLimit records a result cut short by the row cap, which is not an error
+	checkRowLimit := func(err error) error {
+		if errors.Is(err, errRowLimitReached) {
+			limitReached = true
+			return nil
+		}
+		return err
+	}
+
+	if statsConn != nil {
+		readsBefore, readsErr = mysqlHandlerReads(timeoutCtx, statsConn)
+	}
+
+	result, duration, err = analyzer.TrackQueryTimed(timeoutCtx, query, queryParams, func() (interface{}, error) {
 		// Execute query
-		rows, innerErr := db.Query(timeoutCtx, query, queryParams...)
+		rows, innerErr := run(timeoutCtx, query, queryParams...)
 		if innerErr != nil {
+			if queryTimedOut(ctx, timeoutCtx) {
+				return partialQueryResult(query, queryParams, nil, timeout), nil
+			}
 			return nil, fmt.Errorf("failed to execute query: %w", innerErr)
 		}
 		defer cleanupRows(rows)
 
-		// Convert rows to maps
-		results, innerErr := rowsToMaps(rows)
-		if innerErr != nil {
+		if format == "csv" {
+			csvText, rowCount, innerErr := rowsToCSVMax(rows, rowCap)
+			if innerErr = checkRowLimit(innerErr); innerErr != nil {
+				if queryTimedOut(ctx, timeoutCtx) {
+					return csvQueryResult(query, queryParams, csvText, rowCount, timeout), nil
+				}
+				return nil, fmt.Errorf("failed to process query results: %w", innerErr)
+			}
+			return csvQueryResult(query, queryParams, csvText, rowCount, 0), nil
+		}
+
+		if includeColumns {
+			ordered, innerErr := rowsToOrderedMax(rows, rowCap)
+			if innerErr = checkRowLimit(innerErr); innerErr != nil {
+				if queryTimedOut(ctx, timeoutCtx) {
+					return orderedQueryResult(query, queryParams, ordered, timeout), nil
+				}
+				return nil, fmt.Errorf("failed to process query results: %w", innerErr)
+			}
+			return orderedQueryResult(query, queryParams, ordered, 0), nil
+		}
+
+		// Convert every result set to maps; multi-statement queries can return more than one
+		resultSets, innerErr := rowsToResultSetsMax(rows, rowCap)
+		if innerErr = checkRowLimit(innerErr); innerErr != nil {
+			// Keep the rows fetched before the deadline instead of discarding them
+			if queryTimedOut(ctx, timeoutCtx) {
+				return partialQueryResult(query, queryParams, resultSets, timeout), nil
+			}
 			return nil, fmt.Errorf("failed to process query results: %w", innerErr)
 		}
 
-		return map[string]interface{}{
-			"results":  results,
-			"query":    query,
-			"params":   queryParams,
-			"rowCount": len(results),
-		}, nil
+		results := resultSets[0]
+		return withResultSets(map[string]interface{}{
+			"results":   results,
+			"query":     query,
+			"params":    queryParams,
+			"rowCount":  len(results),
+			"timed_out": false,
+		}, resultSets), nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return result, nil
+	}
+	resultMap["execution_ms"] = float64(duration.Microseconds()) / 1000.0
+
+	resultMap["truncated"] = false
+	if paginate {
+		resultMap["limit"] = limit
+		resultMap["offset"] = offset
+		resultMap["hasMore"] = limitReached
+		if limitReached {
+			resultMap["nextOffset"] = offset + limit
+		}
+	} else if limitReached {
+		resultMap["truncated"] = true
+		resultMap["warning"] = fmt.Sprintf("result truncated to the first %d row(s) (DB_MAX_ROWS); "+
+			"use limit and offset to page through the rest", maxRows)
+	}
+
+	if includeStats {
+		rowCount, _ := resultMap["rowCount"].(int)
+		var rowsExamined int64
+		if statsConn != nil && readsErr == nil {
+			var readsAfter int64
+			readsAfter, readsErr = mysqlHandlerReads(timeoutCtx, statsConn)
+			rowsExamined = readsAfter - readsBefore
+		}
+		resultMap["stats"] = rowStats(db.DriverName(), rowCount, rowsExamined, readsErr)
+	}
+
+	return resultMap, nil
+}
+
+// queryTimedOut reports whether the query context hit its own deadline,
+// as opposed to the caller's context being canceled
+func queryTimedOut(parentCtx, timeoutCtx context.Context) bool {
+	return parentCtx.Err() == nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded)
+}
+
+// partialQueryResult builds the response for a query that hit its timeout mid-fetch
+func partialQueryResult(query string, queryParams []interface{}, resultSets [][]map[string]interface{}, timeoutMs int) map[string]interface{} {
+	// Encode a timeout before the first row as [] rather than null
+	results := []map[string]interface{}{}
+	if len(resultSets) > 0 && resultSets[0] != nil {
+		results = resultSets[0]
+	}
+
+	fetched := 0
+	for _, set := range resultSets {
+		fetched += len(set)
+	}
+
+	return withResultSets(map[string]interface{}{
+		"results":   results,
+		"query":     query,
+		"params":    queryParams,
+		"rowCount":  len(results),
+		"timed_out": true,
+		"warning":   timeoutWarning(timeoutMs, fetched),
+	}, resultSets)
+}
+
+// timeoutWarning explains that a result was cut short by the query timeout after
+// fetched rows
+func timeoutWarning(timeoutMs int, fetched int) string {
+	return fmt.Sprintf("query timed out after %dms; returning %d row(s) fetched before the deadline. "+
+		"Add a LIMIT, narrow the WHERE clause or raise the timeout for complete results", timeoutMs, fetched)
+}
+
+// csvQueryResult builds the response for a query rendered as CSV. A non-zero timeoutMs
+// marks the result as cut short by the query timeout.
+func csvQueryResult(query string, queryParams []interface{}, csvText string, rowCount int, timeoutMs int) map[string]interface{} {
+	response := map[string]interface{}{
+		"format":    "csv",
+		"csv":       csvText,
+		"query":     query,
+		"params":    queryParams,
+		"rowCount":  rowCount,
+		"timed_out": timeoutMs > 0,
+	}
+	if timeoutMs > 0 {
+		response["warning"] = timeoutWarning(timeoutMs, rowCount)
+	}
+	return response
+}
+
+// orderedQueryResult builds the response for a query returned with its column names: the
+// usual "results" objects plus "columns" in query order, so clients can lay out the
+// objects' fields deterministically. A non-zero timeoutMs marks the result as cut short
+// by the query timeout.
+func orderedQueryResult(query string, queryParams []interface{}, ordered *OrderedRows, timeoutMs int) map[string]interface{} {
+	if ordered == nil {
+		ordered = &OrderedRows{Columns: []string{}, Rows: [][]interface{}{}}
+	}
+
+	results := make([]map[string]interface{}, 0, len(ordered.Rows))
+	for _, row := range ordered.Rows {
+		result := make(map[string]interface{}, len(ordered.Columns))
+		for i, column := range ordered.Columns {
+			result[column] = row[i]
+		}
+		results = append(results, result)
+	}
+
+	response := map[string]interface{}{
+		"columns":   ordered.Columns,
+		"results":   results,
+		"query":     query,
+		"params":    queryParams,
+		"rowCount":  len(ordered.Rows),
+		"timed_out": timeoutMs > 0,
+	}
+	if timeoutMs > 0 {
+		response["warning"] = timeoutWarning(timeoutMs, len(ordered.Rows))
+	}
+	return response
+}
+
+// withResultSets adds all result sets to a query response when there is more than one.
+// "results" always holds the first set, so single-statement callers see no change.
+func withResultSets(response map[string]interface{}, resultSets [][]map[string]interface{}) map[string]interface{} {
+	if len(resultSets) > 1 {
+		response["result_sets"] = resultSets
+		response["result_set_count"] = len(resultSets)
+	}
+	return response
 }
 
 // containsIgnoreCase checks if a string contains a substring, ignoring case
@@ -0,0 +1,207 @@
+package dbtools
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+const (
+	// maxShapeSamples is how many recent durations are kept per query shape for percentiles
+	maxShapeSamples = 1000
+	// maxQueryShapes bounds the number of shapes tracked; the least recently run is dropped
+	maxQueryShapes = 500
+)
+
+// valueListPattern matches a parenthesized list of normalized literals, e.g. (?, ?, ?)
+var valueListPattern = regexp.MustCompile(`\(\s*(?:'\?'|"\?"|\?)(?:\s*,\s*(?:'\?'|"\?"|\?))+\s*\)`)
+
+// shapeStats aggregates the executions of one query shape
+type shapeStats struct {
+	count    int
+	errors   int
+	timeouts int
+	canceled int
+	total    time.Duration
+	min      time.Duration
+	max      time.Duration
+	samples  []time.Duration
+	last     time.Time
+}
+
+// QueryShapeStats reports the executions of queries that differ only in their literals
+type QueryShapeStats struct {
+	Shape        string    `json:"shape"`
+	Count        int       `json:"count"`
+	Errors       int       `json:"errors"`
+	Timeouts     int       `json:"timeouts"`
+	Canceled     int       `json:"canceled"`
+	MinMs        float64   `json:"min_ms"`
+	MaxMs        float64   `json:"max_ms"`
+	AvgMs        float64   `json:"avg_ms"`
+	P95Ms        float64   `json:"p95_ms"`
+	LastExecuted time.Time `json:"last_executed"`
+}
+
+// queryShape reduces a query to the key its statistics are grouped under: comments are
+// dropped, literals replaced by ? and lists of literals collapsed, so IN lists of any
+// length share a shape
+func queryShape(query string) string {
+	return valueListPattern.ReplaceAllString(normalizeQuery(StripComments(query)), "(?)")
+}
+
+// recordShape adds an execution to its shape's statistics. The caller holds pa.mu.
+func (pa *PerformanceAnalyzer) recordShape(record QueryRecord) {
+	key := queryShape(record.Query)
+	stats, ok := pa.shapes[key]
+	if !ok {
+		if len(pa.shapes) >= maxQueryShapes {
+			pa.evictOldestShape()
+		}
+		stats = &shapeStats{min: record.Duration, max: record.Duration}
+		pa.shapes[key] = stats
+	}
+
+	stats.count++
+	stats.total += record.Duration
+	stats.min = min(stats.min, record.Duration)
+	stats.max = max(stats.max, record.Duration)
+	if record.StartTime.After(stats.last) {
+		stats.last = record.StartTime
+	}
+	if record.Error != "" {
+		stats.errors++
+	}
+	if record.TimedOut {
+		stats.timeouts++
+	}
+	if record.Canceled {
+		stats.canceled++
+	}
+
+	stats.samples = append(stats.samples, record.Duration)
+	if len(stats.samples) > maxShapeSamples {
+		stats.samples = stats.samples[1:]
+	}
+}
+
+// evictOldestShape drops the shape that ran least recently. The caller holds pa.mu.
+func (pa *PerformanceAnalyzer) evictOldestShape() {
+	var oldestKey string
+	var oldest time.Time
+	for key, stats := range pa.shapes {
+		if oldestKey == "" || stats.last.Before(oldest) {
+			oldestKey, oldest = key, stats.last
+		}
+	}
+	delete(pa.shapes, oldestKey)
+}
+
+// QueryStats returns the statistics of every recorded query shape, most executed first
+func (pa *PerformanceAnalyzer) QueryStats() []QueryShapeStats {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
+	result := make([]QueryShapeStats, 0, len(pa.shapes))
+	for shape, stats := range pa.shapes {
+		result = append(result, QueryShapeStats{
+			Shape:        shape,
+			Count:        stats.count,
+			Errors:       stats.errors,
+			Timeouts:     stats.timeouts,
+			Canceled:     stats.canceled,
+			MinMs:        durationMs(stats.min),
+			MaxMs:        durationMs(stats.max),
+			AvgMs:        durationMs(stats.total / time.Duration(stats.count)),
+			P95Ms:        durationMs(percentileDuration(stats.samples, 95)),
+			LastExecuted: stats.last,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Shape < result[j].Shape
+	})
+	return result
+}
+
+// percentileDuration returns the p-th percentile of durations using the nearest-rank
+// method, or 0 for no durations. durations is not modified.
+func percentileDuration(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+	return sorted[rank-1]
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000.0
+}
+
+// createQueryStatsTool creates a tool for reading the aggregated query statistics
+func createQueryStatsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbQueryStats",
+		Description: "Show latency statistics of the queries run through this server, grouped by query shape (literals stripped): count, min/max/avg/p95 latency, errors, timeouts and cancellations",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of query shapes to return, most executed first (default: all)",
+				},
+				"reset": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Clear the statistics after returning them",
+				},
+			},
+		},
+		Handler: handleQueryStats,
+	}
+}
+
+// handleQueryStats handles the query stats tool execution
+func handleQueryStats(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	analyzer := GetPerformanceAnalyzer()
+	stats := analyzer.QueryStats()
+
+	totalQueries, timeouts, canceled := 0, 0, 0
+	for _, shape := range stats {
+		totalQueries += shape.Count
+		timeouts += shape.Timeouts
+		canceled += shape.Canceled
+	}
+
+	shapeCount := len(stats)
+	if limit, ok := getIntParam(params, "limit"); ok && limit > 0 && limit < len(stats) {
+		stats = stats[:limit]
+	}
+
+	if reset, _ := getBoolParam(params, "reset"); reset {
+		analyzer.Reset()
+	}
+
+	return map[string]interface{}{
+		"queries":           stats,
+		"shape_count":       shapeCount,
+		"total_queries":     totalQueries,
+		"timeouts":          timeouts,
+		"canceled":          canceled,
+		"slow_threshold_ms": durationMs(analyzer.GetSlowThreshold()),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package dbtools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryShape(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (?) AND name = '?'",
+		queryShape("SELECT * FROM users WHERE id IN (1, 2, 3) AND name = 'ann'"))
+	assert.Equal(t, queryShape("SELECT * FROM users WHERE id IN (1, 2, 3)"),
+		queryShape("SELECT * FROM users\n  WHERE id IN (7) /* retry */"))
+}
+
+func TestPercentileDuration(t *testing.T) {
+	durations := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 95*time.Millisecond, percentileDuration(durations, 95))
+	assert.Equal(t, 50*time.Millisecond, percentileDuration(durations, 50))
+	assert.Equal(t, 100*time.Millisecond, percentileDuration(durations, 100))
+	// The input keeps its order
+	assert.Equal(t, 100*time.Millisecond, durations[0])
+
+	assert.Equal(t, 7*time.Millisecond, percentileDuration([]time.Duration{7 * time.Millisecond}, 95))
+	assert.Equal(t, time.Duration(0), percentileDuration(nil, 95))
+}
+
+func TestQueryStatsCountsTimeouts(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+	query := "SELECT * FROM orders WHERE user_id = 1"
+
+	_, err := analyzer.TrackQuery(context.Background(), query, nil, func() (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+
+	// A timed-out query that still returns its partial rows counts as a timeout
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = analyzer.TrackQuery(timeoutCtx, "SELECT * FROM orders WHERE user_id = 2", nil, func() (interface{}, error) {
+		<-timeoutCtx.Done()
+		return "partial", nil
+	})
+	assert.NoError(t, err)
+
+	canceledCtx, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, err = analyzer.TrackQuery(canceledCtx, "SELECT * FROM orders WHERE user_id = 3", nil, func() (interface{}, error) {
+		return nil, canceledCtx.Err()
+	})
+	assert.Error(t, err)
+
+	_, err = analyzer.TrackQuery(context.Background(), "SELECT broken", nil, func() (interface{}, error) {
+		return nil, errors.New("syntax error")
+	})
+	assert.Error(t, err)
+
+	stats := analyzer.QueryStats()
+	assert.Len(t, stats, 2)
+
+	orders := stats[0]
+	assert.Equal(t, "SELECT * FROM orders WHERE user_id = ?", orders.Shape)
+	assert.Equal(t, 3, orders.Count)
+	assert.Equal(t, 1, orders.Timeouts)
+	assert.Equal(t, 1, orders.Canceled)
+	assert.Equal(t, 1, orders.Errors)
+	assert.GreaterOrEqual(t, orders.MaxMs, 1.0)
+	assert.LessOrEqual(t, orders.MinMs, orders.AvgMs)
+	assert.LessOrEqual(t, orders.P95Ms, orders.MaxMs)
+
+	broken := stats[1]
+	assert.Equal(t, 1, broken.Count)
+	assert.Equal(t, 1, broken.Errors)
+	assert.Equal(t, 0, broken.Timeouts)
+
+	analyzer.Reset()
+	assert.Empty(t, analyzer.QueryStats())
+}
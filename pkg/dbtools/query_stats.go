@@ -0,0 +1,53 @@
+package dbtools
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// queryFunc runs a query and returns its rows; satisfied by db.Database.Query and sql.Conn.QueryContext
+type queryFunc func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+
+// mysqlHandlerReads sums the session Handler_read_* counters, which MySQL
+// increments for every row the storage engine reads on this connection
+func mysqlHandlerReads(ctx context.Context, conn *sql.Conn) (int64, error) {
+	rows, err := conn.QueryContext(ctx, "SHOW SESSION STATUS LIKE 'Handler_read%'")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read session status: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	var total int64
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return 0, fmt.Errorf("failed to scan session status: %w", err)
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			total += n
+		}
+	}
+	return total, rows.Err()
+}
+
+// rowStats describes rows examined vs returned for a query
+func rowStats(driver string, rowsReturned int, rowsExamined int64, examinedErr error) map[string]interface{} {
+	stats := map[string]interface{}{
+		"rows_returned": rowsReturned,
+	}
+
+	switch {
+	case driver != "mysql":
+		stats["rows_examined"] = nil
+		stats["note"] = fmt.Sprintf("rows examined is not exposed by %s for a plain query; use EXPLAIN ANALYZE to see it", driver)
+	case examinedErr != nil:
+		stats["rows_examined"] = nil
+		stats["note"] = fmt.Sprintf("rows examined unavailable: %v", examinedErr)
+	default:
+		stats["rows_examined"] = rowsExamined
+		stats["note"] = "rows examined is derived from the session Handler_read_* counters and is approximate"
+	}
+	return stats
+}
@@ -0,0 +1,203 @@
+package dbtools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// multiResultDriver is a minimal driver whose queries return two result sets,
+// like "SELECT 1 AS a; SELECT 'x' AS b, 'y' AS c" against MySQL with multiStatements
+type multiResultDriver struct{}
+
+func (multiResultDriver) Open(string) (driver.Conn, error) { return multiResultConn{}, nil }
+
+type multiResultConn struct{}
+
+func (multiResultConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (multiResultConn) Close() error              { return nil }
+func (multiResultConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+func (multiResultConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &multiResultRows{
+		sets: []fakeResultSet{
+			{columns: []string{"a"}, rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+			{columns: []string{"b", "c"}, rows: [][]driver.Value{{[]byte("x"), nil}}},
+		},
+	}, nil
+}
+
+type fakeResultSet struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+type multiResultRows struct {
+	sets []fakeResultSet
+	set  int
+	row  int
+}
+
+func (r *multiResultRows) Columns() []string { return r.sets[r.set].columns }
+func (r *multiResultRows) Close() error      { return nil }
+
+func (r *multiResultRows) Next(dest []driver.Value) error {
+	current := r.sets[r.set]
+	if r.row >= len(current.rows) {
+		return io.EOF
+	}
+	copy(dest, current.rows[r.row])
+	r.row++
+	return nil
+}
+
+func (r *multiResultRows) HasNextResultSet() bool { return r.set+1 < len(r.sets) }
+
+func (r *multiResultRows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = 0
+	return nil
+}
+
+func init() {
+	sql.Register("dbtools-multiresult", multiResultDriver{})
+}
+
+func TestRowsToResultSetsReturnsEverySet(t *testing.T) {
+	conn, err := sql.Open("dbtools-multiresult", "")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "SELECT 1 AS a; SELECT 'x' AS b, NULL AS c")
+	assert.NoError(t, err)
+	defer cleanupRows(rows)
+
+	resultSets, err := rowsToResultSets(rows)
+	assert.NoError(t, err)
+	assert.Len(t, resultSets, 2)
+
+	assert.Equal(t, []map[string]interface{}{{"a": int64(1)}, {"a": int64(2)}}, resultSets[0])
+	assert.Equal(t, []map[string]interface{}{{"b": "x", "c": nil}}, resultSets[1])
+}
+
+func TestWithResultSets(t *testing.T) {
+	single := [][]map[string]interface{}{{{"a": 1}}}
+	response := withResultSets(map[string]interface{}{"results": single[0]}, single)
+	assert.NotContains(t, response, "result_sets")
+
+	multiple := [][]map[string]interface{}{{{"a": 1}}, {{"b": 2}, {"b": 3}}}
+	response = withResultSets(map[string]interface{}{"results": multiple[0]}, multiple)
+	assert.Equal(t, multiple, response["result_sets"])
+	assert.Equal(t, 2, response["result_set_count"])
+}
+
+// csvResultDriver returns a single result set whose columns are deliberately not in
+// alphabetical order, with a NULL and a value containing a comma, quote and newline
+type csvResultDriver struct{}
+
+func (csvResultDriver) Open(string) (driver.Conn, error) { return csvResultConn{}, nil }
+
+type csvResultConn struct{ multiResultConn }
+
+func (csvResultConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &multiResultRows{
+		sets: []fakeResultSet{
+			{columns: []string{"name", "city", "age"}, rows: [][]driver.Value{
+				{[]byte("Ada"), []byte("London, UK"), int64(36)},
+				{[]byte("Bob"), nil, int64(41)},
+				{[]byte(`Cy "C"`), []byte("Line1\nLine2"), nil},
+			}},
+		},
+	}, nil
+}
+
+func init() {
+	sql.Register("dbtools-csvresult", csvResultDriver{})
+}
+
+func TestRowsToCSV(t *testing.T) {
+	conn, err := sql.Open("dbtools-csvresult", "")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "SELECT name, city, age FROM people")
+	assert.NoError(t, err)
+	defer cleanupRows(rows)
+
+	csvText, rowCount, err := rowsToCSV(rows)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rowCount)
+	assert.Equal(t, "name,city,age\n"+
+		"Ada,\"London, UK\",36\n"+
+		"Bob,,41\n"+
+		"\"Cy \"\"C\"\"\",\"Line1\nLine2\",\n", csvText)
+}
+
+func TestRowsToOrderedKeepsColumnOrder(t *testing.T) {
+	conn, err := sql.Open("dbtools-csvresult", "")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "SELECT name, city, age FROM people")
+	assert.NoError(t, err)
+	defer cleanupRows(rows)
+
+	columns, err := rows.Columns()
+	assert.NoError(t, err)
+
+	ordered, err := rowsToOrdered(rows)
+	assert.NoError(t, err)
+	assert.Equal(t, columns, ordered.Columns)
+	assert.Equal(t, []string{"name", "city", "age"}, ordered.Columns)
+	assert.Equal(t, [][]interface{}{
+		{"Ada", "London, UK", int64(36)},
+		{"Bob", nil, int64(41)},
+		{`Cy "C"`, "Line1\nLine2", nil},
+	}, ordered.Rows)
+}
+
+func TestOrderedQueryResult(t *testing.T) {
+	ordered := &OrderedRows{Columns: []string{"b", "a"}, Rows: [][]interface{}{{1, 2}}}
+
+	response := orderedQueryResult("SELECT b, a FROM t", nil, ordered, 0)
+	assert.Equal(t, []string{"b", "a"}, response["columns"])
+	assert.Equal(t, []map[string]interface{}{{"b": 1, "a": 2}}, response["results"])
+	assert.Equal(t, 1, response["rowCount"])
+	assert.NotContains(t, response, "rows")
+	assert.NotContains(t, response, "warning")
+
+	response = orderedQueryResult("SELECT b, a FROM t", nil, nil, 500)
+	assert.Equal(t, []string{}, response["columns"])
+	assert.Equal(t, []map[string]interface{}{}, response["results"])
+	assert.Equal(t, true, response["timed_out"])
+}
+
+func TestPartialQueryResult(t *testing.T) {
+	// A timeout before the first row still returns an empty list, not null
+	response := partialQueryResult("SELECT * FROM t", nil, nil, 500)
+	assert.Equal(t, []map[string]interface{}{}, response["results"])
+	assert.Equal(t, 0, response["rowCount"])
+	assert.Equal(t, true, response["timed_out"])
+	assert.Contains(t, response["warning"], "query timed out after 500ms; returning 0 row(s)")
+
+	encoded, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"results":[]`)
+
+	sets := [][]map[string]interface{}{{{"id": 1}, {"id": 2}}, {{"id": 3}}}
+	response = partialQueryResult("SELECT * FROM t", nil, sets, 500)
+	assert.Equal(t, sets[0], response["results"])
+	assert.Equal(t, 2, response["rowCount"])
+	assert.Contains(t, response["warning"], "returning 3 row(s)")
+}
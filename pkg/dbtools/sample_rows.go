@@ -0,0 +1,122 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+const (
+	// defaultSampleRows is the number of rows dbSampleRows returns without a limit
+	defaultSampleRows = 10
+	// maxSampleRows caps the limit of dbSampleRows; it is for a first look, not for reading tables
+	maxSampleRows = 100
+)
+
+// createSampleRowsTool creates a tool for reading a few rows of a table
+func createSampleRowsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbSampleRows",
+		Description: "Return a few rows of a table to see what its data looks like, without writing a query",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to use",
+				},
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Table to sample, optionally schema-qualified (e.g. 'users' or 'sales.orders')",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Number of rows to return (default: %d, max: %d)", defaultSampleRows, maxSampleRows),
+				},
+			},
+			Required: []string{"database", "table"},
+		},
+		Handler: handleSampleRows,
+	}
+}
+
+// handleSampleRows handles the sample rows tool execution
+func handleSampleRows(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	table, _ := getStringParam(params, "table")
+	table = strings.TrimSpace(table)
+	if table == "" {
+		return nil, fmt.Errorf("table parameter is required")
+	}
+
+	limit, err := sampleRowsLimit(params)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	// The table name is quoted, so it cannot add statements; the read-only guard still
+	// applies to the query as built
+	sample := NewDatabaseStrategy(db.DriverName()).GetSampleRowsQuery(table, limit)
+	if err := validateReadOnlyQuery(sample.query); err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(db.QueryTimeout())*time.Second)
+	defer cancel()
+
+	rows, err := db.Query(timeoutCtx, sample.query, sample.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample table %s: %w", table, err)
+	}
+	defer cleanupRows(rows)
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sample rows: %w", err)
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"database": databaseID,
+		"table":    table,
+		"rows":     results,
+		"count":    len(results),
+		"limit":    limit,
+	}, nil
+}
+
+// sampleRowsLimit reads the limit parameter, defaulting to defaultSampleRows and capped
+// at maxSampleRows
+func sampleRowsLimit(params map[string]interface{}) (int, error) {
+	limit, ok := getIntParam(params, "limit")
+	if !ok {
+		return defaultSampleRows, nil
+	}
+	if limit < 1 {
+		return 0, fmt.Errorf("limit must be at least 1")
+	}
+	if limit > maxSampleRows {
+		return maxSampleRows, nil
+	}
+	return limit, nil
+}
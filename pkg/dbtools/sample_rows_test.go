@@ -0,0 +1,58 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSampleRowsQueryQuotesTable(t *testing.T) {
+	tests := []struct {
+		driver string
+		table  string
+		want   string
+	}{
+		{"postgres", "users", `SELECT * FROM "users" LIMIT 10`},
+		{"postgres", "sales.orders", `SELECT * FROM "sales"."orders" LIMIT 10`},
+		{"postgres", `users"; DROP TABLE users; --`, `SELECT * FROM "users""; DROP TABLE users; --" LIMIT 10`},
+		{"mysql", "users", "SELECT * FROM `users` LIMIT 10"},
+		{"mysql", "users`; DROP TABLE users; --", "SELECT * FROM `users``; DROP TABLE users; --` LIMIT 10"},
+		{"sqlite", "order items", `SELECT * FROM "order items" LIMIT 10`},
+		{"sqlserver", "sales.orders", `SELECT TOP (10) * FROM "sales"."orders"`},
+		{"unknown", "users", `SELECT * FROM "users" LIMIT 10`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver+"/"+tt.table, func(t *testing.T) {
+			sample := NewDatabaseStrategy(tt.driver).GetSampleRowsQuery(tt.table, 10)
+			assert.Equal(t, tt.want, sample.query)
+			assert.Empty(t, sample.args)
+		})
+	}
+}
+
+func TestSampleRowsQueryRejectsInjectedWrites(t *testing.T) {
+	// A quoted name holding a statement separator is still rejected by the read-only guard
+	sample := NewDatabaseStrategy("postgres").GetSampleRowsQuery(`users"; DROP TABLE users; --`, 10)
+	assert.Error(t, validateReadOnlyQuery(sample.query))
+
+	sample = NewDatabaseStrategy("postgres").GetSampleRowsQuery("users", 10)
+	assert.NoError(t, validateReadOnlyQuery(sample.query))
+}
+
+func TestSampleRowsLimit(t *testing.T) {
+	limit, err := sampleRowsLimit(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSampleRows, limit)
+
+	limit, err = sampleRowsLimit(map[string]interface{}{"limit": float64(25)})
+	assert.NoError(t, err)
+	assert.Equal(t, 25, limit)
+
+	limit, err = sampleRowsLimit(map[string]interface{}{"limit": float64(5000)})
+	assert.NoError(t, err)
+	assert.Equal(t, maxSampleRows, limit)
+
+	_, err = sampleRowsLimit(map[string]interface{}{"limit": float64(0)})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/FreePeak/infra-mcp-server/pkg/db"
@@ -21,6 +22,16 @@ type DatabaseStrategy interface {
 	GetEnumValuesQueries() []queryWithArgs
 	GetUniqueConstraintsQueries(table string) []queryWithArgs
 	GetTableStatsQueries(table string) []queryWithArgs
+	GetExplainQuery(query string) queryWithArgs
+	GetExplainAnalyzeQuery(query string) queryWithArgs
+	GetDatabasesQueries() []queryWithArgs
+	GetSettingsQueries(filter string) []queryWithArgs
+	GetSampleRowsQuery(table string, limit int) queryWithArgs
+	GetColumnStatsQueries(table, column string) columnStatsQueries
+	GetViewsQueries() []queryWithArgs
+	GetRoutinesQueries() []queryWithArgs
+	GetTriggersQueries(table string) []queryWithArgs
+	GetSequencesQueries() []queryWithArgs
 }
 
 // NewDatabaseStrategy creates the appropriate strategy for the given database type
@@ -30,6 +41,10 @@ func NewDatabaseStrategy(driverName string) DatabaseStrategy {
 		return &PostgresStrategy{}
 	case "mysql":
 		return &MySQLStrategy{}
+	case "sqlite":
+		return &SQLiteStrategy{}
+	case "sqlserver":
+		return &SQLServerStrategy{}
 	default:
 		logger.Warn("Unknown database driver: %s, will use generic strategy", driverName)
 		return &GenericStrategy{}
@@ -347,6 +362,256 @@ func (s *PostgresStrategy) GetTableStatsQueries(table string) []queryWithArgs {
 	}
 }
 
+// GetExplainQuery returns the query wrapped in a JSON-format EXPLAIN for PostgreSQL
+func (s *PostgresStrategy) GetExplainQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN (FORMAT JSON) " + trimStatement(query)}
+}
+
+// GetExplainAnalyzeQuery returns the query wrapped in a JSON-format EXPLAIN ANALYZE for
+// PostgreSQL, which runs the query and reports actual rows and timing
+func (s *PostgresStrategy) GetExplainAnalyzeQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN (ANALYZE, FORMAT JSON) " + trimStatement(query)}
+}
+
+// GetDatabasesQueries returns queries for listing the databases on a PostgreSQL server
+// that the connected role is allowed to connect to
+func (s *PostgresStrategy) GetDatabasesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					datname AS name,
+					pg_catalog.pg_get_userbyid(datdba) AS owner,
+					pg_catalog.pg_encoding_to_char(encoding) AS encoding,
+					datistemplate AS is_template,
+					datname = current_database() AS is_current
+				FROM pg_catalog.pg_database
+				WHERE has_database_privilege(datname, 'CONNECT')
+				ORDER BY datname
+			`,
+		},
+		{
+			query: `
+				SELECT datname AS name
+				FROM pg_database
+				WHERE has_database_privilege(datname, 'CONNECT') AND NOT datistemplate
+				ORDER BY datname
+			`,
+		},
+	}
+}
+
+// GetSettingsQueries returns queries for reading the effective PostgreSQL runtime settings
+// whose name contains filter
+func (s *PostgresStrategy) GetSettingsQueries(filter string) []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					name,
+					setting,
+					unit,
+					category,
+					short_desc AS description,
+					source,
+					context
+				FROM pg_catalog.pg_settings
+				WHERE name ILIKE $1
+				ORDER BY name
+			`,
+			args: []interface{}{"%" + filter + "%"},
+		},
+		{query: "SHOW ALL"},
+	}
+}
+
+// GetSampleRowsQuery returns a query for the first limit rows of a PostgreSQL table
+func (s *PostgresStrategy) GetSampleRowsQuery(table string, limit int) queryWithArgs {
+	return queryWithArgs{query: fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteIdentifier("postgres", table), limit)}
+}
+
+// GetColumnStatsQueries returns the queries profiling a column of a PostgreSQL table
+func (s *PostgresStrategy) GetColumnStatsQueries(table, column string) columnStatsQueries {
+	return newColumnStatsQueries("postgres", table, column)
+}
+
+// GetViewsQueries returns queries for retrieving views and materialized views in PostgreSQL
+func (s *PostgresStrategy) GetViewsQueries() []queryWithArgs {
+	return []queryWithArgs{
+		// Primary: pg_views and pg_matviews, which tells the two kinds apart
+		{
+			query: `
+				SELECT viewname AS view_name, schemaname AS view_schema, 'view' AS view_type, definition
+				FROM pg_catalog.pg_views
+				WHERE schemaname NOT IN ('pg_catalog', 'information_schema')
+				UNION ALL
+				SELECT matviewname AS view_name, schemaname AS view_schema, 'materialized_view' AS view_type, definition
+				FROM pg_catalog.pg_matviews
+				WHERE schemaname NOT IN ('pg_catalog', 'information_schema')
+				ORDER BY view_schema, view_name
+			`,
+		},
+		// Secondary: information_schema, which does not list materialized views
+		{
+			query: `
+				SELECT table_name AS view_name, table_schema AS view_schema, 'view' AS view_type, view_definition AS definition
+				FROM information_schema.views
+				WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+				ORDER BY table_schema, table_name
+			`,
+		},
+	}
+}
+
+// GetRoutinesQueries returns queries for retrieving functions and procedures in PostgreSQL
+func (s *PostgresStrategy) GetRoutinesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		// Primary: information_schema.routines with the argument list from information_schema.parameters
+		{
+			query: `
+				SELECT
+					r.routine_name,
+					r.routine_schema,
+					LOWER(r.routine_type) AS routine_type,
+					r.data_type AS return_type,
+					COALESCE((
+						SELECT string_agg(
+							CASE WHEN p.parameter_mode <> 'IN' THEN p.parameter_mode || ' ' ELSE '' END ||
+							COALESCE(p.parameter_name || ' ', '') || p.data_type,
+							', ' ORDER BY p.ordinal_position)
+						FROM information_schema.parameters p
+						WHERE p.specific_schema = r.specific_schema AND p.specific_name = r.specific_name
+					), '') AS arguments,
+					LOWER(r.external_language) AS language
+				FROM information_schema.routines r
+				WHERE r.routine_schema NOT IN ('pg_catalog', 'information_schema')
+				ORDER BY r.routine_schema, r.routine_name
+			`,
+		},
+		// Secondary: pg_proc, which also lists routines the user holds no privilege on
+		{
+			query: `
+				SELECT
+					p.proname AS routine_name,
+					n.nspname AS routine_schema,
+					CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END AS routine_type,
+					pg_get_function_result(p.oid) AS return_type,
+					pg_get_function_arguments(p.oid) AS arguments,
+					l.lanname AS language
+				FROM pg_catalog.pg_proc p
+				JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+				JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+				WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+				AND p.prokind IN ('f', 'p')
+				ORDER BY n.nspname, p.proname
+			`,
+		},
+	}
+}
+
+// GetTriggersQueries returns queries for retrieving the triggers of a table, or of all
+// tables when table is empty, in PostgreSQL
+func (s *PostgresStrategy) GetTriggersQueries(table string) []queryWithArgs {
+	// Primary: information_schema, one row per trigger event
+	primary := queryWithArgs{
+		query: `
+			SELECT
+				event_object_table AS table_name,
+				trigger_name,
+				event_manipulation AS event,
+				action_timing AS timing
+			FROM information_schema.triggers
+			WHERE event_object_schema = 'public'`,
+		args: []interface{}{},
+	}
+
+	// Secondary: pg_trigger, which also lists triggers on tables the user cannot modify.
+	// The events and timing are bits of tgtype.
+	secondary := queryWithArgs{
+		query: `
+			SELECT
+				c.relname AS table_name,
+				t.tgname AS trigger_name,
+				concat_ws(' OR ',
+					CASE WHEN t.tgtype::int & 4 = 4 THEN 'INSERT' END,
+					CASE WHEN t.tgtype::int & 16 = 16 THEN 'UPDATE' END,
+					CASE WHEN t.tgtype::int & 8 = 8 THEN 'DELETE' END,
+					CASE WHEN t.tgtype::int & 32 = 32 THEN 'TRUNCATE' END) AS event,
+				CASE
+					WHEN t.tgtype::int & 2 = 2 THEN 'BEFORE'
+					WHEN t.tgtype::int & 64 = 64 THEN 'INSTEAD OF'
+					ELSE 'AFTER'
+				END AS timing
+			FROM pg_catalog.pg_trigger t
+			JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = 'public' AND NOT t.tgisinternal`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		primary.query += " AND event_object_table = $1"
+		primary.args = append(primary.args, table)
+		secondary.query += " AND c.relname = $1"
+		secondary.args = append(secondary.args, table)
+	}
+	primary.query += " ORDER BY event_object_table, trigger_name, event_manipulation"
+	secondary.query += " ORDER BY c.relname, t.tgname"
+
+	return []queryWithArgs{primary, secondary}
+}
+
+// GetSequencesQueries returns queries for retrieving sequences in PostgreSQL, with the
+// table and column owning each serial or identity sequence
+func (s *PostgresStrategy) GetSequencesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		// Primary: pg_sequences (PostgreSQL 10+), with the owner from pg_depend. Serial
+		// columns own their sequence with an auto dependency, identity columns with an
+		// internal one.
+		{
+			query: `
+				SELECT
+					s.sequencename AS sequence_name,
+					s.schemaname AS sequence_schema,
+					s.last_value,
+					s.increment_by,
+					s.min_value,
+					s.max_value,
+					s.cycle,
+					owner_table.relname AS owned_by_table,
+					owner_column.attname AS owned_by_column
+				FROM pg_catalog.pg_sequences s
+				LEFT JOIN pg_catalog.pg_depend d
+					ON d.objid = format('%I.%I', s.schemaname, s.sequencename)::regclass
+					AND d.classid = 'pg_catalog.pg_class'::regclass
+					AND d.refclassid = 'pg_catalog.pg_class'::regclass
+					AND d.deptype IN ('a', 'i')
+				LEFT JOIN pg_catalog.pg_class owner_table ON owner_table.oid = d.refobjid
+				LEFT JOIN pg_catalog.pg_attribute owner_column
+					ON owner_column.attrelid = d.refobjid AND owner_column.attnum = d.refobjsubid
+				WHERE s.schemaname NOT IN ('pg_catalog', 'information_schema')
+				ORDER BY s.schemaname, s.sequencename
+			`,
+		},
+		// Secondary: information_schema, which has neither the last value nor the owner
+		{
+			query: `
+				SELECT
+					sequence_name,
+					sequence_schema,
+					NULL AS last_value,
+					increment::bigint AS increment_by,
+					minimum_value::bigint AS min_value,
+					maximum_value::bigint AS max_value,
+					cycle_option = 'YES' AS cycle
+				FROM information_schema.sequences
+				WHERE sequence_schema NOT IN ('pg_catalog', 'information_schema')
+				ORDER BY sequence_schema, sequence_name
+			`,
+		},
+	}
+}
+
 // MySQLStrategy implements DatabaseStrategy for MySQL
 type MySQLStrategy struct{}
 
@@ -366,7 +631,7 @@ func (s *MySQLStrategy) GetColumnsQueries(table string) []queryWithArgs {
 		// MySQL query for columns
 		{
 			query: `
-				SELECT column_name, data_type, is_nullable, column_default
+				SELECT column_name, data_type, column_type, is_nullable, column_default
 				FROM information_schema.columns
 				WHERE table_name = ? AND table_schema = DATABASE()
 				ORDER BY ordinal_position
@@ -575,6 +840,837 @@ func (s *MySQLStrategy) GetTableStatsQueries(table string) []queryWithArgs {
 	}
 }
 
+// GetExplainQuery returns the query wrapped in EXPLAIN for MySQL
+func (s *MySQLStrategy) GetExplainQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN " + trimStatement(query)}
+}
+
+// GetExplainAnalyzeQuery returns the query wrapped in EXPLAIN ANALYZE for MySQL 8.0.18+,
+// which runs the query and reports actual rows and timing as a tree
+func (s *MySQLStrategy) GetExplainAnalyzeQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN ANALYZE " + trimStatement(query)}
+}
+
+// GetDatabasesQueries returns queries for listing databases in MySQL. SHOW DATABASES
+// only lists databases the user holds some privilege on.
+func (s *MySQLStrategy) GetDatabasesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SHOW DATABASES"},
+		{query: "SELECT SCHEMA_NAME AS name FROM information_schema.SCHEMATA ORDER BY SCHEMA_NAME"},
+	}
+}
+
+// GetSettingsQueries returns queries for reading the MySQL system variables of the
+// current session whose name contains filter
+func (s *MySQLStrategy) GetSettingsQueries(filter string) []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT VARIABLE_NAME AS name, VARIABLE_VALUE AS setting
+				FROM performance_schema.session_variables
+				WHERE VARIABLE_NAME LIKE ?
+				ORDER BY VARIABLE_NAME
+			`,
+			args: []interface{}{"%" + filter + "%"},
+		},
+		{query: "SHOW VARIABLES"},
+	}
+}
+
+// GetSampleRowsQuery returns a query for the first limit rows of a MySQL table
+func (s *MySQLStrategy) GetSampleRowsQuery(table string, limit int) queryWithArgs {
+	return queryWithArgs{query: fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteIdentifier("mysql", table), limit)}
+}
+
+// GetColumnStatsQueries returns the queries profiling a column of a MySQL table
+func (s *MySQLStrategy) GetColumnStatsQueries(table, column string) columnStatsQueries {
+	return newColumnStatsQueries("mysql", table, column)
+}
+
+// GetViewsQueries returns queries for retrieving views in MySQL, which has no
+// materialized views
+func (s *MySQLStrategy) GetViewsQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT table_name AS view_name, 'view' AS view_type, view_definition AS definition, is_updatable
+				FROM information_schema.views
+				WHERE table_schema = DATABASE()
+				ORDER BY table_name
+			`,
+		},
+	}
+}
+
+// GetRoutinesQueries returns queries for retrieving stored functions and procedures in MySQL
+func (s *MySQLStrategy) GetRoutinesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					r.routine_name AS routine_name,
+					LOWER(r.routine_type) AS routine_type,
+					r.dtd_identifier AS return_type,
+					COALESCE((
+						SELECT GROUP_CONCAT(
+							CONCAT_WS(' ', IF(p.parameter_mode = 'IN', NULL, p.parameter_mode), p.parameter_name, p.dtd_identifier)
+							ORDER BY p.ordinal_position SEPARATOR ', ')
+						FROM information_schema.parameters p
+						WHERE p.specific_schema = r.routine_schema AND p.specific_name = r.specific_name
+						AND p.ordinal_position > 0
+					), '') AS arguments
+				FROM information_schema.routines r
+				WHERE r.routine_schema = DATABASE()
+				ORDER BY r.routine_name
+			`,
+		},
+	}
+}
+
+// GetTriggersQueries returns queries for retrieving the triggers of a table, or of all
+// tables when table is empty, in MySQL
+func (s *MySQLStrategy) GetTriggersQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				event_object_table AS table_name,
+				trigger_name AS trigger_name,
+				event_manipulation AS event,
+				action_timing AS timing
+			FROM information_schema.triggers
+			WHERE trigger_schema = DATABASE()`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += " AND event_object_table = ?"
+		query.args = append(query.args, table)
+	}
+	query.query += " ORDER BY event_object_table, trigger_name"
+
+	return []queryWithArgs{query}
+}
+
+// GetSequencesQueries returns no queries: MySQL has no sequences, AUTO_INCREMENT is a
+// column attribute
+func (s *MySQLStrategy) GetSequencesQueries() []queryWithArgs {
+	return nil
+}
+
+// SQLiteStrategy implements DatabaseStrategy for SQLite. Schema details come from
+// sqlite_master and the table-valued PRAGMA functions (SQLite 3.16+).
+type SQLiteStrategy struct{}
+
+// sqliteUserTables restricts sqlite_master rows to user tables, skipping SQLite's own
+const sqliteUserTables = "m.type = 'table' AND m.name NOT LIKE 'sqlite_%'"
+
+// GetTablesQueries returns queries for retrieving tables in SQLite
+func (s *SQLiteStrategy) GetTablesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT m.name AS table_name FROM sqlite_master m WHERE " + sqliteUserTables + " ORDER BY m.name"},
+	}
+}
+
+// GetColumnsQueries returns queries for retrieving columns in SQLite
+func (s *SQLiteStrategy) GetColumnsQueries(table string) []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					name AS column_name,
+					type AS data_type,
+					CASE WHEN "notnull" = 1 THEN 'NO' ELSE 'YES' END AS is_nullable,
+					dflt_value AS column_default
+				FROM pragma_table_info(?)
+				ORDER BY cid
+			`,
+			args: []interface{}{table},
+		},
+		// Fallback for SQLite versions without table-valued PRAGMA functions
+		{query: "PRAGMA table_info(" + quoteIdentifier("sqlite", table) + ")"},
+	}
+}
+
+// GetRelationshipsQueries returns queries for retrieving relationships in SQLite.
+// Foreign keys are unnamed in PRAGMA foreign_key_list, so constraint names are derived
+// from the table and the foreign key id.
+func (s *SQLiteStrategy) GetRelationshipsQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				'main' AS table_schema,
+				m.name || '_fk_' || fk.id AS constraint_name,
+				m.name AS table_name,
+				fk."from" AS column_name,
+				'main' AS foreign_table_schema,
+				fk."table" AS foreign_table_name,
+				fk."to" AS foreign_column_name
+			FROM sqlite_master m
+			JOIN pragma_foreign_key_list(m.name) fk
+			WHERE ` + sqliteUserTables,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += ` AND (m.name = ? OR fk."table" = ?)`
+		query.args = append(query.args, table, table)
+	}
+	query.query += " ORDER BY m.name, fk.id, fk.seq"
+
+	return []queryWithArgs{query}
+}
+
+// GetPrimaryKeysQueries returns queries for retrieving primary keys in SQLite
+func (s *SQLiteStrategy) GetPrimaryKeysQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				m.name AS table_name,
+				p.name AS column_name,
+				'PRIMARY' AS constraint_name
+			FROM sqlite_master m
+			JOIN pragma_table_info(m.name) p
+			WHERE ` + sqliteUserTables + ` AND p.pk > 0`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += " AND m.name = ?"
+		query.args = append(query.args, table)
+	}
+	query.query += " ORDER BY m.name, p.pk"
+
+	return []queryWithArgs{query}
+}
+
+// GetIndexesQueries returns queries for retrieving indexes in SQLite
+func (s *SQLiteStrategy) GetIndexesQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				m.name AS table_name,
+				il.name AS index_name,
+				(SELECT group_concat(ii.name, ',') FROM pragma_index_info(il.name) ii) AS column_names,
+				CASE WHEN il."unique" = 1 THEN 0 ELSE 1 END AS non_unique
+			FROM sqlite_master m
+			JOIN pragma_index_list(m.name) il
+			WHERE ` + sqliteUserTables,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += " AND m.name = ?"
+		query.args = append(query.args, table)
+	}
+	query.query += " ORDER BY m.name, il.name"
+
+	return []queryWithArgs{query}
+}
+
+// GetEnumValuesQueries returns queries for retrieving ENUM type values in SQLite, which
+// has no enum types; the query returns no rows
+func (s *SQLiteStrategy) GetEnumValuesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT NULL AS enum_name, NULL AS enum_value WHERE 0"},
+	}
+}
+
+// GetUniqueConstraintsQueries returns queries for retrieving unique constraints in SQLite.
+// An INTEGER PRIMARY KEY aliases the rowid and has no index, so it is not listed.
+func (s *SQLiteStrategy) GetUniqueConstraintsQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				m.name AS table_name,
+				il.name AS constraint_name,
+				CASE WHEN il.origin = 'pk' THEN 'PRIMARY KEY' ELSE 'UNIQUE' END AS constraint_type,
+				(SELECT group_concat(ii.name, ',') FROM pragma_index_info(il.name) ii) AS column_names
+			FROM sqlite_master m
+			JOIN pragma_index_list(m.name) il
+			WHERE ` + sqliteUserTables + ` AND il."unique" = 1`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += " AND m.name = ?"
+		query.args = append(query.args, table)
+	}
+	query.query += " ORDER BY m.name, il.name"
+
+	return []queryWithArgs{query}
+}
+
+// GetTableStatsQueries returns queries for retrieving table statistics in SQLite. Row
+// estimates come from sqlite_stat1, which only exists once ANALYZE has run.
+func (s *SQLiteStrategy) GetTableStatsQueries(table string) []queryWithArgs {
+	withStats := queryWithArgs{
+		query: `
+			SELECT
+				m.name AS table_name,
+				(SELECT CAST(s.stat AS INTEGER) FROM sqlite_stat1 s WHERE s.tbl = m.name LIMIT 1) AS row_count_estimate
+			FROM sqlite_master m
+			WHERE ` + sqliteUserTables,
+		args: []interface{}{},
+	}
+	withoutStats := queryWithArgs{
+		query: `
+			SELECT m.name AS table_name, NULL AS row_count_estimate
+			FROM sqlite_master m
+			WHERE ` + sqliteUserTables,
+		args: []interface{}{},
+	}
+
+	for _, query := range []*queryWithArgs{&withStats, &withoutStats} {
+		if table != "" {
+			query.query += " AND m.name = ?"
+			query.args = append(query.args, table)
+		}
+		query.query += " ORDER BY m.name"
+	}
+
+	return []queryWithArgs{withStats, withoutStats}
+}
+
+// GetExplainQuery returns the query wrapped in EXPLAIN QUERY PLAN for SQLite; plain
+// EXPLAIN lists virtual machine opcodes
+func (s *SQLiteStrategy) GetExplainQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN QUERY PLAN " + trimStatement(query)}
+}
+
+// GetExplainAnalyzeQuery returns an empty query: SQLite cannot report actual timing
+func (s *SQLiteStrategy) GetExplainAnalyzeQuery(query string) queryWithArgs {
+	return queryWithArgs{}
+}
+
+// GetDatabasesQueries returns queries for listing the databases attached to a SQLite connection
+func (s *SQLiteStrategy) GetDatabasesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT name, file FROM pragma_database_list ORDER BY seq"},
+		{query: "PRAGMA database_list"},
+	}
+}
+
+// GetSettingsQueries returns queries for reading common SQLite PRAGMA values. The filter
+// is applied by the caller.
+func (s *SQLiteStrategy) GetSettingsQueries(filter string) []queryWithArgs {
+	pragmas := []string{
+		"auto_vacuum", "busy_timeout", "cache_size", "encoding", "foreign_keys",
+		"journal_mode", "page_size", "synchronous", "user_version",
+	}
+
+	selects := make([]string, 0, len(pragmas))
+	for _, pragma := range pragmas {
+		selects = append(selects, fmt.Sprintf("SELECT '%s' AS name, (SELECT * FROM pragma_%s) AS setting", pragma, pragma))
+	}
+
+	return []queryWithArgs{
+		{query: strings.Join(selects, " UNION ALL ")},
+		// Names only, for SQLite versions without table-valued PRAGMA functions
+		{query: "PRAGMA pragma_list"},
+	}
+}
+
+// GetSampleRowsQuery returns a query for the first limit rows of a SQLite table
+func (s *SQLiteStrategy) GetSampleRowsQuery(table string, limit int) queryWithArgs {
+	return queryWithArgs{query: fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteIdentifier("sqlite", table), limit)}
+}
+
+// GetColumnStatsQueries returns the queries profiling a column of a SQLite table
+func (s *SQLiteStrategy) GetColumnStatsQueries(table, column string) columnStatsQueries {
+	return newColumnStatsQueries("sqlite", table, column)
+}
+
+// GetViewsQueries returns queries for retrieving views in SQLite
+func (s *SQLiteStrategy) GetViewsQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT name AS view_name, 'view' AS view_type, sql AS definition FROM sqlite_master WHERE type = 'view' ORDER BY name"},
+	}
+}
+
+// GetRoutinesQueries returns queries for retrieving routines in SQLite, which has no
+// stored functions or procedures
+func (s *SQLiteStrategy) GetRoutinesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT NULL AS routine_name, NULL AS routine_type, NULL AS return_type, NULL AS arguments WHERE 0"},
+	}
+}
+
+// GetTriggersQueries returns queries for retrieving the triggers of a table, or of all
+// tables when table is empty, in SQLite. SQLite only keeps the CREATE TRIGGER statement,
+// so event and timing are read from it.
+func (s *SQLiteStrategy) GetTriggersQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				m.tbl_name AS table_name,
+				m.name AS trigger_name,
+				CASE
+					WHEN upper(m.sql) LIKE '% INSERT ON %' THEN 'INSERT'
+					WHEN upper(m.sql) LIKE '% DELETE ON %' THEN 'DELETE'
+					ELSE 'UPDATE'
+				END AS event,
+				CASE
+					WHEN upper(m.sql) LIKE '% BEFORE %' THEN 'BEFORE'
+					WHEN upper(m.sql) LIKE '% INSTEAD OF %' THEN 'INSTEAD OF'
+					ELSE 'AFTER'
+				END AS timing
+			FROM sqlite_master m
+			WHERE m.type = 'trigger'`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		query.query += " AND m.tbl_name = ?"
+		query.args = append(query.args, table)
+	}
+	query.query += " ORDER BY m.tbl_name, m.name"
+
+	return []queryWithArgs{query}
+}
+
+// GetSequencesQueries returns no queries: SQLite has no sequences
+func (s *SQLiteStrategy) GetSequencesQueries() []queryWithArgs {
+	return nil
+}
+
+// SQLServerStrategy implements DatabaseStrategy for SQL Server and Azure SQL. Tables
+// outside the default dbo schema are reported as "schema.table", and table arguments
+// are split the same way, so names round-trip between tables and the per-table queries.
+type SQLServerStrategy struct{}
+
+// sqlServerTableName returns the SQL expression naming a table the way the strategy
+// reports it: bare for dbo, schema-qualified otherwise
+func sqlServerTableName(schemaAlias, tableAlias string) string {
+	return fmt.Sprintf("CASE WHEN %[1]s.name = 'dbo' THEN %[2]s.name ELSE %[1]s.name + '.' + %[2]s.name END", schemaAlias, tableAlias)
+}
+
+// splitSQLServerTable splits a possibly schema-qualified table name, defaulting to dbo
+func splitSQLServerTable(table string) (string, string) {
+	if schema, name, ok := strings.Cut(table, "."); ok {
+		return schema, name
+	}
+	return "dbo", table
+}
+
+// sqlServerTableFilter returns a condition restricting schemaAlias/tableAlias to table,
+// with parameters numbered from firstParam
+func sqlServerTableFilter(schemaAlias, tableAlias string, firstParam int, table string) (string, []interface{}) {
+	schema, name := splitSQLServerTable(table)
+	condition := fmt.Sprintf("%s.name = @p%d AND %s.name = @p%d", schemaAlias, firstParam, tableAlias, firstParam+1)
+	return condition, []interface{}{schema, name}
+}
+
+// GetTablesQueries returns queries for retrieving tables in SQL Server
+func (s *SQLServerStrategy) GetTablesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT ` + sqlServerTableName("s", "t") + ` AS table_name, s.name AS table_schema
+				FROM sys.tables t
+				JOIN sys.schemas s ON s.schema_id = t.schema_id
+				WHERE t.is_ms_shipped = 0
+				ORDER BY s.name, t.name
+			`,
+		},
+		{
+			query: `
+				SELECT
+					CASE WHEN TABLE_SCHEMA = 'dbo' THEN TABLE_NAME ELSE TABLE_SCHEMA + '.' + TABLE_NAME END AS table_name,
+					TABLE_SCHEMA AS table_schema
+				FROM INFORMATION_SCHEMA.TABLES
+				WHERE TABLE_TYPE = 'BASE TABLE'
+				ORDER BY TABLE_SCHEMA, TABLE_NAME
+			`,
+		},
+	}
+}
+
+// GetColumnsQueries returns queries for retrieving columns in SQL Server
+func (s *SQLServerStrategy) GetColumnsQueries(table string) []queryWithArgs {
+	schema, name := splitSQLServerTable(table)
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					COLUMN_NAME AS column_name,
+					DATA_TYPE AS data_type,
+					IS_NULLABLE AS is_nullable,
+					COLUMN_DEFAULT AS column_default
+				FROM INFORMATION_SCHEMA.COLUMNS
+				WHERE TABLE_SCHEMA = @p1 AND TABLE_NAME = @p2
+				ORDER BY ORDINAL_POSITION
+			`,
+			args: []interface{}{schema, name},
+		},
+		// Fallback through the catalog views
+		{
+			query: `
+				SELECT
+					c.name AS column_name,
+					ty.name AS data_type,
+					CASE WHEN c.is_nullable = 1 THEN 'YES' ELSE 'NO' END AS is_nullable,
+					OBJECT_DEFINITION(c.default_object_id) AS column_default
+				FROM sys.columns c
+				JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+				WHERE c.object_id = OBJECT_ID(QUOTENAME(@p1) + '.' + QUOTENAME(@p2))
+				ORDER BY c.column_id
+			`,
+			args: []interface{}{schema, name},
+		},
+	}
+}
+
+// GetRelationshipsQueries returns queries for retrieving relationships in SQL Server
+func (s *SQLServerStrategy) GetRelationshipsQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				ps.name AS table_schema,
+				fk.name AS constraint_name,
+				` + sqlServerTableName("ps", "pt") + ` AS table_name,
+				pc.name AS column_name,
+				rs.name AS foreign_table_schema,
+				` + sqlServerTableName("rs", "rt") + ` AS foreign_table_name,
+				rc.name AS foreign_column_name
+			FROM sys.foreign_keys fk
+			JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+			JOIN sys.tables pt ON pt.object_id = fkc.parent_object_id
+			JOIN sys.schemas ps ON ps.schema_id = pt.schema_id
+			JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+			JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+			JOIN sys.schemas rs ON rs.schema_id = rt.schema_id
+			JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		parent, args := sqlServerTableFilter("ps", "pt", 1, table)
+		referenced, _ := sqlServerTableFilter("rs", "rt", 1, table)
+		query.query += " WHERE (" + parent + ") OR (" + referenced + ")"
+		query.args = args
+	}
+	query.query += " ORDER BY ps.name, pt.name, fk.name, fkc.constraint_column_id"
+
+	return []queryWithArgs{query}
+}
+
+// GetPrimaryKeysQueries returns queries for retrieving primary keys in SQL Server
+func (s *SQLServerStrategy) GetPrimaryKeysQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				` + sqlServerTableName("s", "t") + ` AS table_name,
+				c.name AS column_name,
+				i.name AS constraint_name
+			FROM sys.indexes i
+			JOIN sys.tables t ON t.object_id = i.object_id
+			JOIN sys.schemas s ON s.schema_id = t.schema_id
+			JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			WHERE i.is_primary_key = 1
+		`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		condition, args := sqlServerTableFilter("s", "t", 1, table)
+		query.query += " AND " + condition
+		query.args = args
+	}
+	query.query += " ORDER BY s.name, t.name, ic.key_ordinal"
+
+	return []queryWithArgs{query}
+}
+
+// GetIndexesQueries returns queries for retrieving indexes in SQL Server. STRING_AGG
+// needs SQL Server 2017; older versions fall back to one row per index column.
+func (s *SQLServerStrategy) GetIndexesQueries(table string) []queryWithArgs {
+	from := `
+			FROM sys.indexes i
+			JOIN sys.tables t ON t.object_id = i.object_id
+			JOIN sys.schemas s ON s.schema_id = t.schema_id
+			JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			WHERE i.type > 0 AND ic.is_included_column = 0
+	`
+	var filter string
+	var args []interface{}
+	if table != "" {
+		filter, args = sqlServerTableFilter("s", "t", 1, table)
+		filter = " AND " + filter
+	}
+
+	aggregated := queryWithArgs{
+		query: `
+			SELECT
+				` + sqlServerTableName("s", "t") + ` AS table_name,
+				i.name AS index_name,
+				STRING_AGG(c.name, ',') WITHIN GROUP (ORDER BY ic.key_ordinal) AS column_names,
+				CASE WHEN i.is_unique = 1 THEN 0 ELSE 1 END AS non_unique
+		` + from + filter + `
+			GROUP BY s.name, t.name, i.name, i.is_unique
+			ORDER BY s.name, t.name, i.name
+		`,
+		args: args,
+	}
+	perColumn := queryWithArgs{
+		query: `
+			SELECT
+				` + sqlServerTableName("s", "t") + ` AS table_name,
+				i.name AS index_name,
+				c.name AS column_name,
+				CASE WHEN i.is_unique = 1 THEN 0 ELSE 1 END AS non_unique
+		` + from + filter + `
+			ORDER BY s.name, t.name, i.name, ic.key_ordinal
+		`,
+		args: args,
+	}
+
+	return []queryWithArgs{aggregated, perColumn}
+}
+
+// GetEnumValuesQueries returns queries for retrieving ENUM type values in SQL Server,
+// which has no enum types; the query returns no rows
+func (s *SQLServerStrategy) GetEnumValuesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT NULL AS enum_name, NULL AS enum_value WHERE 1 = 0"},
+	}
+}
+
+// GetUniqueConstraintsQueries returns queries for retrieving unique constraints in SQL Server
+func (s *SQLServerStrategy) GetUniqueConstraintsQueries(table string) []queryWithArgs {
+	query := queryWithArgs{
+		query: `
+			SELECT
+				` + sqlServerTableName("s", "t") + ` AS table_name,
+				kc.name AS constraint_name,
+				CASE kc.type WHEN 'PK' THEN 'PRIMARY KEY' ELSE 'UNIQUE' END AS constraint_type,
+				STRING_AGG(c.name, ',') WITHIN GROUP (ORDER BY ic.key_ordinal) AS column_names
+			FROM sys.key_constraints kc
+			JOIN sys.tables t ON t.object_id = kc.parent_object_id
+			JOIN sys.schemas s ON s.schema_id = t.schema_id
+			JOIN sys.index_columns ic ON ic.object_id = kc.parent_object_id AND ic.index_id = kc.unique_index_id
+			JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			WHERE kc.type IN ('PK', 'UQ')
+		`,
+		args: []interface{}{},
+	}
+
+	if table != "" {
+		condition, args := sqlServerTableFilter("s", "t", 1, table)
+		query.query += " AND " + condition
+		query.args = args
+	}
+	query.query += `
+			GROUP BY s.name, t.name, kc.name, kc.type
+			ORDER BY s.name, t.name, kc.name
+	`
+
+	return []queryWithArgs{query}
+}
+
+// GetTableStatsQueries returns queries for retrieving table statistics in SQL Server.
+// sys.dm_db_partition_stats needs VIEW DATABASE STATE; sys.partitions only has row counts.
+func (s *SQLServerStrategy) GetTableStatsQueries(table string) []queryWithArgs {
+	var filter string
+	var args []interface{}
+	if table != "" {
+		filter, args = sqlServerTableFilter("s", "t", 1, table)
+		filter = " AND " + filter
+	}
+
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					s.name AS table_schema,
+					` + sqlServerTableName("s", "t") + ` AS table_name,
+					SUM(ps.row_count) AS row_count_estimate,
+					SUM(ps.reserved_page_count) * 8192 AS reserved_bytes,
+					t.create_date,
+					t.modify_date
+				FROM sys.tables t
+				JOIN sys.schemas s ON s.schema_id = t.schema_id
+				JOIN sys.dm_db_partition_stats ps ON ps.object_id = t.object_id AND ps.index_id IN (0, 1)
+				WHERE t.is_ms_shipped = 0` + filter + `
+				GROUP BY s.name, t.name, t.create_date, t.modify_date
+				ORDER BY s.name, t.name
+			`,
+			args: args,
+		},
+		{
+			query: `
+				SELECT
+					s.name AS table_schema,
+					` + sqlServerTableName("s", "t") + ` AS table_name,
+					SUM(p.rows) AS row_count_estimate,
+					t.create_date,
+					t.modify_date
+				FROM sys.tables t
+				JOIN sys.schemas s ON s.schema_id = t.schema_id
+				JOIN sys.partitions p ON p.object_id = t.object_id AND p.index_id IN (0, 1)
+				WHERE t.is_ms_shipped = 0` + filter + `
+				GROUP BY s.name, t.name, t.create_date, t.modify_date
+				ORDER BY s.name, t.name
+			`,
+			args: args,
+		},
+	}
+}
+
+// GetExplainQuery returns the query as-is for SQL Server. There is no EXPLAIN statement;
+// the estimated plan is returned when the query runs on a session with SHOWPLAN_XML on.
+func (s *SQLServerStrategy) GetExplainQuery(query string) queryWithArgs {
+	return queryWithArgs{query: trimStatement(query)}
+}
+
+// GetExplainAnalyzeQuery returns an empty query: the actual plan of a SQL Server query
+// needs SET STATISTICS XML, which is not supported
+func (s *SQLServerStrategy) GetExplainAnalyzeQuery(query string) queryWithArgs {
+	return queryWithArgs{}
+}
+
+// GetDatabasesQueries returns queries for listing the databases on a SQL Server instance
+// that the login can access
+func (s *SQLServerStrategy) GetDatabasesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT name, state_desc, compatibility_level FROM sys.databases WHERE HAS_DBACCESS(name) = 1 ORDER BY name"},
+	}
+}
+
+// GetSettingsQueries returns queries for reading the SQL Server configuration options
+// whose name contains filter
+func (s *SQLServerStrategy) GetSettingsQueries(filter string) []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT name, CAST(value_in_use AS NVARCHAR(4000)) AS setting, description
+				FROM sys.configurations
+				WHERE name LIKE @p1
+				ORDER BY name
+			`,
+			args: []interface{}{"%" + filter + "%"},
+		},
+	}
+}
+
+// GetSampleRowsQuery returns a query for the first limit rows of a SQL Server table;
+// SQL Server has no LIMIT clause
+func (s *SQLServerStrategy) GetSampleRowsQuery(table string, limit int) queryWithArgs {
+	return queryWithArgs{query: fmt.Sprintf("SELECT TOP (%d) * FROM %s", limit, quoteIdentifier("sqlserver", table))}
+}
+
+// GetColumnStatsQueries returns the queries profiling a column of a SQL Server table,
+// counting with COUNT_BIG since COUNT overflows past 2^31 rows
+func (s *SQLServerStrategy) GetColumnStatsQueries(table, column string) columnStatsQueries {
+	quotedTable := quoteIdentifier("sqlserver", table)
+	quotedColumn := quoteIdentifier("sqlserver", column)
+	return columnStatsQueries{
+		summary: queryWithArgs{query: fmt.Sprintf(
+			"SELECT COUNT_BIG(*) AS total_count, COUNT_BIG(DISTINCT %[1]s) AS distinct_count, COUNT_BIG(*) - COUNT_BIG(%[1]s) AS null_count FROM %[2]s",
+			quotedColumn, quotedTable)},
+		topValues: queryWithArgs{query: fmt.Sprintf(
+			"SELECT TOP (%[3]d) %[1]s AS value, COUNT_BIG(*) AS count FROM %[2]s GROUP BY %[1]s ORDER BY 2 DESC",
+			quotedColumn, quotedTable, columnStatsTopValues)},
+	}
+}
+
+// GetViewsQueries returns queries for retrieving views in SQL Server. Indexed views,
+// which store their rows, are reported as materialized views.
+func (s *SQLServerStrategy) GetViewsQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					` + sqlServerTableName("s", "v") + ` AS view_name,
+					s.name AS view_schema,
+					CASE WHEN OBJECTPROPERTY(v.object_id, 'IsIndexed') = 1 THEN 'materialized_view' ELSE 'view' END AS view_type,
+					OBJECT_DEFINITION(v.object_id) AS definition
+				FROM sys.views v
+				JOIN sys.schemas s ON s.schema_id = v.schema_id
+				WHERE v.is_ms_shipped = 0
+				ORDER BY s.name, v.name
+			`,
+		},
+	}
+}
+
+// GetRoutinesQueries returns queries for retrieving functions and procedures in SQL Server.
+// STRING_AGG needs SQL Server 2017 or later.
+func (s *SQLServerStrategy) GetRoutinesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					CASE WHEN r.ROUTINE_SCHEMA = 'dbo' THEN r.ROUTINE_NAME ELSE r.ROUTINE_SCHEMA + '.' + r.ROUTINE_NAME END AS routine_name,
+					r.ROUTINE_SCHEMA AS routine_schema,
+					LOWER(r.ROUTINE_TYPE) AS routine_type,
+					r.DATA_TYPE AS return_type,
+					COALESCE((
+						SELECT STRING_AGG(p.PARAMETER_NAME + ' ' + p.DATA_TYPE, ', ') WITHIN GROUP (ORDER BY p.ORDINAL_POSITION)
+						FROM INFORMATION_SCHEMA.PARAMETERS p
+						WHERE p.SPECIFIC_SCHEMA = r.SPECIFIC_SCHEMA AND p.SPECIFIC_NAME = r.SPECIFIC_NAME
+						AND p.ORDINAL_POSITION > 0
+					), '') AS arguments
+				FROM INFORMATION_SCHEMA.ROUTINES r
+				ORDER BY r.ROUTINE_SCHEMA, r.ROUTINE_NAME
+			`,
+		},
+	}
+}
+
+// GetTriggersQueries returns queries for retrieving the DML triggers of a table, or of all
+// tables when table is empty, in SQL Server, one row per trigger event
+func (s *SQLServerStrategy) GetTriggersQueries(table string) []queryWithArgs {
+	filter := "1 = 1"
+	var args []interface{}
+	if table != "" {
+		filter, args = sqlServerTableFilter("s", "t", 1, table)
+	}
+
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					` + sqlServerTableName("s", "t") + ` AS table_name,
+					tr.name AS trigger_name,
+					te.type_desc AS event,
+					CASE WHEN tr.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END AS timing
+				FROM sys.triggers tr
+				JOIN sys.trigger_events te ON te.object_id = tr.object_id
+				JOIN sys.tables t ON t.object_id = tr.parent_id
+				JOIN sys.schemas s ON s.schema_id = t.schema_id
+				WHERE ` + filter + `
+				ORDER BY s.name, t.name, tr.name
+			`,
+			args: args,
+		},
+	}
+}
+
+// GetSequencesQueries returns queries for retrieving sequences in SQL Server. Identity
+// columns do not use sequences, so no owner is reported.
+func (s *SQLServerStrategy) GetSequencesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{
+			query: `
+				SELECT
+					` + sqlServerTableName("s", "seq") + ` AS sequence_name,
+					s.name AS sequence_schema,
+					seq.current_value AS last_value,
+					seq.increment AS increment_by,
+					seq.minimum_value AS min_value,
+					seq.maximum_value AS max_value,
+					seq.is_cycling AS cycle
+				FROM sys.sequences seq
+				JOIN sys.schemas s ON s.schema_id = seq.schema_id
+				ORDER BY s.name, seq.name
+			`,
+		},
+	}
+}
+
 // GenericStrategy implements DatabaseStrategy for unknown database types
 type GenericStrategy struct{}
 
@@ -823,8 +1919,75 @@ func (s *GenericStrategy) GetTableStatsQueries(table string) []queryWithArgs {
 		pgQuery.query += " ORDER BY relname"
 		mysqlQuery.query += " ORDER BY table_name"
 	}
-	
-	return []queryWithArgs{pgQuery, mysqlQuery}
+	
+	return []queryWithArgs{pgQuery, mysqlQuery}
+}
+
+// GetExplainQuery returns the query wrapped in a plain EXPLAIN (generic)
+func (s *GenericStrategy) GetExplainQuery(query string) queryWithArgs {
+	return queryWithArgs{query: "EXPLAIN " + trimStatement(query)}
+}
+
+// GetExplainAnalyzeQuery returns an empty query; EXPLAIN ANALYZE syntax differs too much
+// between databases to guess (generic)
+func (s *GenericStrategy) GetExplainAnalyzeQuery(query string) queryWithArgs {
+	return queryWithArgs{}
+}
+
+// GetDatabasesQueries returns generic queries for listing databases
+func (s *GenericStrategy) GetDatabasesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT schema_name AS name FROM information_schema.schemata ORDER BY schema_name"},
+		{query: "SHOW DATABASES"}, // Last resort
+	}
+}
+
+// GetSettingsQueries returns generic queries for reading server settings
+func (s *GenericStrategy) GetSettingsQueries(filter string) []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SHOW VARIABLES"},
+		{query: "SHOW ALL"}, // Last resort
+	}
+}
+
+// GetSampleRowsQuery returns a generic query for the first limit rows of a table
+func (s *GenericStrategy) GetSampleRowsQuery(table string, limit int) queryWithArgs {
+	return queryWithArgs{query: fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteIdentifier("", table), limit)}
+}
+
+// GetColumnStatsQueries returns generic queries profiling a column of a table
+func (s *GenericStrategy) GetColumnStatsQueries(table, column string) columnStatsQueries {
+	return newColumnStatsQueries("", table, column)
+}
+
+// GetViewsQueries returns generic queries for retrieving views
+func (s *GenericStrategy) GetViewsQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT table_name AS view_name, 'view' AS view_type, view_definition AS definition FROM information_schema.views"},
+	}
+}
+
+// GetRoutinesQueries returns generic queries for retrieving functions and procedures
+func (s *GenericStrategy) GetRoutinesQueries() []queryWithArgs {
+	return []queryWithArgs{
+		{query: "SELECT routine_name, LOWER(routine_type) AS routine_type, data_type AS return_type FROM information_schema.routines"},
+	}
+}
+
+// GetTriggersQueries returns generic queries for retrieving the triggers of a table, or of
+// all tables when table is empty
+func (s *GenericStrategy) GetTriggersQueries(table string) []queryWithArgs {
+	query := "SELECT event_object_table AS table_name, trigger_name, event_manipulation AS event, action_timing AS timing FROM information_schema.triggers"
+	if table == "" {
+		return []queryWithArgs{{query: query}}
+	}
+	return []queryWithArgs{{query: query + " WHERE event_object_table = ?", args: []interface{}{table}}}
+}
+
+// GetSequencesQueries returns no queries; sequence support varies too much between
+// databases to guess
+func (s *GenericStrategy) GetSequencesQueries() []queryWithArgs {
+	return nil
 }
 
 // createSchemaExplorerTool creates a tool for exploring database schema
@@ -838,21 +2001,33 @@ func createSchemaExplorerTool() *tools.Tool {
 			Properties: map[string]interface{}{
 				"component": map[string]interface{}{
 					"type":        "string",
-					"description": "Schema component to explore (tables, columns, relationships, or full)",
-					"enum":        []string{"tables", "columns", "relationships", "full"},
+					"description": "Schema component to explore (tables, columns, relationships, triggers, views, routines, sequences, or full)",
+					"enum":        []string{"tables", "columns", "relationships", "triggers", "views", "routines", "sequences", "full"},
 				},
 				"table": map[string]interface{}{
 					"type":        "string",
-					"description": "Table name to explore (optional, leave empty for all tables)",
+					"description": "Table name to explore (required for columns; optional for relationships and triggers, leave empty for all tables)",
 				},
 				"timeout": map[string]interface{}{
 					"type":        "integer",
-					"description": "Query timeout in milliseconds (default: 10000)",
+					"description": "Timeout in milliseconds (default: the database's schema_timeout, 120000 unless configured)",
 				},
 				"database": map[string]interface{}{
 					"type":        "string",
 					"description": "Database ID to use (optional if only one database is configured)",
 				},
+				"refresh": map[string]interface{}{
+					"type":        "boolean",
+					"description": "For the full component, bypass the schema cache and re-populate it (default: false)",
+				},
+				"include": map[string]interface{}{
+					"type":        "array",
+					"description": "For the full component, only fetch these parts of the schema (default: all). Tables are always listed.",
+					"items": map[string]interface{}{
+						"type": "string",
+						"enum": schemaComponents,
+					},
+				},
 			},
 			Required: []string{"component", "database"},
 		},
@@ -888,14 +2063,8 @@ func handleSchemaExplorer(ctx context.Context, params map[string]interface{}) (i
 	// Extract table parameter (optional depending on component)
 	table, _ := getStringParam(params, "table")
 
-	// Extract timeout
-	timeout := 10000 // Default timeout: 10 seconds
-	if timeoutParam, ok := getIntParam(params, "timeout"); ok {
-		timeout = timeoutParam
-	}
-
-	// Create context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+	// Create context with timeout; full scans of large catalogs need the longer schema budget
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
 	defer cancel()
 
 	// Use actual database queries based on component type
@@ -909,13 +2078,86 @@ func handleSchemaExplorer(ctx context.Context, params map[string]interface{}) (i
 		return getColumns(timeoutCtx, db, table)
 	case "relationships":
 		return getRelationships(timeoutCtx, db, table)
+	case "triggers":
+		return getTriggers(timeoutCtx, db, table)
+	case "views":
+		return getViews(timeoutCtx, db)
+	case "routines":
+		return getRoutines(timeoutCtx, db)
+	case "sequences":
+		return getSequences(timeoutCtx, db)
 	case "full":
-		return getFullSchema(timeoutCtx, db)
+		refresh, _ := getBoolParam(params, "refresh")
+		if includeParam, ok := getArrayParam(params, "include"); ok && len(includeParam) > 0 {
+			include, err := parseSchemaComponents(includeParam)
+			if err != nil {
+				return nil, err
+			}
+			return getPartialSchema(timeoutCtx, db, databaseID, refresh, include)
+		}
+		return getCachedFullSchema(timeoutCtx, db, databaseID, refresh)
 	default:
 		return nil, fmt.Errorf("invalid component: %s", component)
 	}
 }
 
+// schemaOperationTimeout returns the timeout for a schema discovery call: the timeout
+// parameter in milliseconds when given, otherwise the database's schema timeout
+func schemaOperationTimeout(params map[string]interface{}, db db.Database) time.Duration {
+	if timeout, ok := getIntParam(params, "timeout"); ok && timeout > 0 {
+		return time.Duration(timeout) * time.Millisecond
+	}
+	return time.Duration(db.SchemaTimeout()) * time.Second
+}
+
+// getCachedFullSchema returns the full schema of a database from the schema cache,
+// computing and caching it on a miss or when refresh is set. The cache is keyed by
+// database ID, shared with GetDetailedSchema, and only populated when every component
+// loaded; a partial schema is returned but not cached, so the next call retries it.
+func getCachedFullSchema(ctx context.Context, db db.Database, databaseID string, refresh bool) (interface{}, error) {
+	cache := GetSchemaCache()
+	if !refresh {
+		if cached, ok := cache.Get(databaseID); ok {
+			return cached, nil
+		}
+	}
+
+	schema, err := getFullSchema(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isPartialSchema(schema) {
+		cache.Set(databaseID, schema)
+	}
+	return schema, nil
+}
+
+// isPartialSchema reports whether a schema from getSchemaComponents is missing
+// components that failed to load
+func isPartialSchema(schema interface{}) bool {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	partial, _ := schemaMap["partial"].(bool)
+	return partial
+}
+
+// getPartialSchema returns only the included components of a database schema. A cached
+// full schema is narrowed down; otherwise only the included components are queried, and
+// the partial result is not cached.
+func getPartialSchema(ctx context.Context, db db.Database, databaseID string, refresh bool, include map[string]bool) (interface{}, error) {
+	if !refresh {
+		if cached, ok := GetSchemaCache().Get(databaseID); ok {
+			if schema, ok := cached.(map[string]interface{}); ok {
+				return filterSchemaComponents(schema, include), nil
+			}
+		}
+	}
+	return getSchemaComponents(ctx, db, include)
+}
+
 // executeWithFallbacks executes a series of database queries with fallbacks
 // Returns the first successful result or the last error encountered
 type queryWithArgs struct {
@@ -1171,6 +2413,156 @@ func getEnumValues(ctx context.Context, db db.Database) (interface{}, error) {
 	}, nil
 }
 
+// getViews retrieves the views of the database, with a view_type of view or
+// materialized_view
+func getViews(ctx context.Context, db db.Database) (interface{}, error) {
+	driverName := db.DriverName()
+	dbType := driverName
+
+	strategy := NewDatabaseStrategy(driverName)
+	queries := strategy.GetViewsQueries()
+
+	rows, err := executeWithFallbacks(ctx, db, queries, "getViews")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get views: %w", err)
+	}
+
+	defer func() {
+		if rows != nil {
+			if err := rows.Close(); err != nil {
+				logger.Error("error closing rows: %v", err)
+			}
+		}
+	}()
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process views: %w", err)
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"views":  results,
+		"dbType": dbType,
+	}, nil
+}
+
+// getRoutines retrieves the stored functions and procedures of the database, with a
+// routine_type of function or procedure
+func getRoutines(ctx context.Context, db db.Database) (interface{}, error) {
+	driverName := db.DriverName()
+	dbType := driverName
+
+	strategy := NewDatabaseStrategy(driverName)
+	queries := strategy.GetRoutinesQueries()
+
+	rows, err := executeWithFallbacks(ctx, db, queries, "getRoutines")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routines: %w", err)
+	}
+
+	defer func() {
+		if rows != nil {
+			if err := rows.Close(); err != nil {
+				logger.Error("error closing rows: %v", err)
+			}
+		}
+	}()
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process routines: %w", err)
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"routines": results,
+		"dbType":   dbType,
+	}, nil
+}
+
+// getTriggers retrieves the triggers of a table or all tables
+func getTriggers(ctx context.Context, db db.Database, table string) (interface{}, error) {
+	driverName := db.DriverName()
+	dbType := driverName
+
+	strategy := NewDatabaseStrategy(driverName)
+	queries := strategy.GetTriggersQueries(table)
+
+	rows, err := executeWithFallbacks(ctx, db, queries, "getTriggers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triggers: %w", err)
+	}
+
+	defer func() {
+		if rows != nil {
+			if err := rows.Close(); err != nil {
+				logger.Error("error closing rows: %v", err)
+			}
+		}
+	}()
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process triggers: %w", err)
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"triggers": results,
+		"dbType":   dbType,
+		"table":    table,
+	}, nil
+}
+
+// getSequences retrieves the sequences of the database. Databases without sequences
+// return an empty list without querying.
+func getSequences(ctx context.Context, db db.Database) (interface{}, error) {
+	driverName := db.DriverName()
+	dbType := driverName
+
+	strategy := NewDatabaseStrategy(driverName)
+	queries := strategy.GetSequencesQueries()
+	if len(queries) == 0 {
+		return map[string]interface{}{
+			"sequences": []map[string]interface{}{},
+			"dbType":    dbType,
+		}, nil
+	}
+
+	rows, err := executeWithFallbacks(ctx, db, queries, "getSequences")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequences: %w", err)
+	}
+
+	defer func() {
+		if rows != nil {
+			if err := rows.Close(); err != nil {
+				logger.Error("error closing rows: %v", err)
+			}
+		}
+	}()
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process sequences: %w", err)
+	}
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"sequences": results,
+		"dbType":    dbType,
+	}, nil
+}
+
 // getUniqueConstraints retrieves unique constraints for a table or all tables
 func getUniqueConstraints(ctx context.Context, db db.Database, table string) (interface{}, error) {
 	driverName := db.DriverName()
@@ -1272,8 +2664,54 @@ func safeGetString(m map[string]interface{}, key string) (string, error) {
 	return strVal, nil
 }
 
+// schemaComponents lists the optional parts of a full schema fetch, in output order
+var schemaComponents = []string{"columns", "primary_keys", "indexes", "unique_constraints", "statistics", "enums", "foreign_keys", "triggers", "views"}
+
+// allSchemaComponents returns a component set that includes everything
+func allSchemaComponents() map[string]bool {
+	include := make(map[string]bool, len(schemaComponents))
+	for _, component := range schemaComponents {
+		include[component] = true
+	}
+	return include
+}
+
+// parseSchemaComponents validates an include list of component names
+func parseSchemaComponents(values []interface{}) (map[string]bool, error) {
+	include := make(map[string]bool, len(values))
+	for _, value := range values {
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("include entries must be strings, got %T", value)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		valid := false
+		for _, component := range schemaComponents {
+			if name == component {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown schema component %q: must be one of %s", name, strings.Join(schemaComponents, ", "))
+		}
+		include[name] = true
+	}
+	return include, nil
+}
+
 // getFullSchema retrieves the complete database schema
 func getFullSchema(ctx context.Context, db db.Database) (interface{}, error) {
+	return getSchemaComponents(ctx, db, allSchemaComponents())
+}
+
+// getSchemaComponents retrieves the table list plus only the included components, skipping
+// the queries for everything else. The result has the same shape as getFullSchema.
+// Components that fail to load, for example because the timeout ran out, are left empty;
+// the result then has partial set and lists them under failed_components.
+func getSchemaComponents(ctx context.Context, db db.Database, include map[string]bool) (interface{}, error) {
+	failed := []string{}
+
 	tablesResult, err := getTables(ctx, db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tables: %w", err)
@@ -1290,43 +2728,58 @@ func getFullSchema(ctx context.Context, db db.Database) (interface{}, error) {
 	}
 
 	// Get ENUM values for all types
-	enumsResult, enumsErr := getEnumValues(ctx, db)
 	var enumValues []map[string]interface{}
-	var enumsByType map[string][]string
-	if enumsErr != nil {
-		logger.Warn("Failed to get enum values: %v", enumsErr)
-		enumValues = []map[string]interface{}{}
-		enumsByType = make(map[string][]string)
-	} else {
-		enumsMap, _ := safeGetMap(enumsResult)
-		if enums, ok := enumsMap["enums"].([]map[string]interface{}); ok {
-			enumValues = enums
+	enumsByType := make(map[string][]string)
+	if include["enums"] {
+		enumsResult, enumsErr := getEnumValues(ctx, db)
+		if enumsErr != nil {
+			logger.Warn("Failed to get enum values: %v", enumsErr)
+			failed = append(failed, "enums")
+			enumValues = []map[string]interface{}{}
+		} else {
+			enumsMap, _ := safeGetMap(enumsResult)
+			if enums, ok := enumsMap["enums"].([]map[string]interface{}); ok {
+				enumValues = enums
+			}
+
+			// Organize enum values by type name for easy lookup
+			enumsByType = groupEnumValues(enumValues)
 		}
-		
-		// Organize enum values by type name for easy lookup
-		enumsByType = make(map[string][]string)
-		for _, enum := range enumValues {
-			if enumName, ok := enum["enum_name"].(string); ok {
-				if enumValue, ok := enum["enum_value"].(string); ok {
-					enumsByType[enumName] = append(enumsByType[enumName], enumValue)
+	}
+
+	// Get table statistics for all tables
+	statsByTable := make(map[string]map[string]interface{})
+	if include["statistics"] {
+		statsResult, statsErr := getTableStats(ctx, db, "")
+		if statsErr != nil {
+			logger.Warn("Failed to get table stats: %v", statsErr)
+			failed = append(failed, "statistics")
+		} else {
+			statsMap, _ := safeGetMap(statsResult)
+			if stats, ok := statsMap["stats"].([]map[string]interface{}); ok {
+				for _, stat := range stats {
+					if tableName, ok := stat["table_name"].(string); ok {
+						statsByTable[tableName] = stat
+					}
 				}
 			}
 		}
 	}
 
-	// Get table statistics for all tables
-	statsResult, statsErr := getTableStats(ctx, db, "")
-	var statsByTable map[string]map[string]interface{}
-	if statsErr != nil {
-		logger.Warn("Failed to get table stats: %v", statsErr)
-		statsByTable = make(map[string]map[string]interface{})
-	} else {
-		statsMap, _ := safeGetMap(statsResult)
-		if stats, ok := statsMap["stats"].([]map[string]interface{}); ok {
-			statsByTable = make(map[string]map[string]interface{})
-			for _, stat := range stats {
-				if tableName, ok := stat["table_name"].(string); ok {
-					statsByTable[tableName] = stat
+	// Get triggers for all tables
+	triggersByTable := make(map[string][]map[string]interface{})
+	if include["triggers"] {
+		triggersResult, triggersErr := getTriggers(ctx, db, "")
+		if triggersErr != nil {
+			logger.Warn("Failed to get triggers: %v", triggersErr)
+			failed = append(failed, "triggers")
+		} else {
+			triggersMap, _ := safeGetMap(triggersResult)
+			if triggers, ok := triggersMap["triggers"].([]map[string]interface{}); ok {
+				for _, trigger := range triggers {
+					if tableName, ok := trigger["table_name"].(string); ok {
+						triggersByTable[tableName] = append(triggersByTable[tableName], trigger)
+					}
 				}
 			}
 		}
@@ -1340,124 +2793,290 @@ func getFullSchema(ctx context.Context, db db.Database) (interface{}, error) {
 			return nil, fmt.Errorf("invalid table info: %w", err)
 		}
 
+		tableSchema := make(map[string]interface{})
+
 		// Get columns
-		columnsResult, columnsErr := getColumns(ctx, db, tableName)
-		if columnsErr != nil {
-			logger.Warn("Failed to get columns for table %s: %v", tableName, columnsErr)
-			continue
-		}
-		
-		columnsMap, _ := safeGetMap(columnsResult)
-		
-		// Enhance columns with enum values
-		if columns, ok := columnsMap["columns"].([]map[string]interface{}); ok {
-			for i, column := range columns {
-				dataType, _ := column["data_type"].(string)
-				udtName, hasUdtName := column["udt_name"].(string)
-				
-				// For USER-DEFINED types, use the udt_name to look up enum values
-				if dataType == "USER-DEFINED" && hasUdtName {
-					if enumVals, exists := enumsByType[udtName]; exists {
-						columns[i]["enum_values"] = enumVals
-						columns[i]["enum_type"] = udtName
-					}
-				} else {
-					// For other types, check by data_type
-					if enumVals, exists := enumsByType[dataType]; exists {
-						columns[i]["enum_values"] = enumVals
-					}
-				}
+		if include["columns"] {
+			columnsResult, columnsErr := getColumns(ctx, db, tableName)
+			if columnsErr != nil {
+				logger.Warn("Failed to get columns for table %s: %v", tableName, columnsErr)
+				failed = append(failed, "columns of "+tableName)
+				continue
 			}
+
+			columnsMap, _ := safeGetMap(columnsResult)
+
+			// Enhance columns with enum values
+			if columns, ok := columnsMap["columns"].([]map[string]interface{}); ok {
+				attachEnumValues(columns, enumsByType)
+			}
+			tableSchema["columns"] = columnsMap["columns"]
 		}
-		
+
 		// Get primary keys for this table
-		primaryKeysResult, pkErr := getPrimaryKeys(ctx, db, tableName)
-		var primaryKeys []map[string]interface{}
-		if pkErr != nil {
-			logger.Warn("Failed to get primary keys for table %s: %v", tableName, pkErr)
-			primaryKeys = []map[string]interface{}{}
-		} else {
-			pkMap, _ := safeGetMap(primaryKeysResult)
-			if pks, ok := pkMap["primary_keys"].([]map[string]interface{}); ok {
-				primaryKeys = pks
+		if include["primary_keys"] {
+			primaryKeysResult, pkErr := getPrimaryKeys(ctx, db, tableName)
+			primaryKeys := []map[string]interface{}{}
+			if pkErr != nil {
+				logger.Warn("Failed to get primary keys for table %s: %v", tableName, pkErr)
+				failed = append(failed, "primary_keys of "+tableName)
+			} else {
+				pkMap, _ := safeGetMap(primaryKeysResult)
+				if pks, ok := pkMap["primary_keys"].([]map[string]interface{}); ok {
+					primaryKeys = pks
+				}
 			}
+			tableSchema["primary_keys"] = primaryKeys
 		}
-		
+
 		// Get indexes for this table
-		indexesResult, idxErr := getIndexes(ctx, db, tableName)
-		var indexes []map[string]interface{}
-		if idxErr != nil {
-			logger.Warn("Failed to get indexes for table %s: %v", tableName, idxErr)
-			indexes = []map[string]interface{}{}
-		} else {
-			idxMap, _ := safeGetMap(indexesResult)
-			if idxs, ok := idxMap["indexes"].([]map[string]interface{}); ok {
-				indexes = idxs
+		if include["indexes"] {
+			indexesResult, idxErr := getIndexes(ctx, db, tableName)
+			indexes := []map[string]interface{}{}
+			if idxErr != nil {
+				logger.Warn("Failed to get indexes for table %s: %v", tableName, idxErr)
+				failed = append(failed, "indexes of "+tableName)
+			} else {
+				idxMap, _ := safeGetMap(indexesResult)
+				if idxs, ok := idxMap["indexes"].([]map[string]interface{}); ok {
+					indexes = idxs
+				}
 			}
+			tableSchema["indexes"] = indexes
 		}
-		
+
 		// Get unique constraints for this table
-		uniqueConstraintsResult, ucErr := getUniqueConstraints(ctx, db, tableName)
-		var uniqueConstraints []map[string]interface{}
-		if ucErr != nil {
-			logger.Warn("Failed to get unique constraints for table %s: %v", tableName, ucErr)
-			uniqueConstraints = []map[string]interface{}{}
-		} else {
-			ucMap, _ := safeGetMap(uniqueConstraintsResult)
-			if ucs, ok := ucMap["unique_constraints"].([]map[string]interface{}); ok {
-				uniqueConstraints = ucs
+		if include["unique_constraints"] {
+			uniqueConstraintsResult, ucErr := getUniqueConstraints(ctx, db, tableName)
+			uniqueConstraints := []map[string]interface{}{}
+			if ucErr != nil {
+				logger.Warn("Failed to get unique constraints for table %s: %v", tableName, ucErr)
+				failed = append(failed, "unique_constraints of "+tableName)
+			} else {
+				ucMap, _ := safeGetMap(uniqueConstraintsResult)
+				if ucs, ok := ucMap["unique_constraints"].([]map[string]interface{}); ok {
+					uniqueConstraints = ucs
+				}
 			}
+			tableSchema["unique_constraints"] = uniqueConstraints
 		}
 
 		// Get table statistics
-		tableStats := statsByTable[tableName]
-		if tableStats == nil {
-			tableStats = make(map[string]interface{})
+		if include["statistics"] {
+			tableStats := statsByTable[tableName]
+			if tableStats == nil {
+				tableStats = make(map[string]interface{})
+			}
+			tableSchema["statistics"] = tableStats
+		}
+
+		// Get triggers for this table
+		if include["triggers"] {
+			tableTriggers := triggersByTable[tableName]
+			if tableTriggers == nil {
+				tableTriggers = []map[string]interface{}{}
+			}
+			tableSchema["triggers"] = tableTriggers
 		}
 
 		// Build detailed table schema
-		detailedSchema[tableName] = map[string]interface{}{
-			"columns":            columnsMap["columns"],
-			"primary_keys":       primaryKeys,
-			"indexes":            indexes,
-			"unique_constraints": uniqueConstraints,
-			"statistics":         tableStats,
+		detailedSchema[tableName] = tableSchema
+	}
+
+	fullSchema := map[string]interface{}{
+		"tables":          tablesSlice,
+		"detailed_schema": detailedSchema,
+	}
+
+	if include["foreign_keys"] {
+		// Get all relationships
+		relationships, relErr := getRelationships(ctx, db, "")
+		foreignKeys := []map[string]interface{}{}
+		if relErr != nil {
+			logger.Warn("Failed to get relationships: %v", relErr)
+			failed = append(failed, "foreign_keys")
+		} else {
+			relMap, _ := safeGetMap(relationships)
+			if fks, ok := relMap["relationships"].([]map[string]interface{}); ok {
+				foreignKeys = fks
+			}
+		}
+
+		// Organize foreign keys by table
+		fksByTable := make(map[string][]map[string]interface{})
+		for _, fk := range foreignKeys {
+			if tableName, ok := fk["table_name"].(string); ok {
+				fksByTable[tableName] = append(fksByTable[tableName], fk)
+			}
+		}
+
+		// Add foreign keys to each table's detailed schema
+		for tableName, tableSchema := range detailedSchema {
+			if schema, ok := tableSchema.(map[string]interface{}); ok {
+				schema["foreign_keys"] = fksByTable[tableName]
+			}
 		}
+		fullSchema["foreign_keys"] = foreignKeys
 	}
 
-	// Get all relationships
-	relationships, relErr := getRelationships(ctx, db, "")
-	var foreignKeys []map[string]interface{}
-	if relErr != nil {
-		logger.Warn("Failed to get relationships: %v", relErr)
-		foreignKeys = []map[string]interface{}{}
-	} else {
-		relMap, _ := safeGetMap(relationships)
-		if fks, ok := relMap["relationships"].([]map[string]interface{}); ok {
-			foreignKeys = fks
+	if include["enums"] {
+		fullSchema["enum_types"] = enumsByType
+		fullSchema["enum_values"] = enumValues
+	}
+
+	if include["views"] {
+		// Views are listed next to the tables; a database without view support gets an empty list
+		views := []map[string]interface{}{}
+		if viewsResult, viewErr := getViews(ctx, db); viewErr != nil {
+			logger.Warn("Failed to get views: %v", viewErr)
+			failed = append(failed, "views")
+		} else if viewsMap, _ := safeGetMap(viewsResult); viewsMap != nil {
+			if list, ok := viewsMap["views"].([]map[string]interface{}); ok {
+				views = list
+			}
+		}
+		fullSchema["views"] = views
+	}
+
+	if len(failed) > 0 {
+		fullSchema["partial"] = true
+		fullSchema["failed_components"] = failed
+	}
+
+	return fullSchema, nil
+}
+
+// filterSchemaComponents narrows a full schema, such as a cached one, to the included
+// components without querying the database again
+func filterSchemaComponents(schema map[string]interface{}, include map[string]bool) map[string]interface{} {
+	filtered := map[string]interface{}{
+		"tables": schema["tables"],
+	}
+	if include["foreign_keys"] {
+		filtered["foreign_keys"] = schema["foreign_keys"]
+	}
+	if include["enums"] {
+		filtered["enum_types"] = schema["enum_types"]
+		filtered["enum_values"] = schema["enum_values"]
+	}
+	if include["views"] {
+		filtered["views"] = schema["views"]
+	}
+
+	detailedSchema := make(map[string]interface{})
+	if detailed, ok := schema["detailed_schema"].(map[string]interface{}); ok {
+		for tableName, tableSchema := range detailed {
+			tableMap, ok := tableSchema.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			filteredTable := make(map[string]interface{})
+			for _, component := range schemaComponents {
+				if value, exists := tableMap[component]; exists && include[component] {
+					filteredTable[component] = value
+				}
+			}
+			detailedSchema[tableName] = filteredTable
 		}
 	}
+	filtered["detailed_schema"] = detailedSchema
+
+	return filtered
+}
 
-	// Organize foreign keys by table
-	fksByTable := make(map[string][]map[string]interface{})
-	for _, fk := range foreignKeys {
-		if tableName, ok := fk["table_name"].(string); ok {
-			fksByTable[tableName] = append(fksByTable[tableName], fk)
+// groupEnumValues organizes getEnumValues rows by enum type name. MySQL enums are defined
+// inline per column and are keyed by table.column.
+func groupEnumValues(enumValues []map[string]interface{}) map[string][]string {
+	enumsByType := make(map[string][]string)
+	for _, enum := range enumValues {
+		if enumName, ok := enum["enum_name"].(string); ok {
+			if enumValue, ok := enum["enum_value"].(string); ok {
+				enumsByType[enumName] = append(enumsByType[enumName], enumValue)
+			} else if definition, ok := enum["enum_definition"].(string); ok {
+				tableName, _ := enum["table_name"].(string)
+				enumsByType[tableName+"."+enumName] = parseMySQLEnumDefinition(definition)
+			}
 		}
 	}
-	
-	// Add foreign keys to each table's detailed schema
-	for tableName, tableSchema := range detailedSchema {
-		if schema, ok := tableSchema.(map[string]interface{}); ok {
-			schema["foreign_keys"] = fksByTable[tableName]
+	return enumsByType
+}
+
+// attachEnumValues adds enum_values to the enum columns of a table. PostgreSQL enums are
+// named types looked up in enumsByType; MySQL enums are parsed from the column's own
+// definition (column_type, or Type from SHOW COLUMNS).
+func attachEnumValues(columns []map[string]interface{}, enumsByType map[string][]string) {
+	for i, column := range columns {
+		dataType, _ := column["data_type"].(string)
+		udtName, hasUdtName := column["udt_name"].(string)
+
+		// For USER-DEFINED types, use the udt_name to look up enum values
+		if dataType == "USER-DEFINED" && hasUdtName {
+			if enumVals, exists := enumsByType[udtName]; exists {
+				columns[i]["enum_values"] = enumVals
+				columns[i]["enum_type"] = udtName
+			}
+			continue
+		}
+
+		// MySQL inline enums
+		for _, key := range []string{"column_type", "Type"} {
+			if definition, ok := column[key].(string); ok && isMySQLEnumDefinition(definition) {
+				columns[i]["enum_values"] = parseMySQLEnumDefinition(definition)
+				break
+			}
+		}
+		if _, attached := columns[i]["enum_values"]; attached {
+			continue
+		}
+
+		// For other types, check by data_type
+		if enumVals, exists := enumsByType[dataType]; exists {
+			columns[i]["enum_values"] = enumVals
 		}
 	}
+}
 
-	return map[string]interface{}{
-		"tables":          tablesSlice,
-		"detailed_schema": detailedSchema,
-		"foreign_keys":    foreignKeys,
-		"enum_types":      enumsByType,
-		"enum_values":     enumValues,
-	}, nil
+// isMySQLEnumDefinition reports whether a MySQL column type is an enum, e.g. enum('a','b')
+func isMySQLEnumDefinition(definition string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(definition)), "enum(")
+}
+
+// parseMySQLEnumDefinition returns the values of a MySQL enum column type such as
+// enum('active','it''s',"x,y"), unescaping doubled and backslash-escaped quotes
+func parseMySQLEnumDefinition(definition string) []string {
+	definition = strings.TrimSpace(definition)
+	open := strings.Index(definition, "(")
+	closing := strings.LastIndex(definition, ")")
+	if !isMySQLEnumDefinition(definition) || open < 0 || closing <= open {
+		return []string{}
+	}
+	body := definition[open+1 : closing]
+
+	values := []string{}
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if quote == 0 {
+			if ch == '\'' || ch == '"' {
+				quote = ch
+				current.Reset()
+			}
+			continue
+		}
+		switch {
+		case ch == '\\' && i+1 < len(body):
+			i++
+			current.WriteByte(body[i])
+		case ch == quote && i+1 < len(body) && body[i+1] == quote:
+			i++
+			current.WriteByte(quote)
+		case ch == quote:
+			values = append(values, current.String())
+			quote = 0
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	return values
 }
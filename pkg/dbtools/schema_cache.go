@@ -1,19 +1,25 @@
 package dbtools
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/FreePeak/infra-mcp-server/pkg/logger"
 )
 
-// SchemaCache provides a thread-safe cache for database schema information
+// SchemaCache provides a thread-safe cache for database schema information.
+// When dir is set, schemas are also written to <dir>/<dbID>.json so they survive restarts.
 type SchemaCache struct {
 	mu      sync.RWMutex
 	entries map[string]*schemaCacheEntry
 	ttl     time.Duration
+	dir     string
 }
 
 // schemaCacheEntry holds a cached schema with timestamp
@@ -22,17 +28,41 @@ type schemaCacheEntry struct {
 	timestamp time.Time
 }
 
+// schemaSnapshot is the on-disk format of a cached schema
+type schemaSnapshot struct {
+	DatabaseID string      `json:"database_id"`
+	Timestamp  time.Time   `json:"timestamp"`
+	Schema     interface{} `json:"schema"`
+}
+
 // Global schema cache instance
 var schemaCache *SchemaCache
 
 // InitSchemaCache initializes the schema cache with the configured TTL
 func InitSchemaCache() {
 	ttl := getSchemaCacheTTL()
-	schemaCache = &SchemaCache{
+	schemaCache = newSchemaCache(ttl, os.Getenv("SCHEMA_CACHE_DIR"))
+	if schemaCache.dir != "" {
+		logger.Info("Schema cache initialized with TTL: %v, persisted to %s", ttl, schemaCache.dir)
+		schemaCache.LoadFromDisk()
+		return
+	}
+	logger.Info("Schema cache initialized with TTL: %v", ttl)
+}
+
+// newSchemaCache creates a schema cache, persisted to dir when it is not empty
+func newSchemaCache(ttl time.Duration, dir string) *SchemaCache {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			logger.Warn("Failed to create schema cache directory %s, using memory only: %v", dir, err)
+			dir = ""
+		}
+	}
+	return &SchemaCache{
 		entries: make(map[string]*schemaCacheEntry),
 		ttl:     ttl,
+		dir:     dir,
 	}
-	logger.Info("Schema cache initialized with TTL: %v", ttl)
 }
 
 // GetSchemaCache returns the global schema cache instance
@@ -43,14 +73,20 @@ func GetSchemaCache() *SchemaCache {
 	return schemaCache
 }
 
-// Get retrieves a cached schema if it exists and hasn't expired
+// Get retrieves a cached schema if it exists and hasn't expired. On a memory miss it
+// falls back to the on-disk snapshot, if any.
 func (c *SchemaCache) Get(dbID string) (interface{}, bool) {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	entry, exists := c.entries[dbID]
+	c.mu.RUnlock()
+
 	if !exists {
-		return nil, false
+		if entry = c.readSnapshot(dbID); entry == nil {
+			return nil, false
+		}
+		c.mu.Lock()
+		c.entries[dbID] = entry
+		c.mu.Unlock()
 	}
 
 	// Check if entry has expired
@@ -62,17 +98,40 @@ func (c *SchemaCache) Get(dbID string) (interface{}, bool) {
 	return entry.schema, true
 }
 
+// GetEntry retrieves a cached schema and the time it was cached, even if the entry
+// has expired but not yet been cleaned up, falling back to the on-disk snapshot
+func (c *SchemaCache) GetEntry(dbID string) (interface{}, time.Time, bool) {
+	c.mu.RLock()
+	entry, exists := c.entries[dbID]
+	c.mu.RUnlock()
+
+	if !exists {
+		if entry = c.readSnapshot(dbID); entry == nil {
+			return nil, time.Time{}, false
+		}
+	}
+
+	return entry.schema, entry.timestamp, true
+}
+
 // Set stores a schema in the cache
 func (c *SchemaCache) Set(dbID string, schema interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.entries[dbID] = &schemaCacheEntry{
+	entry := &schemaCacheEntry{
 		schema:    schema,
 		timestamp: time.Now(),
 	}
-	
+	c.entries[dbID] = entry
+
 	logger.Debug("Schema cached for database: %s", dbID)
+
+	if c.dir != "" {
+		if err := c.writeSnapshot(dbID, entry); err != nil {
+			logger.Warn("Failed to persist schema cache for database %s: %v", dbID, err)
+		}
+	}
 }
 
 // Invalidate removes a schema from the cache
@@ -81,6 +140,11 @@ func (c *SchemaCache) Invalidate(dbID string) {
 	defer c.mu.Unlock()
 
 	delete(c.entries, dbID)
+	if c.dir != "" {
+		if err := os.Remove(c.snapshotPath(dbID)); err != nil && !os.IsNotExist(err) {
+			logger.Warn("Failed to remove schema cache file for database %s: %v", dbID, err)
+		}
+	}
 	logger.Debug("Schema cache invalidated for database: %s", dbID)
 }
 
@@ -90,9 +154,170 @@ func (c *SchemaCache) InvalidateAll() {
 	defer c.mu.Unlock()
 
 	c.entries = make(map[string]*schemaCacheEntry)
+	if c.dir != "" {
+		files, _ := filepath.Glob(filepath.Join(c.dir, "*.json"))
+		for _, file := range files {
+			if err := os.Remove(file); err != nil {
+				logger.Warn("Failed to remove schema cache file %s: %v", file, err)
+			}
+		}
+	}
 	logger.Info("Schema cache cleared")
 }
 
+// LoadFromDisk warms the in-memory cache from the snapshots in the cache directory.
+// Expired, corrupt and unreadable snapshots are skipped.
+func (c *SchemaCache) LoadFromDisk() {
+	if c.dir == "" {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		logger.Warn("Failed to list schema cache directory %s: %v", c.dir, err)
+		return
+	}
+
+	loaded := 0
+	for _, file := range files {
+		entry, dbID := c.readSnapshotFile(file)
+		if entry == nil || time.Since(entry.timestamp) > c.ttl {
+			continue
+		}
+		c.mu.Lock()
+		c.entries[dbID] = entry
+		c.mu.Unlock()
+		loaded++
+	}
+
+	if loaded > 0 {
+		logger.Info("Loaded %d schema(s) from cache directory %s", loaded, c.dir)
+	}
+}
+
+// snapshotPath returns the snapshot file of a database, with path separators in the ID replaced
+func (c *SchemaCache) snapshotPath(dbID string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(dbID)
+	return filepath.Join(c.dir, name+".json")
+}
+
+// writeSnapshot writes an entry to disk through a temporary file, so a crash never
+// leaves a partially written snapshot behind
+func (c *SchemaCache) writeSnapshot(dbID string, entry *schemaCacheEntry) error {
+	data, err := json.Marshal(schemaSnapshot{
+		DatabaseID: dbID,
+		Timestamp:  entry.timestamp,
+		Schema:     entry.schema,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize schema: %w", err)
+	}
+
+	path := c.snapshotPath(dbID)
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace schema file: %w", err)
+	}
+	return nil
+}
+
+// readSnapshot reads the snapshot of a database, returning nil if there is none
+func (c *SchemaCache) readSnapshot(dbID string) *schemaCacheEntry {
+	if c.dir == "" {
+		return nil
+	}
+	entry, snapshotID := c.readSnapshotFile(c.snapshotPath(dbID))
+	// IDs differing only in replaced path separators share a file
+	if snapshotID != dbID {
+		return nil
+	}
+	return entry
+}
+
+// readSnapshotFile reads a snapshot file and the database ID it belongs to. Unreadable
+// or corrupt files are logged and treated as a miss.
+func (c *SchemaCache) readSnapshotFile(path string) (*schemaCacheEntry, string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warn("Failed to read schema cache file %s: %v", path, err)
+		}
+		return nil, ""
+	}
+
+	var snapshot schemaSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		logger.Warn("Ignoring corrupt schema cache file %s: %v", path, err)
+		return nil, ""
+	}
+	if snapshot.DatabaseID == "" || snapshot.Schema == nil {
+		logger.Warn("Ignoring incomplete schema cache file %s", path)
+		return nil, ""
+	}
+
+	return &schemaCacheEntry{
+		schema:    normalizeSnapshotValue(snapshot.Schema),
+		timestamp: snapshot.Timestamp,
+	}, snapshot.DatabaseID
+}
+
+// normalizeSnapshotValue restores the concrete types getFullSchema produces, which JSON
+// decoding loses: arrays of objects become []map[string]interface{}, arrays of strings
+// []string, and objects whose values are all string arrays map[string][]string.
+func normalizeSnapshotValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		allStrings := len(v) > 0
+		for key, item := range v {
+			v[key] = normalizeSnapshotValue(item)
+			if _, ok := v[key].([]string); !ok {
+				allStrings = false
+			}
+		}
+		if allStrings {
+			m := make(map[string][]string, len(v))
+			for key, item := range v {
+				m[key] = item.([]string)
+			}
+			return m
+		}
+		return v
+	case []interface{}:
+		maps := make([]map[string]interface{}, 0, len(v))
+		strs := make([]string, 0, len(v))
+		for i, item := range v {
+			v[i] = normalizeSnapshotValue(item)
+			switch typed := v[i].(type) {
+			case map[string]interface{}:
+				maps = append(maps, typed)
+			case string:
+				strs = append(strs, typed)
+			}
+		}
+		switch {
+		case len(maps) == len(v):
+			return maps
+		case len(strs) == len(v):
+			return strs
+		}
+		return v
+	}
+	return value
+}
+
 // CleanupExpired removes expired entries from the cache
 func (c *SchemaCache) CleanupExpired() {
 	c.mu.Lock()
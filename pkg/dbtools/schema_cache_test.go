@@ -0,0 +1,97 @@
+package dbtools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"tables": []map[string]interface{}{{"table_name": "users"}},
+		"detailed_schema": map[string]interface{}{
+			"users": map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"column_name": "status", "data_type": "USER-DEFINED", "enum_values": []string{"active", "banned"}},
+				},
+			},
+		},
+		"enum_types": map[string][]string{"user_status": {"active", "banned"}},
+	}
+}
+
+func TestSchemaCachePersistsAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := newSchemaCache(time.Minute, dir)
+	cache.Set("pg1", testSchema())
+	_, err := os.Stat(filepath.Join(dir, "pg1.json"))
+	assert.NoError(t, err)
+
+	// A new cache over the same directory serves the schema on a cold start
+	restarted := newSchemaCache(time.Minute, dir)
+	cached, ok := restarted.Get("pg1")
+	assert.True(t, ok)
+
+	schema := cached.(map[string]interface{})
+	tables, ok := schema["tables"].([]map[string]interface{})
+	assert.True(t, ok)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, map[string][]string{"user_status": {"active", "banned"}}, schema["enum_types"])
+
+	columns := schema["detailed_schema"].(map[string]interface{})["users"].(map[string]interface{})["columns"].([]map[string]interface{})
+	assert.Equal(t, []string{"active", "banned"}, columns[0]["enum_values"])
+}
+
+func TestSchemaCacheLoadFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	newSchemaCache(time.Minute, dir).Set("pg1", testSchema())
+
+	restarted := newSchemaCache(time.Minute, dir)
+	restarted.LoadFromDisk()
+
+	_, _, ok := restarted.GetEntry("pg1")
+	assert.True(t, ok)
+	assert.Len(t, restarted.entries, 1)
+}
+
+func TestSchemaCacheDiskRespectsTTL(t *testing.T) {
+	dir := t.TempDir()
+	newSchemaCache(time.Minute, dir).Set("pg1", testSchema())
+
+	restarted := newSchemaCache(time.Nanosecond, dir)
+	time.Sleep(time.Millisecond)
+	restarted.LoadFromDisk()
+
+	assert.Empty(t, restarted.entries)
+	_, ok := restarted.Get("pg1")
+	assert.False(t, ok)
+}
+
+func TestSchemaCacheCorruptFileIsMiss(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pg1.json"), []byte("{not json"), 0o600))
+
+	cache := newSchemaCache(time.Minute, dir)
+	cache.LoadFromDisk()
+
+	_, ok := cache.Get("pg1")
+	assert.False(t, ok)
+	assert.Empty(t, cache.entries)
+}
+
+func TestSchemaCacheInvalidateRemovesFile(t *testing.T) {
+	dir := t.TempDir()
+	cache := newSchemaCache(time.Minute, dir)
+	cache.Set("pg1", testSchema())
+
+	cache.Invalidate("pg1")
+
+	_, err := os.Stat(filepath.Join(dir, "pg1.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, ok := newSchemaCache(time.Minute, dir).Get("pg1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,314 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// mysqlRawDefault matches MySQL column defaults that are expressions or numbers rather
+// than string literals, which information_schema reports unquoted
+var mysqlRawDefault = regexp.MustCompile(`(?i)^(-?[0-9.]+|NULL|CURRENT_TIMESTAMP(\(\d*\))?|NOW\(\d*\)|\(.*\))$`)
+
+// createSchemaDDLTool creates a tool for exporting a database schema as CREATE statements
+func createSchemaDDLTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbSchemaDDL",
+		Description: "Render the discovered schema of a database as CREATE TABLE statements (with enum types, keys and foreign keys) in the database's dialect",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID whose schema should be exported",
+				},
+				"refresh": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Fetch the schema from the database instead of the schema cache (default: false)",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: the database's schema_timeout)",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handleSchemaDDL,
+	}
+}
+
+// handleSchemaDDL handles the schema DDL tool execution
+func handleSchemaDDL(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+	refresh, _ := getBoolParam(params, "refresh")
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
+	defer cancel()
+
+	schema, err := getCachedFullSchema(timeoutCtx, db, databaseID, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get full schema: %w", err)
+	}
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid schema format")
+	}
+
+	return map[string]interface{}{
+		"database": databaseID,
+		"driver":   db.DriverName(),
+		"ddl":      RenderDDL(schemaMap, db.DriverName()),
+	}, nil
+}
+
+// RenderDDL renders a schema in the format returned by getFullSchema as CREATE
+// statements for driverName. Tables are ordered so that referenced tables come first;
+// foreign keys to tables created later (reference cycles) are added by ALTER TABLE
+// statements at the end, except on SQLite, which accepts forward references. PostgreSQL
+// enum types are created before the first table that uses them.
+func RenderDDL(schema map[string]interface{}, driverName string) string {
+	detailed, _ := schema["detailed_schema"].(map[string]interface{})
+	enumTypes, _ := schema["enum_types"].(map[string][]string)
+
+	tables := make(map[string]map[string]interface{}, len(detailed))
+	for name, tableSchema := range detailed {
+		if tableMap, ok := tableSchema.(map[string]interface{}); ok {
+			tables[name] = tableMap
+		}
+	}
+
+	var statements, deferred []string
+	created := make(map[string]bool, len(tables))
+	createdTypes := make(map[string]bool)
+
+	for _, table := range sortTablesByDependency(tables) {
+		columns, _ := tables[table]["columns"].([]map[string]interface{})
+
+		if driverName == "postgres" {
+			for _, column := range columns {
+				typeName := columnAttribute(column, "udt_name")
+				values, isEnum := enumTypes[typeName]
+				if columnAttribute(column, "data_type") != "USER-DEFINED" || !isEnum || createdTypes[typeName] {
+					continue
+				}
+				statements = append(statements, renderEnumType(typeName, values))
+				createdTypes[typeName] = true
+			}
+		}
+
+		lines := make([]string, 0, len(columns)+4)
+		for _, column := range columns {
+			lines = append(lines, renderColumn(column, driverName))
+		}
+
+		if keyColumns := primaryKeyColumns(tables[table]); len(keyColumns) > 0 {
+			lines = append(lines, "PRIMARY KEY ("+quoteIdentifierList(driverName, keyColumns)+")")
+		}
+
+		uniques, _ := tables[table]["unique_constraints"].([]map[string]interface{})
+		for _, unique := range uniques {
+			if columnAttribute(unique, "constraint_type") != "UNIQUE" {
+				continue
+			}
+			clause := "UNIQUE (" + quoteIdentifierList(driverName, splitColumnNames(columnAttribute(unique, "column_names"))) + ")"
+			lines = append(lines, namedConstraint(driverName, columnAttribute(unique, "constraint_name"), clause))
+		}
+
+		for _, fk := range groupForeignKeys(tables[table]) {
+			clause := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+				quoteIdentifierList(driverName, fk.columns),
+				quoteIdentifier(driverName, fk.foreignTable),
+				quoteIdentifierList(driverName, fk.foreignColumns))
+			constraint := namedConstraint(driverName, fk.name, clause)
+
+			if created[fk.foreignTable] || fk.foreignTable == table || driverName == "sqlite" {
+				lines = append(lines, constraint)
+			} else {
+				deferred = append(deferred, fmt.Sprintf("ALTER TABLE %s ADD %s;", quoteIdentifier(driverName, table), constraint))
+			}
+		}
+
+		statements = append(statements, fmt.Sprintf("CREATE TABLE %s (\n  %s\n);", quoteIdentifier(driverName, table), strings.Join(lines, ",\n  ")))
+		created[table] = true
+	}
+
+	return strings.Join(append(statements, deferred...), "\n\n")
+}
+
+// sortTablesByDependency orders tables so that every table follows the tables its
+// foreign keys reference, breaking ties and cycles alphabetically
+func sortTablesByDependency(tables map[string]map[string]interface{}) []string {
+	dependencies := make(map[string]map[string]bool, len(tables))
+	for name, table := range tables {
+		dependencies[name] = make(map[string]bool)
+		for _, fk := range groupForeignKeys(table) {
+			if _, known := tables[fk.foreignTable]; known && fk.foreignTable != name {
+				dependencies[name][fk.foreignTable] = true
+			}
+		}
+	}
+
+	remaining := sortedKeys(tables)
+	ordered := make([]string, 0, len(remaining))
+	placed := make(map[string]bool, len(remaining))
+	for len(remaining) > 0 {
+		next := -1
+		for i, name := range remaining {
+			ready := true
+			for dependency := range dependencies[name] {
+				if !placed[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Only cycles are left; take the first table and defer its foreign keys
+			next = 0
+		}
+
+		placed[remaining[next]] = true
+		ordered = append(ordered, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+
+	return ordered
+}
+
+// ddlForeignKey is a foreign key constraint, possibly spanning several columns
+type ddlForeignKey struct {
+	name           string
+	columns        []string
+	foreignTable   string
+	foreignColumns []string
+}
+
+// groupForeignKeys collects the per-column foreign key rows of a table into constraints,
+// in the order they were returned
+func groupForeignKeys(table map[string]interface{}) []*ddlForeignKey {
+	rows, _ := table["foreign_keys"].([]map[string]interface{})
+
+	var keys []*ddlForeignKey
+	byName := make(map[string]*ddlForeignKey)
+	for _, row := range rows {
+		name := columnAttribute(row, "constraint_name")
+		fk, ok := byName[name]
+		if !ok || name == "" {
+			fk = &ddlForeignKey{name: name, foreignTable: columnAttribute(row, "foreign_table_name")}
+			keys = append(keys, fk)
+			byName[name] = fk
+		}
+		fk.columns = append(fk.columns, columnAttribute(row, "column_name"))
+		fk.foreignColumns = append(fk.foreignColumns, columnAttribute(row, "foreign_column_name"))
+	}
+
+	return keys
+}
+
+// primaryKeyColumns returns the primary key columns of a table in key order
+func primaryKeyColumns(table map[string]interface{}) []string {
+	keys, _ := table["primary_keys"].([]map[string]interface{})
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if name := columnAttribute(key, "column_name"); name != "" {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// renderColumn renders one column definition
+func renderColumn(column map[string]interface{}, driverName string) string {
+	definition := quoteIdentifier(driverName, columnAttribute(column, "column_name")) + " " + columnType(column, driverName)
+	if strings.EqualFold(columnAttribute(column, "is_nullable"), "NO") {
+		definition += " NOT NULL"
+	}
+	if def := columnAttribute(column, "column_default"); def != "" {
+		if driverName == "mysql" && !mysqlRawDefault.MatchString(def) {
+			def = "'" + strings.ReplaceAll(def, "'", "''") + "'"
+		}
+		definition += " DEFAULT " + def
+	}
+	return definition
+}
+
+// columnType returns the type of a column as written in DDL
+func columnType(column map[string]interface{}, driverName string) string {
+	dataType := columnAttribute(column, "data_type")
+	switch driverName {
+	case "mysql":
+		// column_type carries lengths and inline enum definitions, e.g. varchar(255)
+		if full := columnAttribute(column, "column_type"); full != "" {
+			return full
+		}
+	case "postgres":
+		udtName := columnAttribute(column, "udt_name")
+		if dataType == "USER-DEFINED" && udtName != "" {
+			return quoteIdentifier(driverName, udtName)
+		}
+		if dataType == "ARRAY" && strings.HasPrefix(udtName, "_") {
+			return udtName[1:] + "[]"
+		}
+	}
+	return dataType
+}
+
+// renderEnumType renders a PostgreSQL CREATE TYPE statement for an enum
+func renderEnumType(name string, values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+	}
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", quoteIdentifier("postgres", name), strings.Join(quoted, ", "))
+}
+
+// namedConstraint prefixes a constraint clause with its name, leaving out names the
+// database generated itself
+func namedConstraint(driverName string, name string, clause string) string {
+	if name == "" || strings.HasPrefix(name, "sqlite_autoindex_") {
+		return clause
+	}
+	return "CONSTRAINT " + quoteIdentifier(driverName, name) + " " + clause
+}
+
+// quoteIdentifierList quotes each name and joins them with commas
+func quoteIdentifierList(driverName string, names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, quoteIdentifier(driverName, name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// splitColumnNames splits an aggregated column list such as "a, b" or "a,b"
+func splitColumnNames(list string) []string {
+	parts := strings.Split(list, ",")
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if name := strings.TrimSpace(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,126 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ddlTestSchema is a two-table schema where orders references users and an enum type
+func ddlTestSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"detailed_schema": map[string]interface{}{
+			"orders": map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": "nextval('orders_id_seq'::regclass)"},
+					{"column_name": "user_id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+					{"column_name": "status", "data_type": "USER-DEFINED", "udt_name": "order_status", "column_type": "enum('new','paid')", "is_nullable": "NO", "column_default": "'new'::order_status"},
+				},
+				"primary_keys": []map[string]interface{}{{"column_name": "id", "constraint_name": "orders_pkey"}},
+				"unique_constraints": []map[string]interface{}{
+					{"constraint_name": "orders_pkey", "constraint_type": "PRIMARY KEY", "column_names": "id"},
+				},
+				"foreign_keys": []map[string]interface{}{
+					{"constraint_name": "orders_user_id_fkey", "table_name": "orders", "column_name": "user_id", "foreign_table_name": "users", "foreign_column_name": "id"},
+				},
+			},
+			"users": map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"column_name": "id", "data_type": "integer", "column_type": "int", "is_nullable": "NO", "column_default": nil},
+					{"column_name": "email", "data_type": "text", "column_type": "varchar(255)", "is_nullable": "NO", "column_default": nil},
+					{"column_name": "nickname", "data_type": "text", "column_type": "varchar(64)", "is_nullable": "YES", "column_default": nil},
+				},
+				"primary_keys": []map[string]interface{}{{"column_name": "id", "constraint_name": "users_pkey"}},
+				"unique_constraints": []map[string]interface{}{
+					{"constraint_name": "users_email_key", "constraint_type": "UNIQUE", "column_names": "email"},
+				},
+				"foreign_keys": []map[string]interface{}(nil),
+			},
+		},
+		"enum_types": map[string][]string{"order_status": {"new", "paid"}},
+	}
+}
+
+func TestRenderDDLPostgres(t *testing.T) {
+	ddl := RenderDDL(ddlTestSchema(), "postgres")
+
+	assert.Equal(t, `CREATE TABLE "users" (
+  "id" integer NOT NULL,
+  "email" text NOT NULL,
+  "nickname" text,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "users_email_key" UNIQUE ("email")
+);
+
+CREATE TYPE "order_status" AS ENUM ('new', 'paid');
+
+CREATE TABLE "orders" (
+  "id" integer NOT NULL DEFAULT nextval('orders_id_seq'::regclass),
+  "user_id" integer NOT NULL,
+  "status" "order_status" NOT NULL DEFAULT 'new'::order_status,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "orders_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+);`, ddl)
+}
+
+func TestRenderDDLMySQL(t *testing.T) {
+	schema := ddlTestSchema()
+	orders := schema["detailed_schema"].(map[string]interface{})["orders"].(map[string]interface{})
+	orders["columns"] = []map[string]interface{}{
+		{"column_name": "id", "data_type": "int", "column_type": "int", "is_nullable": "NO", "column_default": nil},
+		{"column_name": "user_id", "data_type": "int", "column_type": "int", "is_nullable": "NO", "column_default": "0"},
+		{"column_name": "status", "data_type": "enum", "column_type": "enum('new','paid')", "is_nullable": "NO", "column_default": "new"},
+	}
+
+	ddl := RenderDDL(schema, "mysql")
+
+	assert.Equal(t, "CREATE TABLE `users` (\n"+
+		"  `id` int NOT NULL,\n"+
+		"  `email` varchar(255) NOT NULL,\n"+
+		"  `nickname` varchar(64),\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  CONSTRAINT `users_email_key` UNIQUE (`email`)\n"+
+		");\n\n"+
+		"CREATE TABLE `orders` (\n"+
+		"  `id` int NOT NULL,\n"+
+		"  `user_id` int NOT NULL DEFAULT 0,\n"+
+		"  `status` enum('new','paid') NOT NULL DEFAULT 'new',\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  CONSTRAINT `orders_user_id_fkey` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)\n"+
+		");", ddl)
+}
+
+func TestRenderDDLDefersCyclicForeignKeys(t *testing.T) {
+	table := func(fkColumn, references string) map[string]interface{} {
+		return map[string]interface{}{
+			"columns": []map[string]interface{}{
+				{"column_name": "id", "data_type": "integer", "is_nullable": "NO"},
+				{"column_name": fkColumn, "data_type": "integer", "is_nullable": "YES"},
+			},
+			"foreign_keys": []map[string]interface{}{
+				{"constraint_name": fkColumn + "_fkey", "column_name": fkColumn, "foreign_table_name": references, "foreign_column_name": "id"},
+			},
+		}
+	}
+	schema := map[string]interface{}{
+		"detailed_schema": map[string]interface{}{
+			"departments": table("manager_id", "employees"),
+			"employees":   table("department_id", "departments"),
+		},
+	}
+
+	ddl := RenderDDL(schema, "postgres")
+
+	assert.Equal(t, `CREATE TABLE "departments" (
+  "id" integer NOT NULL,
+  "manager_id" integer
+);
+
+CREATE TABLE "employees" (
+  "id" integer NOT NULL,
+  "department_id" integer,
+  CONSTRAINT "department_id_fkey" FOREIGN KEY ("department_id") REFERENCES "departments" ("id")
+);
+
+ALTER TABLE "departments" ADD CONSTRAINT "manager_id_fkey" FOREIGN KEY ("manager_id") REFERENCES "employees" ("id");`, ddl)
+}
@@ -0,0 +1,174 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// AttributeDifference holds the differing values of one column attribute
+type AttributeDifference struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// ColumnDifference describes a column present in both schemas with differing attributes
+type ColumnDifference struct {
+	Column      string                         `json:"column"`
+	Differences map[string]AttributeDifference `json:"differences"`
+}
+
+// TableDiff is the column-level difference of a table present in both schemas
+type TableDiff struct {
+	ColumnsOnlyInSource []string           `json:"columns_only_in_source"`
+	ColumnsOnlyInTarget []string           `json:"columns_only_in_target"`
+	ColumnsChanged      []ColumnDifference `json:"columns_changed"`
+}
+
+// SchemaDiff is the difference between the schemas of two databases. Tables holds only
+// the tables present in both schemas whose columns differ.
+type SchemaDiff struct {
+	TablesOnlyInSource []string              `json:"tables_only_in_source"`
+	TablesOnlyInTarget []string              `json:"tables_only_in_target"`
+	Tables             map[string]*TableDiff `json:"tables"`
+}
+
+// HasDifferences reports whether the schemas differ
+func (d *SchemaDiff) HasDifferences() bool {
+	return len(d.TablesOnlyInSource) > 0 || len(d.TablesOnlyInTarget) > 0 || len(d.Tables) > 0
+}
+
+// DiffSchemas compares two schemas in the format returned by getFullSchema, grouping
+// column differences by table. Columns are compared on the same attributes as the
+// drift check (data_type, is_nullable and column_default).
+func DiffSchemas(source, target map[string]interface{}) *SchemaDiff {
+	drift := diffSchemas(source, target)
+
+	diff := &SchemaDiff{
+		TablesOnlyInSource: drift.TablesRemoved,
+		TablesOnlyInTarget: drift.TablesAdded,
+		Tables:             make(map[string]*TableDiff),
+	}
+
+	table := func(name string) *TableDiff {
+		if _, ok := diff.Tables[name]; !ok {
+			diff.Tables[name] = &TableDiff{
+				ColumnsOnlyInSource: []string{},
+				ColumnsOnlyInTarget: []string{},
+				ColumnsChanged:      []ColumnDifference{},
+			}
+		}
+		return diff.Tables[name]
+	}
+
+	for _, ref := range drift.ColumnsRemoved {
+		t := table(ref.Table)
+		t.ColumnsOnlyInSource = append(t.ColumnsOnlyInSource, ref.Column)
+	}
+	for _, ref := range drift.ColumnsAdded {
+		t := table(ref.Table)
+		t.ColumnsOnlyInTarget = append(t.ColumnsOnlyInTarget, ref.Column)
+	}
+	for _, change := range drift.ColumnsAltered {
+		differences := make(map[string]AttributeDifference, len(change.Changes))
+		for attr, values := range change.Changes {
+			differences[attr] = AttributeDifference{Source: values["before"], Target: values["after"]}
+		}
+		t := table(change.Table)
+		t.ColumnsChanged = append(t.ColumnsChanged, ColumnDifference{Column: change.Column, Differences: differences})
+	}
+
+	return diff
+}
+
+// createSchemaDiffTool creates a tool for comparing the schemas of two configured databases
+func createSchemaDiffTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbSchemaDiff",
+		Description: "Compare the schemas of two configured databases, e.g. staging and production, and report tables and columns that differ",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"source_database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to compare from, e.g. staging",
+				},
+				"target_database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to compare against, e.g. production",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: each database's schema_timeout)",
+				},
+			},
+			Required: []string{"source_database", "target_database"},
+		},
+		Handler: handleSchemaDiff,
+	}
+}
+
+// handleSchemaDiff handles the schema diff tool execution
+func handleSchemaDiff(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	sourceID, ok := getStringParam(params, "source_database")
+	if !ok {
+		return nil, fmt.Errorf("source_database parameter is required")
+	}
+	targetID, ok := getStringParam(params, "target_database")
+	if !ok {
+		return nil, fmt.Errorf("target_database parameter is required")
+	}
+
+	sourceSchema, sourceDriver, err := fetchColumnSchema(ctx, params, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	targetSchema, targetDriver, err := fetchColumnSchema(ctx, params, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := DiffSchemas(sourceSchema, targetSchema)
+	result := map[string]interface{}{
+		"source_database":     sourceID,
+		"target_database":     targetID,
+		"differences_found":   diff.HasDifferences(),
+		"diff":                diff,
+		"compared_attributes": driftColumnAttributes,
+	}
+	if sourceDriver != targetDriver {
+		result["warning"] = fmt.Sprintf("comparing a %s database with a %s database; data types and defaults are reported in each engine's own terms and will mostly differ", sourceDriver, targetDriver)
+	}
+
+	return result, nil
+}
+
+// fetchColumnSchema fetches the tables and columns of a database in the getFullSchema
+// format; the diff only compares columns, so the other components are not queried
+func fetchColumnSchema(ctx context.Context, params map[string]interface{}, databaseID string) (map[string]interface{}, string, error) {
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get database %s: %w", databaseID, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
+	defer cancel()
+
+	schema, err := getSchemaComponents(timeoutCtx, db, map[string]bool{"columns": true})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get schema of %s: %w", databaseID, err)
+	}
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("invalid schema format for %s", databaseID)
+	}
+
+	return schemaMap, db.DriverName(), nil
+}
@@ -0,0 +1,81 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSchemasBetweenDatabases(t *testing.T) {
+	staging := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "email", "data_type": "varchar", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "last_login", "data_type": "timestamp", "is_nullable": "YES", "column_default": nil},
+		},
+		"orders": {
+			{"column_name": "id", "data_type": "bigint", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "total", "data_type": "numeric", "is_nullable": "NO", "column_default": "0"},
+		},
+		"feature_flags": {
+			{"column_name": "name", "data_type": "text", "is_nullable": "NO", "column_default": nil},
+		},
+		"countries": {
+			{"column_name": "code", "data_type": "char", "is_nullable": "NO", "column_default": nil},
+		},
+	})
+	production := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "email", "data_type": "varchar", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "nickname", "data_type": "text", "is_nullable": "YES", "column_default": nil},
+		},
+		"orders": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "total", "data_type": "numeric", "is_nullable": "NO", "column_default": "0"},
+		},
+		"legacy_sessions": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+		},
+		"countries": {
+			{"column_name": "code", "data_type": "char", "is_nullable": "NO", "column_default": nil},
+		},
+	})
+
+	diff := DiffSchemas(staging, production)
+
+	assert.True(t, diff.HasDifferences())
+	assert.Equal(t, []string{"feature_flags"}, diff.TablesOnlyInSource)
+	assert.Equal(t, []string{"legacy_sessions"}, diff.TablesOnlyInTarget)
+	assert.Len(t, diff.Tables, 2)
+
+	users := diff.Tables["users"]
+	assert.Equal(t, []string{"last_login"}, users.ColumnsOnlyInSource)
+	assert.Equal(t, []string{"nickname"}, users.ColumnsOnlyInTarget)
+	assert.Empty(t, users.ColumnsChanged)
+
+	orders := diff.Tables["orders"]
+	assert.Empty(t, orders.ColumnsOnlyInSource)
+	assert.Empty(t, orders.ColumnsOnlyInTarget)
+	assert.Equal(t, []ColumnDifference{{
+		Column:      "id",
+		Differences: map[string]AttributeDifference{"data_type": {Source: "bigint", Target: "integer"}},
+	}}, orders.ColumnsChanged)
+
+	// Identical tables are left out
+	_, ok := diff.Tables["countries"]
+	assert.False(t, ok)
+}
+
+func TestDiffSchemasIdentical(t *testing.T) {
+	schema := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil}},
+	})
+
+	diff := DiffSchemas(schema, schema)
+
+	assert.False(t, diff.HasDifferences())
+	assert.Empty(t, diff.TablesOnlyInSource)
+	assert.Empty(t, diff.TablesOnlyInTarget)
+	assert.Empty(t, diff.Tables)
+}
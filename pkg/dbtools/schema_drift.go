@@ -0,0 +1,247 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// driftColumnAttributes are the column attributes compared when checking for altered columns
+var driftColumnAttributes = []string{"data_type", "is_nullable", "column_default"}
+
+// ColumnChange describes a column whose definition differs between two schemas
+type ColumnChange struct {
+	Table   string                       `json:"table"`
+	Column  string                       `json:"column"`
+	Changes map[string]map[string]string `json:"changes"`
+}
+
+// ColumnRef identifies a column of a table
+type ColumnRef struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+}
+
+// SchemaDrift is the difference between a cached schema and the live one
+type SchemaDrift struct {
+	TablesAdded    []string       `json:"tables_added"`
+	TablesRemoved  []string       `json:"tables_removed"`
+	ColumnsAdded   []ColumnRef    `json:"columns_added"`
+	ColumnsRemoved []ColumnRef    `json:"columns_removed"`
+	ColumnsAltered []ColumnChange `json:"columns_altered"`
+}
+
+// HasChanges reports whether any drift was found
+func (d *SchemaDrift) HasChanges() bool {
+	return len(d.TablesAdded) > 0 || len(d.TablesRemoved) > 0 ||
+		len(d.ColumnsAdded) > 0 || len(d.ColumnsRemoved) > 0 || len(d.ColumnsAltered) > 0
+}
+
+// createSchemaDriftTool creates a tool for comparing the cached schema with the live database
+func createSchemaDriftTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbSchemaDriftCheck",
+		Description: "Compare the cached schema of a database with a freshly fetched one to detect tables and columns changed out-of-band",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to check",
+				},
+				"refresh_cache": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Replace the cached schema with the live one after comparing (default: true)",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: the database's schema_timeout)",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handleSchemaDriftCheck,
+	}
+}
+
+// handleSchemaDriftCheck handles the schema drift check tool execution
+func handleSchemaDriftCheck(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+	refreshCache := true
+	if refresh, ok := getBoolParam(params, "refresh_cache"); ok {
+		refreshCache = refresh
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	cache := GetSchemaCache()
+	cached, cachedAt, hasBaseline := cache.GetEntry(databaseID)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
+	defer cancel()
+
+	liveSchema, err := getFullSchema(timeoutCtx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get full schema: %w", err)
+	}
+	liveMap, ok := liveSchema.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid schema format")
+	}
+	// Components that failed to load would show up as dropped, so don't compare them
+	if isPartialSchema(liveMap) {
+		return nil, fmt.Errorf("the live schema could not be read completely (failed: %s); retry with a larger timeout", strings.Join(liveMap["failed_components"].([]string), ", "))
+	}
+
+	if !hasBaseline {
+		// Nothing to compare against yet; the live schema becomes the baseline
+		cache.Set(databaseID, liveMap)
+		return map[string]interface{}{
+			"database":       databaseID,
+			"baseline_found": false,
+			"drift_detected": false,
+			"message":        "No cached schema to compare against; the live schema has been cached as the baseline",
+		}, nil
+	}
+
+	cachedMap, ok := cached.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid cached schema format")
+	}
+
+	drift := diffSchemas(cachedMap, liveMap)
+	if refreshCache {
+		cache.Set(databaseID, liveMap)
+	}
+
+	return map[string]interface{}{
+		"database":          databaseID,
+		"baseline_found":    true,
+		"cached_at":         cachedAt.Format(time.RFC3339),
+		"cache_age_seconds": int(time.Since(cachedAt).Seconds()),
+		"drift_detected":    drift.HasChanges(),
+		"diff":              drift,
+		"cache_refreshed":   refreshCache,
+	}, nil
+}
+
+// diffSchemas compares two schemas in the format returned by getFullSchema
+func diffSchemas(cached, live map[string]interface{}) *SchemaDrift {
+	drift := &SchemaDrift{
+		TablesAdded:    []string{},
+		TablesRemoved:  []string{},
+		ColumnsAdded:   []ColumnRef{},
+		ColumnsRemoved: []ColumnRef{},
+		ColumnsAltered: []ColumnChange{},
+	}
+
+	cachedTables := schemaColumns(cached)
+	liveTables := schemaColumns(live)
+
+	for _, table := range sortedKeys(liveTables) {
+		if _, ok := cachedTables[table]; !ok {
+			drift.TablesAdded = append(drift.TablesAdded, table)
+		}
+	}
+
+	for _, table := range sortedKeys(cachedTables) {
+		cachedColumns := cachedTables[table]
+		liveColumns, ok := liveTables[table]
+		if !ok {
+			drift.TablesRemoved = append(drift.TablesRemoved, table)
+			continue
+		}
+
+		for _, column := range sortedKeys(liveColumns) {
+			if _, ok := cachedColumns[column]; !ok {
+				drift.ColumnsAdded = append(drift.ColumnsAdded, ColumnRef{Table: table, Column: column})
+			}
+		}
+
+		for _, column := range sortedKeys(cachedColumns) {
+			liveColumn, ok := liveColumns[column]
+			if !ok {
+				drift.ColumnsRemoved = append(drift.ColumnsRemoved, ColumnRef{Table: table, Column: column})
+				continue
+			}
+			if changes := diffColumn(cachedColumns[column], liveColumn); len(changes) > 0 {
+				drift.ColumnsAltered = append(drift.ColumnsAltered, ColumnChange{Table: table, Column: column, Changes: changes})
+			}
+		}
+	}
+
+	return drift
+}
+
+// diffColumn returns the attributes that differ between two column definitions
+func diffColumn(cached, live map[string]interface{}) map[string]map[string]string {
+	changes := make(map[string]map[string]string)
+	for _, attr := range driftColumnAttributes {
+		before, after := columnAttribute(cached, attr), columnAttribute(live, attr)
+		if before != after {
+			changes[attr] = map[string]string{"before": before, "after": after}
+		}
+	}
+	return changes
+}
+
+// columnAttribute returns a column attribute as a string, empty for NULL
+func columnAttribute(column map[string]interface{}, attr string) string {
+	value, ok := column[attr]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// schemaColumns indexes the columns of each table in a schema by table and column name
+func schemaColumns(schema map[string]interface{}) map[string]map[string]map[string]interface{} {
+	tables := make(map[string]map[string]map[string]interface{})
+
+	detailed, ok := schema["detailed_schema"].(map[string]interface{})
+	if !ok {
+		return tables
+	}
+
+	for tableName, tableSchema := range detailed {
+		columns := make(map[string]map[string]interface{})
+		if tableMap, ok := tableSchema.(map[string]interface{}); ok {
+			if cols, ok := tableMap["columns"].([]map[string]interface{}); ok {
+				for _, col := range cols {
+					if name, ok := col["column_name"].(string); ok {
+						columns[name] = col
+					}
+				}
+			}
+		}
+		tables[tableName] = columns
+	}
+
+	return tables
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func driftTestSchema(tables map[string][]map[string]interface{}) map[string]interface{} {
+	detailed := make(map[string]interface{})
+	for name, columns := range tables {
+		detailed[name] = map[string]interface{}{"columns": columns}
+	}
+	return map[string]interface{}{"detailed_schema": detailed}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	cached := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "nickname", "data_type": "text", "is_nullable": "YES", "column_default": nil},
+		},
+		"orders": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "status", "data_type": "text", "is_nullable": "YES", "column_default": nil},
+		},
+		"legacy": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+		},
+	})
+	live := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "last_login", "data_type": "timestamp", "is_nullable": "YES", "column_default": nil},
+		},
+		"orders": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+			{"column_name": "status", "data_type": "text", "is_nullable": "NO", "column_default": "'new'::text"},
+		},
+		"audit_log": {
+			{"column_name": "id", "data_type": "bigint", "is_nullable": "NO", "column_default": nil},
+		},
+	})
+
+	drift := diffSchemas(cached, live)
+
+	assert.True(t, drift.HasChanges())
+	assert.Equal(t, []string{"audit_log"}, drift.TablesAdded)
+	assert.Equal(t, []string{"legacy"}, drift.TablesRemoved)
+	assert.Equal(t, []ColumnRef{{Table: "users", Column: "last_login"}}, drift.ColumnsAdded)
+	assert.Equal(t, []ColumnRef{{Table: "users", Column: "nickname"}}, drift.ColumnsRemoved)
+	assert.Len(t, drift.ColumnsAltered, 1)
+	assert.Equal(t, "status", drift.ColumnsAltered[0].Column)
+	assert.Equal(t, map[string]map[string]string{
+		"is_nullable":    {"before": "YES", "after": "NO"},
+		"column_default": {"before": "", "after": "'new'::text"},
+	}, drift.ColumnsAltered[0].Changes)
+}
+
+func TestDiffSchemasUnchanged(t *testing.T) {
+	schema := driftTestSchema(map[string][]map[string]interface{}{
+		"users": {
+			{"column_name": "id", "data_type": "integer", "is_nullable": "NO", "column_default": nil},
+		},
+	})
+
+	drift := diffSchemas(schema, schema)
+
+	assert.False(t, drift.HasChanges())
+	assert.Empty(t, drift.ColumnsAltered)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/FreePeak/infra-mcp-server/internal/logger"
 	pkgLogger "github.com/FreePeak/infra-mcp-server/pkg/logger"
+	"github.com/stretchr/testify/assert"
 )
 
 // TestEnumDetectionLive tests enum detection with a real database connection
@@ -178,14 +179,55 @@ func TestEnumDetectionLive(t *testing.T) {
 
 		// Pretty print a sample for debugging
 		jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-		t.Logf("\n\nFull result sample (first 2000 chars):\n%s\n...", string(jsonBytes[:min(2000, len(jsonBytes))]))
+		t.Logf("\n\nFull result sample (first 2000 chars):\n%s\n...", string(jsonBytes[:minInt(2000, len(jsonBytes))]))
 	})
 }
 
-func min(a, b int) int {
+func minInt(a, b int) int {
 	if a < b {
 		return a
 	}
 	return b
 }
 
+// TestAttachEnumValuesMySQL tests that MySQL enum columns get their values from their own definition
+func TestAttachEnumValuesMySQL(t *testing.T) {
+	columns := []map[string]interface{}{
+		{"column_name": "id", "data_type": "int", "column_type": "int unsigned"},
+		{"column_name": "status", "data_type": "enum", "column_type": "enum('active','suspended','it''s')"},
+	}
+
+	attachEnumValues(columns, map[string][]string{})
+
+	assert.NotContains(t, columns[0], "enum_values")
+	assert.Equal(t, []string{"active", "suspended", "it's"}, columns[1]["enum_values"])
+}
+
+// TestAttachEnumValuesShowColumns tests the SHOW COLUMNS fallback, which reports the definition as Type
+func TestAttachEnumValuesShowColumns(t *testing.T) {
+	columns := []map[string]interface{}{
+		{"Field": "size", "Type": "ENUM('s','m','l')"},
+	}
+
+	attachEnumValues(columns, nil)
+
+	assert.Equal(t, []string{"s", "m", "l"}, columns[0]["enum_values"])
+}
+
+// TestAttachEnumValuesPostgres tests that Postgres USER-DEFINED columns still resolve through their type
+func TestAttachEnumValuesPostgres(t *testing.T) {
+	columns := []map[string]interface{}{
+		{"column_name": "status", "data_type": "USER-DEFINED", "udt_name": "user_status"},
+	}
+
+	attachEnumValues(columns, map[string][]string{"user_status": {"active", "banned"}})
+
+	assert.Equal(t, []string{"active", "banned"}, columns[0]["enum_values"])
+	assert.Equal(t, "user_status", columns[0]["enum_type"])
+}
+
+// TestParseMySQLEnumDefinition tests quoting and escaping in MySQL enum definitions
+func TestParseMySQLEnumDefinition(t *testing.T) {
+	assert.Equal(t, []string{"a,b", "c'd", ""}, parseMySQLEnumDefinition(`enum('a,b','c\'d','')`))
+	assert.Equal(t, []string{}, parseMySQLEnumDefinition("varchar(20)"))
+}
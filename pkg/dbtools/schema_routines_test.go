@@ -0,0 +1,52 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresRoutinesQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("postgres").GetRoutinesQueries()
+	assert.Len(t, queries, 2)
+
+	assert.Contains(t, queries[0].query, "information_schema.routines")
+	assert.Contains(t, queries[0].query, "information_schema.parameters")
+	assert.Contains(t, queries[0].query, "AS language")
+
+	// The pg_proc fallback tells procedures from functions by prokind
+	assert.Contains(t, queries[1].query, "pg_catalog.pg_proc")
+	assert.Contains(t, queries[1].query, "WHEN 'p' THEN 'procedure' ELSE 'function'")
+	assert.Contains(t, queries[1].query, "pg_get_function_arguments(p.oid) AS arguments")
+	assert.Contains(t, queries[1].query, "l.lanname AS language")
+}
+
+func TestMySQLRoutinesQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("mysql").GetRoutinesQueries()
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "information_schema.routines")
+	assert.Contains(t, queries[0].query, "r.routine_schema = DATABASE()")
+	assert.Contains(t, queries[0].query, "GROUP_CONCAT(")
+}
+
+func TestSQLServerRoutinesQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("sqlserver").GetRoutinesQueries()
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "INFORMATION_SCHEMA.ROUTINES")
+	assert.Contains(t, queries[0].query, "STRING_AGG(")
+}
+
+func TestGenericRoutinesQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("oracle").GetRoutinesQueries()
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "information_schema.routines")
+}
+
+func TestSQLiteStrategyRoutines(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+
+	result, err := getRoutines(context.Background(), database)
+	assert.NoError(t, err)
+	assert.Empty(t, result.(map[string]interface{})["routines"])
+}
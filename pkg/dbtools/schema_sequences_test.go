@@ -0,0 +1,41 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unreachableMySQLDatabase is an unreachableDatabase reporting the mysql driver
+type unreachableMySQLDatabase struct {
+	unreachableDatabase
+}
+
+func (u *unreachableMySQLDatabase) DriverName() string { return "mysql" }
+
+func TestPostgresSequencesQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("postgres").GetSequencesQueries()
+	assert.Len(t, queries, 2)
+
+	assert.Contains(t, queries[0].query, "FROM pg_catalog.pg_sequences s")
+	assert.Contains(t, queries[0].query, "pg_catalog.pg_depend")
+	for _, column := range []string{"last_value", "increment_by", "min_value", "max_value", "cycle", "owned_by_table", "owned_by_column"} {
+		assert.Contains(t, queries[0].query, column)
+	}
+
+	assert.Contains(t, queries[1].query, "information_schema.sequences")
+}
+
+func TestMySQLSequencesEmpty(t *testing.T) {
+	assert.Empty(t, NewDatabaseStrategy("mysql").GetSequencesQueries())
+
+	database := &unreachableMySQLDatabase{}
+	result, err := getSequences(context.Background(), database)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, database.queries)
+	assert.Equal(t, map[string]interface{}{
+		"sequences": []map[string]interface{}{},
+		"dbType":    "mysql",
+	}, result)
+}
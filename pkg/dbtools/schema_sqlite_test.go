@@ -0,0 +1,81 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+)
+
+// newSQLiteTestDatabase opens an in-memory SQLite database with two related tables
+func newSQLiteTestDatabase(t *testing.T) db.Database {
+	t.Helper()
+
+	database, err := db.NewDatabase(db.Config{Type: "sqlite", Name: ":memory:"})
+	assert.NoError(t, err)
+	assert.NoError(t, database.Connect())
+	t.Cleanup(func() { database.Close() })
+
+	for _, statement := range []string{
+		`CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE, name TEXT DEFAULT 'anon')`,
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users(id), total REAL)`,
+		`CREATE INDEX orders_user_id ON orders(user_id)`,
+	} {
+		_, err := database.Exec(context.Background(), statement)
+		assert.NoError(t, err)
+	}
+
+	return database
+}
+
+func TestSQLiteStrategyTables(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+
+	result, err := getTables(context.Background(), database)
+	assert.NoError(t, err)
+
+	tables := result.(map[string]interface{})
+	assert.Equal(t, "sqlite", tables["dbType"])
+	assert.Equal(t, []map[string]interface{}{
+		{"table_name": "orders"},
+		{"table_name": "users"},
+	}, tables["tables"])
+}
+
+func TestSQLiteStrategyColumns(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+
+	result, err := getColumns(context.Background(), database, "users")
+	assert.NoError(t, err)
+
+	columns := result.(map[string]interface{})["columns"]
+	assert.Equal(t, []map[string]interface{}{
+		{"column_name": "id", "data_type": "INTEGER", "is_nullable": "YES", "column_default": nil},
+		{"column_name": "email", "data_type": "TEXT", "is_nullable": "NO", "column_default": nil},
+		{"column_name": "name", "data_type": "TEXT", "is_nullable": "YES", "column_default": "'anon'"},
+	}, columns)
+}
+
+func TestSQLiteStrategyRelationshipsAndIndexes(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+	ctx := context.Background()
+
+	result, err := getRelationships(ctx, database, "users")
+	assert.NoError(t, err)
+	relationships := result.(map[string]interface{})["relationships"].([]map[string]interface{})
+	assert.Len(t, relationships, 1)
+	assert.Equal(t, "orders", relationships[0]["table_name"])
+	assert.Equal(t, "user_id", relationships[0]["column_name"])
+	assert.Equal(t, "users", relationships[0]["foreign_table_name"])
+	assert.Equal(t, "id", relationships[0]["foreign_column_name"])
+
+	result, err = getIndexes(ctx, database, "orders")
+	assert.NoError(t, err)
+	indexes := result.(map[string]interface{})["indexes"].([]map[string]interface{})
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, "orders_user_id", indexes[0]["index_name"])
+	assert.Equal(t, "user_id", indexes[0]["column_names"])
+	assert.Equal(t, int64(1), indexes[0]["non_unique"])
+}
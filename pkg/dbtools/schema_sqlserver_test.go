@@ -0,0 +1,42 @@
+package dbtools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSQLServerTable(t *testing.T) {
+	schema, table := splitSQLServerTable("orders")
+	assert.Equal(t, "dbo", schema)
+	assert.Equal(t, "orders", table)
+
+	schema, table = splitSQLServerTable("sales.orders")
+	assert.Equal(t, "sales", schema)
+	assert.Equal(t, "orders", table)
+}
+
+func TestSQLServerStrategyQualifiesTables(t *testing.T) {
+	strategy := NewDatabaseStrategy("sqlserver")
+	_, ok := strategy.(*SQLServerStrategy)
+	assert.True(t, ok)
+
+	columns := strategy.GetColumnsQueries("sales.orders")
+	assert.Len(t, columns, 2)
+	for _, q := range columns {
+		assert.Equal(t, []interface{}{"sales", "orders"}, q.args)
+		assert.True(t, strings.Contains(q.query, "@p1") && strings.Contains(q.query, "@p2"))
+	}
+
+	relationships := strategy.GetRelationshipsQueries("orders")
+	assert.Len(t, relationships, 1)
+	assert.Equal(t, []interface{}{"dbo", "orders"}, relationships[0].args)
+	assert.True(t, strings.Contains(relationships[0].query, "ps.name = @p1 AND pt.name = @p2"))
+	assert.True(t, strings.Contains(relationships[0].query, "rs.name = @p1 AND rt.name = @p2"))
+
+	all := strategy.GetIndexesQueries("")
+	assert.Len(t, all, 2)
+	assert.Empty(t, all[0].args)
+	assert.False(t, strings.Contains(all[0].query, "@p1"))
+}
@@ -3,10 +3,15 @@ package dbtools
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSchemaExplorerTool tests the schema explorer tool creation
@@ -123,3 +128,141 @@ func TestGetFullSchema(t *testing.T) {
 	// 2. Return mock data in that case instead of proceeding with the query
 	// 3. Ensure the mock data has the "mock" flag set to true
 }
+
+// unreachableDatabase fails every query, for testing paths that must not touch the database
+type unreachableDatabase struct {
+	db.Database
+	queries int
+}
+
+func (u *unreachableDatabase) DriverName() string { return "postgres" }
+
+func (u *unreachableDatabase) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	u.queries++
+	return nil, errors.New("connection refused")
+}
+
+// TestGetCachedFullSchemaUsesCache tests that a cached full schema is returned without querying
+func TestGetCachedFullSchemaUsesCache(t *testing.T) {
+	schemaCache = newSchemaCache(time.Minute, "")
+	defer func() { schemaCache = nil }()
+
+	cached := map[string]interface{}{"tables": []map[string]interface{}{{"table_name": "users"}}}
+	schemaCache.Set("db1", cached)
+
+	database := &unreachableDatabase{}
+	schema, err := getCachedFullSchema(context.Background(), database, "db1", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cached, schema)
+	assert.Equal(t, 0, database.queries)
+}
+
+// TestGetCachedFullSchemaRefreshFailureKeepsCache tests that refresh bypasses the cache and a failed
+// computation neither returns nor overwrites the cached schema
+func TestGetCachedFullSchemaRefreshFailureKeepsCache(t *testing.T) {
+	schemaCache = newSchemaCache(time.Minute, "")
+	defer func() { schemaCache = nil }()
+
+	cached := map[string]interface{}{"tables": []map[string]interface{}{}}
+	schemaCache.Set("db1", cached)
+
+	database := &unreachableDatabase{}
+	_, err := getCachedFullSchema(context.Background(), database, "db1", true)
+	assert.Error(t, err)
+	assert.True(t, database.queries > 0)
+
+	_, err = getCachedFullSchema(context.Background(), database, "db2", false)
+	assert.Error(t, err)
+	_, ok := schemaCache.Get("db2")
+	assert.False(t, ok)
+
+	schema, ok := schemaCache.Get("db1")
+	assert.True(t, ok)
+	assert.Equal(t, cached, schema)
+}
+
+// failingTriggersDatabase is a database whose trigger queries fail, as they would when
+// the schema timeout runs out part way through
+type failingTriggersDatabase struct {
+	db.Database
+}
+
+func (f *failingTriggersDatabase) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if strings.Contains(query, "trigger_name") {
+		return nil, context.DeadlineExceeded
+	}
+	return f.Database.Query(ctx, query, args...)
+}
+
+// TestGetCachedFullSchemaSkipsPartialSchema tests that a schema missing a failed component
+// is returned marked partial but not cached, while a complete one is cached
+func TestGetCachedFullSchemaSkipsPartialSchema(t *testing.T) {
+	schemaCache = newSchemaCache(time.Minute, "")
+	defer func() { schemaCache = nil }()
+
+	database := newSQLiteTestDatabase(t)
+
+	schema, err := getCachedFullSchema(context.Background(), &failingTriggersDatabase{database}, "db1", false)
+	require.NoError(t, err)
+	schemaMap := schema.(map[string]interface{})
+	assert.Equal(t, true, schemaMap["partial"])
+	assert.Equal(t, []string{"triggers"}, schemaMap["failed_components"])
+	_, ok := schemaCache.Get("db1")
+	assert.False(t, ok)
+
+	schema, err = getCachedFullSchema(context.Background(), database, "db1", false)
+	require.NoError(t, err)
+	assert.NotContains(t, schema, "partial")
+	cached, ok := schemaCache.Get("db1")
+	assert.True(t, ok)
+	assert.Equal(t, schema, cached)
+}
+
+// TestParseSchemaComponents tests validation of the full component include list
+func TestParseSchemaComponents(t *testing.T) {
+	include, err := parseSchemaComponents([]interface{}{"columns", " Foreign_Keys "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"columns": true, "foreign_keys": true}, include)
+
+	_, err = parseSchemaComponents([]interface{}{"columns", "partitions"})
+	assert.Error(t, err)
+
+	_, err = parseSchemaComponents([]interface{}{42})
+	assert.Error(t, err)
+}
+
+// TestGetPartialSchemaFiltersCachedSchema tests that a cached full schema is narrowed to the
+// included components without querying
+func TestGetPartialSchemaFiltersCachedSchema(t *testing.T) {
+	schemaCache = newSchemaCache(time.Minute, "")
+	defer func() { schemaCache = nil }()
+
+	columns := []map[string]interface{}{{"column_name": "id"}}
+	foreignKeys := []map[string]interface{}{{"table_name": "orders", "column_name": "user_id"}}
+	schemaCache.Set("db1", map[string]interface{}{
+		"tables": []map[string]interface{}{{"table_name": "orders"}},
+		"detailed_schema": map[string]interface{}{
+			"orders": map[string]interface{}{
+				"columns":      columns,
+				"indexes":      []map[string]interface{}{{"index_name": "orders_pkey"}},
+				"statistics":   map[string]interface{}{"row_count_estimate": 10},
+				"foreign_keys": foreignKeys,
+			},
+		},
+		"foreign_keys": foreignKeys,
+		"enum_types":   map[string][]string{},
+	})
+
+	database := &unreachableDatabase{}
+	result, err := getPartialSchema(context.Background(), database, "db1", false, map[string]bool{"columns": true, "foreign_keys": true})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, database.queries)
+
+	schema := result.(map[string]interface{})
+	assert.NotContains(t, schema, "enum_types")
+	assert.Equal(t, foreignKeys, schema["foreign_keys"])
+	assert.Equal(t, map[string]interface{}{
+		"orders": map[string]interface{}{"columns": columns, "foreign_keys": foreignKeys},
+	}, schema["detailed_schema"])
+}
@@ -0,0 +1,59 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresTriggersQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("postgres").GetTriggersQueries("")
+	assert.Len(t, queries, 2)
+	assert.Contains(t, queries[0].query, "information_schema.triggers")
+	assert.Contains(t, queries[0].query, "event_manipulation AS event")
+	assert.Contains(t, queries[0].query, "action_timing AS timing")
+	assert.NotContains(t, queries[0].query, "$1")
+	assert.Empty(t, queries[0].args)
+	assert.Contains(t, queries[1].query, "pg_catalog.pg_trigger")
+	assert.Contains(t, queries[1].query, "NOT t.tgisinternal")
+
+	queries = NewDatabaseStrategy("postgres").GetTriggersQueries("orders")
+	assert.Contains(t, queries[0].query, "AND event_object_table = $1 ORDER BY")
+	assert.Equal(t, []interface{}{"orders"}, queries[0].args)
+	assert.Contains(t, queries[1].query, "AND c.relname = $1 ORDER BY")
+	assert.Equal(t, []interface{}{"orders"}, queries[1].args)
+}
+
+func TestMySQLTriggersQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("mysql").GetTriggersQueries("")
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "information_schema.triggers")
+	assert.Contains(t, queries[0].query, "trigger_schema = DATABASE()")
+	assert.Empty(t, queries[0].args)
+
+	queries = NewDatabaseStrategy("mysql").GetTriggersQueries("orders")
+	assert.Contains(t, queries[0].query, "AND event_object_table = ? ORDER BY")
+	assert.Equal(t, []interface{}{"orders"}, queries[0].args)
+}
+
+func TestSQLiteStrategyTriggers(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+	_, err := database.Exec(context.Background(),
+		`CREATE TRIGGER orders_touch BEFORE DELETE ON orders BEGIN SELECT 1; END`)
+	assert.NoError(t, err)
+
+	result, err := getTriggers(context.Background(), database, "orders")
+	assert.NoError(t, err)
+	triggers := result.(map[string]interface{})["triggers"]
+	assert.Equal(t, []map[string]interface{}{
+		{"table_name": "orders", "trigger_name": "orders_touch", "event": "DELETE", "timing": "BEFORE"},
+	}, triggers)
+
+	// The full schema lists triggers per table
+	schema, err := getSchemaComponents(context.Background(), database, map[string]bool{"triggers": true})
+	assert.NoError(t, err)
+	detailed := schema.(map[string]interface{})["detailed_schema"].(map[string]interface{})
+	assert.Equal(t, triggers, detailed["orders"].(map[string]interface{})["triggers"])
+	assert.Empty(t, detailed["users"].(map[string]interface{})["triggers"])
+}
@@ -0,0 +1,52 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresViewsQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("postgres").GetViewsQueries()
+	assert.Len(t, queries, 2)
+
+	// The primary query lists both kinds and labels them
+	assert.Contains(t, queries[0].query, "pg_catalog.pg_views")
+	assert.Contains(t, queries[0].query, "pg_catalog.pg_matviews")
+	assert.Contains(t, queries[0].query, "'view' AS view_type")
+	assert.Contains(t, queries[0].query, "'materialized_view' AS view_type")
+
+	assert.Contains(t, queries[1].query, "information_schema.views")
+}
+
+func TestMySQLViewsQueries(t *testing.T) {
+	queries := NewDatabaseStrategy("mysql").GetViewsQueries()
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "information_schema.views")
+	assert.Contains(t, queries[0].query, "table_schema = DATABASE()")
+	assert.Contains(t, queries[0].query, "'view' AS view_type")
+	assert.NotContains(t, queries[0].query, "materialized_view")
+}
+
+func TestSQLiteStrategyViews(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+	_, err := database.Exec(context.Background(), `CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100`)
+	assert.NoError(t, err)
+
+	result, err := getViews(context.Background(), database)
+	assert.NoError(t, err)
+
+	views := result.(map[string]interface{})["views"].([]map[string]interface{})
+	assert.Len(t, views, 1)
+	assert.Equal(t, "big_orders", views[0]["view_name"])
+	assert.Equal(t, "view", views[0]["view_type"])
+	assert.Contains(t, views[0]["definition"], "total > 100")
+
+	// Views are a top-level section of a partial schema and do not show up as tables
+	schema, err := getSchemaComponents(context.Background(), database, map[string]bool{"views": true})
+	assert.NoError(t, err)
+	schemaMap := schema.(map[string]interface{})
+	assert.Equal(t, views, schemaMap["views"])
+	assert.Len(t, schemaMap["tables"], 2)
+}
@@ -0,0 +1,119 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// createSettingsTool creates a tool for reading the live server configuration
+func createSettingsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "db_settings",
+		Description: "Read the effective runtime configuration of a database server (PostgreSQL pg_settings, MySQL system variables), optionally filtered by name",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID whose server settings should be read",
+				},
+				"filter": map[string]interface{}{
+					"type":        "string",
+					"description": "Only settings whose name contains this text, case-insensitive (e.g. 'timeout', 'work_mem', 'sql_mode')",
+				},
+			},
+			Required: []string{"database"},
+		},
+		Handler: handleSettings,
+	}
+}
+
+// handleSettings handles the settings tool execution
+func handleSettings(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+	filter, _ := getStringParam(params, "filter")
+	filter = strings.TrimSpace(filter)
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(db.QueryTimeout())*time.Second)
+	defer cancel()
+
+	strategy := NewDatabaseStrategy(db.DriverName())
+	rows, err := executeWithFallbacks(timeoutCtx, db, strategy.GetSettingsQueries(filter), "settings")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	defer cleanupRows(rows)
+
+	results, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	settings := filterSettings(results, filter)
+
+	return map[string]interface{}{
+		"database": databaseID,
+		"driver":   db.DriverName(),
+		"filter":   filter,
+		"settings": settings,
+		"count":    len(settings),
+	}, nil
+}
+
+// filterSettings normalizes setting rows to "name" and "setting" keys and keeps those
+// whose name contains filter. The fallback queries (SHOW ALL, SHOW VARIABLES) are not
+// filtered server-side, and LIKE treats "_" as a wildcard, so the filter is always
+// applied here as well.
+func filterSettings(rows []map[string]interface{}, filter string) []map[string]interface{} {
+	filter = strings.ToLower(filter)
+	settings := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		name := settingField(row, "name", "Variable_name", "VARIABLE_NAME")
+		if name == "" {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(name), filter) {
+			continue
+		}
+
+		entry := make(map[string]interface{}, len(row))
+		for key, value := range row {
+			entry[key] = value
+		}
+		// SHOW VARIABLES returns Variable_name/Value
+		delete(entry, "Variable_name")
+		delete(entry, "Value")
+		entry["name"] = name
+		entry["setting"] = settingField(row, "setting", "Value", "VARIABLE_VALUE")
+		settings = append(settings, entry)
+	}
+	return settings
+}
+
+// settingField returns the first string value found under keys
+func settingField(row map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := row[key].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
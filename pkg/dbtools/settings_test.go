@@ -0,0 +1,37 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSettingsPostgres(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"name": "statement_timeout", "setting": "0", "unit": "ms"},
+		{"name": "TimeZone", "setting": "UTC", "unit": nil},
+		{"name": "work_mem", "setting": "4096", "unit": "kB"},
+	}
+
+	settings := filterSettings(rows, "time")
+
+	assert.Len(t, settings, 2)
+	assert.Equal(t, "statement_timeout", settings[0]["name"])
+	assert.Equal(t, "ms", settings[0]["unit"])
+	assert.Equal(t, "TimeZone", settings[1]["name"])
+	assert.Len(t, filterSettings(rows, ""), 3)
+}
+
+func TestFilterSettingsShowVariables(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"Variable_name": "sql_mode", "Value": "STRICT_TRANS_TABLES"},
+		{"Variable_name": "sqlXmode", "Value": "not a real variable"},
+		{"Variable_name": "max_connections", "Value": "151"},
+	}
+
+	settings := filterSettings(rows, "SQL_MODE")
+
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "sql_mode", "setting": "STRICT_TRANS_TABLES"},
+	}, settings)
+}
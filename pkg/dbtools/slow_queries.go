@@ -0,0 +1,128 @@
+package dbtools
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+const (
+	// defaultSlowQueryMs is the slow query threshold when DB_SLOW_QUERY_MS is not set
+	defaultSlowQueryMs = 1000
+	// slowQueryCapacity is how many slow queries are kept; older ones are overwritten
+	slowQueryCapacity = 200
+)
+
+// SlowQuery is a query that ran longer than the slow query threshold
+type SlowQuery struct {
+	Query      string    `json:"query"`
+	DurationMs float64   `json:"duration_ms"`
+	Timestamp  time.Time `json:"timestamp"`
+	Error      string    `json:"error,omitempty"`
+	TimedOut   bool      `json:"timed_out,omitempty"`
+}
+
+// slowQueryLog is a fixed-size ring buffer of slow queries, safe for concurrent use
+type slowQueryLog struct {
+	mu      sync.Mutex
+	entries []SlowQuery
+	next    int
+	size    int
+}
+
+// newSlowQueryLog creates a ring buffer holding up to capacity slow queries
+func newSlowQueryLog(capacity int) *slowQueryLog {
+	return &slowQueryLog{entries: make([]SlowQuery, capacity)}
+}
+
+// add records a slow query, overwriting the oldest one when the buffer is full
+func (l *slowQueryLog) add(entry SlowQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) == 0 {
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	l.size = min(l.size+1, len(l.entries))
+}
+
+// recent returns up to n slow queries, newest first; n <= 0 returns all of them
+func (l *slowQueryLog) recent(n int) []SlowQuery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 0 || n > l.size {
+		n = l.size
+	}
+
+	result := make([]SlowQuery, 0, n)
+	for i := 1; i <= n; i++ {
+		result = append(result, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return result
+}
+
+// reset removes every recorded slow query
+func (l *slowQueryLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = make([]SlowQuery, len(l.entries))
+	l.next = 0
+	l.size = 0
+}
+
+// slowQueryThreshold reads the slow query threshold from DB_SLOW_QUERY_MS
+func slowQueryThreshold() time.Duration {
+	ms := getIntEnv("DB_SLOW_QUERY_MS", defaultSlowQueryMs)
+	if ms <= 0 {
+		ms = defaultSlowQueryMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// SlowQueries returns up to n of the most recent slow queries, newest first
+func (pa *PerformanceAnalyzer) SlowQueries(n int) []SlowQuery {
+	return pa.slowQueries.recent(n)
+}
+
+// createSlowQueriesTool creates a tool for listing recent slow queries
+func createSlowQueriesTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbSlowQueries",
+		Description: "List the most recent queries that ran longer than the slow query threshold (DB_SLOW_QUERY_MS), newest first, with literals stripped from the query text",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of slow queries to return (default: 20)",
+				},
+			},
+		},
+		Handler: handleSlowQueries,
+	}
+}
+
+// handleSlowQueries handles the slow queries tool execution
+func handleSlowQueries(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	limit := 20
+	if limitParam, ok := getIntParam(params, "limit"); ok && limitParam > 0 {
+		limit = limitParam
+	}
+
+	analyzer := GetPerformanceAnalyzer()
+	slowQueries := analyzer.SlowQueries(limit)
+
+	return map[string]interface{}{
+		"slow_queries": slowQueries,
+		"count":        len(slowQueries),
+		"threshold_ms": durationMs(analyzer.GetSlowThreshold()),
+		"capacity":     slowQueryCapacity,
+	}, nil
+}
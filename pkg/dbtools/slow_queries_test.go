@@ -0,0 +1,78 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowQueriesCapturedAboveThreshold(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+	analyzer.SetSlowThreshold(20 * time.Millisecond)
+
+	_, err := analyzer.TrackQuery(context.Background(), "SELECT * FROM users WHERE id = 1", nil, func() (interface{}, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, analyzer.SlowQueries(0))
+
+	_, err = analyzer.TrackQuery(context.Background(), "SELECT * FROM orders WHERE total > 100", nil, func() (interface{}, error) {
+		time.Sleep(25 * time.Millisecond)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	slow := analyzer.SlowQueries(0)
+	assert.Len(t, slow, 1)
+	assert.Equal(t, "SELECT * FROM orders WHERE total > ?", slow[0].Query)
+	assert.GreaterOrEqual(t, slow[0].DurationMs, 20.0)
+	assert.False(t, slow[0].Timestamp.IsZero())
+
+	analyzer.Reset()
+	assert.Empty(t, analyzer.SlowQueries(0))
+}
+
+func TestSlowQueryThresholdFromEnv(t *testing.T) {
+	t.Setenv("DB_SLOW_QUERY_MS", "250")
+	assert.Equal(t, 250*time.Millisecond, NewPerformanceAnalyzer().GetSlowThreshold())
+
+	t.Setenv("DB_SLOW_QUERY_MS", "")
+	assert.Equal(t, time.Second, NewPerformanceAnalyzer().GetSlowThreshold())
+}
+
+func TestSlowQueryLogEvictsOldest(t *testing.T) {
+	log := newSlowQueryLog(3)
+	for i := 1; i <= 5; i++ {
+		log.add(SlowQuery{Query: fmt.Sprintf("q%d", i)})
+	}
+
+	queries := func(entries []SlowQuery) []string {
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Query)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"q5", "q4", "q3"}, queries(log.recent(0)))
+	assert.Equal(t, []string{"q5", "q4"}, queries(log.recent(2)))
+	assert.Equal(t, []string{"q5", "q4", "q3"}, queries(log.recent(10)))
+
+	// Concurrent writers never grow the buffer past its capacity
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.add(SlowQuery{Query: "concurrent"})
+				log.recent(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, log.recent(0), 3)
+}
@@ -0,0 +1,179 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// TableDependency is a foreign key between the inspected table and one other table
+type TableDependency struct {
+	Constraint string `json:"constraint"`
+	// Schema and Table identify the other side of the foreign key: the referenced table
+	// for outbound keys, the referencing table for inbound ones
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table"`
+	// Columns are the referencing columns and ReferencedColumns the columns they point
+	// at, in key order
+	Columns           []string `json:"columns"`
+	ReferencedColumns []string `json:"referenced_columns"`
+}
+
+// TableDependencies lists the foreign keys of a table in both directions
+type TableDependencies struct {
+	Table string `json:"table"`
+	// References are the table's own foreign keys (tables it depends on)
+	References []TableDependency `json:"references"`
+	// ReferencedBy are the foreign keys of other tables pointing at it (tables that
+	// break if it is dropped or its keys change)
+	ReferencedBy     []TableDependency `json:"referenced_by"`
+	ReferencedTables []string          `json:"referenced_tables"`
+	DependentTables  []string          `json:"dependent_tables"`
+}
+
+// createTableDependentsTool creates a tool for listing the foreign keys into and out of a table
+func createTableDependentsTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "db_table_dependents",
+		Description: "Show a table's foreign key dependencies in both directions: the tables it references and the tables that reference it",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to inspect",
+				},
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Table whose dependencies should be listed",
+				},
+				"timeout": map[string]interface{}{
+					"type":        "integer",
+					"description": "Timeout in milliseconds (default: the database's schema_timeout)",
+				},
+			},
+			Required: []string{"database", "table"},
+		},
+		Handler: handleTableDependents,
+	}
+}
+
+// handleTableDependents handles the table dependents tool execution
+func handleTableDependents(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+	table, ok := getStringParam(params, "table")
+	if !ok || table == "" {
+		return nil, fmt.Errorf("table parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
+	defer cancel()
+
+	result, err := getRelationships(timeoutCtx, db, table)
+	if err != nil {
+		return nil, err
+	}
+	resultMap, _ := result.(map[string]interface{})
+	rows, _ := resultMap["relationships"].([]map[string]interface{})
+
+	return map[string]interface{}{
+		"database":     databaseID,
+		"dbType":       db.DriverName(),
+		"dependencies": splitTableDependencies(table, rows),
+	}, nil
+}
+
+// splitTableDependencies groups per-column relationship rows into foreign keys and
+// sorts them into those declared by table and those pointing at it. A self-referencing
+// key appears in both lists. Table names are compared case-insensitively, as MySQL and
+// SQL Server usually treat them.
+func splitTableDependencies(table string, rows []map[string]interface{}) *TableDependencies {
+	deps := &TableDependencies{
+		Table:            table,
+		References:       []TableDependency{},
+		ReferencedBy:     []TableDependency{},
+		ReferencedTables: []string{},
+		DependentTables:  []string{},
+	}
+
+	type fkKey struct{ schema, table, constraint string }
+	var order []fkKey
+	keys := make(map[fkKey]*TableDependency)
+	targets := make(map[fkKey][2]string)
+
+	for i, row := range rows {
+		key := fkKey{
+			schema:     columnAttribute(row, "table_schema"),
+			table:      columnAttribute(row, "table_name"),
+			constraint: columnAttribute(row, "constraint_name"),
+		}
+		if key.constraint == "" {
+			// Unnamed constraints cannot be grouped; keep each row on its own
+			key.constraint = fmt.Sprintf("#%d", i)
+		}
+
+		fk, ok := keys[key]
+		if !ok {
+			fk = &TableDependency{Constraint: columnAttribute(row, "constraint_name")}
+			keys[key] = fk
+			targets[key] = [2]string{columnAttribute(row, "foreign_table_schema"), columnAttribute(row, "foreign_table_name")}
+			order = append(order, key)
+		}
+		fk.Columns = append(fk.Columns, columnAttribute(row, "column_name"))
+		fk.ReferencedColumns = append(fk.ReferencedColumns, columnAttribute(row, "foreign_column_name"))
+	}
+
+	referenced := make(map[string]bool)
+	dependent := make(map[string]bool)
+	for _, key := range order {
+		fk := *keys[key]
+		target := targets[key]
+
+		if strings.EqualFold(key.table, table) {
+			outbound := fk
+			outbound.Schema, outbound.Table = target[0], target[1]
+			deps.References = append(deps.References, outbound)
+			referenced[target[1]] = true
+		}
+		if strings.EqualFold(target[1], table) {
+			inbound := fk
+			inbound.Schema, inbound.Table = key.schema, key.table
+			deps.ReferencedBy = append(deps.ReferencedBy, inbound)
+			dependent[key.table] = true
+		}
+	}
+
+	sortDependencies(deps.References)
+	sortDependencies(deps.ReferencedBy)
+	deps.ReferencedTables = append(deps.ReferencedTables, sortedKeys(referenced)...)
+	deps.DependentTables = append(deps.DependentTables, sortedKeys(dependent)...)
+
+	return deps
+}
+
+// sortDependencies orders foreign keys by the other table and then by constraint name
+func sortDependencies(deps []TableDependency) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Table != deps[j].Table {
+			return deps[i].Table < deps[j].Table
+		}
+		return deps[i].Constraint < deps[j].Constraint
+	})
+}
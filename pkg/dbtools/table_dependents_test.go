@@ -0,0 +1,57 @@
+package dbtools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTableDependencies(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"table_schema": "public", "constraint_name": "orders_customer_fk", "table_name": "orders", "column_name": "customer_id",
+			"foreign_table_schema": "public", "foreign_table_name": "customers", "foreign_column_name": "id"},
+		{"table_schema": "public", "constraint_name": "order_items_order_fk", "table_name": "order_items", "column_name": "order_id",
+			"foreign_table_schema": "public", "foreign_table_name": "orders", "foreign_column_name": "id"},
+		{"table_schema": "public", "constraint_name": "shipments_order_fk", "table_name": "shipments", "column_name": "order_id",
+			"foreign_table_schema": "public", "foreign_table_name": "orders", "foreign_column_name": "id"},
+		{"table_schema": "public", "constraint_name": "shipments_order_fk", "table_name": "shipments", "column_name": "order_region",
+			"foreign_table_schema": "public", "foreign_table_name": "orders", "foreign_column_name": "region"},
+		{"table_schema": "public", "constraint_name": "orders_parent_fk", "table_name": "orders", "column_name": "parent_id",
+			"foreign_table_schema": "public", "foreign_table_name": "orders", "foreign_column_name": "id"},
+	}
+
+	deps := splitTableDependencies("orders", rows)
+
+	assert.Equal(t, []TableDependency{
+		{Constraint: "orders_customer_fk", Schema: "public", Table: "customers", Columns: []string{"customer_id"}, ReferencedColumns: []string{"id"}},
+		{Constraint: "orders_parent_fk", Schema: "public", Table: "orders", Columns: []string{"parent_id"}, ReferencedColumns: []string{"id"}},
+	}, deps.References)
+	assert.Equal(t, []TableDependency{
+		{Constraint: "order_items_order_fk", Schema: "public", Table: "order_items", Columns: []string{"order_id"}, ReferencedColumns: []string{"id"}},
+		{Constraint: "orders_parent_fk", Schema: "public", Table: "orders", Columns: []string{"parent_id"}, ReferencedColumns: []string{"id"}},
+		{Constraint: "shipments_order_fk", Schema: "public", Table: "shipments", Columns: []string{"order_id", "order_region"}, ReferencedColumns: []string{"id", "region"}},
+	}, deps.ReferencedBy)
+	assert.Equal(t, []string{"customers", "orders"}, deps.ReferencedTables)
+	assert.Equal(t, []string{"order_items", "orders", "shipments"}, deps.DependentTables)
+}
+
+func TestSplitTableDependenciesNoKeys(t *testing.T) {
+	deps := splitTableDependencies("audit_log", nil)
+
+	assert.Equal(t, "audit_log", deps.Table)
+	assert.Empty(t, deps.References)
+	assert.Empty(t, deps.ReferencedBy)
+	assert.Equal(t, []string{}, deps.DependentTables)
+}
+
+func TestSplitTableDependenciesIgnoresCase(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"constraint_name": "FK_Orders_Customers", "table_name": "Orders", "column_name": "CustomerID",
+			"foreign_table_name": "Customers", "foreign_column_name": "ID"},
+	}
+
+	deps := splitTableDependencies("customers", rows)
+
+	assert.Empty(t, deps.References)
+	assert.Equal(t, []string{"Orders"}, deps.DependentTables)
+}
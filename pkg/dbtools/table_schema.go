@@ -0,0 +1,175 @@
+package dbtools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/FreePeak/infra-mcp-server/pkg/db"
+	"github.com/FreePeak/infra-mcp-server/pkg/logger"
+	"github.com/FreePeak/infra-mcp-server/pkg/tools"
+)
+
+// tableSchemaColumn is one column of a dbTableSchema result. An empty fk_to or
+// enum_values is left out to keep the output small.
+type tableSchemaColumn struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Nullable   bool     `json:"nullable"`
+	PK         bool     `json:"pk"`
+	FKTo       string   `json:"fk_to,omitempty"`
+	EnumValues []string `json:"enum_values,omitempty"`
+}
+
+// createTableSchemaTool creates a tool for describing one table in a compact form
+func createTableSchemaTool() *tools.Tool {
+	return &tools.Tool{
+		Name:        "dbTableSchema",
+		Description: "Describe a table compactly: one entry per column with its type, nullability, primary key membership, foreign key target and enum values",
+		Category:    "database",
+		InputSchema: tools.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"database": map[string]interface{}{
+					"type":        "string",
+					"description": "Database ID to use",
+				},
+				"table": map[string]interface{}{
+					"type":        "string",
+					"description": "Table to describe",
+				},
+			},
+			Required: []string{"database", "table"},
+		},
+		Handler: handleTableSchema,
+	}
+}
+
+// handleTableSchema handles the table schema tool execution
+func handleTableSchema(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	// Check if database manager is initialized
+	if dbManager == nil {
+		return nil, fmt.Errorf("database manager not initialized")
+	}
+
+	databaseID, ok := getStringParam(params, "database")
+	if !ok {
+		return nil, fmt.Errorf("database parameter is required")
+	}
+
+	table, _ := getStringParam(params, "table")
+	table = strings.TrimSpace(table)
+	if table == "" {
+		return nil, fmt.Errorf("table parameter is required")
+	}
+
+	db, err := dbManager.GetDatabase(databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, schemaOperationTimeout(params, db))
+	defer cancel()
+
+	columns, err := getTableSchema(timeoutCtx, db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"database": databaseID,
+		"table":    table,
+		"columns":  columns,
+	}, nil
+}
+
+// getTableSchema composes the columns, primary key, foreign keys and enum values of a
+// table into one compact entry per column. Only the columns are required; the other
+// parts are left out with a warning when they cannot be read.
+func getTableSchema(ctx context.Context, db db.Database, table string) ([]tableSchemaColumn, error) {
+	columnsResult, err := getColumns(ctx, db, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	columnsMap, _ := safeGetMap(columnsResult)
+	columns, _ := columnsMap["columns"].([]map[string]interface{})
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist or has no columns", table)
+	}
+
+	var primaryKeys []map[string]interface{}
+	if pkResult, pkErr := getPrimaryKeys(ctx, db, table); pkErr != nil {
+		logger.Warn("Failed to get primary keys for table %s: %v", table, pkErr)
+	} else if pkMap, _ := safeGetMap(pkResult); pkMap != nil {
+		primaryKeys, _ = pkMap["primary_keys"].([]map[string]interface{})
+	}
+
+	var relationships []map[string]interface{}
+	if relResult, relErr := getRelationships(ctx, db, table); relErr != nil {
+		logger.Warn("Failed to get relationships for table %s: %v", table, relErr)
+	} else if relMap, _ := safeGetMap(relResult); relMap != nil {
+		relationships, _ = relMap["relationships"].([]map[string]interface{})
+	}
+
+	// getEnumValues returns an empty list rather than failing
+	var enumValues []map[string]interface{}
+	if enumsResult, enumsErr := getEnumValues(ctx, db); enumsErr == nil {
+		if enumsMap, _ := safeGetMap(enumsResult); enumsMap != nil {
+			enumValues, _ = enumsMap["enums"].([]map[string]interface{})
+		}
+	}
+
+	return compactTableSchema(table, columns, primaryKeys, relationships, groupEnumValues(enumValues)), nil
+}
+
+// compactTableSchema flattens getColumns, getPrimaryKeys and getRelationships rows of a
+// table into tableSchemaColumns. Relationships of other tables referencing this one are
+// ignored.
+func compactTableSchema(table string, columns, primaryKeys, relationships []map[string]interface{}, enumsByType map[string][]string) []tableSchemaColumn {
+	attachEnumValues(columns, enumsByType)
+
+	pkColumns := make(map[string]bool, len(primaryKeys))
+	for _, pk := range primaryKeys {
+		pkColumns[settingField(pk, "column_name", "COLUMN_NAME")] = true
+	}
+
+	fkTargets := make(map[string]string)
+	for _, fk := range relationships {
+		if settingField(fk, "table_name", "TABLE_NAME") != table {
+			continue
+		}
+		column := settingField(fk, "column_name", "COLUMN_NAME")
+		if _, seen := fkTargets[column]; !seen {
+			fkTargets[column] = settingField(fk, "foreign_table_name") + "." + settingField(fk, "foreign_column_name")
+		}
+	}
+
+	result := make([]tableSchemaColumn, 0, len(columns))
+	for _, column := range columns {
+		// information_schema aliases differ in case between engines; SHOW COLUMNS uses Field
+		name := settingField(column, "column_name", "COLUMN_NAME", "Field")
+
+		// MySQL's column_type carries lengths and enum values; PostgreSQL enums are
+		// USER-DEFINED with the type in udt_name
+		columnType := settingField(column, "column_type", "COLUMN_TYPE", "Type")
+		if columnType == "" {
+			columnType = settingField(column, "data_type", "DATA_TYPE")
+			if columnType == "USER-DEFINED" {
+				if udtName := settingField(column, "udt_name"); udtName != "" {
+					columnType = udtName
+				}
+			}
+		}
+
+		entry := tableSchemaColumn{
+			Name:     name,
+			Type:     columnType,
+			Nullable: strings.EqualFold(settingField(column, "is_nullable", "IS_NULLABLE", "Null"), "YES"),
+			PK:       pkColumns[name],
+			FKTo:     fkTargets[name],
+		}
+		entry.EnumValues, _ = column["enum_values"].([]string)
+		result = append(result, entry)
+	}
+	return result
+}
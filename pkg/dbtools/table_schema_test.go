@@ -0,0 +1,60 @@
+package dbtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactTableSchema(t *testing.T) {
+	// PostgreSQL-shaped rows for orders(id PK, user_id FK to users, status order_status enum)
+	columns := []map[string]interface{}{
+		{"column_name": "id", "data_type": "integer", "udt_name": "int4", "is_nullable": "NO"},
+		{"column_name": "user_id", "data_type": "integer", "udt_name": "int4", "is_nullable": "NO"},
+		{"column_name": "status", "data_type": "USER-DEFINED", "udt_name": "order_status", "is_nullable": "YES"},
+	}
+	primaryKeys := []map[string]interface{}{
+		{"table_name": "orders", "column_name": "id"},
+	}
+	relationships := []map[string]interface{}{
+		{"table_name": "orders", "column_name": "user_id", "foreign_table_name": "users", "foreign_column_name": "id"},
+		// A table referencing orders does not make orders.id a foreign key
+		{"table_name": "shipments", "column_name": "id", "foreign_table_name": "orders", "foreign_column_name": "id"},
+	}
+	enumsByType := groupEnumValues([]map[string]interface{}{
+		{"enum_name": "order_status", "enum_value": "pending"},
+		{"enum_name": "order_status", "enum_value": "shipped"},
+	})
+
+	assert.Equal(t, []tableSchemaColumn{
+		{Name: "id", Type: "integer", Nullable: false, PK: true},
+		{Name: "user_id", Type: "integer", Nullable: false, FKTo: "users.id"},
+		{Name: "status", Type: "order_status", Nullable: true, EnumValues: []string{"pending", "shipped"}},
+	}, compactTableSchema("orders", columns, primaryKeys, relationships, enumsByType))
+}
+
+func TestCompactTableSchemaMySQLEnum(t *testing.T) {
+	columns := []map[string]interface{}{
+		{"column_name": "size", "data_type": "enum", "column_type": "enum('s','m','l')", "is_nullable": "NO"},
+	}
+
+	assert.Equal(t, []tableSchemaColumn{
+		{Name: "size", Type: "enum('s','m','l')", EnumValues: []string{"s", "m", "l"}},
+	}, compactTableSchema("shirts", columns, nil, nil, map[string][]string{}))
+}
+
+func TestSQLiteTableSchema(t *testing.T) {
+	database := newSQLiteTestDatabase(t)
+
+	columns, err := getTableSchema(context.Background(), database, "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, []tableSchemaColumn{
+		{Name: "id", Type: "INTEGER", Nullable: true, PK: true},
+		{Name: "user_id", Type: "INTEGER", Nullable: false, FKTo: "users.id"},
+		{Name: "total", Type: "REAL", Nullable: true},
+	}, columns)
+
+	_, err = getTableSchema(context.Background(), database, "missing")
+	assert.Error(t, err)
+}